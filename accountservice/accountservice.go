@@ -2,8 +2,13 @@
 package accountservice
 
 import (
+	"context"
+	"sync"
+
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/any-sync/util/crypto/signeragent"
 )
 
 const CName = "common.accountservice"
@@ -13,12 +18,117 @@ type Service interface {
 	Account() *accountdata.AccountKeys
 }
 
+// KeysSetter is implemented by the services which can swap the account keys, e.g. after the key rotation
+type KeysSetter interface {
+	SetAccountKeys(keys *accountdata.AccountKeys)
+}
+
+// New creates the account service which reads the keys from the account config of the app
+func New() Service {
+	return &accountService{}
+}
+
 type Config struct {
 	PeerId     string `yaml:"peerId"`
 	PeerKey    string `yaml:"peerKey"`
 	SigningKey string `yaml:"signingKey"`
+	// EncryptionKey is the hybrid key which decrypts the acl read keys, it is optional
+	EncryptionKey string `yaml:"encryptionKey"`
+	// Signer keeps the keys in the signer agent, PeerKey and SigningKey are not used if it is set
+	Signer SignerConfig `yaml:"signer"`
+}
+
+type SignerConfig struct {
+	// Addr is the unix socket of the signer agent
+	Addr         string `yaml:"addr"`
+	PeerKeyId    string `yaml:"peerKeyId"`
+	SigningKeyId string `yaml:"signingKeyId"`
+	// EncryptionKeyId is the hybrid key of the agent, it is optional
+	EncryptionKeyId string `yaml:"encryptionKeyId"`
 }
 
 type ConfigGetter interface {
 	GetAccount() Config
 }
+
+type accountService struct {
+	keys   *accountdata.AccountKeys
+	client *signeragent.Client
+	mu     sync.RWMutex
+}
+
+func (s *accountService) Init(a *app.App) (err error) {
+	s.keys, s.client, err = newAccountKeys(a.MustComponent("config").(ConfigGetter).GetAccount())
+	return
+}
+
+func (s *accountService) Name() (name string) {
+	return CName
+}
+
+func (s *accountService) Account() *accountdata.AccountKeys {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys
+}
+
+func (s *accountService) SetAccountKeys(keys *accountdata.AccountKeys) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *accountService) Run(ctx context.Context) (err error) {
+	return nil
+}
+
+func (s *accountService) Close(ctx context.Context) (err error) {
+	if s.client != nil {
+		return s.client.Close()
+	}
+	return nil
+}
+
+// newAccountKeys creates the account keys from the config, the keys of the signer agent never leave the agent process,
+// the client of the agent is returned to be closed with the service
+func newAccountKeys(conf Config) (*accountdata.AccountKeys, *signeragent.Client, error) {
+	if conf.Signer.Addr == "" {
+		peerKey, err := crypto.DecodeKeyFromString(conf.PeerKey, crypto.UnmarshalEd25519PrivateKey, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		signKey, err := crypto.DecodeKeyFromString(conf.SigningKey, crypto.UnmarshalEd25519PrivateKey, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys := accountdata.New(peerKey, signKey)
+		if conf.EncryptionKey != "" {
+			if keys.EncryptionKey, err = crypto.DecodeKeyFromString(conf.EncryptionKey, crypto.NewHybridPrivKeyFromBytes, nil); err != nil {
+				return nil, nil, err
+			}
+		}
+		return keys, nil, nil
+	}
+	client, err := signeragent.Dial(conf.Signer.Addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	peerSigner, err := client.Signer(conf.Signer.PeerKeyId)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, err
+	}
+	signSigner, err := client.Signer(conf.Signer.SigningKeyId)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, err
+	}
+	keys := accountdata.New(crypto.NewSignerKey(peerSigner), crypto.NewSignerKey(signSigner))
+	if conf.Signer.EncryptionKeyId != "" {
+		if keys.EncryptionKey, err = client.EncryptionKey(conf.Signer.EncryptionKeyId); err != nil {
+			_ = client.Close()
+			return nil, nil, err
+		}
+	}
+	return keys, client, nil
+}
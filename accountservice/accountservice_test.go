@@ -0,0 +1,84 @@
+package accountservice
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/any-sync/util/crypto/signeragent"
+)
+
+var ctx = context.Background()
+
+func TestAccountService(t *testing.T) {
+	peerKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	signKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	encKey, err := crypto.GenerateRandomHybridKey()
+	require.NoError(t, err)
+
+	t.Run("keys from config", func(t *testing.T) {
+		peerKeyStr, err := crypto.EncodeKeyToString(peerKey)
+		require.NoError(t, err)
+		signKeyStr, err := crypto.EncodeKeyToString(signKey)
+		require.NoError(t, err)
+		encKeyStr, err := crypto.EncodeKeyToString(encKey)
+		require.NoError(t, err)
+		s := newService(t, Config{PeerKey: peerKeyStr, SigningKey: signKeyStr, EncryptionKey: encKeyStr})
+		assert.True(t, s.Account().SignKey.GetPublic().Equals(signKey.GetPublic()))
+		assert.True(t, s.Account().PeerKey.GetPublic().Equals(peerKey.GetPublic()))
+		assert.True(t, s.Account().EncryptionKey.GetPublic().Equals(encKey.GetPublic()))
+		require.NoError(t, s.(app.ComponentRunnable).Close(ctx))
+	})
+	t.Run("keys from signer agent", func(t *testing.T) {
+		addr := filepath.Join(t.TempDir(), "signer.sock")
+		lis, err := signeragent.Listen(addr)
+		require.NoError(t, err)
+		defer lis.Close()
+		agent := signeragent.NewAgent(map[string]crypto.Signer{"peer": peerKey, "account": signKey})
+		agent.AddEncryptionKey("encryption", encKey)
+		go func() {
+			_ = agent.Serve(lis)
+		}()
+		s := newService(t, Config{Signer: SignerConfig{Addr: addr, PeerKeyId: "peer", SigningKeyId: "account", EncryptionKeyId: "encryption"}})
+		acc := s.Account()
+		assert.True(t, acc.SignKey.GetPublic().Equals(signKey.GetPublic()))
+		assert.True(t, acc.EncryptionKey.GetPublic().Equals(encKey.GetPublic()))
+		sig, err := acc.SignKey.Sign([]byte("data"))
+		require.NoError(t, err)
+		ok, err := signKey.GetPublic().Verify([]byte("data"), sig)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		require.NoError(t, s.(app.ComponentRunnable).Close(ctx))
+	})
+}
+
+func newService(t *testing.T, conf Config) Service {
+	s := New()
+	a := new(app.App)
+	a.Register(testConfig{conf}).Register(s)
+	require.NoError(t, s.Init(a))
+	return s
+}
+
+type testConfig struct {
+	conf Config
+}
+
+func (c testConfig) Init(a *app.App) error {
+	return nil
+}
+
+func (c testConfig) Name() string {
+	return "config"
+}
+
+func (c testConfig) GetAccount() Config {
+	return c.conf
+}
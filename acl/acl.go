@@ -101,7 +101,7 @@ func (as *aclService) AddRecord(ctx context.Context, spaceId string, rec *consen
 		if !acc.Permissions.NoPermissions() {
 			beforeReaders++
 		}
-		if acc.Permissions.CanWrite() {
+		if acl.AclState().RoleCapabilities(acc.Permissions).CanWrite() {
 			beforeWriters++
 		}
 	}
@@ -113,7 +113,7 @@ func (as *aclService) AddRecord(ctx context.Context, spaceId string, rec *consen
 				continue
 			}
 			readers++
-			if acc.Permissions.CanWrite() {
+			if state.RoleCapabilities(acc.Permissions).CanWrite() {
 				writers++
 			}
 		}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 
+	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvinterfaces"
 	"github.com/anyproto/any-sync/commonspace/spacestate"
 	"github.com/anyproto/any-sync/consensus/consensusproto"
 	"github.com/anyproto/any-sync/node/nodeclient"
@@ -44,6 +47,11 @@ type AclSpaceClient interface {
 	RevokeInvite(ctx context.Context, inviteRecordId string) (err error)
 	RevokeAllInvites(ctx context.Context) (err error)
 	AddAccounts(ctx context.Context, add list.AccountsAddPayload) (err error)
+	// RotateKey moves the account to the new keys and uses them as the local identity of the acl after the record is accepted,
+	// the key-value storage and the account service (if it implements accountservice.KeysSetter) are switched to the new keys too
+	RotateKey(ctx context.Context, newKeys *accountdata.AccountKeys) (err error)
+	PublishEncryptionKey(ctx context.Context) (err error)
+	RevokeDevice(ctx context.Context, payload list.DeviceRevokePayload) (err error)
 }
 
 func NewAclSpaceClient() AclSpaceClient {
@@ -53,6 +61,8 @@ func NewAclSpaceClient() AclSpaceClient {
 type aclSpaceClient struct {
 	nodeClient nodeclient.NodeClient
 	acl        list.AclList
+	account    accountservice.Service
+	keyValue   kvinterfaces.KeyValueService
 	spaceId    string
 }
 
@@ -60,6 +70,9 @@ func (c *aclSpaceClient) Init(a *app.App) (err error) {
 	c.nodeClient = a.MustComponent(nodeclient.CName).(nodeclient.NodeClient)
 	c.acl = a.MustComponent(syncacl.CName).(list.AclList)
 	c.spaceId = a.MustComponent(spacestate.CName).(*spacestate.SpaceState).SpaceId
+	// the components holding the account keys are optional, they are switched after the key rotation
+	c.account, _ = a.Component(accountservice.CName).(accountservice.Service)
+	c.keyValue, _ = a.Component(kvinterfaces.CName).(kvinterfaces.KeyValueService)
 	return nil
 }
 
@@ -111,6 +124,58 @@ func (c *aclSpaceClient) AddAccounts(ctx context.Context, add list.AccountsAddPa
 	return c.sendRecordAndUpdate(ctx, c.spaceId, res)
 }
 
+func (c *aclSpaceClient) RotateKey(ctx context.Context, newKeys *accountdata.AccountKeys) (err error) {
+	c.acl.Lock()
+	payload := list.KeyRotatePayload{NewKey: newKeys.SignKey}
+	if newKeys.EncryptionKey != nil {
+		payload.NewEncryptionKey = newKeys.EncryptionKey.GetPublic()
+	}
+	res, err := c.acl.RecordBuilder().BuildKeyRotate(payload)
+	if err != nil {
+		c.acl.Unlock()
+		return
+	}
+	c.acl.Unlock()
+	if err = c.sendRecordAndUpdate(ctx, c.spaceId, res); err != nil {
+		return
+	}
+	c.acl.Lock()
+	err = c.acl.SetAccountKeys(newKeys)
+	c.acl.Unlock()
+	if err != nil {
+		return
+	}
+	if c.keyValue != nil {
+		c.keyValue.DefaultStore().SetAccountKeys(newKeys)
+	}
+	if setter, ok := c.account.(accountservice.KeysSetter); ok {
+		setter.SetAccountKeys(newKeys)
+	}
+	return
+}
+
+func (c *aclSpaceClient) PublishEncryptionKey(ctx context.Context) (err error) {
+	c.acl.Lock()
+	res, err := c.acl.RecordBuilder().BuildEncryptionKey()
+	if err != nil {
+		c.acl.Unlock()
+		return
+	}
+	c.acl.Unlock()
+	return c.sendRecordAndUpdate(ctx, c.spaceId, res)
+}
+
+func (c *aclSpaceClient) RevokeDevice(ctx context.Context, payload list.DeviceRevokePayload) (err error) {
+	c.acl.Lock()
+	res, err := c.acl.RecordBuilder().BuildDeviceRevoke(payload)
+	if err != nil {
+		c.acl.Unlock()
+		return
+	}
+	c.acl.Unlock()
+	return c.sendRecordAndUpdate(ctx, c.spaceId, res)
+}
+
 func (c *aclSpaceClient) RemoveAccounts(ctx context.Context, payload list.AccountRemovePayload) (err error) {
 	c.acl.Lock()
 	res, err := c.acl.RecordBuilder().BuildAccountRemove(payload)
@@ -261,8 +326,9 @@ func (c *aclSpaceClient) GenerateInvite(isRevoke, isRequestToJoin bool, permissi
 		return list.InviteResult{}, err
 	}
 	return list.InviteResult{
-		InviteRec: res.Rec,
-		InviteKey: res.Invites[0],
+		InviteRec:           res.Rec,
+		InviteKey:           res.Invites[0],
+		InviteEncryptionKey: res.InviteEncryptionKeys[0],
 	}, nil
 }
 
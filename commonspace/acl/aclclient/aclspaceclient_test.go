@@ -7,9 +7,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/mock_keyvaluestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvinterfaces/mock_kvinterfaces"
 	"github.com/anyproto/any-sync/commonspace/spacestate"
 	"github.com/anyproto/any-sync/consensus/consensusproto"
 	"github.com/anyproto/any-sync/node/nodeclient"
@@ -66,6 +70,46 @@ func TestAclSpaceClient_StopSharing(t *testing.T) {
 	})
 }
 
+func TestAclSpaceClient_RotateKey(t *testing.T) {
+	fx := newFixture(t)
+	defer fx.finish(t)
+	newKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	store := mock_keyvaluestorage.NewMockStorage(fx.ctrl)
+	keyValue := mock_kvinterfaces.NewMockKeyValueService(fx.ctrl)
+	keyValue.EXPECT().DefaultStore().Return(store)
+	store.EXPECT().SetAccountKeys(newKeys)
+	account := &testAccountService{}
+	fx.keyValue, fx.account = keyValue, account
+	fx.nodeClient.EXPECT().AclAddRecord(ctx, fx.spaceState.SpaceId, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, spaceId string, rec *consensusproto.RawRecord) (*consensusproto.RawRecordWithId, error) {
+			return marshallRecord(t, rec), nil
+		})
+	require.NoError(t, fx.RotateKey(ctx, newKeys))
+	require.True(t, fx.acl.AclState().Identity().Equals(newKeys.SignKey.GetPublic()))
+	require.Equal(t, newKeys, account.Account())
+}
+
+type testAccountService struct {
+	keys *accountdata.AccountKeys
+}
+
+func (s *testAccountService) Init(a *app.App) error {
+	return nil
+}
+
+func (s *testAccountService) Name() string {
+	return accountservice.CName
+}
+
+func (s *testAccountService) Account() *accountdata.AccountKeys {
+	return s.keys
+}
+
+func (s *testAccountService) SetAccountKeys(keys *accountdata.AccountKeys) {
+	s.keys = keys
+}
+
 type namedAcl struct {
 	list.AclList
 }
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	app "github.com/anyproto/any-sync/app"
+	accountdata "github.com/anyproto/any-sync/commonspace/object/accountdata"
 	list "github.com/anyproto/any-sync/commonspace/object/acl/list"
 	consensusproto "github.com/anyproto/any-sync/consensus/consensusproto"
 	crypto "github.com/anyproto/any-sync/util/crypto"
@@ -307,6 +308,20 @@ func (mr *MockAclSpaceClientMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAclSpaceClient)(nil).Name))
 }
 
+// PublishEncryptionKey mocks base method.
+func (m *MockAclSpaceClient) PublishEncryptionKey(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEncryptionKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEncryptionKey indicates an expected call of PublishEncryptionKey.
+func (mr *MockAclSpaceClientMockRecorder) PublishEncryptionKey(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEncryptionKey", reflect.TypeOf((*MockAclSpaceClient)(nil).PublishEncryptionKey), arg0)
+}
+
 // RemoveAccounts mocks base method.
 func (m *MockAclSpaceClient) RemoveAccounts(arg0 context.Context, arg1 list.AccountRemovePayload) error {
 	m.ctrl.T.Helper()
@@ -349,6 +364,20 @@ func (mr *MockAclSpaceClientMockRecorder) RevokeAllInvites(arg0 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllInvites", reflect.TypeOf((*MockAclSpaceClient)(nil).RevokeAllInvites), arg0)
 }
 
+// RevokeDevice mocks base method.
+func (m *MockAclSpaceClient) RevokeDevice(arg0 context.Context, arg1 list.DeviceRevokePayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeDevice", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeDevice indicates an expected call of RevokeDevice.
+func (mr *MockAclSpaceClientMockRecorder) RevokeDevice(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeDevice", reflect.TypeOf((*MockAclSpaceClient)(nil).RevokeDevice), arg0, arg1)
+}
+
 // RevokeInvite mocks base method.
 func (m *MockAclSpaceClient) RevokeInvite(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -363,6 +392,20 @@ func (mr *MockAclSpaceClientMockRecorder) RevokeInvite(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvite", reflect.TypeOf((*MockAclSpaceClient)(nil).RevokeInvite), arg0, arg1)
 }
 
+// RotateKey mocks base method.
+func (m *MockAclSpaceClient) RotateKey(arg0 context.Context, arg1 *accountdata.AccountKeys) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateKey indicates an expected call of RotateKey.
+func (mr *MockAclSpaceClientMockRecorder) RotateKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKey", reflect.TypeOf((*MockAclSpaceClient)(nil).RotateKey), arg0, arg1)
+}
+
 // StopSharing mocks base method.
 func (m *MockAclSpaceClient) StopSharing(arg0 context.Context, arg1 list.ReadKeyChangePayload) error {
 	m.ctrl.T.Helper()
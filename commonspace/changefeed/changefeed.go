@@ -0,0 +1,337 @@
+// Package changefeed keeps the persistent log of the changes of the space objects.
+// Every heads update of the trees, the acl and the key-value storage gets the record with the increasing sequence number,
+// which is used as a cursor, so the readers can continue from the last seen record after the restart.
+// The feed doesn't affect the sync: if the record can't be written, the update is applied without it
+// and the cursor has a gap. The feed is registered only if it is enabled in the space deps and it is not truncated
+// automatically, the owner should call Truncate with the cursor all its readers have reached.
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	anystore "github.com/anyproto/any-store"
+	"github.com/anyproto/any-store/anyenc"
+	"github.com/anyproto/any-store/query"
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/net/peer"
+	"github.com/anyproto/any-sync/util/storeutil"
+)
+
+const CName = "common.commonspace.changefeed"
+
+const (
+	CollName = "changefeed"
+
+	idKey        = "id"
+	objectIdKey  = "o"
+	kindKey      = "k"
+	headsKey     = "h"
+	deletedKey   = "d"
+	peerIdKey    = "p"
+	timestampKey = "t"
+
+	defaultReadLimit = 1000
+)
+
+var log = logger.NewNamed(CName)
+
+var ErrClosed = errors.New("change feed is closed")
+
+type ObjectKind int
+
+const (
+	ObjectKindTree ObjectKind = iota
+	ObjectKindAcl
+	ObjectKindSettings
+	ObjectKindKeyValue
+)
+
+func (k ObjectKind) String() string {
+	switch k {
+	case ObjectKindTree:
+		return "tree"
+	case ObjectKindAcl:
+		return "acl"
+	case ObjectKindSettings:
+		return "settings"
+	case ObjectKindKeyValue:
+		return "keyValue"
+	}
+	return "unknown"
+}
+
+// Record is the entry of the change feed
+type Record struct {
+	// Cursor is the sequence number of the record, it only grows but can have gaps
+	Cursor   uint64
+	ObjectId string
+	Kind     ObjectKind
+	// Heads are the new heads of the object, they are empty if only the deleted status is changed
+	Heads         []string
+	DeletedStatus headstorage.DeletedStatus
+	// PeerId is the peer which sent the change, it is empty for the local changes
+	PeerId    string
+	Timestamp time.Time
+}
+
+// ReadFunc is called for every record, returning false stops the reading
+type ReadFunc func(rec Record) (bool, error)
+
+type ChangeFeed interface {
+	app.ComponentRunnable
+	// LastCursor returns the cursor of the last written record
+	LastCursor() uint64
+	// Read calls readFunc for the records after the cursor, zero cursor reads the feed from the beginning
+	Read(ctx context.Context, cursor uint64, readFunc ReadFunc) error
+	// Tail reads the records after the cursor and waits for the new ones until the context is done,
+	// readFunc returns false or the feed is closed
+	Tail(ctx context.Context, cursor uint64, readFunc ReadFunc) error
+	// Truncate removes the records up to the cursor including it, the readers with the older cursors miss them.
+	// The last record is always kept, because the cursor is restored from it
+	Truncate(ctx context.Context, cursor uint64) error
+}
+
+func New() ChangeFeed {
+	return &changeFeed{
+		notify:  make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+}
+
+type changeFeed struct {
+	coll       anystore.Collection
+	arena      *anyenc.Arena
+	aclId      string
+	settingsId string
+	keyValueId string
+	lastCursor uint64
+	notify     chan struct{}
+	// written is set when the record is written in the transaction which is not committed yet
+	written bool
+	closing chan struct{}
+	mu      sync.Mutex
+}
+
+func (f *changeFeed) Init(a *app.App) (err error) {
+	ctx := context.Background()
+	st := a.MustComponent(spacestorage.CName).(spacestorage.SpaceStorage)
+	state, err := st.StateStorage().GetState(ctx)
+	if err != nil {
+		return
+	}
+	f.aclId, f.settingsId = state.AclId, state.SettingsId
+	if f.keyValueId, err = keyvalue.StorageIdFromSpace(state.SpaceId); err != nil {
+		return
+	}
+	if f.coll, err = st.AnyStore().Collection(ctx, CollName); err != nil {
+		return
+	}
+	if f.lastCursor, err = f.readLastCursor(ctx); err != nil {
+		return
+	}
+	f.arena = &anyenc.Arena{}
+	// the observer should be added before the other components start to write to the storage
+	st.HeadStorage().AddTxObserver(f)
+	return
+}
+
+func (f *changeFeed) Name() (name string) {
+	return CName
+}
+
+func (f *changeFeed) Run(ctx context.Context) (err error) {
+	return nil
+}
+
+func (f *changeFeed) readLastCursor(ctx context.Context) (cursor uint64, err error) {
+	iter, err := f.coll.Find(nil).Sort("-" + idKey).Limit(1).Iter(ctx)
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	if iter.Next() {
+		doc, err := iter.Doc()
+		if err != nil {
+			return 0, err
+		}
+		cursor = uint64(doc.Value().GetFloat64(idKey))
+	}
+	return cursor, iter.Err()
+}
+
+func (f *changeFeed) LastCursor() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastCursor
+}
+
+// OnUpdateTx writes the record in the transaction of the heads update.
+// The error is not returned, so the feed never rolls back the update
+func (f *changeFeed) OnUpdateTx(txCtx context.Context, update headstorage.HeadsUpdate) (err error) {
+	if update.Heads == nil && update.DeletedStatus == nil {
+		// the updates of the other fields are not the changes of the object
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	select {
+	case <-f.closing:
+		return nil
+	default:
+	}
+	defer f.arena.Reset()
+	cursor := f.lastCursor + 1
+	val := f.arena.NewObject()
+	val.Set(idKey, f.arena.NewNumberFloat64(float64(cursor)))
+	val.Set(objectIdKey, f.arena.NewString(update.Id))
+	val.Set(kindKey, f.arena.NewNumberInt(int(f.objectKind(update.Id))))
+	if update.Heads != nil {
+		val.Set(headsKey, storeutil.NewStringArrayValue(update.Heads, f.arena))
+	}
+	if update.DeletedStatus != nil {
+		val.Set(deletedKey, f.arena.NewNumberInt(int(*update.DeletedStatus)))
+	}
+	if peerId, err := peer.CtxPeerId(txCtx); err == nil {
+		val.Set(peerIdKey, f.arena.NewString(peerId))
+	}
+	val.Set(timestampKey, f.arena.NewNumberFloat64(float64(time.Now().UnixMilli())))
+	// if the record is not written or the transaction is rolled back the cursor is skipped
+	f.lastCursor = cursor
+	if err = f.coll.Insert(txCtx, val); err != nil {
+		log.Error("failed to write the record", zap.String("objectId", update.Id), zap.Error(err))
+		return nil
+	}
+	f.written = true
+	return
+}
+
+// OnCommit wakes the tailing readers when the transaction with the written records is committed.
+// If the transaction was rolled back, the readers are woken by the next commit and just find nothing new
+func (f *changeFeed) OnCommit() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.written {
+		return
+	}
+	f.written = false
+	close(f.notify)
+	f.notify = make(chan struct{})
+}
+
+func (f *changeFeed) objectKind(id string) ObjectKind {
+	switch id {
+	case f.aclId:
+		return ObjectKindAcl
+	case f.settingsId:
+		return ObjectKindSettings
+	case f.keyValueId:
+		return ObjectKindKeyValue
+	}
+	return ObjectKindTree
+}
+
+func (f *changeFeed) Read(ctx context.Context, cursor uint64, readFunc ReadFunc) (err error) {
+	_, _, err = f.read(ctx, cursor, readFunc)
+	return
+}
+
+// read returns the cursor of the last read record and whether the reading should be continued
+func (f *changeFeed) read(ctx context.Context, cursor uint64, readFunc ReadFunc) (lastCursor uint64, cont bool, err error) {
+	lastCursor = cursor
+	for {
+		filter := query.Key{Path: []string{idKey}, Filter: query.NewComp(query.CompOpGt, float64(lastCursor))}
+		iter, err := f.coll.Find(filter).Sort(idKey).Limit(defaultReadLimit).Iter(ctx)
+		if err != nil {
+			return lastCursor, false, err
+		}
+		var count int
+		for iter.Next() {
+			doc, err := iter.Doc()
+			if err != nil {
+				_ = iter.Close()
+				return lastCursor, false, err
+			}
+			rec := recordFromValue(doc.Value())
+			count++
+			lastCursor = rec.Cursor
+			if cont, err = readFunc(rec); !cont || err != nil {
+				_ = iter.Close()
+				return lastCursor, false, err
+			}
+		}
+		if err = iter.Close(); err != nil {
+			return lastCursor, false, err
+		}
+		if count < defaultReadLimit {
+			return lastCursor, true, nil
+		}
+	}
+}
+
+func (f *changeFeed) Tail(ctx context.Context, cursor uint64, readFunc ReadFunc) (err error) {
+	for {
+		f.mu.Lock()
+		notify := f.notify
+		f.mu.Unlock()
+		var cont bool
+		if cursor, cont, err = f.read(ctx, cursor, readFunc); err != nil || !cont {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.closing:
+			return ErrClosed
+		case <-notify:
+		}
+	}
+}
+
+func (f *changeFeed) Truncate(ctx context.Context, cursor uint64) (err error) {
+	last, err := f.readLastCursor(ctx)
+	if err != nil {
+		return
+	}
+	if cursor >= last {
+		if last == 0 {
+			return nil
+		}
+		cursor = last - 1
+	}
+	filter := query.Key{Path: []string{idKey}, Filter: query.NewComp(query.CompOpLte, float64(cursor))}
+	_, err = f.coll.Find(filter).Delete(ctx)
+	return
+}
+
+func (f *changeFeed) Close(ctx context.Context) (err error) {
+	f.mu.Lock()
+	select {
+	case <-f.closing:
+	default:
+		close(f.closing)
+	}
+	f.mu.Unlock()
+	return
+}
+
+func recordFromValue(v *anyenc.Value) Record {
+	rec := Record{
+		Cursor:        uint64(v.GetFloat64(idKey)),
+		ObjectId:      v.GetString(objectIdKey),
+		Kind:          ObjectKind(v.GetInt(kindKey)),
+		Heads:         storeutil.StringsFromArrayValue(v, headsKey),
+		DeletedStatus: headstorage.DeletedStatus(v.GetInt(deletedKey)),
+		PeerId:        v.GetString(peerIdKey),
+		Timestamp:     time.UnixMilli(int64(v.GetFloat64(timestampKey))),
+	}
+	return rec
+}
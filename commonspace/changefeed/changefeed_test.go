@@ -0,0 +1,218 @@
+package changefeed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
+	"github.com/anyproto/any-sync/commonspace/headsync/statestorage"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/memstorage"
+	"github.com/anyproto/any-sync/net/peer"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ctx = context.Background()
+
+func TestChangeFeed(t *testing.T) {
+	t.Run("records", func(t *testing.T) {
+		fx := newFixture(t)
+		kvId, err := keyvalue.StorageIdFromSpace(fx.state.SpaceId)
+		require.NoError(t, err)
+		deleted := headstorage.DeletedStatusQueued
+		fx.update(t, peer.CtxWithPeerId(ctx, "peerId"), headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head"}})
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: fx.state.AclId, Heads: []string{"aclHead"}})
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: kvId, Heads: []string{"hash"}})
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", DeletedStatus: &deleted})
+		snapshot := "snapshot"
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", CommonSnapshot: &snapshot})
+		require.Equal(t, uint64(4), fx.LastCursor())
+
+		records := fx.read(t, 0)
+		require.Len(t, records, 4)
+		assert.Equal(t, Record{Cursor: 1, ObjectId: "tree", Kind: ObjectKindTree, Heads: []string{"head"}, PeerId: "peerId"}, records[0])
+		assert.Equal(t, ObjectKindAcl, records[1].Kind)
+		assert.Empty(t, records[1].PeerId)
+		assert.Equal(t, ObjectKindKeyValue, records[2].Kind)
+		assert.Equal(t, Record{Cursor: 4, ObjectId: "tree", Heads: []string{}, DeletedStatus: headstorage.DeletedStatusQueued}, records[3])
+
+		records = fx.read(t, 2)
+		require.Len(t, records, 2)
+		assert.Equal(t, uint64(3), records[0].Cursor)
+	})
+	t.Run("cursor is restored", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head"}})
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head2"}})
+		require.NoError(t, fx.Close(ctx))
+
+		feed := New()
+		require.NoError(t, feed.Init(fx.a))
+		assert.Equal(t, uint64(2), feed.LastCursor())
+	})
+	t.Run("rolled back update", func(t *testing.T) {
+		fx := newFixture(t)
+		tx, err := fx.st.AnyStore().WriteTx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, fx.st.HeadStorage().UpdateEntryTx(tx.Context(), headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head"}}))
+		require.NoError(t, tx.Rollback())
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head2"}})
+
+		records := fx.read(t, 0)
+		require.Len(t, records, 1)
+		assert.Equal(t, []string{"head2"}, records[0].Heads)
+	})
+	t.Run("tail", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head"}})
+		tailCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		records := make(chan Record)
+		done := make(chan error, 1)
+		go func() {
+			done <- fx.Tail(tailCtx, 0, func(rec Record) (bool, error) {
+				records <- rec
+				return rec.Cursor < 2, nil
+			})
+		}()
+		assert.Equal(t, []string{"head"}, (<-records).Heads)
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head2"}})
+		assert.Equal(t, []string{"head2"}, (<-records).Heads)
+		require.NoError(t, <-done)
+	})
+	t.Run("tail is notified after commit", func(t *testing.T) {
+		fx := newFixture(t)
+		tailCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		records := make(chan Record, 1)
+		go func() {
+			_ = fx.Tail(tailCtx, 0, func(rec Record) (bool, error) {
+				records <- rec
+				return false, nil
+			})
+		}()
+		tx, err := fx.st.AnyStore().WriteTx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, fx.st.HeadStorage().UpdateEntryTx(tx.Context(), headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head"}}))
+		select {
+		case <-records:
+			t.Fatal("record is read before commit")
+		case <-time.After(100 * time.Millisecond):
+		}
+		require.NoError(t, fx.st.HeadStorage().CommitTx(tx))
+		select {
+		case rec := <-records:
+			assert.Equal(t, []string{"head"}, rec.Heads)
+		case <-time.After(5 * time.Second):
+			t.Fatal("record is not read after commit")
+		}
+	})
+	t.Run("write error doesn't fail the update", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head"}})
+		// the next record gets the existing cursor
+		fx.ChangeFeed.(*changeFeed).lastCursor = 0
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head2"}})
+
+		entry, err := fx.st.HeadStorage().GetEntry(ctx, "tree")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"head2"}, entry.Heads)
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head3"}})
+		records := fx.read(t, 0)
+		require.Len(t, records, 2)
+		assert.Equal(t, []string{"head3"}, records[1].Heads)
+	})
+	t.Run("truncate", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head"}})
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head2"}})
+		fx.update(t, ctx, headstorage.HeadsUpdate{Id: "tree", Heads: []string{"head3"}})
+		require.NoError(t, fx.Truncate(ctx, 2))
+
+		records := fx.read(t, 0)
+		require.Len(t, records, 1)
+		assert.Equal(t, uint64(3), records[0].Cursor)
+		assert.Equal(t, uint64(3), fx.LastCursor())
+
+		require.NoError(t, fx.Truncate(ctx, 10))
+		require.NoError(t, fx.Close(ctx))
+		feed := New()
+		require.NoError(t, feed.Init(fx.a))
+		assert.Equal(t, uint64(3), feed.LastCursor())
+	})
+	t.Run("tail is stopped on close", func(t *testing.T) {
+		fx := newFixture(t)
+		done := make(chan error, 1)
+		go func() {
+			done <- fx.Tail(ctx, 0, func(rec Record) (bool, error) {
+				return true, nil
+			})
+		}()
+		require.NoError(t, fx.Close(ctx))
+		require.ErrorIs(t, <-done, ErrClosed)
+	})
+}
+
+type fixture struct {
+	ChangeFeed
+	a     *app.App
+	st    spacestorage.SpaceStorage
+	state statestorage.State
+}
+
+func newFixture(t *testing.T) *fixture {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	masterKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	metaKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	payload, err := spacepayloads.StoragePayloadForSpaceCreate(spacepayloads.SpaceCreatePayload{
+		SigningKey:     keys.SignKey,
+		SpaceType:      "space",
+		ReplicationKey: 10,
+		MasterKey:      masterKey,
+		ReadKey:        crypto.NewAES(),
+		MetadataKey:    metaKey,
+		Metadata:       []byte("account"),
+	})
+	require.NoError(t, err)
+	provider := memstorage.New()
+	t.Cleanup(func() {
+		_ = provider.Close(ctx)
+	})
+	fx := &fixture{
+		ChangeFeed: New(),
+		a:          new(app.App),
+	}
+	fx.st, err = provider.CreateSpaceStorage(ctx, payload)
+	require.NoError(t, err)
+	fx.state, err = fx.st.StateStorage().GetState(ctx)
+	require.NoError(t, err)
+	fx.a.Register(fx.st)
+	require.NoError(t, fx.Init(fx.a))
+	require.NoError(t, fx.Run(ctx))
+	return fx
+}
+
+func (fx *fixture) update(t *testing.T, ctx context.Context, update headstorage.HeadsUpdate) {
+	require.NoError(t, fx.st.HeadStorage().UpdateEntry(ctx, update))
+}
+
+func (fx *fixture) read(t *testing.T, cursor uint64) (records []Record) {
+	err := fx.Read(ctx, cursor, func(rec Record) (bool, error) {
+		rec.Timestamp = time.Time{}
+		records = append(records, rec)
+		return true, nil
+	})
+	require.NoError(t, err)
+	return
+}
@@ -8,4 +8,8 @@ type Config struct {
 	GCTTL                int  `yaml:"gcTTL"`
 	SyncPeriod           int  `yaml:"syncPeriod"`
 	KeepTreeDataInMemory bool `yaml:"keepTreeDataInMemory"`
+	KeyValueTombstoneTTL int  `yaml:"keyValueTombstoneTTL"`
+	// SnapshotIntervals are the numbers of the changes between the snapshots by the change types of the trees,
+	// zero disables the snapshots, the other change types use objecttree.DefaultSnapshotPolicy
+	SnapshotIntervals map[string]int `yaml:"snapshotIntervals"`
 }
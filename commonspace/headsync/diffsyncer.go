@@ -23,6 +23,8 @@ import (
 	"github.com/anyproto/any-sync/commonspace/peermanager"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/commonspace/sync/syncdeps"
+	"github.com/anyproto/any-sync/commonspace/syncfilter"
 	"github.com/anyproto/any-sync/net/peer"
 	"github.com/anyproto/any-sync/util/slice"
 )
@@ -48,6 +50,7 @@ func newDiffSyncer(hs *headSync) DiffSyncer {
 		deletionState:      hs.deletionState,
 		syncAcl:            hs.syncAcl,
 		treeSyncer:         hs.treeSyncer,
+		syncFilter:         hs.syncFilter,
 	}
 }
 
@@ -67,6 +70,7 @@ type diffSyncer struct {
 	credentialProvider credentialprovider.CredentialProvider
 	keyValue           kvinterfaces.KeyValueService
 	syncAcl            syncacl.SyncAcl
+	syncFilter         syncfilter.SyncFilter
 }
 
 func (d *diffSyncer) Init() {
@@ -178,6 +182,10 @@ func (d *diffSyncer) syncWithPeer(ctx context.Context, p peer.Peer) (err error)
 	totalLen := len(newIds) + len(changedIds) + len(removedIds)
 	// not syncing ids which were removed through settings document
 	missingIds := d.deletionState.Filter(newIds)
+	// not pulling the trees which are filtered out, they are loaded only on demand
+	missingIds = slice.DiscardFromSlice(missingIds, func(id string) bool {
+		return !d.syncFilter.ShouldSync(ctx, id)
+	})
 	existingIds := append(d.deletionState.Filter(removedIds), d.deletionState.Filter(changedIds)...)
 	var (
 		isStorage = false
@@ -206,8 +214,9 @@ func (d *diffSyncer) syncWithPeer(ctx context.Context, p peer.Peer) (err error)
 		}
 	}
 
-	// treeSyncer should not get acl id, that's why we filter existing ids before
-	err = d.treeSyncer.SyncAll(ctx, p, existingIds, missingIds)
+	// treeSyncer should not get acl id, that's why we filter existing ids before,
+	// the trees are pulled in the background, so the requests of the opened objects are sent first
+	err = d.treeSyncer.SyncAll(syncdeps.CtxWithPriority(ctx, syncdeps.PriorityBackground), p, existingIds, missingIds)
 	if err != nil {
 		return err
 	}
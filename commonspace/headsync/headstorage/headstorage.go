@@ -53,22 +53,39 @@ type IterOpts struct {
 
 type HeadStorage interface {
 	AddObserver(observer Observer)
+	AddTxObserver(observer TxObserver)
 	IterateEntries(ctx context.Context, iterOpts IterOpts, iter EntryIterator) error
 	GetEntry(ctx context.Context, id string) (HeadsEntry, error)
 	DeleteEntryTx(txCtx context.Context, id string) error
 	UpdateEntryTx(txCtx context.Context, update HeadsUpdate) error
 	UpdateEntry(ctx context.Context, update HeadsUpdate) error
+	// CommitTx commits the transaction of the updates and notifies the commit observers
+	CommitTx(tx anystore.WriteTx) error
 }
 
 type Observer interface {
 	OnUpdate(update HeadsUpdate)
 }
 
+// TxObserver is called in the transaction of the update, so it can write its data atomically with the heads.
+// The error of the observer rolls back the update, so the observers which must not affect the sync should return nil.
+// The update may still be rolled back after the observer is called
+type TxObserver interface {
+	OnUpdateTx(txCtx context.Context, update HeadsUpdate) error
+}
+
+// CommitObserver can be implemented by the TxObserver to know when the transaction is committed.
+// It is called after every commit of the write transaction passed to CommitTx
+type CommitObserver interface {
+	OnCommit()
+}
+
 type headStorage struct {
-	store      anystore.DB
-	headsColl  anystore.Collection
-	observers  []Observer
-	parserPool *anyenc.ParserPool
+	store       anystore.DB
+	headsColl   anystore.Collection
+	observers   []Observer
+	txObservers []TxObserver
+	parserPool  *anyenc.ParserPool
 }
 
 func New(ctx context.Context, store anystore.DB) (HeadStorage, error) {
@@ -94,6 +111,11 @@ func (h *headStorage) AddObserver(observer Observer) {
 	h.observers = append(h.observers, observer)
 }
 
+func (h *headStorage) AddTxObserver(observer TxObserver) {
+	// same as with AddObserver the observers should be added before the storage is used
+	h.txObservers = append(h.txObservers, observer)
+}
+
 func (h *headStorage) IterateEntries(ctx context.Context, opts IterOpts, entryIter EntryIterator) error {
 	var qry any
 	if opts.Deleted {
@@ -138,7 +160,19 @@ func (h *headStorage) UpdateEntry(ctx context.Context, update HeadsUpdate) (err
 		tx.Rollback()
 		return
 	}
-	return tx.Commit()
+	return h.CommitTx(tx)
+}
+
+func (h *headStorage) CommitTx(tx anystore.WriteTx) error {
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, observer := range h.txObservers {
+		if commitObserver, ok := observer.(CommitObserver); ok {
+			commitObserver.OnCommit()
+		}
+	}
+	return nil
 }
 
 func (h *headStorage) UpdateEntryTx(ctx context.Context, update HeadsUpdate) (err error) {
@@ -168,7 +202,14 @@ func (h *headStorage) UpdateEntryTx(ctx context.Context, update HeadsUpdate) (er
 		}
 		return v, true, nil
 	})
-	_, err = h.headsColl.UpsertId(ctx, update.Id, mod)
+	if _, err = h.headsColl.UpsertId(ctx, update.Id, mod); err != nil {
+		return
+	}
+	for _, observer := range h.txObservers {
+		if err = observer.OnUpdateTx(ctx, update); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -12,6 +12,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	anystore "github.com/anyproto/any-store"
 	headstorage "github.com/anyproto/any-sync/commonspace/headsync/headstorage"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -51,6 +52,32 @@ func (mr *MockHeadStorageMockRecorder) AddObserver(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddObserver", reflect.TypeOf((*MockHeadStorage)(nil).AddObserver), arg0)
 }
 
+// AddTxObserver mocks base method.
+func (m *MockHeadStorage) AddTxObserver(arg0 headstorage.TxObserver) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddTxObserver", arg0)
+}
+
+// AddTxObserver indicates an expected call of AddTxObserver.
+func (mr *MockHeadStorageMockRecorder) AddTxObserver(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTxObserver", reflect.TypeOf((*MockHeadStorage)(nil).AddTxObserver), arg0)
+}
+
+// CommitTx mocks base method.
+func (m *MockHeadStorage) CommitTx(arg0 anystore.WriteTx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MockHeadStorageMockRecorder) CommitTx(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MockHeadStorage)(nil).CommitTx), arg0)
+}
+
 // DeleteEntryTx mocks base method.
 func (m *MockHeadStorage) DeleteEntryTx(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
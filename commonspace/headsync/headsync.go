@@ -22,6 +22,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/spacestate"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/commonspace/syncfilter"
 	"github.com/anyproto/any-sync/nodeconf"
 	"github.com/anyproto/any-sync/util/periodicsync"
 	"github.com/anyproto/any-sync/util/slice"
@@ -60,6 +61,7 @@ type headSync struct {
 	deletionState      deletionstate.ObjectDeletionState
 	syncAcl            syncacl.SyncAcl
 	keyValue           kvinterfaces.KeyValueService
+	syncFilter         syncfilter.SyncFilter
 }
 
 func New() HeadSync {
@@ -83,6 +85,7 @@ func (h *headSync) Init(a *app.App) (err error) {
 	h.treeSyncer = a.MustComponent(treesyncer.CName).(treesyncer.TreeSyncer)
 	h.deletionState = a.MustComponent(deletionstate.CName).(deletionstate.ObjectDeletionState)
 	h.keyValue = a.MustComponent(kvinterfaces.CName).(kvinterfaces.KeyValueService)
+	h.syncFilter = a.MustComponent(syncfilter.CName).(syncfilter.SyncFilter)
 	h.syncer = createDiffSyncer(h)
 	sync := func(ctx context.Context) (err error) {
 		return h.syncer.Sync(ctx)
@@ -18,6 +18,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
 	"github.com/anyproto/any-sync/commonspace/headsync/headstorage/mock_headstorage"
 	"github.com/anyproto/any-sync/commonspace/headsync/mock_headsync"
+	"github.com/anyproto/any-sync/commonspace/headsync/statestorage"
 	"github.com/anyproto/any-sync/commonspace/headsync/statestorage/mock_statestorage"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl"
@@ -25,6 +26,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/mock_keyvaluestorage"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvinterfaces"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvinterfaces/mock_kvinterfaces"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
 	"github.com/anyproto/any-sync/commonspace/object/treemanager"
 	"github.com/anyproto/any-sync/commonspace/object/treemanager/mock_treemanager"
 	"github.com/anyproto/any-sync/commonspace/object/treesyncer"
@@ -35,6 +37,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacestorage/mock_spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto/mock_spacesyncproto"
+	"github.com/anyproto/any-sync/commonspace/syncfilter"
 	"github.com/anyproto/any-sync/nodeconf"
 	"github.com/anyproto/any-sync/nodeconf/mock_nodeconf"
 	"github.com/anyproto/any-sync/testutil/anymock"
@@ -128,6 +131,7 @@ func newHeadSyncFixture(t *testing.T) *headSyncFixture {
 		Register(treeManagerMock).
 		Register(treeSyncerMock).
 		Register(deletionStateMock).
+		Register(syncfilter.New(nil)).
 		Register(hs)
 	return &headSyncFixture{
 		spaceState:             spaceState,
@@ -164,6 +168,18 @@ func (fx *headSyncFixture) init(t *testing.T) {
 	fx.headSync.diffContainer = fx.diffContainerMock
 }
 
+func (fx *headSyncFixture) setSyncFilter(t *testing.T, filter syncfilter.Filter) {
+	sf := syncfilter.New(filter)
+	fx.configurationMock.EXPECT().IsResponsible(fx.spaceState.SpaceId).Return(false)
+	fx.stateStorage.EXPECT().GetState(gomock.Any()).Return(statestorage.State{AclId: "aclId", SettingsId: "settingsId"}, nil)
+	fx.storageMock.EXPECT().TreeStorage(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, treestorage.ErrUnknownTreeId)
+	require.NoError(t, sf.Init(fx.app))
+	fx.headSync.syncFilter = sf
+	if fx.diffSyncer != nil {
+		fx.diffSyncer.syncFilter = sf
+	}
+}
+
 func (fx *headSyncFixture) stop() {
 	fx.ctrl.Finish()
 }
@@ -275,4 +291,56 @@ func TestHeadSync(t *testing.T) {
 		err = fx.headSync.Close(ctx)
 		require.NoError(t, err)
 	})
+	t.Run("run close, filtered local trees are in diff", func(t *testing.T) {
+		fx := newHeadSyncFixture(t)
+		fx.initDiffSyncer(t)
+		defer fx.stop()
+		fx.setSyncFilter(t, syncfilter.Ids("id1"))
+
+		headEntries := []headstorage.HeadsEntry{
+			{
+				Id:             "id1",
+				Heads:          []string{"h1", "h2"},
+				CommonSnapshot: "id1",
+			},
+			{
+				Id:             "id2",
+				Heads:          []string{"h3", "h4"},
+				CommonSnapshot: "id2",
+			},
+		}
+		fx.headStorage.EXPECT().IterateEntries(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, opts headstorage.IterOpts, entryIter headstorage.EntryIterator) error {
+				for _, entry := range headEntries {
+					if res, err := entryIter(entry); err != nil || !res {
+						return err
+					}
+				}
+				return nil
+			})
+		fx.aclMock.EXPECT().Id().AnyTimes().Return("aclId")
+		fx.aclMock.EXPECT().Head().AnyTimes().Return(&list.AclRecord{Id: "headId"})
+
+		fx.diffContainerMock.EXPECT().Set(ldiff.Element{
+			Id:   "id1",
+			Head: "h1h2",
+		}, ldiff.Element{
+			Id:   "id2",
+			Head: "h3h4",
+		}, ldiff.Element{
+			Id:   "aclId",
+			Head: "headId",
+		})
+		fx.diffMock.EXPECT().Set([]ldiff.Element{})
+		fx.diffContainerMock.EXPECT().NewDiff().AnyTimes().Return(fx.diffMock)
+		fx.diffContainerMock.EXPECT().OldDiff().AnyTimes().Return(fx.diffMock)
+		fx.diffMock.EXPECT().Hash().AnyTimes().Return("hash")
+		fx.stateStorage.EXPECT().SetHash(gomock.Any(), "hash", "hash").Return(nil)
+		fx.diffSyncerMock.EXPECT().Sync(gomock.Any()).Return(nil)
+		fx.diffSyncerMock.EXPECT().Close()
+		err := fx.headSync.Run(ctx)
+		require.NoError(t, err)
+		err = fx.headSync.Close(ctx)
+		require.NoError(t, err)
+	})
 }
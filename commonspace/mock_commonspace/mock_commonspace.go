@@ -15,6 +15,7 @@ import (
 
 	commonspace "github.com/anyproto/any-sync/commonspace"
 	aclclient "github.com/anyproto/any-sync/commonspace/acl/aclclient"
+	changefeed "github.com/anyproto/any-sync/commonspace/changefeed"
 	headsync "github.com/anyproto/any-sync/commonspace/headsync"
 	syncacl "github.com/anyproto/any-sync/commonspace/object/acl/syncacl"
 	kvinterfaces "github.com/anyproto/any-sync/commonspace/object/keyvalue/kvinterfaces"
@@ -80,6 +81,20 @@ func (mr *MockSpaceMockRecorder) AclClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AclClient", reflect.TypeOf((*MockSpace)(nil).AclClient))
 }
 
+// ChangeFeed mocks base method.
+func (m *MockSpace) ChangeFeed() changefeed.ChangeFeed {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeFeed")
+	ret0, _ := ret[0].(changefeed.ChangeFeed)
+	return ret0
+}
+
+// ChangeFeed indicates an expected call of ChangeFeed.
+func (mr *MockSpaceMockRecorder) ChangeFeed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeFeed", reflect.TypeOf((*MockSpace)(nil).ChangeFeed))
+}
+
 // Close mocks base method.
 func (m *MockSpace) Close() error {
 	m.ctrl.T.Helper()
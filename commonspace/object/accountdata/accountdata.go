@@ -2,13 +2,19 @@ package accountdata
 
 import (
 	"crypto/rand"
+
 	"github.com/anyproto/any-sync/util/crypto"
 )
 
+// AccountKeys are the keys of the account, they can be backed by the external signer (see crypto.NewSignerKey),
+// so the raw bytes of the keys are not always available
 type AccountKeys struct {
 	PeerKey crypto.PrivKey
 	SignKey crypto.PrivKey
 	PeerId  string
+	// EncryptionKey is the random hybrid key which decrypts the acl read keys,
+	// it isn't derived from the other keys, so it must be stored with them
+	EncryptionKey crypto.HybridDecryptor
 }
 
 func New(peerKey, signKey crypto.PrivKey) *AccountKeys {
@@ -19,6 +25,16 @@ func New(peerKey, signKey crypto.PrivKey) *AccountKeys {
 	}
 }
 
+// NewDevice returns the keys of the device which acts on behalf of the account with the certificate
+// issued by the account identity, the SignKey signs with the device key (see crypto.NewDeviceSignKey)
+func NewDevice(peerKey, deviceKey crypto.PrivKey, cert *crypto.DeviceCertificate) (*AccountKeys, error) {
+	signKey, err := crypto.NewDeviceSignKey(deviceKey, cert)
+	if err != nil {
+		return nil, err
+	}
+	return New(peerKey, signKey), nil
+}
+
 func NewRandom() (*AccountKeys, error) {
 	peerKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
 	if err != nil {
@@ -28,9 +44,14 @@ func NewRandom() (*AccountKeys, error) {
 	if err != nil {
 		return nil, err
 	}
+	encryptionKey, err := crypto.GenerateRandomHybridKey()
+	if err != nil {
+		return nil, err
+	}
 	return &AccountKeys{
-		PeerKey: peerKey,
-		SignKey: signKey,
-		PeerId:  peerKey.GetPublic().PeerId(),
+		PeerKey:       peerKey,
+		SignKey:       signKey,
+		PeerId:        peerKey.GetPublic().PeerId(),
+		EncryptionKey: encryptionKey,
 	}, nil
 }
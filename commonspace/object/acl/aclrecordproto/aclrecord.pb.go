@@ -47,6 +47,34 @@ func (AclInviteType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_c8e9f754f34e929b, []int{0}
 }
 
+// AclKeyEncryption is the scheme which is used to encrypt the read key for the identity
+type AclKeyEncryption int32
+
+const (
+	// X25519 uses the identity key converted to curve25519
+	AclKeyEncryption_X25519 AclKeyEncryption = 0
+	// X25519MlKem768 uses the hybrid encryption key of the account or the invite
+	AclKeyEncryption_X25519MlKem768 AclKeyEncryption = 1
+)
+
+var AclKeyEncryption_name = map[int32]string{
+	0: "X25519",
+	1: "X25519MlKem768",
+}
+
+var AclKeyEncryption_value = map[string]int32{
+	"X25519":         0,
+	"X25519MlKem768": 1,
+}
+
+func (x AclKeyEncryption) String() string {
+	return proto.EnumName(AclKeyEncryption_name, int32(x))
+}
+
+func (AclKeyEncryption) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{1}
+}
+
 // AclUserPermissions contains different possible user roles
 type AclUserPermissions int32
 
@@ -82,7 +110,60 @@ func (x AclUserPermissions) String() string {
 }
 
 func (AclUserPermissions) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{1}
+	return fileDescriptor_c8e9f754f34e929b, []int{2}
+}
+
+// AclCapability is an action which can be granted to a role
+type AclCapability int32
+
+const (
+	AclCapability_NoCapability      AclCapability = 0
+	AclCapability_WriteTrees        AclCapability = 1
+	AclCapability_WriteKeyValue     AclCapability = 2
+	AclCapability_DeleteObjects     AclCapability = 3
+	AclCapability_Invite            AclCapability = 4
+	AclCapability_ApproveJoin       AclCapability = 5
+	AclCapability_AddAccounts       AclCapability = 6
+	AclCapability_RemoveAccounts    AclCapability = 7
+	AclCapability_ChangePermissions AclCapability = 8
+	AclCapability_RequestRemove     AclCapability = 9
+	AclCapability_ManageRoles       AclCapability = 10
+)
+
+var AclCapability_name = map[int32]string{
+	0:  "NoCapability",
+	1:  "WriteTrees",
+	2:  "WriteKeyValue",
+	3:  "DeleteObjects",
+	4:  "Invite",
+	5:  "ApproveJoin",
+	6:  "AddAccounts",
+	7:  "RemoveAccounts",
+	8:  "ChangePermissions",
+	9:  "RequestRemove",
+	10: "ManageRoles",
+}
+
+var AclCapability_value = map[string]int32{
+	"NoCapability":      0,
+	"WriteTrees":        1,
+	"WriteKeyValue":     2,
+	"DeleteObjects":     3,
+	"Invite":            4,
+	"ApproveJoin":       5,
+	"AddAccounts":       6,
+	"RemoveAccounts":    7,
+	"ChangePermissions": 8,
+	"RequestRemove":     9,
+	"ManageRoles":       10,
+}
+
+func (x AclCapability) String() string {
+	return proto.EnumName(AclCapability_name, int32(x))
+}
+
+func (AclCapability) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{3}
 }
 
 // AclRoot is a root of access control list
@@ -208,6 +289,15 @@ type AclAccountInvite struct {
 	InviteType       AclInviteType      `protobuf:"varint,2,opt,name=inviteType,proto3,enum=aclrecord.AclInviteType" json:"inviteType,omitempty"`
 	Permissions      AclUserPermissions `protobuf:"varint,3,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
 	EncryptedReadKey []byte             `protobuf:"bytes,4,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
+	// ExpireTimestamp is a unix time (seconds) after which the invite can't be used to join, 0 means no expiry
+	ExpireTimestamp int64 `protobuf:"varint,5,opt,name=expireTimestamp,proto3" json:"expireTimestamp,omitempty"`
+	// MaxUses limits the number of joins made with the invite, 0 means unlimited
+	MaxUses uint32 `protobuf:"varint,6,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	// EncryptionKey is the public part of the random hybrid key of the invite,
+	// the private part is transmitted along with the invite key
+	EncryptionKey []byte `protobuf:"bytes,7,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
+	// Encryption is the scheme which is used to encrypt the read key
+	Encryption AclKeyEncryption `protobuf:"varint,8,opt,name=encryption,proto3,enum=aclrecord.AclKeyEncryption" json:"encryption,omitempty"`
 }
 
 func (m *AclAccountInvite) Reset()         { *m = AclAccountInvite{} }
@@ -279,6 +369,34 @@ func (m *AclAccountInvite) GetEncryptedReadKey() []byte {
 	return nil
 }
 
+func (m *AclAccountInvite) GetExpireTimestamp() int64 {
+	if m != nil {
+		return m.ExpireTimestamp
+	}
+	return 0
+}
+
+func (m *AclAccountInvite) GetMaxUses() uint32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *AclAccountInvite) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
+func (m *AclAccountInvite) GetEncryption() AclKeyEncryption {
+	if m != nil {
+		return m.Encryption
+	}
+	return AclKeyEncryption_X25519
+}
+
 type AclAccountInviteChange struct {
 	InviteRecordId string             `protobuf:"bytes,1,opt,name=inviteRecordId,proto3" json:"inviteRecordId,omitempty"`
 	Permissions    AclUserPermissions `protobuf:"varint,2,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
@@ -346,6 +464,8 @@ type AclAccountRequestJoin struct {
 	InviteIdentitySignature []byte `protobuf:"bytes,3,opt,name=inviteIdentitySignature,proto3" json:"inviteIdentitySignature,omitempty"`
 	// Metadata is encrypted with metadata key of the space
 	Metadata []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// EncryptionKey is the hybrid key of the requestor, the read keys are encrypted with it on accept
+	EncryptionKey []byte `protobuf:"bytes,5,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
 }
 
 func (m *AclAccountRequestJoin) Reset()         { *m = AclAccountRequestJoin{} }
@@ -417,6 +537,13 @@ func (m *AclAccountRequestJoin) GetMetadata() []byte {
 	return nil
 }
 
+func (m *AclAccountRequestJoin) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
 // AclInviteJoin contains the reference to the invite record and the data of the person who wants to join, confirmed by the private invite key
 // The person must encrypt the key with its own public key
 type AclAccountInviteJoin struct {
@@ -426,6 +553,10 @@ type AclAccountInviteJoin struct {
 	// Metadata is encrypted with metadata key of the space
 	Metadata         []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	EncryptedReadKey []byte `protobuf:"bytes,5,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
+	// EncryptionKey is the hybrid key of the person who joins
+	EncryptionKey []byte `protobuf:"bytes,6,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
+	// Encryption is the scheme which is used to encrypt the read key
+	Encryption AclKeyEncryption `protobuf:"varint,7,opt,name=encryption,proto3,enum=aclrecord.AclKeyEncryption" json:"encryption,omitempty"`
 }
 
 func (m *AclAccountInviteJoin) Reset()         { *m = AclAccountInviteJoin{} }
@@ -504,12 +635,28 @@ func (m *AclAccountInviteJoin) GetEncryptedReadKey() []byte {
 	return nil
 }
 
+func (m *AclAccountInviteJoin) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
+func (m *AclAccountInviteJoin) GetEncryption() AclKeyEncryption {
+	if m != nil {
+		return m.Encryption
+	}
+	return AclKeyEncryption_X25519
+}
+
 // AclAccountRequestAccept contains the reference to join record and all read keys, encrypted with the identity of the requestor
 type AclAccountRequestAccept struct {
 	Identity         []byte             `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
 	RequestRecordId  string             `protobuf:"bytes,2,opt,name=requestRecordId,proto3" json:"requestRecordId,omitempty"`
 	EncryptedReadKey []byte             `protobuf:"bytes,3,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
 	Permissions      AclUserPermissions `protobuf:"varint,4,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+	// Encryption is the scheme which is used to encrypt the read key
+	Encryption AclKeyEncryption `protobuf:"varint,5,opt,name=encryption,proto3,enum=aclrecord.AclKeyEncryption" json:"encryption,omitempty"`
 }
 
 func (m *AclAccountRequestAccept) Reset()         { *m = AclAccountRequestAccept{} }
@@ -581,6 +728,13 @@ func (m *AclAccountRequestAccept) GetPermissions() AclUserPermissions {
 	return AclUserPermissions_None
 }
 
+func (m *AclAccountRequestAccept) GetEncryption() AclKeyEncryption {
+	if m != nil {
+		return m.Encryption
+	}
+	return AclKeyEncryption_X25519
+}
+
 // AclAccountRequestDecline contains the reference to join record
 type AclAccountRequestDecline struct {
 	RequestRecordId string `protobuf:"bytes,1,opt,name=requestRecordId,proto3" json:"requestRecordId,omitempty"`
@@ -689,8 +843,9 @@ func (m *AclAccountInviteRevoke) GetInviteRecordId() string {
 
 // AclEncryptedReadKeys are new key for specific identity
 type AclEncryptedReadKey struct {
-	Identity         []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
-	EncryptedReadKey []byte `protobuf:"bytes,2,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
+	Identity         []byte           `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	EncryptedReadKey []byte           `protobuf:"bytes,2,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
+	Encryption       AclKeyEncryption `protobuf:"varint,3,opt,name=encryption,proto3,enum=aclrecord.AclKeyEncryption" json:"encryption,omitempty"`
 }
 
 func (m *AclEncryptedReadKey) Reset()         { *m = AclEncryptedReadKey{} }
@@ -748,6 +903,66 @@ func (m *AclEncryptedReadKey) GetEncryptedReadKey() []byte {
 	return nil
 }
 
+func (m *AclEncryptedReadKey) GetEncryption() AclKeyEncryption {
+	if m != nil {
+		return m.Encryption
+	}
+	return AclKeyEncryption_X25519
+}
+
+// AclAccountEncryptionKey sets the hybrid encryption key of the record author, the next read keys are encrypted with it
+type AclAccountEncryptionKey struct {
+	EncryptionKey []byte `protobuf:"bytes,1,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
+}
+
+func (m *AclAccountEncryptionKey) Reset()         { *m = AclAccountEncryptionKey{} }
+func (m *AclAccountEncryptionKey) String() string { return proto.CompactTextString(m) }
+func (*AclAccountEncryptionKey) ProtoMessage()    {}
+func (*AclAccountEncryptionKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{9}
+}
+func (m *AclAccountEncryptionKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclAccountEncryptionKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclAccountEncryptionKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclAccountEncryptionKey) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *AclAccountEncryptionKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclAccountEncryptionKey.Merge(m, src)
+}
+func (m *AclAccountEncryptionKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclAccountEncryptionKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclAccountEncryptionKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclAccountEncryptionKey proto.InternalMessageInfo
+
+func (m *AclAccountEncryptionKey) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
 // AclAccountPermissionChanges contains permission changes for certain identities
 type AclAccountPermissionChanges struct {
 	Changes []*AclAccountPermissionChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
@@ -757,7 +972,7 @@ func (m *AclAccountPermissionChanges) Reset()         { *m = AclAccountPermissio
 func (m *AclAccountPermissionChanges) String() string { return proto.CompactTextString(m) }
 func (*AclAccountPermissionChanges) ProtoMessage()    {}
 func (*AclAccountPermissionChanges) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{9}
+	return fileDescriptor_c8e9f754f34e929b, []int{10}
 }
 func (m *AclAccountPermissionChanges) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -810,7 +1025,7 @@ func (m *AclAccountsAdd) Reset()         { *m = AclAccountsAdd{} }
 func (m *AclAccountsAdd) String() string { return proto.CompactTextString(m) }
 func (*AclAccountsAdd) ProtoMessage()    {}
 func (*AclAccountsAdd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{10}
+	return fileDescriptor_c8e9f754f34e929b, []int{11}
 }
 func (m *AclAccountsAdd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -860,13 +1075,17 @@ type AclAccountAdd struct {
 	Permissions      AclUserPermissions `protobuf:"varint,2,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
 	Metadata         []byte             `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	EncryptedReadKey []byte             `protobuf:"bytes,4,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
+	// EncryptionKey is the hybrid key of the added account
+	EncryptionKey []byte `protobuf:"bytes,5,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
+	// Encryption is the scheme which is used to encrypt the read key
+	Encryption AclKeyEncryption `protobuf:"varint,6,opt,name=encryption,proto3,enum=aclrecord.AclKeyEncryption" json:"encryption,omitempty"`
 }
 
 func (m *AclAccountAdd) Reset()         { *m = AclAccountAdd{} }
 func (m *AclAccountAdd) String() string { return proto.CompactTextString(m) }
 func (*AclAccountAdd) ProtoMessage()    {}
 func (*AclAccountAdd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{11}
+	return fileDescriptor_c8e9f754f34e929b, []int{12}
 }
 func (m *AclAccountAdd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -931,6 +1150,20 @@ func (m *AclAccountAdd) GetEncryptedReadKey() []byte {
 	return nil
 }
 
+func (m *AclAccountAdd) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
+func (m *AclAccountAdd) GetEncryption() AclKeyEncryption {
+	if m != nil {
+		return m.Encryption
+	}
+	return AclKeyEncryption_X25519
+}
+
 // AclRequestCancel contains reference to the request that is canceled by the account
 type AclAccountRequestCancel struct {
 	RecordId string `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
@@ -940,7 +1173,7 @@ func (m *AclAccountRequestCancel) Reset()         { *m = AclAccountRequestCancel
 func (m *AclAccountRequestCancel) String() string { return proto.CompactTextString(m) }
 func (*AclAccountRequestCancel) ProtoMessage()    {}
 func (*AclAccountRequestCancel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{12}
+	return fileDescriptor_c8e9f754f34e929b, []int{13}
 }
 func (m *AclAccountRequestCancel) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -994,7 +1227,7 @@ func (m *AclAccountPermissionChange) Reset()         { *m = AclAccountPermission
 func (m *AclAccountPermissionChange) String() string { return proto.CompactTextString(m) }
 func (*AclAccountPermissionChange) ProtoMessage()    {}
 func (*AclAccountPermissionChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{13}
+	return fileDescriptor_c8e9f754f34e929b, []int{14}
 }
 func (m *AclAccountPermissionChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1060,7 +1293,7 @@ func (m *AclReadKeyChange) Reset()         { *m = AclReadKeyChange{} }
 func (m *AclReadKeyChange) String() string { return proto.CompactTextString(m) }
 func (*AclReadKeyChange) ProtoMessage()    {}
 func (*AclReadKeyChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{14}
+	return fileDescriptor_c8e9f754f34e929b, []int{15}
 }
 func (m *AclReadKeyChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1142,7 +1375,7 @@ func (m *AclAccountRemove) Reset()         { *m = AclAccountRemove{} }
 func (m *AclAccountRemove) String() string { return proto.CompactTextString(m) }
 func (*AclAccountRemove) ProtoMessage()    {}
 func (*AclAccountRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{15}
+	return fileDescriptor_c8e9f754f34e929b, []int{16}
 }
 func (m *AclAccountRemove) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1201,7 +1434,7 @@ func (m *AclAccountRequestRemove) Reset()         { *m = AclAccountRequestRemove
 func (m *AclAccountRequestRemove) String() string { return proto.CompactTextString(m) }
 func (*AclAccountRequestRemove) ProtoMessage()    {}
 func (*AclAccountRequestRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{16}
+	return fileDescriptor_c8e9f754f34e929b, []int{17}
 }
 func (m *AclAccountRequestRemove) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1238,6 +1471,174 @@ func (m *AclAccountRequestRemove) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AclAccountRequestRemove proto.InternalMessageInfo
 
+// AclAccountKeyRotate moves the account of the record author to the new identity
+type AclAccountKeyRotate struct {
+	NewIdentity []byte `protobuf:"bytes,1,opt,name=newIdentity,proto3" json:"newIdentity,omitempty"`
+	// NewIdentitySignature is the succession statement with the author and new identities signed by the author
+	NewIdentitySignature []byte `protobuf:"bytes,2,opt,name=newIdentitySignature,proto3" json:"newIdentitySignature,omitempty"`
+	// OldIdentitySignature is the same succession statement signed by the new identity
+	OldIdentitySignature []byte `protobuf:"bytes,3,opt,name=oldIdentitySignature,proto3" json:"oldIdentitySignature,omitempty"`
+	// EncryptedReadKey is the current read key encrypted with the new identity
+	EncryptedReadKey []byte `protobuf:"bytes,4,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
+	// EncryptionKey is the hybrid key of the new identity
+	EncryptionKey []byte `protobuf:"bytes,5,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
+	// Encryption is the scheme which is used to encrypt the read key
+	Encryption AclKeyEncryption `protobuf:"varint,6,opt,name=encryption,proto3,enum=aclrecord.AclKeyEncryption" json:"encryption,omitempty"`
+}
+
+func (m *AclAccountKeyRotate) Reset()         { *m = AclAccountKeyRotate{} }
+func (m *AclAccountKeyRotate) String() string { return proto.CompactTextString(m) }
+func (*AclAccountKeyRotate) ProtoMessage()    {}
+func (*AclAccountKeyRotate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{18}
+}
+func (m *AclAccountKeyRotate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclAccountKeyRotate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclAccountKeyRotate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclAccountKeyRotate) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *AclAccountKeyRotate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclAccountKeyRotate.Merge(m, src)
+}
+func (m *AclAccountKeyRotate) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclAccountKeyRotate) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclAccountKeyRotate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclAccountKeyRotate proto.InternalMessageInfo
+
+func (m *AclAccountKeyRotate) GetNewIdentity() []byte {
+	if m != nil {
+		return m.NewIdentity
+	}
+	return nil
+}
+
+func (m *AclAccountKeyRotate) GetNewIdentitySignature() []byte {
+	if m != nil {
+		return m.NewIdentitySignature
+	}
+	return nil
+}
+
+func (m *AclAccountKeyRotate) GetOldIdentitySignature() []byte {
+	if m != nil {
+		return m.OldIdentitySignature
+	}
+	return nil
+}
+
+func (m *AclAccountKeyRotate) GetEncryptedReadKey() []byte {
+	if m != nil {
+		return m.EncryptedReadKey
+	}
+	return nil
+}
+
+func (m *AclAccountKeyRotate) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
+func (m *AclAccountKeyRotate) GetEncryption() AclKeyEncryption {
+	if m != nil {
+		return m.Encryption
+	}
+	return AclKeyEncryption_X25519
+}
+
+// AclAccountDeviceRevoke revokes the device of the account, the account keeps its permissions and the read key is not changed
+type AclAccountDeviceRevoke struct {
+	Identity  []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	DeviceKey []byte `protobuf:"bytes,2,opt,name=deviceKey,proto3" json:"deviceKey,omitempty"`
+	// DeviceCertificate is the certificate issued by the identity to the device, it proves that the device belongs to the identity
+	DeviceCertificate []byte `protobuf:"bytes,3,opt,name=deviceCertificate,proto3" json:"deviceCertificate,omitempty"`
+}
+
+func (m *AclAccountDeviceRevoke) Reset()         { *m = AclAccountDeviceRevoke{} }
+func (m *AclAccountDeviceRevoke) String() string { return proto.CompactTextString(m) }
+func (*AclAccountDeviceRevoke) ProtoMessage()    {}
+func (*AclAccountDeviceRevoke) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{19}
+}
+func (m *AclAccountDeviceRevoke) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclAccountDeviceRevoke) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclAccountDeviceRevoke.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclAccountDeviceRevoke) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *AclAccountDeviceRevoke) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclAccountDeviceRevoke.Merge(m, src)
+}
+func (m *AclAccountDeviceRevoke) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclAccountDeviceRevoke) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclAccountDeviceRevoke.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclAccountDeviceRevoke proto.InternalMessageInfo
+
+func (m *AclAccountDeviceRevoke) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *AclAccountDeviceRevoke) GetDeviceKey() []byte {
+	if m != nil {
+		return m.DeviceKey
+	}
+	return nil
+}
+
+func (m *AclAccountDeviceRevoke) GetDeviceCertificate() []byte {
+	if m != nil {
+		return m.DeviceCertificate
+	}
+	return nil
+}
+
 // AclContentValue contains possible values for Acl
 type AclContentValue struct {
 	// Types that are valid to be assigned to Value:
@@ -1256,6 +1657,10 @@ type AclContentValue struct {
 	//	*AclContentValue_RequestCancel
 	//	*AclContentValue_InviteJoin
 	//	*AclContentValue_InviteChange
+	//	*AclContentValue_RoleDefine
+	//	*AclContentValue_KeyRotate
+	//	*AclContentValue_EncryptionKey
+	//	*AclContentValue_DeviceRevoke
 	Value isAclContentValueValue `protobuf_oneof:"value"`
 }
 
@@ -1263,7 +1668,7 @@ func (m *AclContentValue) Reset()         { *m = AclContentValue{} }
 func (m *AclContentValue) String() string { return proto.CompactTextString(m) }
 func (*AclContentValue) ProtoMessage()    {}
 func (*AclContentValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{17}
+	return fileDescriptor_c8e9f754f34e929b, []int{20}
 }
 func (m *AclContentValue) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1348,6 +1753,18 @@ type AclContentValue_InviteJoin struct {
 type AclContentValue_InviteChange struct {
 	InviteChange *AclAccountInviteChange `protobuf:"bytes,14,opt,name=inviteChange,proto3,oneof" json:"inviteChange,omitempty"`
 }
+type AclContentValue_RoleDefine struct {
+	RoleDefine *AclRoleDefine `protobuf:"bytes,15,opt,name=roleDefine,proto3,oneof" json:"roleDefine,omitempty"`
+}
+type AclContentValue_KeyRotate struct {
+	KeyRotate *AclAccountKeyRotate `protobuf:"bytes,16,opt,name=keyRotate,proto3,oneof" json:"keyRotate,omitempty"`
+}
+type AclContentValue_EncryptionKey struct {
+	EncryptionKey *AclAccountEncryptionKey `protobuf:"bytes,17,opt,name=encryptionKey,proto3,oneof" json:"encryptionKey,omitempty"`
+}
+type AclContentValue_DeviceRevoke struct {
+	DeviceRevoke *AclAccountDeviceRevoke `protobuf:"bytes,18,opt,name=deviceRevoke,proto3,oneof" json:"deviceRevoke,omitempty"`
+}
 
 func (*AclContentValue_Invite) isAclContentValueValue()               {}
 func (*AclContentValue_InviteRevoke) isAclContentValueValue()         {}
@@ -1363,6 +1780,10 @@ func (*AclContentValue_AccountsAdd) isAclContentValueValue()          {}
 func (*AclContentValue_RequestCancel) isAclContentValueValue()        {}
 func (*AclContentValue_InviteJoin) isAclContentValueValue()           {}
 func (*AclContentValue_InviteChange) isAclContentValueValue()         {}
+func (*AclContentValue_RoleDefine) isAclContentValueValue()           {}
+func (*AclContentValue_KeyRotate) isAclContentValueValue()            {}
+func (*AclContentValue_EncryptionKey) isAclContentValueValue()        {}
+func (*AclContentValue_DeviceRevoke) isAclContentValueValue()         {}
 
 func (m *AclContentValue) GetValue() isAclContentValueValue {
 	if m != nil {
@@ -1469,6 +1890,34 @@ func (m *AclContentValue) GetInviteChange() *AclAccountInviteChange {
 	return nil
 }
 
+func (m *AclContentValue) GetRoleDefine() *AclRoleDefine {
+	if x, ok := m.GetValue().(*AclContentValue_RoleDefine); ok {
+		return x.RoleDefine
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetKeyRotate() *AclAccountKeyRotate {
+	if x, ok := m.GetValue().(*AclContentValue_KeyRotate); ok {
+		return x.KeyRotate
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetEncryptionKey() *AclAccountEncryptionKey {
+	if x, ok := m.GetValue().(*AclContentValue_EncryptionKey); ok {
+		return x.EncryptionKey
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetDeviceRevoke() *AclAccountDeviceRevoke {
+	if x, ok := m.GetValue().(*AclContentValue_DeviceRevoke); ok {
+		return x.DeviceRevoke
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*AclContentValue) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1486,26 +1935,35 @@ func (*AclContentValue) XXX_OneofWrappers() []interface{} {
 		(*AclContentValue_RequestCancel)(nil),
 		(*AclContentValue_InviteJoin)(nil),
 		(*AclContentValue_InviteChange)(nil),
+		(*AclContentValue_RoleDefine)(nil),
+		(*AclContentValue_KeyRotate)(nil),
+		(*AclContentValue_EncryptionKey)(nil),
+		(*AclContentValue_DeviceRevoke)(nil),
 	}
 }
 
-// AclData contains different acl content
-type AclData struct {
-	AclContent []*AclContentValue `protobuf:"bytes,1,rep,name=aclContent,proto3" json:"aclContent,omitempty"`
+// AclRoleDefine defines a custom role, the role can't be changed after it is defined
+type AclRoleDefine struct {
+	// Role is the value which is used instead of the built-in permissions for the accounts and invites with this role
+	Role AclUserPermissions `protobuf:"varint,1,opt,name=role,proto3,enum=aclrecord.AclUserPermissions" json:"role,omitempty"`
+	// Name is a human readable name of the role
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Capabilities are the actions allowed for the role
+	Capabilities []AclCapability `protobuf:"varint,3,rep,packed,name=capabilities,proto3,enum=aclrecord.AclCapability" json:"capabilities,omitempty"`
 }
 
-func (m *AclData) Reset()         { *m = AclData{} }
-func (m *AclData) String() string { return proto.CompactTextString(m) }
-func (*AclData) ProtoMessage()    {}
-func (*AclData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{18}
+func (m *AclRoleDefine) Reset()         { *m = AclRoleDefine{} }
+func (m *AclRoleDefine) String() string { return proto.CompactTextString(m) }
+func (*AclRoleDefine) ProtoMessage()    {}
+func (*AclRoleDefine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{21}
 }
-func (m *AclData) XXX_Unmarshal(b []byte) error {
+func (m *AclRoleDefine) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AclData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AclRoleDefine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AclData.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AclRoleDefine.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1515,7 +1973,74 @@ func (m *AclData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *AclData) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+func (m *AclRoleDefine) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *AclRoleDefine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclRoleDefine.Merge(m, src)
+}
+func (m *AclRoleDefine) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclRoleDefine) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclRoleDefine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclRoleDefine proto.InternalMessageInfo
+
+func (m *AclRoleDefine) GetRole() AclUserPermissions {
+	if m != nil {
+		return m.Role
+	}
+	return AclUserPermissions_None
+}
+
+func (m *AclRoleDefine) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AclRoleDefine) GetCapabilities() []AclCapability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+// AclData contains different acl content
+type AclData struct {
+	AclContent []*AclContentValue `protobuf:"bytes,1,rep,name=aclContent,proto3" json:"aclContent,omitempty"`
+}
+
+func (m *AclData) Reset()         { *m = AclData{} }
+func (m *AclData) String() string { return proto.CompactTextString(m) }
+func (*AclData) ProtoMessage()    {}
+func (*AclData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{22}
+}
+func (m *AclData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclData) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
 	b = b[:newLen]
 	_, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
@@ -1544,7 +2069,9 @@ func (m *AclData) GetAclContent() []*AclContentValue {
 
 func init() {
 	proto.RegisterEnum("aclrecord.AclInviteType", AclInviteType_name, AclInviteType_value)
+	proto.RegisterEnum("aclrecord.AclKeyEncryption", AclKeyEncryption_name, AclKeyEncryption_value)
 	proto.RegisterEnum("aclrecord.AclUserPermissions", AclUserPermissions_name, AclUserPermissions_value)
+	proto.RegisterEnum("aclrecord.AclCapability", AclCapability_name, AclCapability_value)
 	proto.RegisterType((*AclRoot)(nil), "aclrecord.AclRoot")
 	proto.RegisterType((*AclAccountInvite)(nil), "aclrecord.AclAccountInvite")
 	proto.RegisterType((*AclAccountInviteChange)(nil), "aclrecord.AclAccountInviteChange")
@@ -1554,6 +2081,7 @@ func init() {
 	proto.RegisterType((*AclAccountRequestDecline)(nil), "aclrecord.AclAccountRequestDecline")
 	proto.RegisterType((*AclAccountInviteRevoke)(nil), "aclrecord.AclAccountInviteRevoke")
 	proto.RegisterType((*AclEncryptedReadKey)(nil), "aclrecord.AclEncryptedReadKey")
+	proto.RegisterType((*AclAccountEncryptionKey)(nil), "aclrecord.AclAccountEncryptionKey")
 	proto.RegisterType((*AclAccountPermissionChanges)(nil), "aclrecord.AclAccountPermissionChanges")
 	proto.RegisterType((*AclAccountsAdd)(nil), "aclrecord.AclAccountsAdd")
 	proto.RegisterType((*AclAccountAdd)(nil), "aclrecord.AclAccountAdd")
@@ -1562,7 +2090,10 @@ func init() {
 	proto.RegisterType((*AclReadKeyChange)(nil), "aclrecord.AclReadKeyChange")
 	proto.RegisterType((*AclAccountRemove)(nil), "aclrecord.AclAccountRemove")
 	proto.RegisterType((*AclAccountRequestRemove)(nil), "aclrecord.AclAccountRequestRemove")
+	proto.RegisterType((*AclAccountKeyRotate)(nil), "aclrecord.AclAccountKeyRotate")
+	proto.RegisterType((*AclAccountDeviceRevoke)(nil), "aclrecord.AclAccountDeviceRevoke")
 	proto.RegisterType((*AclContentValue)(nil), "aclrecord.AclContentValue")
+	proto.RegisterType((*AclRoleDefine)(nil), "aclrecord.AclRoleDefine")
 	proto.RegisterType((*AclData)(nil), "aclrecord.AclData")
 }
 
@@ -1571,80 +2102,107 @@ func init() {
 }
 
 var fileDescriptor_c8e9f754f34e929b = []byte{
-	// 1155 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x41, 0x8f, 0xdb, 0x54,
-	0x10, 0xb6, 0x93, 0xcd, 0x66, 0x33, 0xd9, 0x4d, 0xbd, 0xaf, 0xa5, 0x75, 0xb7, 0x10, 0x82, 0x51,
-	0xab, 0x68, 0x85, 0xba, 0x28, 0x68, 0xab, 0xaa, 0x02, 0xb6, 0x6e, 0xb6, 0x6a, 0xd2, 0xaa, 0x50,
-	0xbd, 0x96, 0x16, 0x21, 0x81, 0xe4, 0xb5, 0x9f, 0x8a, 0xc1, 0xb1, 0x83, 0xfd, 0x12, 0x94, 0x23,
-	0xff, 0x80, 0xff, 0xc0, 0x8d, 0x33, 0x57, 0xee, 0x1c, 0x38, 0xf4, 0x06, 0x07, 0x0e, 0x68, 0xf7,
-	0x17, 0xf0, 0x0f, 0xd0, 0x7b, 0x76, 0xec, 0xf7, 0x1c, 0x3b, 0xc9, 0x0a, 0xa1, 0x1e, 0xda, 0xb5,
-	0xe7, 0xcd, 0xcc, 0xfb, 0x66, 0xe6, 0x9b, 0x99, 0x18, 0x3e, 0xb4, 0x83, 0xd1, 0x28, 0xf0, 0xa3,
-	0xb1, 0x65, 0x93, 0x83, 0xe0, 0xe4, 0x1b, 0x62, 0xd3, 0x03, 0xcb, 0xf6, 0xd8, 0xbf, 0x90, 0xd8,
-	0x41, 0xe8, 0x8c, 0xc3, 0x80, 0x06, 0x07, 0xfc, 0xff, 0x28, 0x93, 0xde, 0xe4, 0x02, 0xd4, 0x48,
-	0x05, 0xc6, 0x3f, 0x15, 0xa8, 0x9b, 0xb6, 0x87, 0x83, 0x80, 0xa2, 0x3d, 0xd8, 0x72, 0x1d, 0xe2,
-	0x53, 0x97, 0xce, 0x74, 0xb5, 0xa3, 0x76, 0xb7, 0x71, 0xfa, 0x8e, 0xde, 0x84, 0xc6, 0xc8, 0x8a,
-	0x28, 0x09, 0x1f, 0x91, 0x99, 0x5e, 0xe1, 0x87, 0x99, 0x00, 0xe9, 0x50, 0xe7, 0x50, 0x86, 0x8e,
-	0x5e, 0xed, 0xa8, 0xdd, 0x06, 0x9e, 0xbf, 0xa2, 0x7d, 0xd0, 0x88, 0x6f, 0x87, 0xb3, 0x31, 0x25,
-	0x0e, 0x26, 0x96, 0xc3, 0xcc, 0x37, 0xb8, 0xf9, 0x82, 0x9c, 0xdd, 0x41, 0xdd, 0x11, 0x89, 0xa8,
-	0x35, 0x1a, 0xeb, 0xb5, 0x8e, 0xda, 0xad, 0xe2, 0x4c, 0x80, 0xde, 0x83, 0xdd, 0x39, 0x9a, 0xa7,
-	0xee, 0x4b, 0xdf, 0xa2, 0x93, 0x90, 0xe8, 0x9b, 0xdc, 0xd5, 0xe2, 0x01, 0xba, 0x01, 0xad, 0x11,
-	0xa1, 0x96, 0x63, 0x51, 0xeb, 0xc9, 0xe4, 0x84, 0xdd, 0x5a, 0xe7, 0xaa, 0x39, 0x29, 0xba, 0x03,
-	0x7a, 0x8a, 0xe3, 0xf1, 0xfc, 0x28, 0x74, 0xa7, 0xcc, 0x62, 0x8b, 0x5b, 0x94, 0x9e, 0xa3, 0x5b,
-	0x70, 0x39, 0x3d, 0xfb, 0xf4, 0x7b, 0x9f, 0x84, 0x73, 0x05, 0xbd, 0xc1, 0x2d, 0x4b, 0x4e, 0x8d,
-	0x3f, 0x54, 0xd0, 0x4c, 0xdb, 0x33, 0x6d, 0x3b, 0x98, 0xf8, 0x74, 0xe8, 0x4f, 0x5d, 0x4a, 0x58,
-	0xf0, 0x2e, 0x7f, 0x62, 0x37, 0xc7, 0xd9, 0xcf, 0x04, 0xe8, 0x36, 0x40, 0xfc, 0xf2, 0x6c, 0x36,
-	0x26, 0x3c, 0xff, 0xad, 0x9e, 0x7e, 0x33, 0xab, 0xab, 0x69, 0x7b, 0xc3, 0xf4, 0x1c, 0x0b, 0xba,
-	0xe8, 0x08, 0x9a, 0x63, 0x12, 0x8e, 0xdc, 0x28, 0x72, 0x03, 0x3f, 0xe2, 0xe5, 0x69, 0xf5, 0xde,
-	0x92, 0x4d, 0x3f, 0x8b, 0x48, 0xf8, 0x24, 0x53, 0xc2, 0xa2, 0xc5, 0x79, 0x2a, 0x68, 0xfc, 0xa0,
-	0xc2, 0xe5, 0x7c, 0x64, 0xfd, 0xaf, 0x2d, 0xff, 0x25, 0x2f, 0x48, 0x8c, 0x0a, 0xf3, 0x6b, 0x87,
-	0x0e, 0x0f, 0xb2, 0x81, 0x73, 0xd2, 0x3c, 0xde, 0xca, 0x79, 0xf1, 0x1a, 0xbf, 0xaa, 0xf0, 0x46,
-	0x86, 0x01, 0x93, 0xef, 0x26, 0x24, 0xa2, 0x0f, 0x03, 0xd7, 0xcf, 0x20, 0x0c, 0x65, 0x96, 0xe7,
-	0xa4, 0x05, 0x50, 0x2b, 0x85, 0x50, 0x6f, 0xc3, 0x15, 0xd9, 0x32, 0xe3, 0x65, 0x95, 0x3b, 0x2e,
-	0x3b, 0x66, 0x9d, 0x36, 0xe7, 0x61, 0x92, 0xcb, 0xf4, 0xdd, 0xf8, 0x4b, 0x85, 0x4b, 0xf9, 0x1c,
-	0x72, 0xf8, 0xcb, 0xda, 0xf3, 0xb5, 0x42, 0x2e, 0xa4, 0x48, 0xad, 0x84, 0x22, 0xbf, 0xab, 0x70,
-	0x65, 0xa1, 0x3c, 0xa6, 0x6d, 0x93, 0xf1, 0xf2, 0x01, 0xd4, 0x85, 0x0b, 0x61, 0xac, 0x9c, 0x0b,
-	0x31, 0x2f, 0x2e, 0x44, 0x53, 0x2d, 0x19, 0x39, 0x39, 0xb6, 0x6d, 0x9c, 0x9b, 0x6d, 0xc7, 0xa0,
-	0x2f, 0x44, 0x73, 0x4c, 0x6c, 0xcf, 0xf5, 0x49, 0x11, 0x64, 0xb5, 0x10, 0xb2, 0x71, 0x77, 0xb1,
-	0x6d, 0x30, 0x99, 0x06, 0xdf, 0xae, 0xdd, 0x36, 0xc6, 0x97, 0x70, 0xd1, 0xb4, 0xbd, 0xfb, 0xf9,
-	0xf8, 0x96, 0x65, 0xb4, 0x28, 0x4f, 0x95, 0x92, 0xaa, 0x7d, 0x05, 0xd7, 0x32, 0x80, 0x59, 0x32,
-	0xe2, 0xde, 0x8e, 0xd0, 0x11, 0xd4, 0xed, 0xf8, 0x51, 0x57, 0x3b, 0xd5, 0x6e, 0xb3, 0x77, 0x5d,
-	0x4e, 0x61, 0x89, 0x21, 0x9e, 0x5b, 0x19, 0x03, 0x68, 0x65, 0x6a, 0x91, 0xe9, 0x38, 0xe8, 0x16,
-	0x34, 0x2c, 0xc7, 0x71, 0x29, 0xaf, 0x4b, 0xec, 0x54, 0x2f, 0x74, 0x6a, 0x3a, 0x0e, 0xce, 0x54,
-	0x8d, 0x5f, 0x54, 0xd8, 0x91, 0x0e, 0x97, 0xe6, 0xe0, 0xbf, 0x4e, 0x1b, 0xa9, 0x2d, 0xaa, 0x6b,
-	0xb4, 0x45, 0xd9, 0xe4, 0x3c, 0x2c, 0xe8, 0x8a, 0xbe, 0xe5, 0xdb, 0xc4, 0x63, 0x57, 0x84, 0x72,
-	0xf1, 0xd3, 0x77, 0x63, 0x06, 0x7b, 0xe5, 0xe9, 0xfd, 0x5f, 0x23, 0x37, 0x7e, 0xae, 0xf0, 0x2d,
-	0x96, 0x04, 0x90, 0xdc, 0x78, 0x17, 0x9a, 0x56, 0x0c, 0xe6, 0x11, 0x99, 0xcd, 0xeb, 0xd6, 0x96,
-	0xbd, 0xe6, 0x49, 0x8a, 0x45, 0x93, 0x82, 0xc5, 0x5d, 0x39, 0xf7, 0xe2, 0xae, 0xae, 0x58, 0xdc,
-	0xef, 0xc3, 0xc5, 0x6c, 0x35, 0x7b, 0xb9, 0xda, 0x14, 0x1d, 0xa1, 0x8f, 0xe7, 0xfb, 0x97, 0x87,
-	0x55, 0x5b, 0x2b, 0x2c, 0xc1, 0xc2, 0x98, 0x88, 0x1b, 0x1f, 0x93, 0x51, 0x30, 0x25, 0xa8, 0x0d,
-	0x90, 0x54, 0xc3, 0x4d, 0xfa, 0x66, 0x1b, 0x0b, 0x12, 0x64, 0xc2, 0x4e, 0x28, 0x26, 0x97, 0x27,
-	0xa2, 0xd9, 0xbb, 0x26, 0x5f, 0x2b, 0xe5, 0x1f, 0xcb, 0x16, 0xc6, 0xd5, 0x02, 0x56, 0xc5, 0xb7,
-	0x1b, 0x3f, 0x6d, 0xc1, 0x05, 0xd3, 0xf6, 0xfa, 0x81, 0x4f, 0x89, 0x4f, 0x9f, 0x5b, 0xde, 0x84,
-	0xa0, 0x43, 0xd8, 0x8c, 0x31, 0x73, 0xb6, 0x2c, 0x5c, 0x25, 0xcd, 0xa7, 0x81, 0x82, 0x13, 0x65,
-	0xf4, 0x00, 0xb6, 0x5d, 0x61, 0x66, 0x25, 0x38, 0xdf, 0x59, 0x62, 0x1c, 0x2b, 0x0e, 0x14, 0x2c,
-	0x19, 0xa2, 0x63, 0x68, 0x86, 0xd9, 0xbe, 0xe6, 0x65, 0x6c, 0xf6, 0x3a, 0x85, 0x7e, 0x84, 0xbd,
-	0x3e, 0x50, 0xb0, 0x68, 0x86, 0x1e, 0xb2, 0xbc, 0x09, 0x6b, 0x85, 0xd7, 0xb5, 0xd9, 0x33, 0x96,
-	0xf9, 0x89, 0x35, 0x07, 0x0a, 0x96, 0x4d, 0xd1, 0x53, 0xd0, 0xc6, 0xb9, 0xae, 0xe2, 0x9b, 0x6d,
-	0xdd, 0x09, 0x37, 0x50, 0xf0, 0x82, 0x03, 0xd4, 0x87, 0x1d, 0x4b, 0x64, 0x02, 0xff, 0x15, 0x5b,
-	0x96, 0xed, 0x58, 0x85, 0x21, 0x93, 0x6c, 0x98, 0x13, 0x99, 0x1d, 0xf5, 0x95, 0xec, 0x88, 0xc3,
-	0x13, 0xdb, 0xf5, 0x31, 0xb4, 0x42, 0x69, 0x67, 0xf1, 0xdf, 0xbc, 0xcd, 0xde, 0xbb, 0xcb, 0x72,
-	0x95, 0xa8, 0x0e, 0x14, 0x9c, 0x33, 0x46, 0x9f, 0xc3, 0x25, 0xab, 0x80, 0x6b, 0xfc, 0xe7, 0xf0,
-	0x8a, 0x02, 0xa4, 0x61, 0x16, 0x7a, 0x40, 0xcf, 0x61, 0x37, 0x9f, 0xc6, 0x48, 0x07, 0xee, 0xf6,
-	0xc6, 0x5a, 0x85, 0x88, 0x06, 0x0a, 0x5e, 0x74, 0x81, 0x3e, 0x4a, 0xe7, 0x15, 0x5b, 0x3a, 0x7a,
-	0x93, 0x7b, 0xbc, 0x5a, 0xe8, 0x91, 0x29, 0x30, 0xaa, 0x09, 0xfa, 0x02, 0xd5, 0xe2, 0x59, 0xad,
-	0x6f, 0xaf, 0x8e, 0x34, 0xd6, 0x14, 0xa8, 0x96, 0x8c, 0x79, 0x73, 0x3e, 0x62, 0x38, 0xf7, 0x77,
-	0xb8, 0xa3, 0xb7, 0x97, 0xf4, 0x50, 0x42, 0x7d, 0xc1, 0x28, 0x6b, 0xc4, 0x84, 0x12, 0xad, 0x95,
-	0x8d, 0x98, 0x12, 0x43, 0x32, 0xbc, 0x57, 0x87, 0xda, 0x94, 0x4d, 0x04, 0xe3, 0x3e, 0xff, 0x3a,
-	0x3c, 0x66, 0xdb, 0xec, 0x0e, 0x80, 0x95, 0xce, 0x8b, 0x64, 0xb2, 0xef, 0xc9, 0xae, 0xc5, 0x61,
-	0x82, 0x05, 0xed, 0xfd, 0x43, 0xbe, 0x93, 0xb3, 0x2f, 0x14, 0xb4, 0x0b, 0x3b, 0x49, 0x3a, 0x9e,
-	0x05, 0x0c, 0xba, 0xa6, 0x30, 0x91, 0xe9, 0xcf, 0x02, 0x9f, 0xf4, 0x2d, 0x9f, 0x8b, 0xd4, 0xfd,
-	0x17, 0x80, 0x16, 0xb7, 0x10, 0xda, 0x82, 0x8d, 0x4f, 0x02, 0x9f, 0x68, 0x0a, 0x6a, 0x40, 0x8d,
-	0x7f, 0x59, 0x69, 0x2a, 0x7b, 0x34, 0x9d, 0x91, 0xeb, 0x6b, 0x15, 0x04, 0xb0, 0xf9, 0x22, 0x74,
-	0x29, 0x09, 0xb5, 0x2a, 0x7b, 0x66, 0x2d, 0x40, 0x42, 0x6d, 0x83, 0xa9, 0x3c, 0x60, 0x37, 0x6a,
-	0xb5, 0x7b, 0x47, 0xbf, 0x9d, 0xb6, 0xd5, 0x57, 0xa7, 0x6d, 0xf5, 0xef, 0xd3, 0xb6, 0xfa, 0xe3,
-	0x59, 0x5b, 0x79, 0x75, 0xd6, 0x56, 0xfe, 0x3c, 0x6b, 0x2b, 0x5f, 0x5c, 0x5f, 0xeb, 0xc3, 0xfa,
-	0x64, 0x93, 0xff, 0xf9, 0xe0, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xae, 0x8a, 0x0f, 0xac, 0x88,
-	0x0f, 0x00, 0x00,
+	// 1596 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcb, 0x8f, 0xdc, 0x44,
+	0x13, 0xb7, 0xe7, 0xb9, 0x53, 0xb3, 0x33, 0xeb, 0xed, 0xbc, 0x9c, 0xe4, 0xfb, 0xe6, 0x9b, 0xcf,
+	0x90, 0x68, 0xb5, 0x42, 0x09, 0x19, 0xb4, 0x21, 0x84, 0x90, 0x8d, 0xb3, 0xbb, 0xca, 0x6c, 0x56,
+	0x9b, 0x44, 0x9d, 0xcd, 0x43, 0x1c, 0x90, 0xbc, 0x76, 0x27, 0x98, 0x78, 0xec, 0xc1, 0xf6, 0x4e,
+	0x32, 0x37, 0x38, 0x72, 0xe3, 0x80, 0x84, 0xc4, 0x9f, 0xc0, 0x81, 0x13, 0x77, 0xae, 0x1c, 0x73,
+	0xe0, 0xc0, 0x11, 0x65, 0xff, 0x02, 0xfe, 0x01, 0x84, 0xba, 0xfd, 0x6c, 0xdb, 0xf3, 0x12, 0x42,
+	0x70, 0x48, 0xd6, 0x5d, 0x5d, 0x55, 0xd3, 0xf5, 0xab, 0x5f, 0x55, 0x97, 0x0d, 0x37, 0x74, 0x67,
+	0x30, 0x70, 0x6c, 0x6f, 0xa8, 0xe9, 0xe4, 0xb2, 0x73, 0xf8, 0x19, 0xd1, 0xfd, 0xcb, 0x9a, 0x6e,
+	0xd1, 0x7f, 0x2e, 0xd1, 0x1d, 0xd7, 0x18, 0xba, 0x8e, 0xef, 0x5c, 0x66, 0xff, 0x7b, 0x89, 0xf4,
+	0x12, 0x13, 0xa0, 0x46, 0x2c, 0x50, 0x7e, 0x2f, 0x41, 0x5d, 0xd5, 0x2d, 0xec, 0x38, 0x3e, 0x3a,
+	0x07, 0x4b, 0xa6, 0x41, 0x6c, 0xdf, 0xf4, 0xc7, 0xb2, 0xd8, 0x15, 0xd7, 0x96, 0x71, 0xbc, 0x46,
+	0xff, 0x81, 0xc6, 0x40, 0xf3, 0x7c, 0xe2, 0xee, 0x91, 0xb1, 0x5c, 0x62, 0x9b, 0x89, 0x00, 0xc9,
+	0x50, 0x67, 0x47, 0xd9, 0x35, 0xe4, 0x72, 0x57, 0x5c, 0x6b, 0xe0, 0x68, 0x89, 0xd6, 0x41, 0x22,
+	0xb6, 0xee, 0x8e, 0x87, 0x3e, 0x31, 0x30, 0xd1, 0x0c, 0x6a, 0x5e, 0x61, 0xe6, 0x39, 0x39, 0xfd,
+	0x0d, 0xdf, 0x1c, 0x10, 0xcf, 0xd7, 0x06, 0x43, 0xb9, 0xda, 0x15, 0xd7, 0xca, 0x38, 0x11, 0xa0,
+	0x77, 0x60, 0x35, 0x3a, 0xcd, 0x43, 0xf3, 0xb9, 0xad, 0xf9, 0x47, 0x2e, 0x91, 0x6b, 0xcc, 0x55,
+	0x7e, 0x03, 0x5d, 0x84, 0xf6, 0x80, 0xf8, 0x9a, 0xa1, 0xf9, 0xda, 0x83, 0xa3, 0x43, 0xfa, 0xab,
+	0x75, 0xa6, 0x9a, 0x91, 0xa2, 0xeb, 0x20, 0xc7, 0xe7, 0xd8, 0x8f, 0xb6, 0x5c, 0x73, 0x44, 0x2d,
+	0x96, 0x98, 0xc5, 0xc4, 0x7d, 0x74, 0x15, 0x4e, 0xc7, 0x7b, 0xf7, 0x5f, 0xda, 0xc4, 0x8d, 0x14,
+	0xe4, 0x06, 0xb3, 0x9c, 0xb0, 0xab, 0xfc, 0x51, 0x02, 0x49, 0xd5, 0x2d, 0x55, 0xd7, 0x9d, 0x23,
+	0xdb, 0xdf, 0xb5, 0x47, 0xa6, 0x4f, 0x68, 0xf0, 0x26, 0x7b, 0xda, 0x23, 0x11, 0xfa, 0x89, 0x00,
+	0x5d, 0x03, 0x08, 0x16, 0x07, 0xe3, 0x21, 0x61, 0xf8, 0xb7, 0x7b, 0xf2, 0xa5, 0x24, 0xaf, 0xaa,
+	0x6e, 0xed, 0xc6, 0xfb, 0x38, 0xa5, 0x8b, 0x36, 0xa1, 0x39, 0x24, 0xee, 0xc0, 0xf4, 0x3c, 0xd3,
+	0xb1, 0x3d, 0x96, 0x9e, 0x76, 0xef, 0xbf, 0xbc, 0xe9, 0x23, 0x8f, 0xb8, 0x0f, 0x12, 0x25, 0x9c,
+	0xb6, 0x58, 0x28, 0x83, 0x6b, 0xb0, 0x42, 0x5e, 0x0d, 0x4d, 0x97, 0x1c, 0x64, 0xf2, 0x98, 0x15,
+	0x53, 0xc6, 0x0c, 0xb4, 0x57, 0x8f, 0x3c, 0xe2, 0xb1, 0x1c, 0xb6, 0x70, 0xb4, 0x44, 0x6f, 0x43,
+	0x2b, 0xf4, 0x6b, 0x3a, 0x76, 0x92, 0x38, 0x5e, 0x88, 0x3e, 0x04, 0x48, 0x04, 0x2c, 0x53, 0xed,
+	0xde, 0x79, 0x3e, 0xaa, 0x3d, 0x32, 0xde, 0x89, 0x55, 0x70, 0x4a, 0x5d, 0xf9, 0x52, 0x84, 0xd3,
+	0xd9, 0x04, 0x6c, 0x7d, 0xaa, 0xd9, 0xcf, 0x19, 0x6f, 0x02, 0xf0, 0x30, 0xf3, 0xb3, 0x6b, 0xb0,
+	0x5c, 0x34, 0x70, 0x46, 0x9a, 0x85, 0xb5, 0xb4, 0x28, 0xac, 0xca, 0xb1, 0x08, 0xa7, 0x92, 0x33,
+	0x60, 0xf2, 0xf9, 0x11, 0xf1, 0xfc, 0xbb, 0x8e, 0x69, 0x27, 0x47, 0xd8, 0xe5, 0x8b, 0x31, 0x23,
+	0x2d, 0x38, 0x6a, 0xa9, 0xf0, 0xa8, 0xd7, 0xe0, 0x0c, 0x6f, 0x99, 0x94, 0x4f, 0x99, 0x39, 0x9e,
+	0xb4, 0x4d, 0x1b, 0x42, 0x54, 0x2e, 0x61, 0xca, 0xe3, 0x75, 0x3e, 0x4d, 0xd5, 0x82, 0x34, 0x29,
+	0x3f, 0x95, 0xe0, 0x64, 0x16, 0x69, 0x16, 0xe4, 0xb4, 0x5e, 0xf3, 0xcf, 0x06, 0x56, 0xc4, 0xf7,
+	0xea, 0x04, 0xbe, 0xe7, 0x40, 0xa8, 0xcd, 0xe6, 0x6a, 0x7d, 0x31, 0xae, 0x7e, 0x55, 0x82, 0x33,
+	0x39, 0x9e, 0xa8, 0xba, 0x4e, 0x86, 0xd3, 0x1b, 0xf6, 0x1a, 0xac, 0xb8, 0x81, 0x72, 0x06, 0xc5,
+	0xac, 0xb8, 0x30, 0xe0, 0xf2, 0x84, 0x80, 0x33, 0xb4, 0xaf, 0x2c, 0xdc, 0x4d, 0x78, 0x2c, 0xaa,
+	0x8b, 0x61, 0xb1, 0x0d, 0x72, 0x0e, 0x8a, 0x6d, 0xa2, 0x5b, 0xa6, 0x4d, 0x8a, 0xe2, 0x15, 0x0b,
+	0xe3, 0x55, 0x6e, 0xe5, 0x8b, 0x1f, 0x93, 0x91, 0xf3, 0x62, 0xee, 0xe2, 0x57, 0xbe, 0x13, 0xe1,
+	0x84, 0xaa, 0x5b, 0x3b, 0x59, 0x74, 0xa6, 0xe5, 0xa3, 0x08, 0xe5, 0xd2, 0x04, 0x94, 0x79, 0x90,
+	0xca, 0x8b, 0x81, 0xb4, 0x99, 0xe6, 0xcb, 0x0e, 0x47, 0xc4, 0x1c, 0x5d, 0xc5, 0xa2, 0x9a, 0xfd,
+	0x04, 0xce, 0x27, 0x0e, 0x92, 0x44, 0x06, 0x0d, 0xd2, 0x43, 0x9b, 0x50, 0xd7, 0x83, 0x47, 0x59,
+	0xec, 0x96, 0xd7, 0x9a, 0xbd, 0x0b, 0xfc, 0xc9, 0x26, 0x18, 0xe2, 0xc8, 0x4a, 0xe9, 0x43, 0x3b,
+	0x51, 0xf3, 0x54, 0xc3, 0x40, 0x57, 0xa1, 0xa1, 0x19, 0x86, 0xe9, 0x33, 0x4e, 0x05, 0x4e, 0xe5,
+	0x42, 0xa7, 0xaa, 0x61, 0xe0, 0x44, 0x55, 0xf9, 0xb6, 0x04, 0x2d, 0x6e, 0x73, 0x6a, 0x06, 0xfe,
+	0x6a, 0xcb, 0xe6, 0xba, 0x46, 0x79, 0x8e, 0xae, 0x51, 0x99, 0xb7, 0x6b, 0x54, 0x67, 0x77, 0x8d,
+	0xda, 0x62, 0x24, 0xd8, 0x28, 0x68, 0x1a, 0x5b, 0x9a, 0xad, 0x13, 0x8b, 0x46, 0xe1, 0xf2, 0xf4,
+	0x8e, 0xd7, 0xca, 0x18, 0xce, 0x4d, 0xce, 0xe0, 0xdf, 0x0a, 0xae, 0xf2, 0x7d, 0x30, 0x14, 0x85,
+	0x18, 0x85, 0xbf, 0x78, 0x0b, 0x9a, 0x5a, 0x70, 0x98, 0x3d, 0x32, 0x8e, 0xa8, 0xd1, 0xe1, 0xbd,
+	0x66, 0xab, 0x10, 0xa7, 0x4d, 0x0a, 0xe6, 0xc0, 0xd2, 0xc2, 0x73, 0x60, 0x79, 0xc6, 0x1c, 0xf8,
+	0x2e, 0x9c, 0x48, 0x26, 0x3d, 0x2b, 0x93, 0xfe, 0xa2, 0x2d, 0x74, 0x33, 0x1a, 0xe7, 0x58, 0x58,
+	0xd5, 0xb9, 0xc2, 0x4a, 0x59, 0x28, 0x47, 0xe9, 0x01, 0x12, 0x93, 0x81, 0x33, 0x22, 0xa8, 0x03,
+	0x10, 0x66, 0xc3, 0x0c, 0x4b, 0x73, 0x19, 0xa7, 0x24, 0x48, 0x85, 0x96, 0x9b, 0x06, 0x97, 0x01,
+	0xd1, 0xcc, 0x52, 0x8a, 0xc3, 0x1f, 0xf3, 0x16, 0xca, 0xd9, 0x02, 0x56, 0x05, 0xbf, 0xae, 0xfc,
+	0x50, 0x82, 0x13, 0xc9, 0x1e, 0x3d, 0xaf, 0xe3, 0x6b, 0x3e, 0x41, 0x5d, 0x68, 0xda, 0xe4, 0x65,
+	0x66, 0x92, 0x49, 0x8b, 0x50, 0x0f, 0x4e, 0xa6, 0x96, 0xc9, 0x15, 0x1e, 0xe4, 0xa9, 0x70, 0x8f,
+	0xda, 0x38, 0x96, 0x31, 0xe9, 0xda, 0x2f, 0xdc, 0xfb, 0xb7, 0x55, 0xe8, 0x17, 0xdc, 0x0c, 0xba,
+	0x4d, 0x46, 0xa6, 0x1e, 0x5d, 0x43, 0x33, 0xde, 0xc3, 0x0c, 0xa6, 0x9b, 0x7a, 0x0f, 0x8b, 0x05,
+	0xf4, 0x1d, 0x29, 0x58, 0x6c, 0x11, 0xd7, 0x37, 0x9f, 0x99, 0xba, 0xe6, 0x47, 0xa0, 0xe4, 0x37,
+	0x94, 0x1f, 0x01, 0x56, 0x54, 0xdd, 0xda, 0x72, 0x6c, 0x9f, 0xd8, 0xfe, 0x63, 0xcd, 0x3a, 0x22,
+	0x68, 0x03, 0x6a, 0x01, 0xcf, 0x64, 0xb1, 0x88, 0x1e, 0xdc, 0xad, 0xd9, 0x17, 0x70, 0xa8, 0x8c,
+	0xee, 0xc0, 0xb2, 0x99, 0xba, 0x49, 0x43, 0x6e, 0xfd, 0x7f, 0x8a, 0x71, 0xa0, 0xd8, 0x17, 0x30,
+	0x67, 0x88, 0xb6, 0xa1, 0xe9, 0x26, 0xb3, 0x30, 0x3b, 0x7b, 0xb3, 0xd7, 0x2d, 0xf4, 0x93, 0x9a,
+	0x99, 0xfb, 0x02, 0x4e, 0x9b, 0xa1, 0xbb, 0xd0, 0x0a, 0x97, 0xc1, 0xa4, 0xc4, 0x12, 0xdd, 0xec,
+	0x29, 0xd3, 0xfc, 0x04, 0x9a, 0x7d, 0x01, 0xf3, 0xa6, 0xe8, 0x21, 0x48, 0xc3, 0x4c, 0x27, 0x64,
+	0x74, 0x98, 0xf7, 0xe2, 0xeb, 0x0b, 0x38, 0xe7, 0x00, 0x6d, 0x41, 0x4b, 0x4b, 0x57, 0xaf, 0x5c,
+	0x9b, 0x82, 0x76, 0xa0, 0x42, 0x4f, 0xc6, 0xd9, 0x50, 0x27, 0x7c, 0x45, 0xd7, 0x67, 0x56, 0x74,
+	0x10, 0x5e, 0x4a, 0x80, 0xf6, 0xa1, 0xed, 0x72, 0x93, 0x14, 0x7b, 0x99, 0x6a, 0xf6, 0xde, 0x9a,
+	0x86, 0x55, 0xa8, 0xda, 0x17, 0x70, 0xc6, 0x18, 0x3d, 0x85, 0x93, 0x5a, 0x41, 0x7f, 0x90, 0x1b,
+	0xb3, 0x13, 0x10, 0x87, 0x59, 0xe8, 0x01, 0x3d, 0x86, 0xd5, 0x2c, 0x8c, 0x9e, 0x0c, 0xcc, 0xed,
+	0xc5, 0xb9, 0x12, 0xe1, 0xf5, 0x05, 0x9c, 0x77, 0x81, 0x3e, 0x8a, 0xef, 0x18, 0x3a, 0x8b, 0xc8,
+	0x4d, 0xe6, 0xf1, 0x6c, 0xa1, 0x47, 0xaa, 0x40, 0xa9, 0x96, 0xd2, 0x4f, 0x51, 0x2d, 0xb8, 0x5f,
+	0xe5, 0xe5, 0xd9, 0x91, 0x06, 0x9a, 0x29, 0xaa, 0x05, 0x02, 0xa4, 0x46, 0xd7, 0x02, 0xe3, 0x7e,
+	0x8b, 0x39, 0xfa, 0xdf, 0x94, 0x1a, 0x0a, 0xa9, 0x9f, 0x32, 0x4a, 0x0a, 0x31, 0xa4, 0x44, 0x7b,
+	0x66, 0x21, 0xc6, 0xc4, 0xe0, 0x0c, 0xd1, 0x75, 0x00, 0xd7, 0xb1, 0xc8, 0x36, 0x79, 0x46, 0x39,
+	0xb1, 0xd2, 0x15, 0xf3, 0x43, 0x19, 0x8e, 0xf7, 0xe9, 0x21, 0x12, 0x6d, 0x74, 0x13, 0x1a, 0x2f,
+	0xa2, 0x1b, 0x40, 0x96, 0xba, 0x62, 0xfe, 0x76, 0xcb, 0xde, 0x13, 0x7d, 0x01, 0x27, 0x26, 0x14,
+	0x53, 0xbe, 0xfd, 0xae, 0x4e, 0xc1, 0x94, 0x1b, 0x71, 0x29, 0xa6, 0x7c, 0x93, 0xbe, 0x03, 0xcb,
+	0x46, 0xaa, 0xb9, 0xca, 0x68, 0x0a, 0x20, 0xe9, 0x2e, 0x4c, 0x01, 0x49, 0x1b, 0xde, 0xae, 0x43,
+	0x75, 0x44, 0x5b, 0xa4, 0xf2, 0x8d, 0x08, 0x2d, 0x2e, 0x7a, 0x74, 0x05, 0x2a, 0x34, 0x7a, 0x59,
+	0x9c, 0x67, 0xea, 0x61, 0xaa, 0x08, 0x41, 0xc5, 0xd6, 0x06, 0x24, 0x7c, 0x27, 0x63, 0xcf, 0xe8,
+	0x06, 0x2c, 0xeb, 0xda, 0x50, 0x3b, 0x34, 0xad, 0xe0, 0x0e, 0x2f, 0x77, 0xcb, 0xf9, 0xcf, 0x3c,
+	0x5b, 0x91, 0xc6, 0x18, 0x73, 0xda, 0xca, 0x0e, 0xfb, 0x90, 0xb7, 0x4d, 0x87, 0xd1, 0xeb, 0x00,
+	0x5a, 0xdc, 0xd7, 0xc3, 0xa9, 0xe9, 0x5c, 0xc6, 0x4d, 0xaa, 0xe9, 0xe3, 0x94, 0xf6, 0xfa, 0x06,
+	0x0b, 0x2e, 0xf9, 0x98, 0x84, 0x56, 0xa1, 0x15, 0xd2, 0xf6, 0xc0, 0xa1, 0x14, 0x93, 0x04, 0x2a,
+	0x52, 0xed, 0xb1, 0x63, 0x93, 0x2d, 0xcd, 0x66, 0x22, 0x71, 0xbd, 0x07, 0x52, 0xf6, 0xba, 0x43,
+	0x00, 0xb5, 0xa7, 0xbd, 0x8d, 0x8d, 0x2b, 0x1f, 0x48, 0x02, 0x42, 0xd0, 0x0e, 0x9e, 0xf7, 0xad,
+	0x3d, 0x32, 0x78, 0xff, 0xea, 0x35, 0x49, 0x5c, 0x7f, 0x02, 0x28, 0x8f, 0x0f, 0x5a, 0x82, 0xca,
+	0x3d, 0xc7, 0x26, 0x92, 0x80, 0x1a, 0x50, 0x65, 0x1f, 0xce, 0x24, 0x91, 0x3e, 0xaa, 0xc6, 0xc0,
+	0xb4, 0xa5, 0x12, 0xf5, 0xfa, 0xc4, 0x35, 0x7d, 0xe2, 0x4a, 0x65, 0xfa, 0x4c, 0xdb, 0x1b, 0x71,
+	0xa5, 0x0a, 0x55, 0xb9, 0x43, 0x4f, 0x29, 0x55, 0xd7, 0x7f, 0x09, 0x32, 0x94, 0x40, 0x85, 0x24,
+	0x58, 0xbe, 0xe7, 0x24, 0x6b, 0x49, 0x40, 0x6d, 0x00, 0xe6, 0xe6, 0xc0, 0x25, 0xc4, 0x93, 0x44,
+	0x1a, 0x13, 0x5b, 0xef, 0x91, 0x31, 0x03, 0x45, 0x2a, 0x51, 0xd1, 0x36, 0xb1, 0x88, 0x4f, 0xee,
+	0xb3, 0x0f, 0xac, 0x5e, 0xf0, 0x83, 0x01, 0x34, 0x52, 0x05, 0xad, 0x40, 0x53, 0x1d, 0x0e, 0x5d,
+	0x67, 0xc4, 0x2a, 0x4f, 0xaa, 0x32, 0x81, 0x61, 0x44, 0xbd, 0x42, 0xaa, 0xd1, 0xa0, 0x83, 0xe6,
+	0x15, 0xcb, 0xea, 0xe8, 0x14, 0xac, 0x06, 0x15, 0x96, 0x8a, 0x59, 0x5a, 0x4a, 0xa1, 0x1c, 0x58,
+	0x48, 0x0d, 0xea, 0x6e, 0x5f, 0xb3, 0xb5, 0xe7, 0x84, 0x32, 0xcd, 0x93, 0xe0, 0xf6, 0xe6, 0xcf,
+	0x6f, 0x3a, 0xe2, 0xeb, 0x37, 0x1d, 0xf1, 0xb7, 0x37, 0x1d, 0xf1, 0xeb, 0xe3, 0x8e, 0xf0, 0xfa,
+	0xb8, 0x23, 0xfc, 0x7a, 0xdc, 0x11, 0x3e, 0xbe, 0x30, 0xd7, 0xe7, 0xe0, 0xc3, 0x1a, 0xfb, 0xf3,
+	0xde, 0x9f, 0x03, 0x00, 0xf5, 0x2d, 0xb9, 0x09, 0x3e, 0x16, 0x00, 0x00,
 }
 
 func (m *AclRoot) Marshal() (dAtA []byte, err error) {
@@ -1751,6 +2309,28 @@ func (m *AclAccountInvite) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Encryption != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Encryption))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.EncryptionKey) > 0 {
+		i -= len(m.EncryptionKey)
+		copy(dAtA[i:], m.EncryptionKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptionKey)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.MaxUses != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.MaxUses))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ExpireTimestamp != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.ExpireTimestamp))
+		i--
+		dAtA[i] = 0x28
+	}
 	if len(m.EncryptedReadKey) > 0 {
 		i -= len(m.EncryptedReadKey)
 		copy(dAtA[i:], m.EncryptedReadKey)
@@ -1833,6 +2413,13 @@ func (m *AclAccountRequestJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.EncryptionKey) > 0 {
+		i -= len(m.EncryptionKey)
+		copy(dAtA[i:], m.EncryptionKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptionKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Metadata) > 0 {
 		i -= len(m.Metadata)
 		copy(dAtA[i:], m.Metadata)
@@ -1884,6 +2471,18 @@ func (m *AclAccountInviteJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Encryption != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Encryption))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.EncryptionKey) > 0 {
+		i -= len(m.EncryptionKey)
+		copy(dAtA[i:], m.EncryptionKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptionKey)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if len(m.EncryptedReadKey) > 0 {
 		i -= len(m.EncryptedReadKey)
 		copy(dAtA[i:], m.EncryptedReadKey)
@@ -1942,6 +2541,11 @@ func (m *AclAccountRequestAccept) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.Encryption != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Encryption))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.Permissions != 0 {
 		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
 		i--
@@ -2051,6 +2655,11 @@ func (m *AclEncryptedReadKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Encryption != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Encryption))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.EncryptedReadKey) > 0 {
 		i -= len(m.EncryptedReadKey)
 		copy(dAtA[i:], m.EncryptedReadKey)
@@ -2068,6 +2677,36 @@ func (m *AclEncryptedReadKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AclAccountEncryptionKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountEncryptionKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountEncryptionKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EncryptionKey) > 0 {
+		i -= len(m.EncryptionKey)
+		copy(dAtA[i:], m.EncryptionKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptionKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *AclAccountPermissionChanges) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2162,6 +2801,18 @@ func (m *AclAccountAdd) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Encryption != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Encryption))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.EncryptionKey) > 0 {
+		i -= len(m.EncryptionKey)
+		copy(dAtA[i:], m.EncryptionKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptionKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.EncryptedReadKey) > 0 {
 		i -= len(m.EncryptedReadKey)
 		copy(dAtA[i:], m.EncryptedReadKey)
@@ -2395,6 +3046,113 @@ func (m *AclAccountRequestRemove) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *AclAccountKeyRotate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountKeyRotate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountKeyRotate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Encryption != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Encryption))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.EncryptionKey) > 0 {
+		i -= len(m.EncryptionKey)
+		copy(dAtA[i:], m.EncryptionKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptionKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.EncryptedReadKey) > 0 {
+		i -= len(m.EncryptedReadKey)
+		copy(dAtA[i:], m.EncryptedReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.OldIdentitySignature) > 0 {
+		i -= len(m.OldIdentitySignature)
+		copy(dAtA[i:], m.OldIdentitySignature)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.OldIdentitySignature)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.NewIdentitySignature) > 0 {
+		i -= len(m.NewIdentitySignature)
+		copy(dAtA[i:], m.NewIdentitySignature)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.NewIdentitySignature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NewIdentity) > 0 {
+		i -= len(m.NewIdentity)
+		copy(dAtA[i:], m.NewIdentity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.NewIdentity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclAccountDeviceRevoke) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountDeviceRevoke) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountDeviceRevoke) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DeviceCertificate) > 0 {
+		i -= len(m.DeviceCertificate)
+		copy(dAtA[i:], m.DeviceCertificate)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.DeviceCertificate)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.DeviceKey) > 0 {
+		i -= len(m.DeviceKey)
+		copy(dAtA[i:], m.DeviceKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.DeviceKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *AclContentValue) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2721,7 +3479,97 @@ func (m *AclContentValue_InviteChange) MarshalToSizedBuffer(dAtA []byte) (int, e
 	}
 	return len(dAtA) - i, nil
 }
-func (m *AclData) Marshal() (dAtA []byte, err error) {
+func (m *AclContentValue_RoleDefine) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_RoleDefine) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RoleDefine != nil {
+		{
+			size, err := m.RoleDefine.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_KeyRotate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_KeyRotate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.KeyRotate != nil {
+		{
+			size, err := m.KeyRotate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_EncryptionKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_EncryptionKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.EncryptionKey != nil {
+		{
+			size, err := m.EncryptionKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_DeviceRevoke) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_DeviceRevoke) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DeviceRevoke != nil {
+		{
+			size, err := m.DeviceRevoke.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclRoleDefine) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2731,22 +3579,75 @@ func (m *AclData) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AclData) MarshalTo(dAtA []byte) (int, error) {
+func (m *AclRoleDefine) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AclData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AclRoleDefine) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.AclContent) > 0 {
-		for iNdEx := len(m.AclContent) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.AclContent[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
+	if len(m.Capabilities) > 0 {
+		dAtA21 := make([]byte, len(m.Capabilities)*10)
+		var j20 int
+		for _, num := range m.Capabilities {
+			for num >= 1<<7 {
+				dAtA21[j20] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j20++
+			}
+			dAtA21[j20] = uint8(num)
+			j20++
+		}
+		i -= j20
+		copy(dAtA[i:], dAtA21[:j20])
+		i = encodeVarintAclrecord(dAtA, i, uint64(j20))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Role != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AclContent) > 0 {
+		for iNdEx := len(m.AclContent) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AclContent[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
 				}
 				i -= size
 				i = encodeVarintAclrecord(dAtA, i, uint64(size))
@@ -2833,6 +3734,19 @@ func (m *AclAccountInvite) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAclrecord(uint64(l))
 	}
+	if m.ExpireTimestamp != 0 {
+		n += 1 + sovAclrecord(uint64(m.ExpireTimestamp))
+	}
+	if m.MaxUses != 0 {
+		n += 1 + sovAclrecord(uint64(m.MaxUses))
+	}
+	l = len(m.EncryptionKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Encryption != 0 {
+		n += 1 + sovAclrecord(uint64(m.Encryption))
+	}
 	return n
 }
 
@@ -2874,6 +3788,10 @@ func (m *AclAccountRequestJoin) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAclrecord(uint64(l))
 	}
+	l = len(m.EncryptionKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
 	return n
 }
 
@@ -2903,6 +3821,13 @@ func (m *AclAccountInviteJoin) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAclrecord(uint64(l))
 	}
+	l = len(m.EncryptionKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Encryption != 0 {
+		n += 1 + sovAclrecord(uint64(m.Encryption))
+	}
 	return n
 }
 
@@ -2927,6 +3852,9 @@ func (m *AclAccountRequestAccept) Size() (n int) {
 	if m.Permissions != 0 {
 		n += 1 + sovAclrecord(uint64(m.Permissions))
 	}
+	if m.Encryption != 0 {
+		n += 1 + sovAclrecord(uint64(m.Encryption))
+	}
 	return n
 }
 
@@ -2970,6 +3898,22 @@ func (m *AclEncryptedReadKey) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAclrecord(uint64(l))
 	}
+	if m.Encryption != 0 {
+		n += 1 + sovAclrecord(uint64(m.Encryption))
+	}
+	return n
+}
+
+func (m *AclAccountEncryptionKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EncryptionKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
 	return n
 }
 
@@ -3024,6 +3968,13 @@ func (m *AclAccountAdd) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAclrecord(uint64(l))
 	}
+	l = len(m.EncryptionKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Encryption != 0 {
+		n += 1 + sovAclrecord(uint64(m.Encryption))
+	}
 	return n
 }
 
@@ -3117,6 +4068,59 @@ func (m *AclAccountRequestRemove) Size() (n int) {
 	return n
 }
 
+func (m *AclAccountKeyRotate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NewIdentity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.NewIdentitySignature)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.OldIdentitySignature)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptionKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Encryption != 0 {
+		n += 1 + sovAclrecord(uint64(m.Encryption))
+	}
+	return n
+}
+
+func (m *AclAccountDeviceRevoke) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.DeviceKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.DeviceCertificate)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
 func (m *AclContentValue) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3297,6 +4301,77 @@ func (m *AclContentValue_InviteChange) Size() (n int) {
 	}
 	return n
 }
+func (m *AclContentValue_RoleDefine) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RoleDefine != nil {
+		l = m.RoleDefine.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_KeyRotate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.KeyRotate != nil {
+		l = m.KeyRotate.Size()
+		n += 2 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_EncryptionKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EncryptionKey != nil {
+		l = m.EncryptionKey.Size()
+		n += 2 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_DeviceRevoke) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeviceRevoke != nil {
+		l = m.DeviceRevoke.Size()
+		n += 2 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclRoleDefine) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Role != 0 {
+		n += 1 + sovAclrecord(uint64(m.Role))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		l = 0
+		for _, e := range m.Capabilities {
+			l += sovAclrecord(uint64(e))
+		}
+		n += 1 + sovAclrecord(uint64(l)) + l
+	}
+	return n
+}
+
 func (m *AclData) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3792,6 +4867,97 @@ func (m *AclAccountInvite) Unmarshal(dAtA []byte) error {
 				m.EncryptedReadKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTimestamp", wireType)
+			}
+			m.ExpireTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTimestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxUses", wireType)
+			}
+			m.MaxUses = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxUses |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKey = append(m.EncryptionKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptionKey == nil {
+				m.EncryptionKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encryption", wireType)
+			}
+			m.Encryption = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encryption |= AclKeyEncryption(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -4077,25 +5243,59 @@ func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
 				m.Metadata = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAclrecord(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKey", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKey = append(m.EncryptionKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptionKey == nil {
+				m.EncryptionKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
 	return nil
 }
 func (m *AclAccountInviteJoin) Unmarshal(dAtA []byte) error {
@@ -4295,6 +5495,59 @@ func (m *AclAccountInviteJoin) Unmarshal(dAtA []byte) error {
 				m.EncryptedReadKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKey = append(m.EncryptionKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptionKey == nil {
+				m.EncryptionKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encryption", wireType)
+			}
+			m.Encryption = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encryption |= AclKeyEncryption(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -4464,6 +5717,25 @@ func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encryption", wireType)
+			}
+			m.Encryption = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encryption |= AclKeyEncryption(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -4746,6 +6018,109 @@ func (m *AclEncryptedReadKey) Unmarshal(dAtA []byte) error {
 				m.EncryptedReadKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encryption", wireType)
+			}
+			m.Encryption = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encryption |= AclKeyEncryption(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountEncryptionKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountEncryptionKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountEncryptionKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKey = append(m.EncryptionKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptionKey == nil {
+				m.EncryptionKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -5085,6 +6460,59 @@ func (m *AclAccountAdd) Unmarshal(dAtA []byte) error {
 				m.EncryptedReadKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKey = append(m.EncryptionKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptionKey == nil {
+				m.EncryptionKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encryption", wireType)
+			}
+			m.Encryption = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encryption |= AclKeyEncryption(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -5679,7 +7107,7 @@ func (m *AclAccountRequestRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclContentValue) Unmarshal(dAtA []byte) error {
+func (m *AclAccountKeyRotate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5702,17 +7130,17 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclContentValue: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclAccountKeyRotate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclContentValue: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclAccountKeyRotate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Invite", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewIdentity", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -5722,12 +7150,403 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewIdentity = append(m.NewIdentity[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewIdentity == nil {
+				m.NewIdentity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewIdentitySignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewIdentitySignature = append(m.NewIdentitySignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewIdentitySignature == nil {
+				m.NewIdentitySignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldIdentitySignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldIdentitySignature = append(m.OldIdentitySignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.OldIdentitySignature == nil {
+				m.OldIdentitySignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedReadKey == nil {
+				m.EncryptedReadKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKey = append(m.EncryptionKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptionKey == nil {
+				m.EncryptionKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encryption", wireType)
+			}
+			m.Encryption = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encryption |= AclKeyEncryption(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountDeviceRevoke) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountDeviceRevoke: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountDeviceRevoke: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceKey = append(m.DeviceKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceKey == nil {
+				m.DeviceKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceCertificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceCertificate = append(m.DeviceCertificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceCertificate == nil {
+				m.DeviceCertificate = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclContentValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclContentValue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclContentValue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Invite", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			postIndex := iNdEx + msglen
@@ -6198,6 +8017,316 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &AclContentValue_InviteChange{v}
 			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoleDefine", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclRoleDefine{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_RoleDefine{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyRotate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountKeyRotate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_KeyRotate{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountEncryptionKey{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_EncryptionKey{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceRevoke", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountDeviceRevoke{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_DeviceRevoke{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclRoleDefine) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclRoleDefine: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclRoleDefine: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= AclUserPermissions(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v AclCapability
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAclrecord
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= AclCapability(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Capabilities = append(m.Capabilities, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAclrecord
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAclrecord
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAclrecord
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.Capabilities) == 0 {
+					m.Capabilities = make([]AclCapability, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v AclCapability
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAclrecord
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= AclCapability(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Capabilities = append(m.Capabilities, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -29,7 +29,9 @@ type RequestJoinPayload struct {
 
 type InviteJoinPayload struct {
 	InviteKey crypto.PrivKey
-	Metadata  []byte
+	// InviteEncryptionKey is the hybrid key of the invite, it is transmitted along with the invite key
+	InviteEncryptionKey *crypto.HybridPrivKey
+	Metadata            []byte
 }
 
 type ReadKeyChangePayload struct {
@@ -64,12 +66,23 @@ type AccountAdd struct {
 	Identity    crypto.PubKey
 	Permissions AclPermissions
 	Metadata    []byte
+	// EncryptionKey is the hybrid key of the account, the read key is encrypted only with the identity if it is not set
+	EncryptionKey *crypto.HybridPubKey
 }
 
 type NewInvites struct {
 	Permissions AclPermissions
 }
 
+type InvitePayload struct {
+	// Permissions are given to the joining account, AclPermissionsNone creates a request to join invite
+	Permissions AclPermissions
+	// ExpireTimestamp is a unix time in seconds after which the invite can't be used, 0 means no expiry
+	ExpireTimestamp int64
+	// MaxUses limits the number of joins made with the invite, 0 means unlimited
+	MaxUses uint32
+}
+
 type BatchRequestPayload struct {
 	Additions     []AccountAdd
 	Changes       []PermissionChangePayload
@@ -86,14 +99,29 @@ type AccountRemovePayload struct {
 	Change     ReadKeyChangePayload
 }
 
+type KeyRotatePayload struct {
+	NewKey crypto.Signer
+	// NewEncryptionKey is the hybrid key of the new identity, it is optional
+	NewEncryptionKey *crypto.HybridPubKey
+}
+
+// DeviceRevokePayload revokes the device of the certificate, the device belongs to the identity which issued the certificate
+type DeviceRevokePayload struct {
+	Certificate *crypto.DeviceCertificate
+}
+
 type InviteResult struct {
 	InviteRec *consensusproto.RawRecord
 	InviteKey crypto.PrivKey
+	// InviteEncryptionKey must be transmitted along with the invite key, it is set only for the invites with the read key
+	InviteEncryptionKey *crypto.HybridPrivKey
 }
 
 type BatchResult struct {
 	Rec     *consensusproto.RawRecord
 	Invites []crypto.PrivKey
+	// InviteEncryptionKeys are the hybrid keys of the invites in the same order, they are nil for the request to join invites
+	InviteEncryptionKeys []*crypto.HybridPrivKey
 }
 
 type AclRecordBuilder interface {
@@ -104,6 +132,7 @@ type AclRecordBuilder interface {
 	BuildBatchRequest(payload BatchRequestPayload) (batchResult BatchResult, err error)
 	BuildInvite() (res InviteResult, err error)
 	BuildInviteAnyone(permissions AclPermissions) (res InviteResult, err error)
+	BuildInviteWithLimits(payload InvitePayload) (res InviteResult, err error)
 	BuildInviteChange(inviteChange InviteChangePayload) (rawRecord *consensusproto.RawRecord, err error)
 	BuildInviteRevoke(inviteRecordId string) (rawRecord *consensusproto.RawRecord, err error)
 	BuildInviteJoin(payload InviteJoinPayload) (rawRecord *consensusproto.RawRecord, err error)
@@ -117,6 +146,10 @@ type AclRecordBuilder interface {
 	BuildReadKeyChange(payload ReadKeyChangePayload) (rawRecord *consensusproto.RawRecord, err error)
 	BuildAccountRemove(payload AccountRemovePayload) (rawRecord *consensusproto.RawRecord, err error)
 	BuildAccountsAdd(payload AccountsAddPayload) (rawRecord *consensusproto.RawRecord, err error)
+	BuildRoleDefine(role AclRole) (rawRecord *consensusproto.RawRecord, err error)
+	BuildKeyRotate(payload KeyRotatePayload) (rawRecord *consensusproto.RawRecord, err error)
+	BuildEncryptionKey() (rawRecord *consensusproto.RawRecord, err error)
+	BuildDeviceRevoke(payload DeviceRevokePayload) (rawRecord *consensusproto.RawRecord, err error)
 }
 
 type aclRecordBuilder struct {
@@ -191,22 +224,24 @@ func (a *aclRecordBuilder) BuildBatchRequest(payload BatchRequestPayload) (batch
 		contentList = append(contentList, content)
 	}
 	for _, perms := range payload.NewInvites {
-		var privKey crypto.PrivKey
+		var (
+			privKey       crypto.PrivKey
+			encryptionKey *crypto.HybridPrivKey
+		)
 		if perms.NoPermissions() {
-			privKey, content, err = a.buildInvite()
+			privKey, content, err = a.buildInvite(InvitePayload{})
 			if err != nil {
 				return
 			}
-			contentList = append(contentList, content)
-			batchResult.Invites = append(batchResult.Invites, privKey)
 		} else {
-			privKey, content, err = a.buildInviteAnyone(perms)
+			privKey, encryptionKey, content, err = a.buildInviteAnyone(InvitePayload{Permissions: perms})
 			if err != nil {
 				return
 			}
-			contentList = append(contentList, content)
-			batchResult.Invites = append(batchResult.Invites, privKey)
 		}
+		contentList = append(contentList, content)
+		batchResult.Invites = append(batchResult.Invites, privKey)
+		batchResult.InviteEncryptionKeys = append(batchResult.InviteEncryptionKeys, encryptionKey)
 	}
 	res, err := a.buildRecords(contentList)
 	if err != nil {
@@ -231,10 +266,11 @@ func (a *aclRecordBuilder) buildRecords(aclContent []*aclrecordproto.AclContentV
 		return
 	}
 	rec := &consensusproto.Record{
-		PrevId:    a.state.lastRecordId,
-		Identity:  protoKey,
-		Data:      marshalledData,
-		Timestamp: time.Now().Unix(),
+		PrevId:            a.state.lastRecordId,
+		Identity:          protoKey,
+		Data:              marshalledData,
+		Timestamp:         time.Now().Unix(),
+		DeviceCertificate: crypto.MarshalledDeviceCertificate(a.accountKeys.SignKey),
 	}
 	marshalledRec, err := rec.Marshal()
 	if err != nil {
@@ -271,7 +307,7 @@ func (a *aclRecordBuilder) BuildPermissionChanges(payload PermissionChangesPaylo
 }
 
 func (a *aclRecordBuilder) buildPermissionChanges(payload PermissionChangesPayload) (content *aclrecordproto.AclContentValue, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.hasCapability(CapabilityChangePermissions) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -339,7 +375,11 @@ func (a *aclRecordBuilder) buildAccountsAdd(payload AccountsAddPayload, mkKey cr
 		if err != nil {
 			return nil, err
 		}
-		enc, err := acc.Identity.Encrypt(protoKey)
+		enc, encryption, err := encryptReadKey(acc.Identity, acc.EncryptionKey, protoKey)
+		if err != nil {
+			return nil, err
+		}
+		protoEncryptionKey, err := marshallEncryptionKey(acc.EncryptionKey)
 		if err != nil {
 			return nil, err
 		}
@@ -352,6 +392,8 @@ func (a *aclRecordBuilder) buildAccountsAdd(payload AccountsAddPayload, mkKey cr
 			Permissions:      aclrecordproto.AclUserPermissions(acc.Permissions),
 			Metadata:         encMeta,
 			EncryptedReadKey: enc,
+			EncryptionKey:    protoEncryptionKey,
+			Encryption:       encryption,
 		})
 	}
 	return &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_AccountsAdd{
@@ -360,7 +402,20 @@ func (a *aclRecordBuilder) buildAccountsAdd(payload AccountsAddPayload, mkKey cr
 }
 
 func (a *aclRecordBuilder) BuildInvite() (res InviteResult, err error) {
-	privKey, content, err := a.buildInvite()
+	return a.BuildInviteWithLimits(InvitePayload{})
+}
+
+func (a *aclRecordBuilder) BuildInviteWithLimits(payload InvitePayload) (res InviteResult, err error) {
+	var (
+		privKey       crypto.PrivKey
+		encryptionKey *crypto.HybridPrivKey
+		content       *aclrecordproto.AclContentValue
+	)
+	if payload.Permissions.NoPermissions() {
+		privKey, content, err = a.buildInvite(payload)
+	} else {
+		privKey, encryptionKey, content, err = a.buildInviteAnyone(payload)
+	}
 	if err != nil {
 		return
 	}
@@ -369,15 +424,20 @@ func (a *aclRecordBuilder) BuildInvite() (res InviteResult, err error) {
 		return
 	}
 	res.InviteKey = privKey
+	res.InviteEncryptionKey = encryptionKey
 	res.InviteRec = rawRec
 	return
 }
 
-func (a *aclRecordBuilder) buildInvite() (invKey crypto.PrivKey, content *aclrecordproto.AclContentValue, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+func (a *aclRecordBuilder) buildInvite(payload InvitePayload) (invKey crypto.PrivKey, content *aclrecordproto.AclContentValue, err error) {
+	if !a.hasCapability(CapabilityInvite) {
 		err = ErrInsufficientPermissions
 		return
 	}
+	if payload.ExpireTimestamp != 0 && payload.ExpireTimestamp <= time.Now().Unix() {
+		err = ErrInviteExpired
+		return
+	}
 	privKey, pubKey, err := crypto.GenerateRandomEd25519KeyPair()
 	if err != nil {
 		return
@@ -386,7 +446,11 @@ func (a *aclRecordBuilder) buildInvite() (invKey crypto.PrivKey, content *aclrec
 	if err != nil {
 		return
 	}
-	inviteRec := &aclrecordproto.AclAccountInvite{InviteKey: invitePubKey}
+	inviteRec := &aclrecordproto.AclAccountInvite{
+		InviteKey:       invitePubKey,
+		ExpireTimestamp: payload.ExpireTimestamp,
+		MaxUses:         payload.MaxUses,
+	}
 	content = &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_Invite{Invite: inviteRec}}
 	invKey = privKey
 	return
@@ -401,7 +465,7 @@ func (a *aclRecordBuilder) BuildInviteChange(inviteChange InviteChangePayload) (
 }
 
 func (a *aclRecordBuilder) buildInviteChange(inviteChange InviteChangePayload) (content *aclrecordproto.AclContentValue, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.hasCapability(CapabilityInvite) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -414,7 +478,7 @@ func (a *aclRecordBuilder) buildInviteChange(inviteChange InviteChangePayload) (
 }
 
 func (a *aclRecordBuilder) BuildInviteAnyone(permissions AclPermissions) (res InviteResult, err error) {
-	privKey, content, err := a.buildInviteAnyone(permissions)
+	privKey, encryptionKey, content, err := a.buildInviteAnyone(InvitePayload{Permissions: permissions})
 	if err != nil {
 		return
 	}
@@ -423,15 +487,20 @@ func (a *aclRecordBuilder) BuildInviteAnyone(permissions AclPermissions) (res In
 		return
 	}
 	res.InviteKey = privKey
+	res.InviteEncryptionKey = encryptionKey
 	res.InviteRec = rawRec
 	return
 }
 
-func (a *aclRecordBuilder) buildInviteAnyone(permissions AclPermissions) (invKey crypto.PrivKey, content *aclrecordproto.AclContentValue, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+func (a *aclRecordBuilder) buildInviteAnyone(payload InvitePayload) (invKey crypto.PrivKey, invEncryptionKey *crypto.HybridPrivKey, content *aclrecordproto.AclContentValue, err error) {
+	if !a.hasCapability(CapabilityInvite) {
 		err = ErrInsufficientPermissions
 		return
 	}
+	if payload.ExpireTimestamp != 0 && payload.ExpireTimestamp <= time.Now().Unix() {
+		err = ErrInviteExpired
+		return
+	}
 	privKey, pubKey, err := crypto.GenerateRandomEd25519KeyPair()
 	if err != nil {
 		return
@@ -448,18 +517,33 @@ func (a *aclRecordBuilder) buildInviteAnyone(permissions AclPermissions) (invKey
 	if err != nil {
 		return
 	}
-	encReadKey, err := pubKey.Encrypt(raw)
+	// the hybrid key is independent of the invite key, so the read key stays secret
+	// even if the invite key is recovered from the record later
+	encryptionKey, err := crypto.GenerateRandomHybridKey()
+	if err != nil {
+		return
+	}
+	encReadKey, encryption, err := encryptReadKey(pubKey, encryptionKey.GetPublic(), raw)
+	if err != nil {
+		return
+	}
+	protoEncryptionKey, err := encryptionKey.GetPublic().Marshall()
 	if err != nil {
 		return
 	}
 	inviteRec := &aclrecordproto.AclAccountInvite{
 		InviteKey:        invitePubKey,
 		InviteType:       aclrecordproto.AclInviteType_AnyoneCanJoin,
-		Permissions:      aclrecordproto.AclUserPermissions(permissions),
+		Permissions:      aclrecordproto.AclUserPermissions(payload.Permissions),
 		EncryptedReadKey: encReadKey,
+		ExpireTimestamp:  payload.ExpireTimestamp,
+		MaxUses:          payload.MaxUses,
+		EncryptionKey:    protoEncryptionKey,
+		Encryption:       encryption,
 	}
 	content = &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_Invite{Invite: inviteRec}}
 	invKey = privKey
+	invEncryptionKey = encryptionKey
 	return
 }
 
@@ -472,7 +556,7 @@ func (a *aclRecordBuilder) BuildInviteRevoke(inviteRecordId string) (rawRecord *
 }
 
 func (a *aclRecordBuilder) buildInviteRevoke(inviteRecordId string) (value *aclrecordproto.AclContentValue, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.hasCapability(CapabilityInvite) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -501,6 +585,9 @@ func (a *aclRecordBuilder) BuildRequestJoin(payload RequestJoinPayload) (rawReco
 		err = ErrIncorrectInviteKey
 		return
 	}
+	if err = invite.checkUsable(time.Now().Unix()); err != nil {
+		return
+	}
 	if !a.state.Permissions(a.accountKeys.SignKey.GetPublic()).NoPermissions() {
 		err = ErrInsufficientPermissions
 		return
@@ -528,11 +615,16 @@ func (a *aclRecordBuilder) BuildRequestJoin(payload RequestJoinPayload) (rawReco
 	if err != nil {
 		return
 	}
+	protoEncryptionKey, err := marshallEncryptionKey(a.accountEncryptionKey())
+	if err != nil {
+		return
+	}
 	joinRec := &aclrecordproto.AclAccountRequestJoin{
 		InviteIdentity:          protoIdentity,
 		InviteRecordId:          inviteId,
 		InviteIdentitySignature: signature,
 		Metadata:                encMeta,
+		EncryptionKey:           protoEncryptionKey,
 	}
 	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RequestJoin{RequestJoin: joinRec}}
 	return a.buildRecord(content)
@@ -554,6 +646,9 @@ func (a *aclRecordBuilder) BuildInviteJoin(payload InviteJoinPayload) (rawRecord
 		err = ErrIncorrectInviteKey
 		return
 	}
+	if err = invite.checkUsable(time.Now().Unix()); err != nil {
+		return
+	}
 	if !a.state.Permissions(a.accountKeys.SignKey.GetPublic()).NoPermissions() {
 		err = ErrInsufficientPermissions
 		return
@@ -577,7 +672,7 @@ func (a *aclRecordBuilder) BuildInviteJoin(payload InviteJoinPayload) (rawRecord
 	if err != nil {
 		return
 	}
-	key, err := a.state.DecryptInvite(payload.InviteKey)
+	key, err := a.state.DecryptInvite(payload.InviteKey, payload.InviteEncryptionKey)
 	if err != nil {
 		return
 	}
@@ -585,7 +680,12 @@ func (a *aclRecordBuilder) BuildInviteJoin(payload InviteJoinPayload) (rawRecord
 	if err != nil {
 		return
 	}
-	encReadKey, err := a.accountKeys.SignKey.GetPublic().Encrypt(readKey)
+	encryptionKey := a.accountEncryptionKey()
+	encReadKey, encryption, err := encryptReadKey(a.accountKeys.SignKey.GetPublic(), encryptionKey, readKey)
+	if err != nil {
+		return
+	}
+	protoEncryptionKey, err := marshallEncryptionKey(encryptionKey)
 	if err != nil {
 		return
 	}
@@ -599,6 +699,8 @@ func (a *aclRecordBuilder) BuildInviteJoin(payload InviteJoinPayload) (rawRecord
 		InviteIdentitySignature: signature,
 		Metadata:                encMeta,
 		EncryptedReadKey:        encReadKey,
+		EncryptionKey:           protoEncryptionKey,
+		Encryption:              encryption,
 	}
 	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_InviteJoin{InviteJoin: joinRec}}
 	return a.buildRecord(content)
@@ -613,7 +715,7 @@ func (a *aclRecordBuilder) BuildRequestAccept(payload RequestAcceptPayload) (raw
 }
 
 func (a *aclRecordBuilder) buildRequestAccept(payload RequestAcceptPayload, readKey crypto.SymKey) (value *aclrecordproto.AclContentValue, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.hasCapability(CapabilityApproveJoin) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -632,7 +734,7 @@ func (a *aclRecordBuilder) buildRequestAccept(payload RequestAcceptPayload, read
 	if err != nil {
 		return nil, err
 	}
-	enc, err := request.RequestIdentity.Encrypt(protoKey)
+	enc, encryption, err := encryptReadKey(request.RequestIdentity, request.EncryptionKey, protoKey)
 	if err != nil {
 		return nil, err
 	}
@@ -645,6 +747,7 @@ func (a *aclRecordBuilder) buildRequestAccept(payload RequestAcceptPayload, read
 		RequestRecordId:  payload.RequestRecordId,
 		EncryptedReadKey: enc,
 		Permissions:      aclrecordproto.AclUserPermissions(payload.Permissions),
+		Encryption:       encryption,
 	}
 	return &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RequestAccept{RequestAccept: acceptRec}}, nil
 }
@@ -658,7 +761,7 @@ func (a *aclRecordBuilder) BuildRequestDecline(requestRecordId string) (rawRecor
 }
 
 func (a *aclRecordBuilder) buildRequestDecline(requestRecordId string) (value *aclrecordproto.AclContentValue, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.hasCapability(CapabilityApproveJoin) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -687,8 +790,7 @@ func (a *aclRecordBuilder) BuildRequestCancel(requestRecordId string) (rawRecord
 }
 
 func (a *aclRecordBuilder) BuildPermissionChange(payload PermissionChangePayload) (rawRecord *consensusproto.RawRecord, err error) {
-	permissions := a.state.Permissions(a.state.pubKey)
-	if !permissions.CanManageAccounts() || payload.Identity.Equals(a.state.pubKey) {
+	if !a.hasCapability(CapabilityChangePermissions) || payload.Identity.Equals(a.state.pubKey) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -709,7 +811,7 @@ func (a *aclRecordBuilder) BuildPermissionChange(payload PermissionChangePayload
 }
 
 func (a *aclRecordBuilder) BuildReadKeyChange(payload ReadKeyChangePayload) (rawRecord *consensusproto.RawRecord, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.hasCapability(CapabilityRemoveAccounts) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -744,13 +846,14 @@ func (a *aclRecordBuilder) buildReadKeyChange(payload ReadKeyChangePayload, remo
 		if err != nil {
 			return nil, err
 		}
-		enc, err := st.PubKey.Encrypt(protoKey)
+		enc, encryption, err := encryptReadKey(st.PubKey, st.EncryptionKey, protoKey)
 		if err != nil {
 			return nil, err
 		}
 		aclReadKeys = append(aclReadKeys, &aclrecordproto.AclEncryptedReadKey{
 			Identity:         protoIdentity,
 			EncryptedReadKey: enc,
+			Encryption:       encryption,
 		})
 	}
 	for _, invite := range a.state.invites {
@@ -761,13 +864,14 @@ func (a *aclRecordBuilder) buildReadKeyChange(payload ReadKeyChangePayload, remo
 		if err != nil {
 			return nil, err
 		}
-		enc, err := invite.Key.Encrypt(protoKey)
+		enc, encryption, err := encryptReadKey(invite.Key, invite.EncryptionKey, protoKey)
 		if err != nil {
 			return nil, err
 		}
 		invites = append(invites, &aclrecordproto.AclEncryptedReadKey{
 			Identity:         protoIdentity,
 			EncryptedReadKey: enc,
+			Encryption:       encryption,
 		})
 	}
 	// encrypting metadata key with new read key
@@ -826,7 +930,7 @@ func (a *aclRecordBuilder) buildAccountRemove(payload AccountRemovePayload) (val
 		}
 		deletedMap[mapKeyFromPubKey(key)] = struct{}{}
 	}
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.hasCapability(CapabilityRemoveAccounts) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -846,6 +950,120 @@ func (a *aclRecordBuilder) buildAccountRemove(payload AccountRemovePayload) (val
 	return &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_AccountRemove{AccountRemove: removeRec}}, nil
 }
 
+func (a *aclRecordBuilder) BuildRoleDefine(role AclRole) (rawRecord *consensusproto.RawRecord, err error) {
+	if !a.hasCapability(CapabilityManageRoles) {
+		err = ErrInsufficientPermissions
+		return
+	}
+	if !role.Permissions.IsCustom() {
+		err = ErrIncorrectRole
+		return
+	}
+	if a.state.isKnownRole(role.Permissions) {
+		err = ErrRoleExists
+		return
+	}
+	roleRec := &aclrecordproto.AclRoleDefine{
+		Role:         aclrecordproto.AclUserPermissions(role.Permissions),
+		Name:         role.Name,
+		Capabilities: capabilitiesToProto(role.Capabilities),
+	}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RoleDefine{RoleDefine: roleRec}}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) BuildKeyRotate(payload KeyRotatePayload) (rawRecord *consensusproto.RawRecord, err error) {
+	if a.state.Permissions(a.state.pubKey).NoPermissions() {
+		err = ErrNoSuchAccount
+		return
+	}
+	succession, err := crypto.NewKeySuccession(a.accountKeys.SignKey, payload.NewKey)
+	if err != nil {
+		return
+	}
+	readKey, err := a.state.CurrentReadKey()
+	if err != nil {
+		return nil, ErrNoReadKey
+	}
+	protoKey, err := readKey.Marshall()
+	if err != nil {
+		return
+	}
+	encReadKey, encryption, err := encryptReadKey(succession.NewIdentity, payload.NewEncryptionKey, protoKey)
+	if err != nil {
+		return
+	}
+	protoEncryptionKey, err := marshallEncryptionKey(payload.NewEncryptionKey)
+	if err != nil {
+		return
+	}
+	protoIdentity, err := succession.NewIdentity.Marshall()
+	if err != nil {
+		return
+	}
+	rotateRec := &aclrecordproto.AclAccountKeyRotate{
+		NewIdentity:          protoIdentity,
+		NewIdentitySignature: succession.NewIdentitySignature,
+		OldIdentitySignature: succession.OldIdentitySignature,
+		EncryptedReadKey:     encReadKey,
+		EncryptionKey:        protoEncryptionKey,
+		Encryption:           encryption,
+	}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_KeyRotate{KeyRotate: rotateRec}}
+	return a.buildRecord(content)
+}
+
+// BuildEncryptionKey publishes the public part of the hybrid key of the account,
+// so the next read keys are encrypted for the account with X25519 and ML-KEM
+func (a *aclRecordBuilder) BuildEncryptionKey() (rawRecord *consensusproto.RawRecord, err error) {
+	if a.state.Permissions(a.state.pubKey).NoPermissions() {
+		err = ErrNoSuchAccount
+		return
+	}
+	if a.accountKeys.EncryptionKey == nil {
+		err = ErrNoEncryptionKey
+		return
+	}
+	protoKey, err := a.accountKeys.EncryptionKey.GetPublic().Marshall()
+	if err != nil {
+		return
+	}
+	encryptionKeyRec := &aclrecordproto.AclAccountEncryptionKey{EncryptionKey: protoKey}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_EncryptionKey{EncryptionKey: encryptionKeyRec}}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) BuildDeviceRevoke(payload DeviceRevokePayload) (rawRecord *consensusproto.RawRecord, err error) {
+	identity, deviceKey := payload.Certificate.Identity, payload.Certificate.DeviceKey
+	if a.state.Permissions(identity).NoPermissions() {
+		err = ErrNoSuchAccount
+		return
+	}
+	if !identity.Equals(a.state.pubKey) && !a.hasCapability(CapabilityRemoveAccounts) {
+		err = ErrInsufficientPermissions
+		return
+	}
+	if a.state.IsDeviceRevoked(identity, deviceKey) {
+		err = ErrDeviceRevoked
+		return
+	}
+	protoIdentity, err := identity.Marshall()
+	if err != nil {
+		return
+	}
+	protoDeviceKey, err := deviceKey.Marshall()
+	if err != nil {
+		return
+	}
+	revokeRec := &aclrecordproto.AclAccountDeviceRevoke{
+		Identity:          protoIdentity,
+		DeviceKey:         protoDeviceKey,
+		DeviceCertificate: payload.Certificate.Marshall(),
+	}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_DeviceRevoke{DeviceRevoke: revokeRec}}
+	return a.buildRecord(content)
+}
+
 func (a *aclRecordBuilder) BuildRequestRemove() (rawRecord *consensusproto.RawRecord, err error) {
 	permissions := a.state.Permissions(a.state.pubKey)
 	if permissions.NoPermissions() {
@@ -865,6 +1083,35 @@ func (a *aclRecordBuilder) BuildRequestRemove() (rawRecord *consensusproto.RawRe
 	return a.buildRecord(content)
 }
 
+// encryptReadKey encrypts the read key with the hybrid key if it is set, otherwise with the identity key
+func encryptReadKey(pubKey crypto.PubKey, encryptionKey *crypto.HybridPubKey, protoKey []byte) ([]byte, aclrecordproto.AclKeyEncryption, error) {
+	if encryptionKey != nil {
+		enc, err := encryptionKey.Encrypt(protoKey)
+		return enc, aclrecordproto.AclKeyEncryption_X25519MlKem768, err
+	}
+	enc, err := pubKey.Encrypt(protoKey)
+	return enc, aclrecordproto.AclKeyEncryption_X25519, err
+}
+
+// accountEncryptionKey returns the public hybrid key of the account, it is nil if the account doesn't have it
+func (a *aclRecordBuilder) accountEncryptionKey() *crypto.HybridPubKey {
+	if a.accountKeys.EncryptionKey == nil {
+		return nil
+	}
+	return a.accountKeys.EncryptionKey.GetPublic()
+}
+
+func marshallEncryptionKey(encryptionKey *crypto.HybridPubKey) ([]byte, error) {
+	if encryptionKey == nil {
+		return nil, nil
+	}
+	return encryptionKey.Marshall()
+}
+
+func (a *aclRecordBuilder) hasCapability(capability AclCapabilities) bool {
+	return a.state.Capabilities(a.state.pubKey).Has(capability)
+}
+
 func (a *aclRecordBuilder) Unmarshall(rawRecord *consensusproto.RawRecord) (rec *AclRecord, err error) {
 	aclRecord := &consensusproto.Record{}
 	err = proto.Unmarshal(rawRecord.Payload, aclRecord)
@@ -889,21 +1136,15 @@ func (a *aclRecordBuilder) Unmarshall(rawRecord *consensusproto.RawRecord) (rec
 		Identity:          pubKey,
 		Model:             aclData,
 	}
-	res, err := pubKey.Verify(rawRecord.Payload, rawRecord.Signature)
-	if err != nil {
-		return
-	}
-	if !res {
-		err = ErrInvalidSignature
-		return
-	}
+	rec.DeviceKey, err = verifyIdentitySignature(pubKey, rawRecord, aclRecord.DeviceCertificate)
 	return
 }
 
 func (a *aclRecordBuilder) UnmarshallWithId(rawIdRecord *consensusproto.RawRecordWithId) (rec *AclRecord, err error) {
 	var (
-		rawRec = &consensusproto.RawRecord{}
-		pubKey crypto.PubKey
+		rawRec     = &consensusproto.RawRecord{}
+		pubKey     crypto.PubKey
+		deviceCert []byte
 	)
 	err = proto.Unmarshal(rawIdRecord.Payload, rawRec)
 	if err != nil {
@@ -946,17 +1187,19 @@ func (a *aclRecordBuilder) UnmarshallWithId(rawIdRecord *consensusproto.RawRecor
 			return
 		}
 		rec = &AclRecord{
-			Id:        rawIdRecord.Id,
-			PrevId:    aclRecord.PrevId,
-			Timestamp: aclRecord.Timestamp,
-			Data:      aclRecord.Data,
-			Signature: rawRec.Signature,
-			Identity:  pubKey,
-			Model:     aclData,
+			Id:                rawIdRecord.Id,
+			PrevId:            aclRecord.PrevId,
+			Timestamp:         aclRecord.Timestamp,
+			AcceptorTimestamp: rawRec.AcceptorTimestamp,
+			Data:              aclRecord.Data,
+			Signature:         rawRec.Signature,
+			Identity:          pubKey,
+			Model:             aclData,
 		}
+		deviceCert = aclRecord.DeviceCertificate
 	}
 
-	err = verifyRaw(pubKey, rawRec, rawIdRecord)
+	rec.DeviceKey, err = verifyRaw(pubKey, rawRec, rawIdRecord, deviceCert)
 	return
 }
 
@@ -1017,16 +1260,13 @@ func (a *aclRecordBuilder) BuildRoot(content RootContent) (rec *consensusproto.R
 func verifyRaw(
 	pubKey crypto.PubKey,
 	rawRec *consensusproto.RawRecord,
-	recWithId *consensusproto.RawRecordWithId) (err error) {
+	recWithId *consensusproto.RawRecordWithId,
+	deviceCert []byte) (deviceKey crypto.PubKey, err error) {
 	// verifying signature
-	res, err := pubKey.Verify(rawRec.Payload, rawRec.Signature)
+	deviceKey, err = verifyIdentitySignature(pubKey, rawRec, deviceCert)
 	if err != nil {
 		return
 	}
-	if !res {
-		err = ErrInvalidSignature
-		return
-	}
 
 	// verifying ID
 	if !cidutil.VerifyCid(recWithId.Payload, recWithId.Id) {
@@ -1035,6 +1275,16 @@ func verifyRaw(
 	return
 }
 
+// verifyIdentitySignature verifies the record signature, the record can be signed by the device of the identity,
+// the device key is returned in this case
+func verifyIdentitySignature(pubKey crypto.PubKey, rawRec *consensusproto.RawRecord, deviceCert []byte) (deviceKey crypto.PubKey, err error) {
+	deviceKey, err = crypto.VerifyIdentitySignature(pubKey, rawRec.Payload, rawRec.Signature, deviceCert)
+	if errors.Is(err, crypto.ErrInvalidSignature) {
+		err = ErrInvalidSignature
+	}
+	return
+}
+
 func marshalAclRoot(aclRoot *aclrecordproto.AclRoot, key crypto.PrivKey) (rawWithId *consensusproto.RawRecordWithId, err error) {
 	marshalledRoot, err := aclRoot.Marshal()
 	if err != nil {
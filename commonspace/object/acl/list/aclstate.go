@@ -38,6 +38,16 @@ var (
 	ErrIncorrectRecordSequence   = errors.New("incorrect prev id of a record")
 	ErrMetadataTooLarge          = errors.New("metadata size too large")
 	ErrOwnerNotFound             = errors.New("owner not found")
+	ErrInviteExpired             = errors.New("invite expired")
+	ErrInviteExhausted           = errors.New("invite has no uses left")
+	ErrNoSuchRole                = errors.New("no such role")
+	ErrRoleExists                = errors.New("role already exists")
+	ErrIncorrectRole             = errors.New("incorrect role")
+	ErrUnknownCapability         = errors.New("unknown capability")
+	ErrAccountExists             = errors.New("account already exists")
+	ErrUnknownEncryption         = errors.New("unknown read key encryption")
+	ErrDeviceRevoked             = errors.New("device is revoked")
+	ErrNoEncryptionKey           = errors.New("no encryption key")
 )
 
 const MaxMetadataLen = 1024
@@ -57,11 +67,48 @@ type AclKeys struct {
 }
 
 type Invite struct {
-	Key          crypto.PubKey
-	Type         aclrecordproto.AclInviteType
-	Permissions  AclPermissions
-	Id           string
-	encryptedKey []byte
+	Key         crypto.PubKey
+	Type        aclrecordproto.AclInviteType
+	Permissions AclPermissions
+	Id          string
+	// ExpireTimestamp is a unix time in seconds after which the invite can't be used, 0 means no expiry
+	ExpireTimestamp int64
+	// MaxUses is the number of joins allowed with the invite, 0 means unlimited
+	MaxUses uint32
+	// Uses is the number of accounts which joined with the invite, the join requests are counted when accepted
+	Uses uint32
+	// EncryptionKey is the hybrid key of the invite, the read keys are encrypted with it if it is set
+	EncryptionKey *crypto.HybridPubKey
+	encryptedKey  []byte
+	encryption    aclrecordproto.AclKeyEncryption
+}
+
+// IsExpired checks if the invite is expired at the given unix timestamp, the zero timestamp means the time is unknown
+func (i Invite) IsExpired(timestamp int64) bool {
+	return i.ExpireTimestamp != 0 && timestamp != 0 && timestamp >= i.ExpireTimestamp
+}
+
+// IsExhausted checks if all allowed uses of the invite were spent
+func (i Invite) IsExhausted() bool {
+	return i.MaxUses != 0 && i.Uses >= i.MaxUses
+}
+
+// inviteTimestamp returns the time the invite is checked against when the record is applied.
+// The author sets the record timestamp and can backdate it, so the timestamp of the consensus node is used.
+// The records which are not accepted yet have no timestamp and the expiration is not checked for them,
+// the local clock is never used, otherwise the same acl could be rejected on reload
+func inviteTimestamp(record *AclRecord) int64 {
+	return record.AcceptorTimestamp
+}
+
+func (i Invite) checkUsable(timestamp int64) error {
+	if i.IsExpired(timestamp) {
+		return ErrInviteExpired
+	}
+	if i.IsExhausted() {
+		return ErrInviteExhausted
+	}
+	return nil
 }
 
 type AclState struct {
@@ -76,11 +123,17 @@ type AclState struct {
 	requestRecords map[string]RequestRecord
 	// pendingRequests is a map pubKey -> recordId
 	pendingRequests map[string]string
+	// roles is a map of the custom roles
+	roles map[AclPermissions]AclRole
+	// revokedDevices is a map of revoked devices (see revokedDeviceKey) to the ids of the revoking records
+	revokedDevices map[string]string
 	// readKeyChanges is a list of records containing read key changes
 	readKeyChanges []string
 	key            crypto.PrivKey
-	pubKey         crypto.PubKey
-	keyStore       crypto.KeyStorage
+	// encryptionKey decrypts the read keys which were encrypted with the hybrid key of the account
+	encryptionKey crypto.HybridDecryptor
+	pubKey        crypto.PubKey
+	keyStore      crypto.KeyStorage
 
 	lastRecordId     string
 	contentValidator ContentValidator
@@ -90,16 +143,20 @@ type AclState struct {
 func newAclStateWithKeys(
 	rootRecord *AclRecord,
 	key crypto.PrivKey,
+	encryptionKey crypto.HybridDecryptor,
 	verifier recordverifier.AcceptorVerifier) (st *AclState, err error) {
 	st = &AclState{
 		id:              rootRecord.Id,
 		key:             key,
+		encryptionKey:   encryptionKey,
 		pubKey:          key.GetPublic(),
 		keys:            make(map[string]AclKeys),
 		accountStates:   make(map[string]AccountState),
 		invites:         make(map[string]Invite),
 		requestRecords:  make(map[string]RequestRecord),
 		pendingRequests: make(map[string]string),
+		roles:           make(map[AclPermissions]AclRole),
+		revokedDevices:  make(map[string]string),
 		keyStore:        crypto.NewKeyStorage(),
 	}
 	st.contentValidator = newContentValidator(st.keyStore, st, verifier)
@@ -118,6 +175,8 @@ func newAclState(rootRecord *AclRecord, verifier recordverifier.AcceptorVerifier
 		invites:         make(map[string]Invite),
 		requestRecords:  make(map[string]RequestRecord),
 		pendingRequests: make(map[string]string),
+		roles:           make(map[AclPermissions]AclRole),
+		revokedDevices:  make(map[string]string),
 		keyStore:        crypto.NewKeyStorage(),
 	}
 	st.contentValidator = newContentValidator(st.keyStore, st, verifier)
@@ -249,13 +308,23 @@ func (st *AclState) RequestIds() []string {
 	return requests
 }
 
-func (st *AclState) DecryptInvite(invitePk crypto.PrivKey) (key crypto.SymKey, err error) {
+// DecryptInvite decrypts the read key of the invite, the encryption key of the invite is required
+// if the read key was encrypted with the hybrid key
+func (st *AclState) DecryptInvite(invitePk crypto.PrivKey, encryptionKey *crypto.HybridPrivKey) (key crypto.SymKey, err error) {
 	if invitePk == nil {
 		return nil, ErrNoReadKey
 	}
+	var hybridDecryptor crypto.HybridDecryptor
+	if encryptionKey != nil {
+		hybridDecryptor = encryptionKey
+	}
 	for _, invite := range st.invites {
 		if invite.Key.Equals(invitePk.GetPublic()) {
-			res, err := st.unmarshallDecryptReadKey(invite.encryptedKey, invitePk.Decrypt)
+			decryptor, err := readKeyDecryptor(invitePk, hybridDecryptor, invite.encryption)
+			if err != nil {
+				return nil, err
+			}
+			res, err := st.unmarshallDecryptReadKey(invite.encryptedKey, decryptor)
 			if err != nil {
 				return nil, err
 			}
@@ -279,6 +348,10 @@ func (st *AclState) ApplyRecord(record *AclRecord) (err error) {
 		}
 		record.Model = aclData
 	}
+	if record.DeviceKey != nil && st.IsDeviceRevoked(record.Identity, record.DeviceKey) {
+		err = ErrDeviceRevoked
+		return
+	}
 	// applying records contents
 	err = st.applyChangeData(record)
 	if err != nil {
@@ -386,12 +459,15 @@ func (st *AclState) Copy() *AclState {
 	newSt := &AclState{
 		id:              st.id,
 		key:             st.key,
+		encryptionKey:   st.encryptionKey,
 		pubKey:          st.key.GetPublic(),
 		keys:            make(map[string]AclKeys),
 		accountStates:   make(map[string]AccountState),
 		invites:         make(map[string]Invite),
 		requestRecords:  make(map[string]RequestRecord),
 		pendingRequests: make(map[string]string),
+		roles:           make(map[AclPermissions]AclRole),
+		revokedDevices:  make(map[string]string),
 		keyStore:        st.keyStore,
 	}
 	for k, v := range st.keys {
@@ -413,6 +489,12 @@ func (st *AclState) Copy() *AclState {
 	for k, v := range st.pendingRequests {
 		newSt.pendingRequests[k] = v
 	}
+	for k, v := range st.roles {
+		newSt.roles[k] = v
+	}
+	for k, v := range st.revokedDevices {
+		newSt.revokedDevices[k] = v
+	}
 	newSt.readKeyChanges = append(newSt.readKeyChanges, st.readKeyChanges...)
 	newSt.list = st.list
 	newSt.lastRecordId = st.lastRecordId
@@ -450,6 +532,14 @@ func (st *AclState) applyChangeContent(ch *aclrecordproto.AclContentValue, recor
 		return st.applyAccountsAdd(ch.GetAccountsAdd(), record)
 	case ch.GetPermissionChanges() != nil:
 		return st.applyPermissionChanges(ch.GetPermissionChanges(), record)
+	case ch.GetRoleDefine() != nil:
+		return st.applyRoleDefine(ch.GetRoleDefine(), record)
+	case ch.GetKeyRotate() != nil:
+		return st.applyKeyRotate(ch.GetKeyRotate(), record)
+	case ch.GetEncryptionKey() != nil:
+		return st.applyEncryptionKey(ch.GetEncryptionKey(), record)
+	case ch.GetDeviceRevoke() != nil:
+		return st.applyDeviceRevoke(ch.GetDeviceRevoke(), record)
 	default:
 		log.Errorf("got unexpected content type: %s", record.Id)
 		return nil
@@ -497,6 +587,27 @@ func (st *AclState) applyPermissionChange(ch *aclrecordproto.AclAccountPermissio
 	return nil
 }
 
+func (st *AclState) applyRoleDefine(ch *aclrecordproto.AclRoleDefine, record *AclRecord) error {
+	err := st.contentValidator.ValidateRoleDefine(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	role := AclPermissions(ch.Role)
+	if _, exists := st.roles[role]; exists {
+		return ErrRoleExists
+	}
+	capabilities, err := capabilitiesFromProto(ch.Capabilities)
+	if err != nil {
+		return err
+	}
+	st.roles[role] = AclRole{
+		Permissions:  role,
+		Name:         ch.Name,
+		Capabilities: capabilities,
+	}
+	return nil
+}
+
 func (st *AclState) applyInvite(ch *aclrecordproto.AclAccountInvite, record *AclRecord) error {
 	inviteKey, err := st.keyStore.PubKeyFromProto(ch.InviteKey)
 	if err != nil {
@@ -506,12 +617,20 @@ func (st *AclState) applyInvite(ch *aclrecordproto.AclAccountInvite, record *Acl
 	if err != nil {
 		return err
 	}
+	encryptionKey, err := unmarshallEncryptionKey(ch.EncryptionKey)
+	if err != nil {
+		return err
+	}
 	st.invites[record.Id] = Invite{
-		Key:          inviteKey,
-		Id:           record.Id,
-		Type:         ch.InviteType,
-		Permissions:  AclPermissions(ch.Permissions),
-		encryptedKey: ch.EncryptedReadKey,
+		Key:             inviteKey,
+		Id:              record.Id,
+		Type:            ch.InviteType,
+		Permissions:     AclPermissions(ch.Permissions),
+		ExpireTimestamp: ch.ExpireTimestamp,
+		MaxUses:         ch.MaxUses,
+		EncryptionKey:   encryptionKey,
+		encryptedKey:    ch.EncryptedReadKey,
+		encryption:      ch.Encryption,
 	}
 	return nil
 }
@@ -526,10 +645,22 @@ func (st *AclState) applyInviteRevoke(ch *aclrecordproto.AclAccountInviteRevoke,
 }
 
 func (st *AclState) applyRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, record *AclRecord) error {
-	err := st.contentValidator.ValidateRequestJoin(ch, record.Identity)
+	timestamp := inviteTimestamp(record)
+	err := st.contentValidator.ValidateRequestJoin(ch, record.Identity, timestamp)
 	if err != nil {
 		return err
 	}
+	encryptionKey, err := unmarshallEncryptionKey(ch.EncryptionKey)
+	if err != nil {
+		return err
+	}
+	// the use of the invite is counted when the request is accepted,
+	// so the declined and canceled requests don't spend it
+	if invite, exists := st.invites[ch.InviteRecordId]; exists {
+		if err = invite.checkUsable(timestamp); err != nil {
+			return err
+		}
+	}
 	st.pendingRequests[mapKeyFromPubKey(record.Identity)] = record.Id
 	st.requestRecords[record.Id] = RequestRecord{
 		RequestIdentity: record.Identity,
@@ -537,6 +668,8 @@ func (st *AclState) applyRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, r
 		KeyRecordId:     st.CurrentReadKeyId(),
 		RecordId:        record.Id,
 		Type:            RequestTypeJoin,
+		EncryptionKey:   encryptionKey,
+		inviteRecordId:  ch.InviteRecordId,
 	}
 	pKeyString := mapKeyFromPubKey(record.Identity)
 	state, exists := st.accountStates[pKeyString]
@@ -571,12 +704,17 @@ func (st *AclState) applyAccountsAdd(ch *aclrecordproto.AclAccountsAdd, record *
 		if err != nil {
 			return err
 		}
+		encryptionKey, err := unmarshallEncryptionKey(acc.EncryptionKey)
+		if err != nil {
+			return err
+		}
 		st.accountStates[mapKeyFromPubKey(identity)] = AccountState{
 			PubKey:          identity,
 			Permissions:     AclPermissions(acc.Permissions),
 			Status:          StatusActive,
 			RequestMetadata: acc.Metadata,
 			KeyRecordId:     st.CurrentReadKeyId(),
+			EncryptionKey:   encryptionKey,
 			PermissionChanges: []PermissionChange{
 				{
 					Permission: AclPermissions(acc.Permissions),
@@ -587,7 +725,7 @@ func (st *AclState) applyAccountsAdd(ch *aclrecordproto.AclAccountsAdd, record *
 		if !st.pubKey.Equals(identity) {
 			continue
 		}
-		err = st.unpackAllKeys(acc.EncryptedReadKey)
+		err = st.unpackAllKeys(acc.EncryptedReadKey, acc.Encryption)
 		if err != nil {
 			return err
 		}
@@ -595,6 +733,89 @@ func (st *AclState) applyAccountsAdd(ch *aclrecordproto.AclAccountsAdd, record *
 	return nil
 }
 
+func (st *AclState) applyKeyRotate(ch *aclrecordproto.AclAccountKeyRotate, record *AclRecord) error {
+	err := st.contentValidator.ValidateKeyRotate(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	newIdentity, err := st.keyStore.PubKeyFromProto(ch.NewIdentity)
+	if err != nil {
+		return err
+	}
+	encryptionKey, err := unmarshallEncryptionKey(ch.EncryptionKey)
+	if err != nil {
+		return err
+	}
+	oldKey := mapKeyFromPubKey(record.Identity)
+	oldState, exists := st.accountStates[oldKey]
+	if !exists {
+		return ErrNoSuchAccount
+	}
+	newKey := mapKeyFromPubKey(newIdentity)
+	newState := st.accountStates[newKey]
+	// the new identity gets the permissions only from this record, so it can't act on behalf of the old one in the past
+	st.accountStates[newKey] = AccountState{
+		PubKey:          newIdentity,
+		Permissions:     oldState.Permissions,
+		Status:          StatusActive,
+		RequestMetadata: oldState.RequestMetadata,
+		KeyRecordId:     oldState.KeyRecordId,
+		EncryptionKey:   encryptionKey,
+		PermissionChanges: append(newState.PermissionChanges, PermissionChange{
+			Permission: oldState.Permissions,
+			RecordId:   record.Id,
+		}),
+	}
+	oldState.Status = StatusRotated
+	oldState.Permissions = AclPermissionsNone
+	oldState.Successor = newIdentity
+	oldState.PermissionChanges = append(oldState.PermissionChanges, PermissionChange{
+		Permission: AclPermissionsNone,
+		RecordId:   record.Id,
+	})
+	st.accountStates[oldKey] = oldState
+	if !st.pubKey.Equals(newIdentity) {
+		return nil
+	}
+	return st.unpackAllKeys(ch.EncryptedReadKey, ch.Encryption)
+}
+
+func (st *AclState) applyEncryptionKey(ch *aclrecordproto.AclAccountEncryptionKey, record *AclRecord) error {
+	err := st.contentValidator.ValidateEncryptionKey(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	encryptionKey, err := crypto.UnmarshalHybridPublicKeyProto(ch.EncryptionKey)
+	if err != nil {
+		return err
+	}
+	pk := mapKeyFromPubKey(record.Identity)
+	accSt, exists := st.accountStates[pk]
+	if !exists {
+		return ErrNoSuchAccount
+	}
+	accSt.EncryptionKey = encryptionKey
+	st.accountStates[pk] = accSt
+	return nil
+}
+
+func (st *AclState) applyDeviceRevoke(ch *aclrecordproto.AclAccountDeviceRevoke, record *AclRecord) error {
+	err := st.contentValidator.ValidateDeviceRevoke(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	identity, err := st.keyStore.PubKeyFromProto(ch.Identity)
+	if err != nil {
+		return err
+	}
+	deviceKey, err := st.keyStore.PubKeyFromProto(ch.DeviceKey)
+	if err != nil {
+		return err
+	}
+	st.revokedDevices[revokedDeviceKey(identity, deviceKey)] = record.Id
+	return nil
+}
+
 func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccept, record *AclRecord) error {
 	err := st.contentValidator.ValidateRequestAccept(ch, record.Identity)
 	if err != nil {
@@ -605,6 +826,9 @@ func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccep
 		return err
 	}
 	requestRecord, _ := st.requestRecords[ch.RequestRecordId]
+	if err = st.useInvite(requestRecord.inviteRecordId); err != nil {
+		return err
+	}
 	pKeyString := mapKeyFromPubKey(acceptIdentity)
 	state, exists := st.accountStates[pKeyString]
 	if !exists {
@@ -614,6 +838,7 @@ func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccep
 			RequestMetadata: requestRecord.RequestMetadata,
 			KeyRecordId:     requestRecord.KeyRecordId,
 			Status:          StatusActive,
+			EncryptionKey:   requestRecord.EncryptionKey,
 			PermissionChanges: []PermissionChange{
 				{
 					Permission: AclPermissions(ch.Permissions),
@@ -628,6 +853,7 @@ func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccep
 			RequestMetadata: requestRecord.RequestMetadata,
 			KeyRecordId:     requestRecord.KeyRecordId,
 			Status:          StatusActive,
+			EncryptionKey:   requestRecord.EncryptionKey,
 			PermissionChanges: append(state.PermissionChanges, PermissionChange{
 				Permission: AclPermissions(ch.Permissions),
 				RecordId:   record.Id,
@@ -639,11 +865,12 @@ func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccep
 	if !st.pubKey.Equals(acceptIdentity) {
 		return nil
 	}
-	return st.unpackAllKeys(ch.EncryptedReadKey)
+	return st.unpackAllKeys(ch.EncryptedReadKey, ch.Encryption)
 }
 
 func (st *AclState) applyInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, record *AclRecord) error {
-	err := st.contentValidator.ValidateInviteJoin(ch, record.Identity)
+	timestamp := inviteTimestamp(record)
+	err := st.contentValidator.ValidateInviteJoin(ch, record.Identity, timestamp)
 	if err != nil {
 		return err
 	}
@@ -651,6 +878,17 @@ func (st *AclState) applyInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, rec
 	if err != nil {
 		return err
 	}
+	encryptionKey, err := unmarshallEncryptionKey(ch.EncryptionKey)
+	if err != nil {
+		return err
+	}
+	if invite, exists := st.invites[ch.InviteRecordId]; exists && invite.IsExpired(timestamp) {
+		return ErrInviteExpired
+	}
+	err = st.useInvite(ch.InviteRecordId)
+	if err != nil {
+		return err
+	}
 	inviteRecord, _ := st.invites[ch.InviteRecordId]
 	pKeyString := mapKeyFromPubKey(identity)
 	state, exists := st.accountStates[pKeyString]
@@ -661,6 +899,7 @@ func (st *AclState) applyInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, rec
 			RequestMetadata: ch.Metadata,
 			KeyRecordId:     st.CurrentReadKeyId(),
 			Status:          StatusActive,
+			EncryptionKey:   encryptionKey,
 			PermissionChanges: []PermissionChange{
 				{
 					Permission: inviteRecord.Permissions,
@@ -675,6 +914,7 @@ func (st *AclState) applyInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, rec
 			RequestMetadata: ch.Metadata,
 			KeyRecordId:     st.CurrentReadKeyId(),
 			Status:          StatusActive,
+			EncryptionKey:   encryptionKey,
 			PermissionChanges: append(state.PermissionChanges, PermissionChange{
 				Permission: inviteRecord.Permissions,
 				RecordId:   record.Id,
@@ -689,26 +929,47 @@ func (st *AclState) applyInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, rec
 		}
 	}
 	if st.pubKey.Equals(identity) {
-		return st.unpackAllKeys(ch.EncryptedReadKey)
+		return st.unpackAllKeys(ch.EncryptedReadKey, ch.Encryption)
+	}
+	return nil
+}
+
+// useInvite spends one use of the invite, the expiry is checked by the caller
+func (st *AclState) useInvite(inviteRecordId string) error {
+	invite, exists := st.invites[inviteRecordId]
+	if !exists {
+		return nil
+	}
+	if invite.IsExhausted() {
+		return ErrInviteExhausted
 	}
+	invite.Uses++
+	st.invites[inviteRecordId] = invite
 	return nil
 }
 
-func (st *AclState) unpackAllKeys(rk []byte) error {
-	iterReadKey, err := st.unmarshallDecryptReadKey(rk, st.key.Decrypt)
+func (st *AclState) unpackAllKeys(rk []byte, encryption aclrecordproto.AclKeyEncryption) error {
+	decryptor, err := readKeyDecryptor(st.key, st.encryptionKey, encryption)
+	if err != nil {
+		return err
+	}
+	iterReadKey, err := st.unmarshallDecryptReadKey(rk, decryptor)
 	if err != nil {
 		return err
 	}
 	for idx := len(st.readKeyChanges) - 1; idx >= 0; idx-- {
 		recId := st.readKeyChanges[idx]
 		keys := st.keys[recId]
-		metadataKey, err := st.unmarshallDecryptPrivKey(keys.encMetadatKey, iterReadKey.Decrypt)
-		if err != nil {
-			return err
-		}
 		aclKeys := st.keys[recId]
 		aclKeys.ReadKey = iterReadKey
-		aclKeys.MetadataPrivKey = metadataKey
+		// the root of the derived acl doesn't have the metadata key
+		if keys.encMetadatKey != nil {
+			metadataKey, err := st.unmarshallDecryptPrivKey(keys.encMetadatKey, iterReadKey.Decrypt)
+			if err != nil {
+				return err
+			}
+			aclKeys.MetadataPrivKey = metadataKey
+		}
 		st.keys[recId] = aclKeys
 		if idx != 0 {
 			if keys.oldEncryptedReadKey == nil {
@@ -776,7 +1037,7 @@ func (st *AclState) applyRequestRemove(ch *aclrecordproto.AclAccountRequestRemov
 	st.pendingRequests[mapKeyFromPubKey(record.Identity)] = record.Id
 	pk := mapKeyFromPubKey(record.Identity)
 	accSt, exists := st.accountStates[pk]
-	if !accSt.Permissions.CanRequestRemove() {
+	if !st.RoleCapabilities(accSt.Permissions).Has(CapabilityRequestRemove) {
 		return ErrInsufficientPermissions
 	}
 	if !exists {
@@ -838,7 +1099,11 @@ func (st *AclState) applyReadKeyChange(ch *aclrecordproto.AclReadKeyChange, reco
 	for _, accKey := range ch.AccountKeys {
 		identity, _ := st.keyStore.PubKeyFromProto(accKey.Identity)
 		if st.pubKey.Equals(identity) {
-			res, err := st.unmarshallDecryptReadKey(accKey.EncryptedReadKey, st.key.Decrypt)
+			decryptor, err := readKeyDecryptor(st.key, st.encryptionKey, accKey.Encryption)
+			if err != nil {
+				return err
+			}
+			res, err := st.unmarshallDecryptReadKey(accKey.EncryptedReadKey, decryptor)
 			if err != nil {
 				return err
 			}
@@ -853,6 +1118,7 @@ func (st *AclState) applyReadKeyChange(ch *aclrecordproto.AclReadKeyChange, reco
 		for key, invite := range st.invites {
 			if invite.Key.Equals(invKey) {
 				invite.encryptedKey = encKey.EncryptedReadKey
+				invite.encryption = encKey.Encryption
 				st.invites[key] = invite
 				break
 			}
@@ -869,6 +1135,29 @@ func (st *AclState) applyReadKeyChange(ch *aclrecordproto.AclReadKeyChange, reco
 	return nil
 }
 
+// readKeyDecryptor returns the decryptor of the read keys which were encrypted with the given scheme,
+// the keys can be kept outside the process, so only their decryption is used
+func readKeyDecryptor(key crypto.Signer, encryptionKey crypto.HybridDecryptor, encryption aclrecordproto.AclKeyEncryption) (func(msg []byte) ([]byte, error), error) {
+	switch encryption {
+	case aclrecordproto.AclKeyEncryption_X25519:
+		return key.Decrypt, nil
+	case aclrecordproto.AclKeyEncryption_X25519MlKem768:
+		if encryptionKey == nil {
+			return nil, ErrNoEncryptionKey
+		}
+		return encryptionKey.Decrypt, nil
+	}
+	return nil, ErrUnknownEncryption
+}
+
+// unmarshallEncryptionKey unmarshalls the optional hybrid key of the record
+func unmarshallEncryptionKey(protoKey []byte) (*crypto.HybridPubKey, error) {
+	if len(protoKey) == 0 {
+		return nil, nil
+	}
+	return crypto.UnmarshalHybridPublicKeyProto(protoKey)
+}
+
 func (st *AclState) unmarshallDecryptReadKey(msg []byte, decryptor func(msg []byte) ([]byte, error)) (crypto.SymKey, error) {
 	decrypted, err := decryptor(msg)
 	if err != nil {
@@ -925,6 +1214,40 @@ func (st *AclState) Permissions(identity crypto.PubKey) AclPermissions {
 	return state.Permissions
 }
 
+// Roles returns the custom roles defined in the acl
+func (st *AclState) Roles() []AclRole {
+	roles := make([]AclRole, 0, len(st.roles))
+	for _, role := range st.roles {
+		roles = append(roles, role)
+	}
+	slices.SortFunc(roles, func(a, b AclRole) int {
+		return int(a.Permissions) - int(b.Permissions)
+	})
+	return roles
+}
+
+// RoleCapabilities returns the capabilities of a built-in or a custom role
+func (st *AclState) RoleCapabilities(permissions AclPermissions) AclCapabilities {
+	if permissions.IsCustom() {
+		return st.roles[permissions].Capabilities
+	}
+	return builtinCapabilities[permissions]
+}
+
+// Capabilities returns the current capabilities of the identity
+func (st *AclState) Capabilities(identity crypto.PubKey) AclCapabilities {
+	return st.RoleCapabilities(st.Permissions(identity))
+}
+
+func (st *AclState) isKnownRole(permissions AclPermissions) bool {
+	if permissions.IsCustom() {
+		_, exists := st.roles[permissions]
+		return exists
+	}
+	_, exists := builtinCapabilities[permissions]
+	return exists
+}
+
 func (st *AclState) JoinRecords(decrypt bool) (records []RequestRecord, err error) {
 	for _, recId := range st.pendingRequests {
 		rec := st.requestRecords[recId]
@@ -984,6 +1307,28 @@ func (st *AclState) RemoveRecords() (records []RequestRecord) {
 	return
 }
 
+// IsDeviceRevoked checks if the device certificate issued by the identity was revoked
+func (st *AclState) IsDeviceRevoked(identity, deviceKey crypto.PubKey) bool {
+	_, revoked := st.revokedDevices[revokedDeviceKey(identity, deviceKey)]
+	return revoked
+}
+
+// IsDeviceRevokedAtRecord checks if the device of the identity was revoked at the time of the record,
+// so the changes of the device made before the revocation stay valid
+func (st *AclState) IsDeviceRevokedAtRecord(id string, identity, deviceKey crypto.PubKey) (bool, error) {
+	if !st.list.HasHead(id) {
+		return false, ErrNoSuchRecord
+	}
+	revokeId, revoked := st.revokedDevices[revokedDeviceKey(identity, deviceKey)]
+	return revoked && st.list.isAfterNoCheck(id, revokeId), nil
+}
+
+// revokedDeviceKey binds the device to the identity, so the revocation of the device named by one account
+// doesn't affect the other accounts
+func revokedDeviceKey(identity, deviceKey crypto.PubKey) string {
+	return mapKeyFromPubKey(identity) + mapKeyFromPubKey(deviceKey)
+}
+
 func (st *AclState) LastRecordId() string {
 	return st.lastRecordId
 }
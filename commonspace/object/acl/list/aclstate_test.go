@@ -3,7 +3,12 @@ package list
 import (
 	"crypto/rand"
 	"testing"
+	"time"
 
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
 	"github.com/anyproto/any-sync/util/crypto"
 
 	"github.com/stretchr/testify/require"
@@ -111,3 +116,708 @@ func TestAclState_FirstMetadataKey(t *testing.T) {
 		require.ErrorIs(t, err, ErrNoMetadataKey)
 	})
 }
+
+func newAcls(t *testing.T, count int) (ownerAcl AclList, accountAcls []AclList) {
+	ownerKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	ownerAcl, err = NewInMemoryDerivedAcl("spaceId", ownerKeys)
+	require.NoError(t, err)
+	for i := 0; i < count; i++ {
+		keys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		copyStorage := ownerAcl.(*aclList).storage.(*inMemoryStorage).Copy()
+		acl, err := BuildAclListWithIdentity(keys, copyStorage, recordverifier.NewValidateFull())
+		require.NoError(t, err)
+		accountAcls = append(accountAcls, acl)
+	}
+	return
+}
+
+// aclWithKeys builds the acl of the owner for the other account
+func aclWithKeys(t *testing.T, ownerAcl AclList, keys *accountdata.AccountKeys) AclList {
+	copyStorage := ownerAcl.(*aclList).storage.(*inMemoryStorage).Copy()
+	acl, err := BuildAclListWithIdentity(keys, copyStorage, recordverifier.NewValidateFull())
+	require.NoError(t, err)
+	return acl
+}
+
+func addRec(t *testing.T, rec *consensusproto.RawRecord, acls ...AclList) {
+	for _, acl := range acls {
+		require.NoError(t, acl.AddRawRecord(WrapAclRecord(rec)))
+	}
+}
+
+func TestAclState_InviteLimits(t *testing.T) {
+	t.Run("anyone can join invite is exhausted after max uses", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 2)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteWithLimits(InvitePayload{
+			Permissions: AclPermissionsWriter,
+			MaxUses:     1,
+		})
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		firstJoin, err := accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		secondJoin, err := accAcls[1].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		addRec(t, firstJoin, allAcls...)
+		invites := ownerAcl.AclState().Invites()
+		require.Len(t, invites, 1)
+		require.Equal(t, uint32(1), invites[0].Uses)
+		require.True(t, invites[0].IsExhausted())
+		// the second join was built before the first one was applied
+		join, err := ownerAcl.RecordBuilder().Unmarshall(secondJoin)
+		require.NoError(t, err)
+		join.PrevId = ownerAcl.AclState().LastRecordId()
+		require.ErrorIs(t, ownerAcl.AclState().Copy().ApplyRecord(join), ErrInviteExhausted)
+		_, err = accAcls[1].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.ErrorIs(t, err, ErrInviteExhausted)
+	})
+	t.Run("request to join invite is exhausted after max uses", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 2)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteWithLimits(InvitePayload{MaxUses: 1})
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		join, err := accAcls[0].RecordBuilder().BuildRequestJoin(RequestJoinPayload{InviteKey: inv.InviteKey})
+		require.NoError(t, err)
+		addRec(t, join, allAcls...)
+		firstRequestId := ownerAcl.AclState().LastRecordId()
+		// the pending requests don't spend the invite
+		join, err = accAcls[1].RecordBuilder().BuildRequestJoin(RequestJoinPayload{InviteKey: inv.InviteKey})
+		require.NoError(t, err)
+		addRec(t, join, allAcls...)
+		secondRequestId := ownerAcl.AclState().LastRecordId()
+
+		accept, err := ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+			RequestRecordId: firstRequestId,
+			Permissions:     AclPermissionsReader,
+		})
+		require.NoError(t, err)
+		addRec(t, accept, allAcls...)
+		_, err = ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+			RequestRecordId: secondRequestId,
+			Permissions:     AclPermissionsReader,
+		})
+		require.ErrorIs(t, err, ErrInviteExhausted)
+	})
+	t.Run("declined requests don't spend the invite", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 2)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteWithLimits(InvitePayload{MaxUses: 1})
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		join, err := accAcls[0].RecordBuilder().BuildRequestJoin(RequestJoinPayload{InviteKey: inv.InviteKey})
+		require.NoError(t, err)
+		addRec(t, join, allAcls...)
+		decline, err := ownerAcl.RecordBuilder().BuildRequestDecline(ownerAcl.AclState().LastRecordId())
+		require.NoError(t, err)
+		addRec(t, decline, allAcls...)
+		require.Zero(t, ownerAcl.AclState().Invites()[0].Uses)
+
+		join, err = accAcls[1].RecordBuilder().BuildRequestJoin(RequestJoinPayload{InviteKey: inv.InviteKey})
+		require.NoError(t, err)
+		addRec(t, join, allAcls...)
+		accept, err := ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+			RequestRecordId: ownerAcl.AclState().LastRecordId(),
+			Permissions:     AclPermissionsReader,
+		})
+		require.NoError(t, err)
+		addRec(t, accept, allAcls...)
+		require.True(t, ownerAcl.AclState().Invites()[0].IsExhausted())
+	})
+	t.Run("expired invite is rejected by every peer", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		_, err := ownerAcl.RecordBuilder().BuildInviteWithLimits(InvitePayload{
+			Permissions:     AclPermissionsReader,
+			ExpireTimestamp: time.Now().Add(-time.Minute).Unix(),
+		})
+		require.ErrorIs(t, err, ErrInviteExpired)
+		expireTimestamp := time.Now().Add(time.Hour).Unix()
+		inv, err := ownerAcl.RecordBuilder().BuildInviteWithLimits(InvitePayload{
+			Permissions:     AclPermissionsReader,
+			ExpireTimestamp: expireTimestamp,
+		})
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		rawJoin, err := accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		join, err := ownerAcl.RecordBuilder().Unmarshall(rawJoin)
+		require.NoError(t, err)
+		// the author can backdate the record, the consensus timestamp is checked
+		join.Timestamp = expireTimestamp - 100
+		join.AcceptorTimestamp = expireTimestamp
+
+		// validating peer
+		st := ownerAcl.AclState().Copy()
+		require.ErrorIs(t, st.ApplyRecord(join), ErrInviteExpired)
+
+		// peer which doesn't validate the contents still refuses the join
+		st = ownerAcl.AclState().Copy()
+		st.contentValidator = newContentValidator(st.keyStore, st, recordverifier.New())
+		require.ErrorIs(t, st.ApplyRecord(join), ErrInviteExpired)
+	})
+	t.Run("accepted join is valid after the invite expires", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		expireTimestamp := time.Now().Add(2 * time.Second).Unix()
+		inv, err := ownerAcl.RecordBuilder().BuildInviteWithLimits(InvitePayload{
+			Permissions:     AclPermissionsReader,
+			ExpireTimestamp: expireTimestamp,
+		})
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		join, err := accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		join.AcceptorTimestamp = time.Now().Unix()
+		addRec(t, join, allAcls...)
+		for time.Now().Unix() < expireTimestamp {
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		// the acl is reloaded with the timestamp of the consensus node
+		storage := ownerAcl.(*aclList).storage.(*inMemoryStorage).Copy()
+		keys := ownerAcl.(*aclList).recordBuilder.(*aclRecordBuilder).accountKeys
+		reloaded, err := BuildAclListWithIdentity(keys, storage, recordverifier.NewValidateFull())
+		require.NoError(t, err)
+		require.Equal(t, ownerAcl.Head().Id, reloaded.Head().Id)
+		require.Equal(t, join.AcceptorTimestamp, reloaded.Head().AcceptorTimestamp)
+		require.Equal(t, AclPermissionsReader, reloaded.AclState().Permissions(accAcls[0].AclState().Identity()))
+	})
+}
+
+func TestAclState_CustomRoles(t *testing.T) {
+	inviterRole := AclRole{
+		Permissions:  MinCustomRole,
+		Name:         "inviter",
+		Capabilities: CapabilityInvite | CapabilityRequestRemove,
+	}
+	t.Run("built-in roles are presets", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		st := ownerAcl.AclState()
+		require.True(t, st.RoleCapabilities(AclPermissionsOwner).Has(CapabilityManageRoles))
+		require.False(t, st.RoleCapabilities(AclPermissionsAdmin).Has(CapabilityManageRoles))
+		require.True(t, st.RoleCapabilities(AclPermissionsAdmin).Has(CapabilityRemoveAccounts))
+		require.True(t, st.RoleCapabilities(AclPermissionsWriter).Has(CapabilityWriteTrees|CapabilityWriteKeyValue))
+		require.False(t, st.RoleCapabilities(AclPermissionsWriter).Has(CapabilityInvite))
+		require.False(t, st.RoleCapabilities(AclPermissionsReader).CanWrite())
+		require.Zero(t, st.RoleCapabilities(AclPermissionsGuest))
+		require.Zero(t, st.RoleCapabilities(AclPermissionsNone))
+	})
+	t.Run("account with custom role can invite but not remove or write", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		rec, err := ownerAcl.RecordBuilder().BuildRoleDefine(inviterRole)
+		require.NoError(t, err)
+		addRec(t, rec, allAcls...)
+		require.Equal(t, []AclRole{inviterRole}, accAcls[0].AclState().Roles())
+
+		inv, err := ownerAcl.RecordBuilder().BuildInviteAnyone(inviterRole.Permissions)
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		join, err := accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		addRec(t, join, allAcls...)
+
+		accKey := accAcls[0].AclState().Identity()
+		require.Equal(t, inviterRole.Permissions, ownerAcl.AclState().Permissions(accKey))
+		require.Equal(t, inviterRole.Capabilities, ownerAcl.AclState().Capabilities(accKey))
+		require.False(t, ownerAcl.AclState().Capabilities(accKey).CanWrite())
+
+		reqInv, err := accAcls[0].RecordBuilder().BuildInvite()
+		require.NoError(t, err)
+		addRec(t, reqInv.InviteRec, allAcls...)
+		// can't give away more than the role has
+		_, err = accAcls[0].RecordBuilder().BuildInviteAnyone(AclPermissionsWriter)
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+		_, err = accAcls[0].RecordBuilder().BuildAccountRemove(AccountRemovePayload{
+			Identities: []crypto.PubKey{ownerAcl.AclState().Identity()},
+		})
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+		_, err = accAcls[0].RecordBuilder().BuildRoleDefine(AclRole{Permissions: MinCustomRole + 1})
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+	})
+	t.Run("incorrect role definitions", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		_, err := ownerAcl.RecordBuilder().BuildRoleDefine(AclRole{Permissions: AclPermissionsWriter})
+		require.ErrorIs(t, err, ErrIncorrectRole)
+		rec, err := ownerAcl.RecordBuilder().BuildRoleDefine(inviterRole)
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl)
+		_, err = ownerAcl.RecordBuilder().BuildRoleDefine(inviterRole)
+		require.ErrorIs(t, err, ErrRoleExists)
+		// the role must be defined before it is assigned
+		_, err = ownerAcl.RecordBuilder().BuildInviteAnyone(MinCustomRole + 1)
+		require.ErrorIs(t, err, ErrNoSuchRole)
+	})
+}
+
+func TestAclState_KeyRotate(t *testing.T) {
+	joinWriter := func(t *testing.T, ownerAcl, accAcl AclList) {
+		inv, err := ownerAcl.RecordBuilder().BuildInviteAnyone(AclPermissionsWriter)
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, ownerAcl, accAcl)
+		join, err := accAcl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		addRec(t, join, ownerAcl, accAcl)
+	}
+	t.Run("member is moved to the new identity", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		joinWriter(t, ownerAcl, accAcls[0])
+		oldIdentity := accAcls[0].AclState().Identity()
+		newKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+
+		prevId := ownerAcl.AclState().LastRecordId()
+		rec, err := accAcls[0].RecordBuilder().BuildKeyRotate(KeyRotatePayload{NewKey: newKeys.SignKey})
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl, accAcls[0])
+
+		st := ownerAcl.AclState()
+		require.Equal(t, AclPermissionsWriter, st.Permissions(newKeys.SignKey.GetPublic()))
+		require.True(t, st.Permissions(oldIdentity).NoPermissions())
+		oldState := st.accountStates[mapKeyFromPubKey(oldIdentity)]
+		require.Equal(t, StatusRotated, oldState.Status)
+		require.True(t, oldState.Successor.Equals(newKeys.SignKey.GetPublic()))
+		// the new identity has no permissions before the rotation
+		perms, err := st.PermissionsAtRecord(prevId, newKeys.SignKey.GetPublic())
+		require.NoError(t, err)
+		require.True(t, perms.NoPermissions())
+
+		newAcl := aclWithKeys(t, ownerAcl, newKeys)
+		ownerReadKey, err := st.CurrentReadKey()
+		require.NoError(t, err)
+		newReadKey, err := newAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, ownerReadKey.Equals(newReadKey))
+
+		// the new identity gets the next read keys as the regular member
+		metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		nextReadKey := crypto.NewAES()
+		rkChange, err := ownerAcl.RecordBuilder().BuildReadKeyChange(ReadKeyChangePayload{
+			MetadataKey: metadataKey,
+			ReadKey:     nextReadKey,
+		})
+		require.NoError(t, err)
+		addRec(t, rkChange, ownerAcl, newAcl)
+		newReadKey, err = newAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, nextReadKey.Equals(newReadKey))
+	})
+	t.Run("rotating member swaps the local identity", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		joinWriter(t, ownerAcl, accAcls[0])
+		newKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		rec, err := accAcls[0].RecordBuilder().BuildKeyRotate(KeyRotatePayload{NewKey: newKeys.SignKey})
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl, accAcls[0])
+		require.True(t, accAcls[0].AclState().Permissions(accAcls[0].AclState().Identity()).NoPermissions())
+
+		require.NoError(t, accAcls[0].SetAccountKeys(newKeys))
+		st := accAcls[0].AclState()
+		require.True(t, st.Identity().Equals(newKeys.SignKey.GetPublic()))
+		require.Equal(t, AclPermissionsWriter, st.Permissions(st.Identity()))
+		ownerReadKey, err := ownerAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		readKey, err := st.CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, ownerReadKey.Equals(readKey))
+		// the records are signed by the new identity
+		rawRec, err := accAcls[0].RecordBuilder().BuildRequestRemove()
+		require.NoError(t, err)
+		requestRemove, err := ownerAcl.RecordBuilder().Unmarshall(rawRec)
+		require.NoError(t, err)
+		require.True(t, requestRemove.Identity.Equals(newKeys.SignKey.GetPublic()))
+	})
+	t.Run("owner is moved to the new identity", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		newKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		rec, err := ownerAcl.RecordBuilder().BuildKeyRotate(KeyRotatePayload{NewKey: newKeys.SignKey})
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl)
+		owner, err := ownerAcl.AclState().OwnerPubKey()
+		require.NoError(t, err)
+		require.True(t, owner.Equals(newKeys.SignKey.GetPublic()))
+
+		newAcl := aclWithKeys(t, ownerAcl, newKeys)
+		_, err = newAcl.RecordBuilder().BuildInvite()
+		require.NoError(t, err)
+		_, err = ownerAcl.RecordBuilder().BuildInvite()
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+	})
+	t.Run("can't rotate to the existing member", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		joinWriter(t, ownerAcl, accAcls[0])
+		_, err := ownerAcl.RecordBuilder().BuildKeyRotate(KeyRotatePayload{NewKey: accAcls[0].AclState().Key()})
+		require.ErrorIs(t, err, ErrAccountExists)
+	})
+	t.Run("non member can't rotate", func(t *testing.T) {
+		_, accAcls := newAcls(t, 1)
+		newKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		_, err = accAcls[0].RecordBuilder().BuildKeyRotate(KeyRotatePayload{NewKey: newKeys.SignKey})
+		require.ErrorIs(t, err, ErrNoSuchAccount)
+	})
+	t.Run("succession must be signed by the new identity", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		newKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		otherKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		rawRec, err := ownerAcl.RecordBuilder().BuildKeyRotate(KeyRotatePayload{NewKey: newKeys.SignKey})
+		require.NoError(t, err)
+		rec, err := ownerAcl.RecordBuilder().Unmarshall(rawRec)
+		require.NoError(t, err)
+		rotate := rec.Model.(*aclrecordproto.AclData).AclContent[0].GetKeyRotate()
+		other, err := crypto.NewKeySuccession(ownerAcl.AclState().Key(), otherKeys.SignKey)
+		require.NoError(t, err)
+		rotate.OldIdentitySignature = other.OldIdentitySignature
+		require.ErrorIs(t, ownerAcl.AclState().Copy().ApplyRecord(rec), ErrInvalidSignature)
+	})
+}
+
+func TestAclState_EncryptionKey(t *testing.T) {
+	readKeyChange := func(t *testing.T, acl AclList) (crypto.SymKey, *aclrecordproto.AclReadKeyChange, *consensusproto.RawRecord) {
+		metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		readKey := crypto.NewAES()
+		rec, err := acl.RecordBuilder().BuildReadKeyChange(ReadKeyChangePayload{
+			MetadataKey: metadataKey,
+			ReadKey:     readKey,
+		})
+		require.NoError(t, err)
+		aclRec, err := acl.RecordBuilder().Unmarshall(rec)
+		require.NoError(t, err)
+		return readKey, aclRec.Model.(*aclrecordproto.AclData).AclContent[0].GetReadKeyChange(), rec
+	}
+	encryptionOf := func(t *testing.T, keys []*aclrecordproto.AclEncryptedReadKey, identity crypto.PubKey) aclrecordproto.AclKeyEncryption {
+		protoIdentity, err := identity.Marshall()
+		require.NoError(t, err)
+		for _, key := range keys {
+			if string(key.Identity) == string(protoIdentity) {
+				return key.Encryption
+			}
+		}
+		require.Fail(t, "no read key for identity")
+		return 0
+	}
+	t.Run("read keys are encrypted with the published key", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteAnyone(AclPermissionsWriter)
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		join, err := accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		addRec(t, join, allAcls...)
+
+		rec, err := accAcls[0].RecordBuilder().BuildEncryptionKey()
+		require.NoError(t, err)
+		addRec(t, rec, allAcls...)
+		accIdentity := accAcls[0].AclState().Identity()
+		require.NotNil(t, ownerAcl.AclState().accountStates[mapKeyFromPubKey(accIdentity)].EncryptionKey)
+
+		readKey, ch, rkRec := readKeyChange(t, ownerAcl)
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519MlKem768, encryptionOf(t, ch.AccountKeys, accIdentity))
+		// the owner didn't publish the key, so it gets the old envelope
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519, encryptionOf(t, ch.AccountKeys, ownerAcl.AclState().Identity()))
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519MlKem768, encryptionOf(t, ch.InviteKeys, inv.InviteKey.GetPublic()))
+		addRec(t, rkRec, allAcls...)
+		for _, acl := range allAcls {
+			curKey, err := acl.AclState().CurrentReadKey()
+			require.NoError(t, err)
+			require.True(t, readKey.Equals(curKey))
+		}
+		// the invite is still usable after the key change
+		invKey, err := ownerAcl.AclState().DecryptInvite(inv.InviteKey, inv.InviteEncryptionKey)
+		require.NoError(t, err)
+		require.True(t, readKey.Equals(invKey))
+	})
+	t.Run("non member can't publish the key", func(t *testing.T) {
+		_, accAcls := newAcls(t, 1)
+		_, err := accAcls[0].RecordBuilder().BuildEncryptionKey()
+		require.ErrorIs(t, err, ErrNoSuchAccount)
+	})
+	t.Run("account without encryption key can't publish it", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		ownerAcl.RecordBuilder().(*aclRecordBuilder).accountKeys.EncryptionKey = nil
+		_, err := ownerAcl.RecordBuilder().BuildEncryptionKey()
+		require.ErrorIs(t, err, ErrNoEncryptionKey)
+	})
+	t.Run("invite join requires the invite encryption key", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteAnyone(AclPermissionsWriter)
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, ownerAcl, accAcls[0])
+		_, err = accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey})
+		require.ErrorIs(t, err, ErrNoEncryptionKey)
+		other, err := crypto.GenerateRandomHybridKey()
+		require.NoError(t, err)
+		_, err = accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: other})
+		require.ErrorIs(t, err, ErrFailedToDecrypt)
+	})
+	t.Run("join records use the account encryption key", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 2)
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteAnyone(AclPermissionsWriter)
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		join, err := accAcls[0].RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519MlKem768, contentOf(t, ownerAcl, join).GetInviteJoin().Encryption)
+		addRec(t, join, allAcls...)
+
+		reqInv, err := ownerAcl.RecordBuilder().BuildInvite()
+		require.NoError(t, err)
+		addRec(t, reqInv.InviteRec, allAcls...)
+		requestJoin, err := accAcls[1].RecordBuilder().BuildRequestJoin(RequestJoinPayload{InviteKey: reqInv.InviteKey})
+		require.NoError(t, err)
+		addRec(t, requestJoin, allAcls...)
+		accept, err := ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+			RequestRecordId: ownerAcl.AclState().LastRecordId(),
+			Permissions:     AclPermissionsReader,
+		})
+		require.NoError(t, err)
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519MlKem768, contentOf(t, ownerAcl, accept).GetRequestAccept().Encryption)
+		addRec(t, accept, allAcls...)
+
+		ownerReadKey, err := ownerAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		for _, acl := range accAcls {
+			require.NotNil(t, ownerAcl.AclState().accountStates[mapKeyFromPubKey(acl.AclState().Identity())].EncryptionKey)
+			readKey, err := acl.AclState().CurrentReadKey()
+			require.NoError(t, err)
+			require.True(t, ownerReadKey.Equals(readKey))
+		}
+	})
+	t.Run("accounts add and key rotate use the encryption key", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		accKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		add, err := ownerAcl.RecordBuilder().BuildAccountsAdd(AccountsAddPayload{Additions: []AccountAdd{{
+			Identity:      accKeys.SignKey.GetPublic(),
+			Permissions:   AclPermissionsWriter,
+			EncryptionKey: accKeys.EncryptionKey.GetPublic(),
+		}}})
+		require.NoError(t, err)
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519MlKem768, contentOf(t, ownerAcl, add).GetAccountsAdd().Additions[0].Encryption)
+		addRec(t, add, ownerAcl)
+		accAcl := aclWithKeys(t, ownerAcl, accKeys)
+		ownerReadKey, err := ownerAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		readKey, err := accAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, ownerReadKey.Equals(readKey))
+
+		newKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		rotate, err := accAcl.RecordBuilder().BuildKeyRotate(KeyRotatePayload{
+			NewKey:           newKeys.SignKey,
+			NewEncryptionKey: newKeys.EncryptionKey.GetPublic(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519MlKem768, contentOf(t, ownerAcl, rotate).GetKeyRotate().Encryption)
+		addRec(t, rotate, ownerAcl, accAcl)
+		require.NoError(t, accAcl.SetAccountKeys(newKeys))
+		readKey, err = accAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, ownerReadKey.Equals(readKey))
+	})
+	t.Run("signer backed account decrypts with the separate key", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		accKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		accKeys.SignKey = crypto.NewSignerKey(accKeys.SignKey)
+		accAcl := aclWithKeys(t, ownerAcl, accKeys)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteAnyone(AclPermissionsWriter)
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, ownerAcl, accAcl)
+		join, err := accAcl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+		require.NoError(t, err)
+		addRec(t, join, ownerAcl, accAcl)
+		rec, err := accAcl.RecordBuilder().BuildEncryptionKey()
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl, accAcl)
+
+		readKey, ch, rkRec := readKeyChange(t, ownerAcl)
+		require.Equal(t, aclrecordproto.AclKeyEncryption_X25519MlKem768, encryptionOf(t, ch.AccountKeys, accKeys.SignKey.GetPublic()))
+		addRec(t, rkRec, ownerAcl, accAcl)
+		curKey, err := accAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, readKey.Equals(curKey))
+	})
+}
+
+// deviceCertificate issues the certificate for the device key with the identity of the acl account
+func deviceCertificate(t *testing.T, acl AclList, deviceKey crypto.PubKey) *crypto.DeviceCertificate {
+	signKey := acl.(*aclList).recordBuilder.(*aclRecordBuilder).accountKeys.SignKey
+	cert, err := crypto.NewDeviceCertificate(signKey, deviceKey)
+	require.NoError(t, err)
+	return cert
+}
+
+func mustRandomKeys(t *testing.T) *accountdata.AccountKeys {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	return keys
+}
+
+func contentOf(t *testing.T, acl AclList, rawRec *consensusproto.RawRecord) *aclrecordproto.AclContentValue {
+	rec, err := acl.RecordBuilder().Unmarshall(rawRec)
+	require.NoError(t, err)
+	return rec.Model.(*aclrecordproto.AclData).AclContent[0]
+}
+
+func TestAclState_DeviceRevoke(t *testing.T) {
+	joinWriter := func(t *testing.T, ownerAcl AclList, accAcls ...AclList) {
+		allAcls := append([]AclList{ownerAcl}, accAcls...)
+		inv, err := ownerAcl.RecordBuilder().BuildInviteAnyone(AclPermissionsWriter)
+		require.NoError(t, err)
+		addRec(t, inv.InviteRec, allAcls...)
+		for _, acl := range accAcls {
+			join, err := acl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{InviteKey: inv.InviteKey, InviteEncryptionKey: inv.InviteEncryptionKey})
+			require.NoError(t, err)
+			addRec(t, join, allAcls...)
+		}
+	}
+	t.Run("account revokes own device", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		joinWriter(t, ownerAcl, accAcls...)
+		_, deviceKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		readKeyId := ownerAcl.AclState().CurrentReadKeyId()
+		accIdentity := accAcls[0].AclState().Identity()
+
+		cert := deviceCertificate(t, accAcls[0], deviceKey)
+		rec, err := accAcls[0].RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: cert})
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl, accAcls[0])
+		require.True(t, ownerAcl.AclState().IsDeviceRevoked(accIdentity, deviceKey))
+		// the account and the read key stay the same
+		require.Equal(t, AclPermissionsWriter, ownerAcl.AclState().Permissions(accIdentity))
+		require.Equal(t, readKeyId, ownerAcl.AclState().CurrentReadKeyId())
+
+		_, err = accAcls[0].RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: cert})
+		require.ErrorIs(t, err, ErrDeviceRevoked)
+	})
+	t.Run("owner revokes device of the member", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 1)
+		joinWriter(t, ownerAcl, accAcls...)
+		_, deviceKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		rec, err := ownerAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{
+			Certificate: deviceCertificate(t, accAcls[0], deviceKey),
+		})
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl, accAcls[0])
+		require.True(t, accAcls[0].AclState().IsDeviceRevoked(accAcls[0].AclState().Identity(), deviceKey))
+	})
+	t.Run("member can't revoke device of another member", func(t *testing.T) {
+		ownerAcl, accAcls := newAcls(t, 2)
+		joinWriter(t, ownerAcl, accAcls...)
+		_, deviceKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		payload := DeviceRevokePayload{Certificate: deviceCertificate(t, accAcls[1], deviceKey)}
+		_, err = accAcls[0].RecordBuilder().BuildDeviceRevoke(payload)
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+
+		// the record which is built by the owner is rejected if it is authored by the member
+		rawRec, err := ownerAcl.RecordBuilder().BuildDeviceRevoke(payload)
+		require.NoError(t, err)
+		rec, err := ownerAcl.RecordBuilder().Unmarshall(rawRec)
+		require.NoError(t, err)
+		rec.Identity = accAcls[0].AclState().Identity()
+		require.ErrorIs(t, ownerAcl.AclState().Copy().ApplyRecord(rec), ErrInsufficientPermissions)
+	})
+	t.Run("member can't revoke device of the owner", func(t *testing.T) {
+		ownerKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		ownerAcl, err := NewInMemoryDerivedAcl("spaceId", ownerKeys)
+		require.NoError(t, err)
+		accAcl := aclWithKeys(t, ownerAcl, mustRandomKeys(t))
+		joinWriter(t, ownerAcl, accAcl)
+		ownerIdentity := ownerAcl.AclState().Identity()
+		accIdentity := accAcl.AclState().Identity()
+		_, ownerDeviceKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		ownerCert, err := crypto.NewDeviceCertificate(ownerKeys.SignKey, ownerDeviceKey)
+		require.NoError(t, err)
+
+		// the member names itself, but passes the device of the owner
+		_, memberDeviceKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		rawRec, err := accAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: deviceCertificate(t, accAcl, memberDeviceKey)})
+		require.NoError(t, err)
+		rec, err := ownerAcl.RecordBuilder().Unmarshall(rawRec)
+		require.NoError(t, err)
+		revoke := rec.Model.(*aclrecordproto.AclData).AclContent[0].GetDeviceRevoke()
+		revoke.DeviceKey, err = ownerDeviceKey.Marshall()
+		require.NoError(t, err)
+		require.ErrorIs(t, ownerAcl.AclState().Copy().ApplyRecord(rec), ErrIncorrectIdentity)
+		revoke.DeviceCertificate = ownerCert.Marshall()
+		require.ErrorIs(t, ownerAcl.AclState().Copy().ApplyRecord(rec), ErrIncorrectIdentity)
+		_, err = accAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: ownerCert})
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+
+		// the member can issue its own certificate for the same key, it revokes only the device of the member
+		rawRec, err = accAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: deviceCertificate(t, accAcl, ownerDeviceKey)})
+		require.NoError(t, err)
+		addRec(t, rawRec, ownerAcl, accAcl)
+		require.True(t, ownerAcl.AclState().IsDeviceRevoked(accIdentity, ownerDeviceKey))
+		require.False(t, ownerAcl.AclState().IsDeviceRevoked(ownerIdentity, ownerDeviceKey))
+	})
+	t.Run("device signs records until it is revoked", func(t *testing.T) {
+		ownerAcl, _ := newAcls(t, 0)
+		accKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		accAcl := aclWithKeys(t, ownerAcl, accKeys)
+		joinWriter(t, ownerAcl, accAcl)
+		devicePrivKey, devicePubKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		cert, err := crypto.NewDeviceCertificate(accKeys.SignKey, devicePubKey)
+		require.NoError(t, err)
+		deviceKeys, err := accountdata.NewDevice(accKeys.PeerKey, devicePrivKey, cert)
+		require.NoError(t, err)
+		deviceKeys.EncryptionKey = accKeys.EncryptionKey
+		deviceAcl := aclWithKeys(t, ownerAcl, deviceKeys)
+		readKey, err := accAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		deviceReadKey, err := deviceAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, readKey.Equals(deviceReadKey))
+
+		// the device acts on behalf of the account
+		_, otherDeviceKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		otherDeviceCert, err := crypto.NewDeviceCertificate(accKeys.SignKey, otherDeviceKey)
+		require.NoError(t, err)
+		accIdentity := accAcl.AclState().Identity()
+		rec, err := deviceAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: otherDeviceCert})
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl, accAcl, deviceAcl)
+		require.True(t, ownerAcl.Head().Identity.Equals(accIdentity))
+		require.True(t, ownerAcl.Head().DeviceKey.Equals(devicePubKey))
+
+		// the record signed by the device before the revocation is rejected after it
+		_, anotherDeviceKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		anotherDeviceCert, err := crypto.NewDeviceCertificate(accKeys.SignKey, anotherDeviceKey)
+		require.NoError(t, err)
+		rawRec, err := deviceAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: anotherDeviceCert})
+		require.NoError(t, err)
+		rec, err = ownerAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: cert})
+		require.NoError(t, err)
+		addRec(t, rec, ownerAcl, accAcl, deviceAcl)
+		deviceRec, err := ownerAcl.RecordBuilder().Unmarshall(rawRec)
+		require.NoError(t, err)
+		deviceRec.PrevId = ownerAcl.Head().Id
+		require.ErrorIs(t, ownerAcl.AclState().Copy().ApplyRecord(deviceRec), ErrDeviceRevoked)
+		_, err = deviceAcl.RecordBuilder().BuildDeviceRevoke(DeviceRevokePayload{Certificate: anotherDeviceCert})
+		require.ErrorIs(t, err, ErrDeviceRevoked)
+	})
+}
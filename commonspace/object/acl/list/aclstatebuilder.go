@@ -6,13 +6,15 @@ import (
 )
 
 type aclStateBuilder struct {
-	privKey crypto.PrivKey
-	id      string
+	privKey       crypto.PrivKey
+	encryptionKey crypto.HybridDecryptor
+	id            string
 }
 
 func newAclStateBuilderWithIdentity(keys *accountdata.AccountKeys) *aclStateBuilder {
 	return &aclStateBuilder{
-		privKey: keys.SignKey,
+		privKey:       keys.SignKey,
+		encryptionKey: keys.EncryptionKey,
 	}
 }
 
@@ -29,7 +31,7 @@ func (sb *aclStateBuilder) Build(records []*AclRecord, list *aclList) (state *Ac
 		return nil, ErrIncorrectRecordSequence
 	}
 	if sb.privKey != nil {
-		state, err = newAclStateWithKeys(records[0], sb.privKey, list.verifier)
+		state, err = newAclStateWithKeys(records[0], sb.privKey, sb.encryptionKey, list.verifier)
 		if err != nil {
 			return
 		}
@@ -32,6 +32,7 @@ type AclTestExecutor struct {
 	ownerMeta           []byte
 	root                *consensusproto.RawRecordWithId
 	invites             map[string]crypto.PrivKey
+	inviteEncKeys       map[string]*crypto.HybridPrivKey
 	actualAccounts      map[string]*TestAclState
 	expectedAccounts    map[string]*accountExpectedState
 	expectedPermissions map[string][]accountExpectedState
@@ -41,6 +42,7 @@ func NewAclExecutor(spaceId string) *AclTestExecutor {
 	return &AclTestExecutor{
 		spaceId:             spaceId,
 		invites:             map[string]crypto.PrivKey{},
+		inviteEncKeys:       map[string]*crypto.HybridPrivKey{},
 		actualAccounts:      make(map[string]*TestAclState),
 		expectedAccounts:    make(map[string]*accountExpectedState),
 		expectedPermissions: make(map[string][]accountExpectedState),
@@ -54,6 +56,7 @@ func NewExternalKeysAclExecutor(spaceId string, keys *accountdata.AccountKeys, o
 		root:                root,
 		ownerMeta:           ownerMeta,
 		invites:             map[string]crypto.PrivKey{},
+		inviteEncKeys:       map[string]*crypto.HybridPrivKey{},
 		actualAccounts:      make(map[string]*TestAclState),
 		expectedAccounts:    make(map[string]*accountExpectedState),
 		expectedPermissions: make(map[string][]accountExpectedState),
@@ -237,6 +240,7 @@ func (a *AclTestExecutor) buildBatchRequest(args []string, acl AclList, getPerm
 	}
 	for i, id := range inviteIds {
 		a.invites[id] = res.Invites[i]
+		a.inviteEncKeys[id] = res.InviteEncryptionKeys[i]
 	}
 	return afterAll, addRec(WrapAclRecord(res.Rec))
 }
@@ -435,6 +439,7 @@ func (a *AclTestExecutor) Execute(cmd string) (err error) {
 			return err
 		}
 		a.invites[inviteParts[0]] = res.InviteKey
+		a.inviteEncKeys[inviteParts[0]] = res.InviteEncryptionKey
 		err = addRec(WrapAclRecord(res.InviteRec))
 		if err != nil {
 			return err
@@ -558,8 +563,9 @@ func (a *AclTestExecutor) Execute(cmd string) (err error) {
 	case "invite_join":
 		invite := a.invites[args[0]]
 		inviteJoin, err := acl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{
-			InviteKey: invite,
-			Metadata:  []byte(account),
+			InviteKey:           invite,
+			InviteEncryptionKey: a.inviteEncKeys[args[0]],
+			Metadata:            []byte(account),
 		})
 		if err != nil {
 			return err
@@ -9,7 +9,9 @@ import (
 
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/net/peer"
 	"github.com/anyproto/any-sync/util/cidutil"
 	"github.com/anyproto/any-sync/util/crypto"
 )
@@ -46,6 +48,9 @@ type AclList interface {
 
 	KeyStorage() crypto.KeyStorage
 	RecordBuilder() AclRecordBuilder
+	// SetAccountKeys swaps the local identity, e.g. after the key rotation, and rebuilds the state with the new keys.
+	// Only the acl is switched, aclclient.AclSpaceClient.RotateKey switches the other components of the space
+	SetAccountKeys(acc *accountdata.AccountKeys) (err error)
 
 	ValidateRawRecord(rawRec *consensusproto.RawRecord, afterValid func(state *AclState) error) (err error)
 	AddRawRecord(rawRec *consensusproto.RawRecordWithId) (err error)
@@ -168,6 +173,19 @@ func (a *aclList) RecordBuilder() AclRecordBuilder {
 	return a.recordBuilder
 }
 
+func (a *aclList) SetAccountKeys(acc *accountdata.AccountKeys) (err error) {
+	stateBuilder := newAclStateBuilderWithIdentity(acc)
+	stateBuilder.Init(a.id)
+	state, err := stateBuilder.Build(a.records, a)
+	if err != nil {
+		return
+	}
+	a.stateBuilder = stateBuilder
+	a.recordBuilder.(*aclRecordBuilder).accountKeys = acc
+	a.setState(state)
+	return
+}
+
 func (a *aclList) Records() []*AclRecord {
 	return a.records
 }
@@ -361,3 +379,26 @@ func WrapAclRecord(rawRec *consensusproto.RawRecord) *consensusproto.RawRecordWi
 		Id:      id,
 	}
 }
+
+// CheckPeerDevice checks that the device of the remote peer wasn't revoked in the acl,
+// the check passes if the peer is authenticated with the identity key.
+// The returned spacesyncproto.ErrDeviceRevoked can be sent to the peer as is
+func CheckPeerDevice(ctx context.Context, acl AclList) error {
+	deviceKey, err := peer.CtxDeviceKey(ctx)
+	if errors.Is(err, peer.ErrDeviceKeyNotFoundInContext) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	identity, err := peer.CtxPubKey(ctx)
+	if err != nil {
+		return err
+	}
+	acl.RLock()
+	defer acl.RUnlock()
+	if acl.AclState().IsDeviceRevoked(identity, deviceKey) {
+		return spacesyncproto.ErrDeviceRevoked
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	accountdata "github.com/anyproto/any-sync/commonspace/object/accountdata"
 	list "github.com/anyproto/any-sync/commonspace/object/acl/list"
 	consensusproto "github.com/anyproto/any-sync/consensus/consensusproto"
 	crypto "github.com/anyproto/any-sync/util/crypto"
@@ -330,6 +331,20 @@ func (mr *MockAclListMockRecorder) Root() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Root", reflect.TypeOf((*MockAclList)(nil).Root))
 }
 
+// SetAccountKeys mocks base method.
+func (m *MockAclList) SetAccountKeys(arg0 *accountdata.AccountKeys) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountKeys", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccountKeys indicates an expected call of SetAccountKeys.
+func (mr *MockAclListMockRecorder) SetAccountKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountKeys", reflect.TypeOf((*MockAclList)(nil).SetAccountKeys), arg0)
+}
+
 // Unlock mocks base method.
 func (m *MockAclList) Unlock() {
 	m.ctrl.T.Helper()
@@ -15,6 +15,7 @@ const (
 	StatusDeclined
 	StatusRemoving
 	StatusCanceled
+	StatusRotated
 )
 
 type AclRecord struct {
@@ -24,6 +25,8 @@ type AclRecord struct {
 	AcceptorTimestamp int64
 	Data              []byte
 	Identity          crypto.PubKey
+	// DeviceKey is set if the record is signed by the device on behalf of the identity
+	DeviceKey         crypto.PubKey
 	AcceptorIdentity  crypto.PubKey
 	AcceptorSignature []byte
 	Model             interface{}
@@ -36,6 +39,10 @@ type RequestRecord struct {
 	KeyRecordId     string
 	RecordId        string
 	Type            RequestType
+	// EncryptionKey is the hybrid key of the requestor, the read keys are encrypted with it on accept if it is set
+	EncryptionKey *crypto.HybridPubKey
+
+	inviteRecordId string
 }
 
 type AclAccountState struct {
@@ -57,6 +64,10 @@ type AccountState struct {
 	RequestMetadata   []byte
 	KeyRecordId       string
 	PermissionChanges []PermissionChange
+	// Successor is the identity which the account was rotated to
+	Successor crypto.PubKey
+	// EncryptionKey is the hybrid key published by the account, the read keys are encrypted with it if it is set
+	EncryptionKey *crypto.HybridPubKey
 }
 
 type RequestType int
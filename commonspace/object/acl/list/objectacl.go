@@ -0,0 +1,48 @@
+package list
+
+import (
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// ObjectAcl is a permission overlay of a single object inside the space.
+// It can only narrow the space permissions: the roles which can change permissions keep their rights,
+// other space writers can write to the object only if they are listed as writers.
+// Nil ObjectAcl means that the space permissions apply as is.
+type ObjectAcl struct {
+	writers []crypto.PubKey
+	keys    map[string]struct{}
+}
+
+func NewObjectAcl(writers []crypto.PubKey) *ObjectAcl {
+	acl := &ObjectAcl{
+		writers: writers,
+		keys:    make(map[string]struct{}, len(writers)),
+	}
+	for _, w := range writers {
+		acl.keys[mapKeyFromPubKey(w)] = struct{}{}
+	}
+	return acl
+}
+
+func (o *ObjectAcl) Writers() []crypto.PubKey {
+	if o == nil {
+		return nil
+	}
+	return o.writers
+}
+
+func (o *ObjectAcl) IsWriter(identity crypto.PubKey) bool {
+	if o == nil {
+		return true
+	}
+	_, exists := o.keys[mapKeyFromPubKey(identity)]
+	return exists
+}
+
+// Capabilities returns the capabilities of identity for the object given its space capabilities
+func (o *ObjectAcl) Capabilities(identity crypto.PubKey, spaceCapabilities AclCapabilities) AclCapabilities {
+	if o == nil || spaceCapabilities.Has(CapabilityChangePermissions) || o.IsWriter(identity) {
+		return spaceCapabilities
+	}
+	return spaceCapabilities &^ (CapabilityWriteTrees | CapabilityWriteKeyValue | CapabilityDeleteObjects)
+}
@@ -0,0 +1,46 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestObjectAcl_Capabilities(t *testing.T) {
+	writer, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	other, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	writerKey := writer.SignKey.GetPublic()
+	otherKey := other.SignKey.GetPublic()
+	objectAcl := NewObjectAcl([]crypto.PubKey{writerKey})
+	writerCaps := builtinCapabilities[AclPermissionsWriter]
+	readerCaps := builtinCapabilities[AclPermissionsReader]
+
+	t.Run("nil acl keeps space capabilities", func(t *testing.T) {
+		var nilAcl *ObjectAcl
+		require.Equal(t, writerCaps, nilAcl.Capabilities(otherKey, writerCaps))
+		require.True(t, nilAcl.IsWriter(otherKey))
+	})
+	t.Run("listed writer can write", func(t *testing.T) {
+		require.Equal(t, writerCaps, objectAcl.Capabilities(writerKey, writerCaps))
+	})
+	t.Run("other writer can only read", func(t *testing.T) {
+		caps := objectAcl.Capabilities(otherKey, writerCaps)
+		require.False(t, caps.CanWrite())
+		require.True(t, caps.Has(CapabilityRequestRemove))
+	})
+	t.Run("owner and admin are not restricted", func(t *testing.T) {
+		ownerCaps := builtinCapabilities[AclPermissionsOwner]
+		adminCaps := builtinCapabilities[AclPermissionsAdmin]
+		require.Equal(t, ownerCaps, objectAcl.Capabilities(otherKey, ownerCaps))
+		require.Equal(t, adminCaps, objectAcl.Capabilities(otherKey, adminCaps))
+	})
+	t.Run("overlay never elevates capabilities", func(t *testing.T) {
+		require.Equal(t, readerCaps, objectAcl.Capabilities(writerKey, readerCaps))
+		require.Equal(t, AclCapabilities(0), objectAcl.Capabilities(writerKey, 0))
+	})
+}
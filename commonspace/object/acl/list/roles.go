@@ -0,0 +1,79 @@
+package list
+
+import (
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+)
+
+// AclCapabilities is a set of actions which are allowed for a role
+type AclCapabilities uint64
+
+const (
+	CapabilityWriteTrees AclCapabilities = 1 << iota
+	CapabilityWriteKeyValue
+	CapabilityDeleteObjects
+	CapabilityInvite
+	CapabilityApproveJoin
+	CapabilityAddAccounts
+	CapabilityRemoveAccounts
+	CapabilityChangePermissions
+	CapabilityRequestRemove
+	CapabilityManageRoles
+
+	capabilitiesAll = CapabilityManageRoles<<1 - 1
+)
+
+// MinCustomRole is the lowest permission value which can be used for a custom role,
+// the values below are reserved for the built-in roles
+const MinCustomRole = AclPermissions(100)
+
+var builtinCapabilities = map[AclPermissions]AclCapabilities{
+	AclPermissionsOwner:  capabilitiesAll,
+	AclPermissionsAdmin:  capabilitiesAll &^ CapabilityManageRoles,
+	AclPermissionsWriter: CapabilityWriteTrees | CapabilityWriteKeyValue | CapabilityDeleteObjects | CapabilityRequestRemove,
+	AclPermissionsReader: CapabilityRequestRemove,
+	AclPermissionsGuest:  0,
+}
+
+func (c AclCapabilities) Has(capability AclCapabilities) bool {
+	return c&capability == capability
+}
+
+// Contains checks that every capability of other is also in c
+func (c AclCapabilities) Contains(other AclCapabilities) bool {
+	return other&^c == 0
+}
+
+// CanWrite checks if any of the write capabilities is present
+func (c AclCapabilities) CanWrite() bool {
+	return c&(CapabilityWriteTrees|CapabilityWriteKeyValue|CapabilityDeleteObjects) != 0
+}
+
+func (p AclPermissions) IsCustom() bool {
+	return p >= MinCustomRole
+}
+
+// AclRole is a role which can be assigned to accounts and invites
+type AclRole struct {
+	Permissions  AclPermissions
+	Name         string
+	Capabilities AclCapabilities
+}
+
+func capabilitiesFromProto(capabilities []aclrecordproto.AclCapability) (res AclCapabilities, err error) {
+	for _, c := range capabilities {
+		if c <= aclrecordproto.AclCapability_NoCapability || c > aclrecordproto.AclCapability_ManageRoles {
+			return 0, ErrUnknownCapability
+		}
+		res |= 1 << (c - 1)
+	}
+	return res, nil
+}
+
+func capabilitiesToProto(capabilities AclCapabilities) (res []aclrecordproto.AclCapability) {
+	for c := aclrecordproto.AclCapability_WriteTrees; c <= aclrecordproto.AclCapability_ManageRoles; c++ {
+		if capabilities.Has(1 << (c - 1)) {
+			res = append(res, c)
+		}
+	}
+	return
+}
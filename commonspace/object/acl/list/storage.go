@@ -70,7 +70,7 @@ func CreateStorage(ctx context.Context, root *consensusproto.RawRecordWithId, he
 		tx.Rollback()
 		return nil, err
 	}
-	return storage, tx.Commit()
+	return storage, headStorage.CommitTx(tx)
 }
 
 func CreateStorageTx(ctx context.Context, root *consensusproto.RawRecordWithId, headStorage headstorage.HeadStorage, store anystore.DB) (Storage, error) {
@@ -210,7 +210,7 @@ func (s *storage) AddAll(ctx context.Context, records []StorageRecord) error {
 		if err != nil {
 			_ = tx.Rollback()
 		} else {
-			err = tx.Commit()
+			err = s.headStorage.CommitTx(tx)
 		}
 	}()
 	vals := make([]*anyenc.Value, 0, len(records))
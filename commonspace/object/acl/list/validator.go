@@ -14,16 +14,20 @@ type ContentValidator interface {
 	ValidatePermissionChanges(ch *aclrecordproto.AclAccountPermissionChanges, authorIdentity crypto.PubKey) (err error)
 	ValidateAccountsAdd(ch *aclrecordproto.AclAccountsAdd, authorIdentity crypto.PubKey) (err error)
 	ValidateInvite(ch *aclrecordproto.AclAccountInvite, authorIdentity crypto.PubKey) (err error)
-	ValidateInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, authorIdentity crypto.PubKey) (err error)
+	ValidateInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, authorIdentity crypto.PubKey, timestamp int64) (err error)
 	ValidateInviteChange(ch *aclrecordproto.AclAccountInviteChange, authorIdentity crypto.PubKey) (err error)
 	ValidateInviteRevoke(ch *aclrecordproto.AclAccountInviteRevoke, authorIdentity crypto.PubKey) (err error)
-	ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey) (err error)
+	ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey, timestamp int64) (err error)
 	ValidateRequestAccept(ch *aclrecordproto.AclAccountRequestAccept, authorIdentity crypto.PubKey) (err error)
 	ValidateRequestDecline(ch *aclrecordproto.AclAccountRequestDecline, authorIdentity crypto.PubKey) (err error)
 	ValidateRequestCancel(ch *aclrecordproto.AclAccountRequestCancel, authorIdentity crypto.PubKey) (err error)
 	ValidateAccountRemove(ch *aclrecordproto.AclAccountRemove, authorIdentity crypto.PubKey) (err error)
 	ValidateRequestRemove(ch *aclrecordproto.AclAccountRequestRemove, authorIdentity crypto.PubKey) (err error)
 	ValidateReadKeyChange(ch *aclrecordproto.AclReadKeyChange, authorIdentity crypto.PubKey) (err error)
+	ValidateRoleDefine(ch *aclrecordproto.AclRoleDefine, authorIdentity crypto.PubKey) (err error)
+	ValidateKeyRotate(ch *aclrecordproto.AclAccountKeyRotate, authorIdentity crypto.PubKey) (err error)
+	ValidateEncryptionKey(ch *aclrecordproto.AclAccountEncryptionKey, authorIdentity crypto.PubKey) (err error)
+	ValidateDeviceRevoke(ch *aclrecordproto.AclAccountDeviceRevoke, authorIdentity crypto.PubKey) (err error)
 }
 
 type contentValidator struct {
@@ -57,7 +61,7 @@ func (c *contentValidator) ValidateAccountsAdd(ch *aclrecordproto.AclAccountsAdd
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(CapabilityAddAccounts) {
 		return ErrInsufficientPermissions
 	}
 	for _, ch := range ch.Additions {
@@ -75,11 +79,14 @@ func (c *contentValidator) ValidateAccountsAdd(ch *aclrecordproto.AclAccountsAdd
 		if perm.NoPermissions() {
 			return ErrInsufficientPermissions
 		}
+		if err = c.validateGrantedRole(authorIdentity, perm); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (c *contentValidator) ValidateInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, authorIdentity crypto.PubKey) (err error) {
+func (c *contentValidator) ValidateInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, authorIdentity crypto.PubKey, timestamp int64) (err error) {
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
@@ -96,6 +103,9 @@ func (c *contentValidator) ValidateInviteJoin(ch *aclrecordproto.AclAccountInvit
 	if !c.aclState.Permissions(authorIdentity).NoPermissions() {
 		return ErrInsufficientPermissions
 	}
+	if err = invite.checkUsable(timestamp); err != nil {
+		return err
+	}
 	inviteIdentity, err := c.keyStore.PubKeyFromProto(ch.Identity)
 	if err != nil {
 		return
@@ -130,9 +140,12 @@ func (c *contentValidator) ValidateAclRecordContents(ch *AclRecord) (err error)
 	if ch.PrevId != c.aclState.lastRecordId {
 		return ErrIncorrectRecordSequence
 	}
+	if ch.DeviceKey != nil && c.aclState.IsDeviceRevoked(ch.Identity, ch.DeviceKey) {
+		return ErrDeviceRevoked
+	}
 	aclData := ch.Model.(*aclrecordproto.AclData)
 	for _, content := range aclData.AclContent {
-		err = c.validateAclRecordContent(content, ch.Identity)
+		err = c.validateAclRecordContent(content, ch.Identity, inviteTimestamp(ch))
 		if err != nil {
 			return
 		}
@@ -140,7 +153,7 @@ func (c *contentValidator) ValidateAclRecordContents(ch *AclRecord) (err error)
 	return
 }
 
-func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclContentValue, authorIdentity crypto.PubKey) (err error) {
+func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclContentValue, authorIdentity crypto.PubKey, timestamp int64) (err error) {
 	switch {
 	case ch.GetPermissionChange() != nil:
 		return c.ValidatePermissionChange(ch.GetPermissionChange(), authorIdentity)
@@ -149,9 +162,9 @@ func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclConten
 	case ch.GetInviteRevoke() != nil:
 		return c.ValidateInviteRevoke(ch.GetInviteRevoke(), authorIdentity)
 	case ch.GetRequestJoin() != nil:
-		return c.ValidateRequestJoin(ch.GetRequestJoin(), authorIdentity)
+		return c.ValidateRequestJoin(ch.GetRequestJoin(), authorIdentity, timestamp)
 	case ch.GetInviteJoin() != nil:
-		return c.ValidateInviteJoin(ch.GetInviteJoin(), authorIdentity)
+		return c.ValidateInviteJoin(ch.GetInviteJoin(), authorIdentity, timestamp)
 	case ch.GetRequestAccept() != nil:
 		return c.ValidateRequestAccept(ch.GetRequestAccept(), authorIdentity)
 	case ch.GetRequestDecline() != nil:
@@ -166,6 +179,14 @@ func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclConten
 		return c.ValidatePermissionChanges(ch.GetPermissionChanges(), authorIdentity)
 	case ch.GetAccountsAdd() != nil:
 		return c.ValidateAccountsAdd(ch.GetAccountsAdd(), authorIdentity)
+	case ch.GetRoleDefine() != nil:
+		return c.ValidateRoleDefine(ch.GetRoleDefine(), authorIdentity)
+	case ch.GetKeyRotate() != nil:
+		return c.ValidateKeyRotate(ch.GetKeyRotate(), authorIdentity)
+	case ch.GetEncryptionKey() != nil:
+		return c.ValidateEncryptionKey(ch.GetEncryptionKey(), authorIdentity)
+	case ch.GetDeviceRevoke() != nil:
+		return c.ValidateDeviceRevoke(ch.GetDeviceRevoke(), authorIdentity)
 	default:
 		return ErrUnexpectedContentType
 	}
@@ -175,7 +196,7 @@ func (c *contentValidator) ValidatePermissionChange(ch *aclrecordproto.AclAccoun
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(CapabilityChangePermissions) {
 		return ErrInsufficientPermissions
 	}
 	chIdentity, err := c.keyStore.PubKeyFromProto(ch.Identity)
@@ -210,14 +231,18 @@ func (c *contentValidator) ValidatePermissionChange(ch *aclrecordproto.AclAccoun
 		return ErrInsufficientPermissions
 	}
 
-	return
+	if !c.aclState.Capabilities(authorIdentity).Contains(c.aclState.RoleCapabilities(currentState.Permissions)) {
+		// the author can't change the role of an account which is allowed to do more than the author
+		return ErrInsufficientPermissions
+	}
+	return c.validateGrantedRole(authorIdentity, AclPermissions(ch.Permissions))
 }
 
 func (c *contentValidator) ValidateInvite(ch *aclrecordproto.AclAccountInvite, authorIdentity crypto.PubKey) (err error) {
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(CapabilityInvite) {
 		return ErrInsufficientPermissions
 	}
 	permissions := AclPermissions(ch.Permissions)
@@ -225,10 +250,18 @@ func (c *contentValidator) ValidateInvite(ch *aclrecordproto.AclAccountInvite, a
 		if permissions.IsOwner() || permissions.NoPermissions() || permissions.IsGuest() {
 			return ErrInsufficientPermissions
 		}
+		if err = c.validateGrantedRole(authorIdentity, permissions); err != nil {
+			return err
+		}
 		if ch.EncryptedReadKey == nil {
 			return ErrIncorrectReadKey
 		}
 	}
+	if ch.EncryptionKey != nil {
+		if _, err = crypto.UnmarshalHybridPublicKeyProto(ch.EncryptionKey); err != nil {
+			return err
+		}
+	}
 	_, err = c.keyStore.PubKeyFromProto(ch.InviteKey)
 	return
 }
@@ -237,7 +270,7 @@ func (c *contentValidator) ValidateInviteChange(ch *aclrecordproto.AclAccountInv
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(CapabilityInvite) {
 		return ErrInsufficientPermissions
 	}
 	invite, exists := c.aclState.invites[ch.InviteRecordId]
@@ -254,14 +287,14 @@ func (c *contentValidator) ValidateInviteChange(ch *aclrecordproto.AclAccountInv
 	if permissions.IsOwner() || permissions.NoPermissions() || permissions.IsGuest() {
 		return ErrInsufficientPermissions
 	}
-	return
+	return c.validateGrantedRole(authorIdentity, permissions)
 }
 
 func (c *contentValidator) ValidateInviteRevoke(ch *aclrecordproto.AclAccountInviteRevoke, authorIdentity crypto.PubKey) (err error) {
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(CapabilityInvite) {
 		return ErrInsufficientPermissions
 	}
 	_, exists := c.aclState.invites[ch.InviteRecordId]
@@ -271,7 +304,7 @@ func (c *contentValidator) ValidateInviteRevoke(ch *aclrecordproto.AclAccountInv
 	return
 }
 
-func (c *contentValidator) ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey) (err error) {
+func (c *contentValidator) ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey, timestamp int64) (err error) {
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
@@ -285,6 +318,9 @@ func (c *contentValidator) ValidateRequestJoin(ch *aclrecordproto.AclAccountRequ
 	if invite.Type != aclrecordproto.AclInviteType_RequestToJoin {
 		return ErrNoSuchInvite
 	}
+	if err = invite.checkUsable(timestamp); err != nil {
+		return err
+	}
 	inviteIdentity, err := c.keyStore.PubKeyFromProto(ch.InviteIdentity)
 	if err != nil {
 		return
@@ -316,7 +352,7 @@ func (c *contentValidator) ValidateRequestAccept(ch *aclrecordproto.AclAccountRe
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(CapabilityApproveJoin) {
 		return ErrInsufficientPermissions
 	}
 	record, exists := c.aclState.requestRecords[ch.RequestRecordId]
@@ -330,17 +366,20 @@ func (c *contentValidator) ValidateRequestAccept(ch *aclrecordproto.AclAccountRe
 	if !acceptIdentity.Equals(record.RequestIdentity) {
 		return ErrIncorrectIdentity
 	}
+	if invite, exists := c.aclState.invites[record.inviteRecordId]; exists && invite.IsExhausted() {
+		return ErrInviteExhausted
+	}
 	if ch.Permissions == aclrecordproto.AclUserPermissions_Owner {
 		return ErrInsufficientPermissions
 	}
-	return
+	return c.validateGrantedRole(authorIdentity, AclPermissions(ch.Permissions))
 }
 
 func (c *contentValidator) ValidateRequestDecline(ch *aclrecordproto.AclAccountRequestDecline, authorIdentity crypto.PubKey) (err error) {
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(CapabilityApproveJoin) {
 		return ErrInsufficientPermissions
 	}
 	rec, exists := c.aclState.requestRecords[ch.RequestRecordId]
@@ -368,7 +407,8 @@ func (c *contentValidator) ValidateAccountRemove(ch *aclrecordproto.AclAccountRe
 	if !c.verifier.ShouldValidate() {
 		return nil
 	}
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	authorCapabilities := c.aclState.Capabilities(authorIdentity)
+	if !authorCapabilities.Has(CapabilityRemoveAccounts) {
 		return ErrInsufficientPermissions
 	}
 	seenIdentities := map[string]struct{}{}
@@ -384,7 +424,7 @@ func (c *contentValidator) ValidateAccountRemove(ch *aclrecordproto.AclAccountRe
 		if permissions.NoPermissions() {
 			return ErrNoSuchAccount
 		}
-		if permissions.IsOwner() {
+		if permissions.IsOwner() || !authorCapabilities.Contains(c.aclState.RoleCapabilities(permissions)) {
 			return ErrInsufficientPermissions
 		}
 		idKey := mapKeyFromPubKey(identity)
@@ -412,6 +452,120 @@ func (c *contentValidator) ValidateRequestRemove(ch *aclrecordproto.AclAccountRe
 	return
 }
 
+func (c *contentValidator) ValidateRoleDefine(ch *aclrecordproto.AclRoleDefine, authorIdentity crypto.PubKey) (err error) {
+	if !c.verifier.ShouldValidate() {
+		return nil
+	}
+	authorCapabilities := c.aclState.Capabilities(authorIdentity)
+	if !authorCapabilities.Has(CapabilityManageRoles) {
+		return ErrInsufficientPermissions
+	}
+	role := AclPermissions(ch.Role)
+	if !role.IsCustom() {
+		return ErrIncorrectRole
+	}
+	if c.aclState.isKnownRole(role) {
+		return ErrRoleExists
+	}
+	capabilities, err := capabilitiesFromProto(ch.Capabilities)
+	if err != nil {
+		return err
+	}
+	if !authorCapabilities.Contains(capabilities) {
+		return ErrInsufficientPermissions
+	}
+	return
+}
+
+func (c *contentValidator) ValidateKeyRotate(ch *aclrecordproto.AclAccountKeyRotate, authorIdentity crypto.PubKey) (err error) {
+	if !c.verifier.ShouldValidate() {
+		return nil
+	}
+	if c.aclState.Permissions(authorIdentity).NoPermissions() {
+		return ErrNoSuchAccount
+	}
+	if _, exists := c.aclState.pendingRequests[mapKeyFromPubKey(authorIdentity)]; exists {
+		return ErrPendingRequest
+	}
+	newIdentity, err := c.keyStore.PubKeyFromProto(ch.NewIdentity)
+	if err != nil {
+		return err
+	}
+	if !c.aclState.Permissions(newIdentity).NoPermissions() {
+		return ErrAccountExists
+	}
+	if _, exists := c.aclState.pendingRequests[mapKeyFromPubKey(newIdentity)]; exists {
+		return ErrPendingRequest
+	}
+	succession := &crypto.KeySuccession{
+		OldIdentity:          authorIdentity,
+		NewIdentity:          newIdentity,
+		NewIdentitySignature: ch.NewIdentitySignature,
+		OldIdentitySignature: ch.OldIdentitySignature,
+	}
+	if err = succession.Verify(); err != nil {
+		return ErrInvalidSignature
+	}
+	return
+}
+
+func (c *contentValidator) ValidateEncryptionKey(ch *aclrecordproto.AclAccountEncryptionKey, authorIdentity crypto.PubKey) (err error) {
+	if !c.verifier.ShouldValidate() {
+		return nil
+	}
+	if c.aclState.Permissions(authorIdentity).NoPermissions() {
+		return ErrNoSuchAccount
+	}
+	_, err = crypto.UnmarshalHybridPublicKeyProto(ch.EncryptionKey)
+	return
+}
+
+func (c *contentValidator) ValidateDeviceRevoke(ch *aclrecordproto.AclAccountDeviceRevoke, authorIdentity crypto.PubKey) (err error) {
+	if !c.verifier.ShouldValidate() {
+		return nil
+	}
+	identity, err := c.keyStore.PubKeyFromProto(ch.Identity)
+	if err != nil {
+		return err
+	}
+	deviceKey, err := c.keyStore.PubKeyFromProto(ch.DeviceKey)
+	if err != nil {
+		return err
+	}
+	permissions := c.aclState.Permissions(identity)
+	if permissions.NoPermissions() {
+		return ErrNoSuchAccount
+	}
+	if !identity.Equals(authorIdentity) {
+		// the devices of other accounts can be revoked by the ones who can remove these accounts
+		authorCapabilities := c.aclState.Capabilities(authorIdentity)
+		if !authorCapabilities.Has(CapabilityRemoveAccounts) || permissions.IsOwner() ||
+			!authorCapabilities.Contains(c.aclState.RoleCapabilities(permissions)) {
+			return ErrInsufficientPermissions
+		}
+	}
+	// the certificate proves that the device belongs to the identity
+	certDeviceKey, err := crypto.DeviceKeyFromCertificate(identity, ch.DeviceCertificate)
+	if err != nil || !certDeviceKey.Equals(deviceKey) {
+		return ErrIncorrectIdentity
+	}
+	if c.aclState.IsDeviceRevoked(identity, deviceKey) {
+		return ErrDeviceRevoked
+	}
+	return
+}
+
+// validateGrantedRole checks that the role exists and doesn't allow more than the author can do
+func (c *contentValidator) validateGrantedRole(authorIdentity crypto.PubKey, permissions AclPermissions) error {
+	if !permissions.NoPermissions() && !c.aclState.isKnownRole(permissions) {
+		return ErrNoSuchRole
+	}
+	if !c.aclState.Capabilities(authorIdentity).Contains(c.aclState.RoleCapabilities(permissions)) {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
+
 func (c *contentValidator) ValidateReadKeyChange(ch *aclrecordproto.AclReadKeyChange, authorIdentity crypto.PubKey) (err error) {
 	if !c.verifier.ShouldValidate() {
 		return nil
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	app "github.com/anyproto/any-sync/app"
+	accountdata "github.com/anyproto/any-sync/commonspace/object/accountdata"
 	list "github.com/anyproto/any-sync/commonspace/object/acl/list"
 	headupdater "github.com/anyproto/any-sync/commonspace/object/acl/syncacl/headupdater"
 	response "github.com/anyproto/any-sync/commonspace/object/acl/syncacl/response"
@@ -534,6 +535,20 @@ func (mr *MockSyncAclMockRecorder) Run(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSyncAcl)(nil).Run), arg0)
 }
 
+// SetAccountKeys mocks base method.
+func (m *MockSyncAcl) SetAccountKeys(arg0 *accountdata.AccountKeys) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountKeys", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccountKeys indicates an expected call of SetAccountKeys.
+func (mr *MockSyncAclMockRecorder) SetAccountKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountKeys", reflect.TypeOf((*MockSyncAcl)(nil).SetAccountKeys), arg0)
+}
+
 // SetAclUpdater mocks base method.
 func (m *MockSyncAcl) SetAclUpdater(arg0 headupdater.AclUpdater) {
 	m.ctrl.T.Helper()
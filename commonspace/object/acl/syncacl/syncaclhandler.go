@@ -67,6 +67,9 @@ func (s *syncAclHandler) HandleHeadUpdate(ctx context.Context, statusUpdater syn
 	}
 	err = s.aclList.AddRawRecords(contentUpdate.Records)
 	if !errors.Is(err, list.ErrIncorrectRecordSequence) {
+		if err != nil {
+			statusUpdater.SyncError(peerId, update.ObjectId(), err)
+		}
 		return nil, err
 	}
 	return s.syncClient.CreateFullSyncRequest(peerId, s.aclList), nil
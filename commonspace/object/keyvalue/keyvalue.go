@@ -3,6 +3,7 @@ package keyvalue
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/anyproto/protobuf/proto"
 	"go.uber.org/zap"
@@ -11,11 +12,14 @@ import (
 	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/config"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/syncstorage"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvinterfaces"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvresolver"
 	"github.com/anyproto/any-sync/commonspace/spacestate"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
@@ -24,10 +28,16 @@ import (
 	"github.com/anyproto/any-sync/net/peer"
 	"github.com/anyproto/any-sync/net/rpc/rpcerr"
 	"github.com/anyproto/any-sync/util/cidutil"
+	"github.com/anyproto/any-sync/util/periodicsync"
 )
 
 var ErrUnexpectedMessageType = errors.New("unexpected message type")
 
+const (
+	cleanupPeriodSecs   = 60
+	defaultTombstoneTTL = 30 * 24 * time.Hour
+)
+
 var log = logger.NewNamed(kvinterfaces.CName)
 
 type keyValueService struct {
@@ -39,16 +49,57 @@ type keyValueService struct {
 	limiter       *concurrentLimiter
 	defaultStore  keyvaluestorage.Storage
 	clientFactory spacesyncproto.ClientFactory
+	tombstoneTTL  time.Duration
+	cleanup       periodicsync.PeriodicSync
+	resolvers     *kvresolver.Registry
+	aclList       list.AclList
 }
 
 func New() kvinterfaces.KeyValueService {
-	return &keyValueService{}
+	return &keyValueService{resolvers: kvresolver.NewRegistry()}
 }
 
 func (k *keyValueService) DefaultStore() keyvaluestorage.Storage {
 	return k.defaultStore
 }
 
+func (k *keyValueService) RegisterResolver(prefix string, resolver kvresolver.Resolver) {
+	k.resolvers.Register(prefix, resolver)
+}
+
+func (k *keyValueService) Get(ctx context.Context, key string) (value []byte, err error) {
+	resolver := k.resolvers.Resolver(key)
+	if resolver == nil {
+		resolver = kvresolver.LastWriterWins()
+	}
+	var values []kvresolver.Value
+	err = k.defaultStore.GetAll(ctx, key, func(decryptor keyvaluestorage.Decryptor, keyValues []innerstorage.KeyValue) error {
+		for _, kv := range keyValues {
+			if kv.Key != key {
+				continue
+			}
+			decrypted, err := decryptor(kv)
+			if err != nil {
+				return err
+			}
+			values = append(values, kvresolver.Value{
+				Value:          decrypted,
+				TimestampMicro: kv.TimestampMilli,
+				PeerId:         kv.PeerId,
+				Identity:       kv.Identity,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, kvinterfaces.ErrKeyNotFound
+	}
+	return resolver.Resolve(key, values)
+}
+
 func (k *keyValueService) SyncWithPeer(p peer.Peer) (err error) {
 	k.limiter.ScheduleRequest(k.ctx, p.Id(), func() {
 		err = k.syncWithPeer(k.ctx, p)
@@ -123,10 +174,16 @@ func (k *keyValueService) syncWithPeer(ctx context.Context, p peer.Peer) (err er
 }
 
 func (k *keyValueService) HandleStoreDiffRequest(ctx context.Context, req *spacesyncproto.StoreDiffRequest) (resp *spacesyncproto.StoreDiffResponse, err error) {
+	if err = list.CheckPeerDevice(ctx, k.aclList); err != nil {
+		return
+	}
 	return HandleRangeRequest(ctx, k.defaultStore.InnerStorage().Diff(), req)
 }
 
 func (k *keyValueService) HandleStoreElementsRequest(ctx context.Context, stream spacesyncproto.DRPCSpaceSync_StoreElementsStream) (err error) {
+	if err = list.CheckPeerDevice(ctx, k.aclList); err != nil {
+		return
+	}
 	var (
 		messagesToSave []*spacesyncproto.StoreKeyValue
 		messagesToSend []string
@@ -192,9 +249,10 @@ func (k *keyValueService) Init(a *app.App) (err error) {
 	k.limiter = newConcurrentLimiter()
 	accountService := a.MustComponent(accountservice.CName).(accountservice.Service)
 	aclList := a.MustComponent(syncacl.CName).(list.AclList)
+	k.aclList = aclList
 	spaceStorage := a.MustComponent(spacestorage.CName).(spacestorage.SpaceStorage)
 	syncService := a.MustComponent(sync.CName).(sync.SyncService)
-	k.storageId, err = storageIdFromSpace(k.spaceId)
+	k.storageId, err = StorageIdFromSpace(k.spaceId)
 	if err != nil {
 		return err
 	}
@@ -202,6 +260,10 @@ func (k *keyValueService) Init(a *app.App) (err error) {
 	if indexer == nil {
 		indexer = keyvaluestorage.NoOpIndexer{}
 	}
+	objectAcls, ok := a.Component(keyvaluestorage.ObjectAclProviderCName).(keyvaluestorage.ObjectAclProvider)
+	if !ok {
+		objectAcls = keyvaluestorage.NoOpObjectAclProvider{}
+	}
 	syncClient := syncstorage.New(spaceState.SpaceId, syncService)
 	k.defaultStore, err = keyvaluestorage.New(
 		k.ctx,
@@ -211,25 +273,48 @@ func (k *keyValueService) Init(a *app.App) (err error) {
 		accountService.Account(),
 		syncClient,
 		aclList,
-		indexer)
+		indexer,
+		objectAcls)
+	if err != nil {
+		return
+	}
+	k.tombstoneTTL = defaultTombstoneTTL
+	if ttl := a.MustComponent("config").(config.ConfigGetter).GetSpace().KeyValueTombstoneTTL; ttl > 0 {
+		k.tombstoneTTL = time.Duration(ttl) * time.Second
+	}
+	k.cleanup = periodicsync.NewPeriodicSync(cleanupPeriodSecs, time.Minute, k.cleanupStore, log)
 	return
 }
 
+func (k *keyValueService) cleanupStore(ctx context.Context) error {
+	if err := k.defaultStore.RemoveExpired(ctx); err != nil {
+		return err
+	}
+	return k.defaultStore.RemoveTombstones(ctx, k.tombstoneTTL)
+}
+
 func (k *keyValueService) Name() (name string) {
 	return kvinterfaces.CName
 }
 
 func (k *keyValueService) Run(ctx context.Context) (err error) {
-	return k.defaultStore.Prepare()
+	if err = k.defaultStore.Prepare(); err != nil {
+		return
+	}
+	k.cleanup.Run()
+	return
 }
 
 func (k *keyValueService) Close(ctx context.Context) (err error) {
+	if k.cleanup != nil {
+		k.cleanup.Close()
+	}
 	k.cancel()
 	k.limiter.Close()
 	return nil
 }
 
-func storageIdFromSpace(spaceId string) (storageId string, err error) {
+func StorageIdFromSpace(spaceId string) (storageId string, err error) {
 	header := &spacesyncproto.StorageHeader{
 		SpaceId:     spaceId,
 		StorageName: "default",
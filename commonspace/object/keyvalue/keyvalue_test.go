@@ -15,11 +15,14 @@ import (
 	anystore "github.com/anyproto/any-store"
 	"github.com/stretchr/testify/require"
 
+	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvinterfaces"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvresolver"
 	"github.com/anyproto/any-sync/commonspace/spacepayloads"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
@@ -231,6 +234,154 @@ func TestKeyValueServiceIterate(t *testing.T) {
 	})
 }
 
+func TestKeyValueServiceExpiry(t *testing.T) {
+	t.Run("expired values are not accepted", func(t *testing.T) {
+		fxClient, fxServer, serverPeer := prepareFixtures(t)
+		err := fxClient.defaultStore.SetWithExpiry(ctx, "key1", []byte("value1"), time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		require.False(t, fxClient.check(t, "key1", []byte("value1")))
+		err = fxClient.SyncWithPeer(serverPeer)
+		require.NoError(t, err)
+		fxClient.limiter.Close()
+		require.Equal(t, 0, fxServer.defaultStore.InnerStorage().Diff().Len())
+	})
+}
+
+func TestKeyValueServiceDelete(t *testing.T) {
+	t.Run("tombstone hides the key", func(t *testing.T) {
+		fxClient, fxServer, serverPeer := prepareFixtures(t)
+		fxClient.add(t, "key1", []byte("value1"))
+		fxServer.add(t, "key1", []byte("value2"))
+		fxClient.add(t, "key2", []byte("value3"))
+		require.NoError(t, fxClient.defaultStore.Delete(ctx, "key1"))
+		err := fxClient.SyncWithPeer(serverPeer)
+		require.NoError(t, err)
+		fxClient.limiter.Close()
+		waitSynced(t, fxClient, fxServer)
+		require.False(t, fxClient.check(t, "key1", []byte("value1")))
+		require.False(t, fxClient.check(t, "key1", []byte("value2")))
+		require.False(t, fxServer.check(t, "key1", []byte("value1")))
+		require.False(t, fxServer.check(t, "key1", []byte("value2")))
+		require.True(t, fxServer.check(t, "key2", []byte("value3")))
+		var keys []string
+		err = fxServer.defaultStore.Iterate(ctx, func(decryptor keyvaluestorage.Decryptor, key string, values []innerstorage.KeyValue) (bool, error) {
+			keys = append(keys, key)
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"key2"}, keys)
+	})
+	t.Run("set after delete", func(t *testing.T) {
+		fxClient, _, _ := prepareFixtures(t)
+		fxClient.add(t, "key1", []byte("value1"))
+		require.NoError(t, fxClient.defaultStore.Delete(ctx, "key1"))
+		fxClient.add(t, "key1", []byte("value2"))
+		require.True(t, fxClient.check(t, "key1", []byte("value2")))
+	})
+	t.Run("tombstones are removed after ttl", func(t *testing.T) {
+		fxClient, fxServer, serverPeer := prepareFixtures(t)
+		fxClient.add(t, "key1", []byte("value1"))
+		fxServer.add(t, "key1", []byte("value2"))
+		fxServer.add(t, "key2", []byte("value3"))
+		require.NoError(t, fxClient.defaultStore.Delete(ctx, "key1"))
+		err := fxClient.SyncWithPeer(serverPeer)
+		require.NoError(t, err)
+		fxClient.limiter.Close()
+		waitSynced(t, fxClient, fxServer)
+
+		require.NoError(t, fxClient.defaultStore.RemoveTombstones(ctx, time.Hour))
+		require.Equal(t, 3, fxClient.defaultStore.InnerStorage().Diff().Len())
+		require.NoError(t, fxClient.defaultStore.RemoveTombstones(ctx, 0))
+		require.NoError(t, fxServer.defaultStore.RemoveTombstones(ctx, 0))
+		require.Equal(t, 1, fxClient.defaultStore.InnerStorage().Diff().Len())
+		require.Equal(t, fxClient.defaultStore.InnerStorage().Diff().Hash(), fxServer.defaultStore.InnerStorage().Diff().Hash())
+	})
+}
+
+func TestKeyValueServiceGet(t *testing.T) {
+	fxClient, fxServer, serverPeer := prepareFixtures(t)
+	fxServer.add(t, "lww", []byte("1"))
+	fxServer.add(t, "max", []byte("9"))
+	fxServer.add(t, "merge", []byte("a"))
+	fxClient.add(t, "lww", []byte("2"))
+	fxClient.add(t, "max", []byte("3"))
+	fxClient.add(t, "merge", []byte("b"))
+	err := fxClient.SyncWithPeer(serverPeer)
+	require.NoError(t, err)
+	fxClient.limiter.Close()
+	waitSynced(t, fxClient, fxServer)
+	for _, fx := range []*fixture{fxClient, fxServer} {
+		fx.RegisterResolver("max", kvresolver.MaxValue(nil))
+		fx.RegisterResolver("merge", kvresolver.Merge(func(key string, a, b []byte) ([]byte, error) {
+			return append(append([]byte{}, a...), b...), nil
+		}))
+		value, err := fx.Get(ctx, "lww")
+		require.NoError(t, err)
+		require.Equal(t, "2", string(value))
+		value, err = fx.Get(ctx, "max")
+		require.NoError(t, err)
+		require.Equal(t, "9", string(value))
+		value, err = fx.Get(ctx, "merge")
+		require.NoError(t, err)
+		require.Equal(t, "ab", string(value))
+		_, err = fx.Get(ctx, "missing")
+		require.ErrorIs(t, err, kvinterfaces.ErrKeyNotFound)
+	}
+}
+
+type testObjectAcls map[string]*list.ObjectAcl
+
+func (t testObjectAcls) Init(a *app.App) (err error) {
+	return nil
+}
+
+func (t testObjectAcls) Name() (name string) {
+	return keyvaluestorage.ObjectAclProviderCName
+}
+
+func (t testObjectAcls) ObjectAcl(key string) (*list.ObjectAcl, error) {
+	return t[key], nil
+}
+
+func TestKeyValueServiceObjectAcl(t *testing.T) {
+	ownerKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	writerKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	payload := newStorageCreatePayload(t, ownerKeys)
+	fxOwner := newFixtureWithObjectAcls(t, ownerKeys, payload, testObjectAcls{
+		"restricted": list.NewObjectAcl([]crypto.PubKey{ownerKeys.SignKey.GetPublic()}),
+	})
+	fxWriter := newFixture(t, writerKeys, payload)
+	rec, err := fxOwner.aclList.RecordBuilder().BuildAccountsAdd(list.AccountsAddPayload{
+		Additions: []list.AccountAdd{{Identity: writerKeys.SignKey.GetPublic(), Permissions: list.AclPermissionsWriter}},
+	})
+	require.NoError(t, err)
+	for _, acl := range []list.AclList{fxOwner.aclList, fxWriter.aclList} {
+		acl.Lock()
+		require.NoError(t, acl.AddRawRecord(list.WrapAclRecord(rec)))
+		acl.Unlock()
+	}
+	fxWriter.add(t, "restricted", []byte("value"))
+	fxWriter.add(t, "free", []byte("value"))
+
+	var values []*spacesyncproto.StoreKeyValue
+	require.NoError(t, fxWriter.defaultStore.InnerStorage().IterateValues(ctx, func(kv innerstorage.KeyValue) (bool, error) {
+		// the iterator reuses the buffers
+		data, err := kv.Proto().Marshal()
+		if err != nil {
+			return false, err
+		}
+		value := &spacesyncproto.StoreKeyValue{}
+		values = append(values, value)
+		return true, value.Unmarshal(data)
+	}))
+	require.Len(t, values, 2)
+	require.NoError(t, fxOwner.defaultStore.SetRaw(ctx, values...))
+	require.False(t, fxOwner.check(t, "restricted", []byte("value")))
+	require.True(t, fxOwner.check(t, "free", []byte("value")))
+}
+
 func prepareFixtures(t *testing.T) (fxClient *fixture, fxServer *fixture, serverPeer peer.Peer) {
 	firstKeys, err := accountdata.NewRandom()
 	require.NoError(t, err)
@@ -248,6 +399,13 @@ func prepareFixtures(t *testing.T) (fxClient *fixture, fxServer *fixture, server
 	return
 }
 
+// waitSynced waits for the server to save the values sent by the client, it happens after the sync is finished on the client
+func waitSynced(t *testing.T, fxClient, fxServer *fixture) {
+	require.Eventually(t, func() bool {
+		return fxClient.defaultStore.InnerStorage().Diff().Hash() == fxServer.defaultStore.InnerStorage().Diff().Hash()
+	}, time.Second, 10*time.Millisecond)
+}
+
 func mapEqual[K comparable, V comparable](map1, map2 map[K]V) bool {
 	if len(map1) != len(map2) {
 		return false
@@ -270,10 +428,15 @@ func (n noOpSyncClient) Broadcast(ctx context.Context, objectId string, keyValue
 
 type fixture struct {
 	*keyValueService
-	server *rpctest.TestServer
+	server  *rpctest.TestServer
+	aclList list.AclList
 }
 
 func newFixture(t *testing.T, keys *accountdata.AccountKeys, spacePayload spacestorage.SpaceStorageCreatePayload) *fixture {
+	return newFixtureWithObjectAcls(t, keys, spacePayload, keyvaluestorage.NoOpObjectAclProvider{})
+}
+
+func newFixtureWithObjectAcls(t *testing.T, keys *accountdata.AccountKeys, spacePayload spacestorage.SpaceStorageCreatePayload, objectAcls keyvaluestorage.ObjectAclProvider) *fixture {
 	storePath := filepath.Join(t.TempDir(), "store.db")
 	anyStore, err := anystore.Open(ctx, storePath, nil)
 	require.NoError(t, err)
@@ -292,7 +455,8 @@ func newFixture(t *testing.T, keys *accountdata.AccountKeys, spacePayload spaces
 		keys,
 		noOpSyncClient{},
 		aclList,
-		keyvaluestorage.NoOpIndexer{})
+		keyvaluestorage.NoOpIndexer{},
+		objectAcls)
 	require.NoError(t, err)
 	ctx, cancel := context.WithCancel(ctx)
 	service := &keyValueService{
@@ -303,11 +467,13 @@ func newFixture(t *testing.T, keys *accountdata.AccountKeys, spacePayload spaces
 		cancel:        cancel,
 		clientFactory: spacesyncproto.ClientFactoryFunc(spacesyncproto.NewDRPCSpaceSyncClient),
 		defaultStore:  defaultStorage,
+		resolvers:     kvresolver.NewRegistry(),
 	}
 	require.NoError(t, spacesyncproto.DRPCRegisterSpaceSync(rpcHandler, &testServer{service: service, t: t}))
 	return &fixture{
 		keyValueService: service,
 		server:          rpcHandler,
+		aclList:         aclList,
 	}
 }
 
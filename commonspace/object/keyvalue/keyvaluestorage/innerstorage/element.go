@@ -2,6 +2,7 @@ package innerstorage
 
 import (
 	"errors"
+	"time"
 
 	"github.com/anyproto/any-store/anyenc"
 
@@ -20,6 +21,8 @@ type KeyValue struct {
 	Identity       string
 	PeerId         string
 	AclId          string
+	ExpiresAtMicro int
+	Deleted        bool
 }
 
 type Value struct {
@@ -50,6 +53,8 @@ func KeyValueFromProto(proto *spacesyncproto.StoreKeyValue, verify bool) (kv Key
 	kv.PeerId = peerId.PeerId()
 	kv.Key = innerValue.Key
 	kv.AclId = innerValue.AclHeadId
+	kv.ExpiresAtMicro = int(innerValue.ExpiresAtMicro)
+	kv.Deleted = innerValue.Deleted
 	// TODO: check that key-peerId is equal to key+peerId?
 	if verify {
 		if verify, _ = identity.Verify(proto.Value, proto.IdentitySignature); !verify {
@@ -79,9 +84,20 @@ func (kv KeyValue) AnyEnc(a *anyenc.Arena) *anyenc.Value {
 	obj.Set("t", a.NewNumberInt(kv.TimestampMilli))
 	obj.Set("i", a.NewString(kv.Identity))
 	obj.Set("p", a.NewString(kv.PeerId))
+	if kv.ExpiresAtMicro != 0 {
+		obj.Set("e", a.NewNumberInt(kv.ExpiresAtMicro))
+	}
+	if kv.Deleted {
+		obj.Set("d", a.NewTrue())
+	}
 	return obj
 }
 
+// IsExpired checks if the value has an expiry which is not after now
+func (kv KeyValue) IsExpired(now time.Time) bool {
+	return kv.ExpiresAtMicro != 0 && int64(kv.ExpiresAtMicro) <= now.UnixMicro()
+}
+
 func (kv KeyValue) Proto() *spacesyncproto.StoreKeyValue {
 	return &spacesyncproto.StoreKeyValue{
 		KeyPeerId:         kv.KeyPeerId,
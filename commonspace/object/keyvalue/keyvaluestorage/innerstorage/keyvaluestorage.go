@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"slices"
 	"strings"
+	"time"
 
 	anystore "github.com/anyproto/any-store"
 	"github.com/anyproto/any-store/anyenc"
@@ -25,6 +27,10 @@ type KeyValueStorage interface {
 	GetKeyPeerId(ctx context.Context, keyPeerId string) (keyValue KeyValue, err error)
 	IterateValues(context.Context, func(kv KeyValue) (bool, error)) (err error)
 	IteratePrefix(context.Context, string, func(kv KeyValue) error) (err error)
+	// RemoveExpired removes the values which expired before now, they are removed from the diff as well
+	RemoveExpired(ctx context.Context, now time.Time) (removed int, err error)
+	// RemoveTombstones removes the tombstones written before the given time together with the values they hide
+	RemoveTombstones(ctx context.Context, before time.Time) (removed int, err error)
 }
 
 type storage struct {
@@ -40,6 +46,14 @@ func New(ctx context.Context, storageName string, headStorage headstorage.HeadSt
 	if err != nil {
 		return nil, err
 	}
+	err = collection.EnsureIndex(ctx,
+		anystore.IndexInfo{Fields: []string{"e"}, Sparse: true},
+		anystore.IndexInfo{Fields: []string{"d"}, Sparse: true},
+		anystore.IndexInfo{Fields: []string{"k"}},
+	)
+	if err != nil {
+		return nil, err
+	}
 	tx, err := store.WriteTx(ctx)
 	if err != nil {
 		return nil, err
@@ -48,7 +62,7 @@ func New(ctx context.Context, storageName string, headStorage headstorage.HeadSt
 		if err != nil {
 			_ = tx.Rollback()
 		} else {
-			err = tx.Commit()
+			err = headStorage.CommitTx(tx)
 		}
 	}()
 	storage := &storage{
@@ -97,7 +111,8 @@ func (s *storage) GetKeyPeerId(ctx context.Context, keyPeerId string) (value Key
 }
 
 func (s *storage) IterateValues(ctx context.Context, iterFunc func(kv KeyValue) (bool, error)) (err error) {
-	iter, err := s.collection.Find(nil).Iter(ctx)
+	// the values of the same key go one after another, so the readers can group them
+	iter, err := s.collection.Find(nil).Sort("k", "id").Iter(ctx)
 	if err != nil {
 		return
 	}
@@ -161,6 +176,8 @@ func (s *storage) keyValueFromDoc(doc anystore.Doc) KeyValue {
 		Identity:       doc.Value().GetString("i"),
 		PeerId:         doc.Value().GetString("p"),
 		Key:            doc.Value().GetString("k"),
+		ExpiresAtMicro: doc.Value().GetInt("e"),
+		Deleted:        doc.Value().GetBool("d"),
 	}
 }
 
@@ -194,7 +211,7 @@ func (s *storage) Set(ctx context.Context, values ...KeyValue) (err error) {
 		if err != nil {
 			_ = tx.Rollback()
 		} else {
-			err = tx.Commit()
+			err = s.headStorage.CommitTx(tx)
 		}
 	}()
 	ctx = tx.Context()
@@ -210,6 +227,116 @@ func (s *storage) Set(ctx context.Context, values ...KeyValue) (err error) {
 	return
 }
 
+func (s *storage) RemoveExpired(ctx context.Context, now time.Time) (removed int, err error) {
+	tx, err := s.collection.WriteTx(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = s.headStorage.CommitTx(tx)
+		}
+	}()
+	ctx = tx.Context()
+	filter := query.Key{Path: []string{"e"}, Filter: query.NewComp(query.CompOpLte, now.UnixMicro())}
+	ids, err := s.findIds(ctx, filter)
+	if err != nil {
+		return
+	}
+	return len(ids), s.removeIds(ctx, ids)
+}
+
+func (s *storage) RemoveTombstones(ctx context.Context, before time.Time) (removed int, err error) {
+	tx, err := s.collection.WriteTx(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = s.headStorage.CommitTx(tx)
+		}
+	}()
+	ctx = tx.Context()
+	tombstones, err := s.findKeyValues(ctx, query.And{
+		query.Key{Path: []string{"d"}, Filter: query.NewComp(query.CompOpEq, true)},
+		query.Key{Path: []string{"t"}, Filter: query.NewComp(query.CompOpLte, before.UnixMicro())},
+	})
+	if err != nil {
+		return
+	}
+	var ids []string
+	for _, tombstone := range tombstones {
+		hiddenIds, err := s.findIds(ctx, query.And{
+			query.Key{Path: []string{"k"}, Filter: query.NewComp(query.CompOpEq, tombstone.Key)},
+			query.Key{Path: []string{"t"}, Filter: query.NewComp(query.CompOpLte, tombstone.TimestampMilli)},
+		})
+		if err != nil {
+			return 0, err
+		}
+		ids = append(ids, hiddenIds...)
+	}
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	return len(ids), s.removeIds(ctx, ids)
+}
+
+func (s *storage) findIds(ctx context.Context, filter query.Filter) (ids []string, err error) {
+	iter, err := s.collection.Find(filter).Iter(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+	var doc anystore.Doc
+	for iter.Next() {
+		if doc, err = iter.Doc(); err != nil {
+			return
+		}
+		ids = append(ids, doc.Value().GetString("id"))
+	}
+	return
+}
+
+func (s *storage) findKeyValues(ctx context.Context, filter query.Filter) (keyValues []KeyValue, err error) {
+	iter, err := s.collection.Find(filter).Iter(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+	var doc anystore.Doc
+	for iter.Next() {
+		if doc, err = iter.Doc(); err != nil {
+			return
+		}
+		keyValues = append(keyValues, s.keyValueFromDoc(doc))
+	}
+	return
+}
+
+func (s *storage) removeIds(ctx context.Context, ids []string) (err error) {
+	if len(ids) == 0 {
+		return
+	}
+	for _, id := range ids {
+		if err = s.collection.DeleteId(ctx, id); err != nil {
+			return
+		}
+		if rmErr := s.diff.RemoveId(id); rmErr != nil && !errors.Is(rmErr, ldiff.ErrElementNotFound) {
+			return rmErr
+		}
+	}
+	return s.headStorage.UpdateEntryTx(ctx, headstorage.HeadsUpdate{
+		Id:    s.storageName,
+		Heads: []string{s.diff.Hash()},
+	})
+}
+
 func (s *storage) updateValues(ctx context.Context, values ...KeyValue) (elements []ldiff.Element, err error) {
 	parser := parserPool.Get()
 	defer parserPool.Put(parser)
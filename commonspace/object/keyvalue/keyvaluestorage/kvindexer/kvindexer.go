@@ -0,0 +1,238 @@
+package kvindexer
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	anystore "github.com/anyproto/any-store"
+	"github.com/anyproto/any-store/anyenc"
+	"github.com/anyproto/any-store/query"
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+)
+
+const (
+	collectionName = "keyValueIndex"
+	keyField       = "k"
+	fieldsField    = "f"
+	wordsField     = "w"
+	expiresField   = "e"
+	timeField      = "t"
+)
+
+var log = logger.NewNamed("common.keyvalue.kvindexer")
+
+var arenaPool = &anyenc.ArenaPool{}
+
+// New returns an indexer which keeps the decrypted values in the space any-store.
+// JSON object values are indexed by their fields, the listed fields get the secondary indexes,
+// all the string values are split into words for the text search.
+//
+// Note that the index is not encrypted: the fields and the words of the values are stored in plaintext
+// in the "keyValueIndex" collection, so the indexer should be used only where the local store is trusted
+// (e.g. on the client device), the searchable content is as protected as the local database is.
+func New(indexedFields ...string) keyvaluestorage.QueryIndexer {
+	return &indexer{indexedFields: indexedFields}
+}
+
+type indexer struct {
+	indexedFields []string
+	store         anystore.DB
+	collection    anystore.Collection
+}
+
+func (i *indexer) Init(a *app.App) (err error) {
+	i.store = a.MustComponent(spacestorage.CName).(spacestorage.SpaceStorage).AnyStore()
+	ctx := context.Background()
+	i.collection, err = i.store.Collection(ctx, collectionName)
+	if err != nil {
+		return err
+	}
+	indexes := []anystore.IndexInfo{
+		{Fields: []string{keyField}},
+		{Fields: []string{wordsField}, Sparse: true},
+	}
+	for _, field := range i.indexedFields {
+		indexes = append(indexes, anystore.IndexInfo{
+			Fields: []string{fieldsField + "." + field},
+			Sparse: true,
+		})
+	}
+	return i.collection.EnsureIndex(ctx, indexes...)
+}
+
+func (i *indexer) Name() (name string) {
+	return keyvaluestorage.IndexerCName
+}
+
+func (i *indexer) Index(decryptor keyvaluestorage.Decryptor, keyValue ...innerstorage.KeyValue) (err error) {
+	ctx := context.Background()
+	tx, err := i.store.WriteTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+	arena := arenaPool.Get()
+	defer arenaPool.Put(arena)
+	for _, kv := range keyValue {
+		if kv.Deleted {
+			if err = i.removeDeleted(tx.Context(), kv); err != nil {
+				return err
+			}
+			continue
+		}
+		value, decryptErr := decryptor(kv)
+		if decryptErr != nil {
+			// the value can't be decrypted e.g. if the read key is not available yet, it doesn't prevent indexing of the others
+			log.Warn("failed to decrypt value for index", zap.String("key", kv.Key), zap.String("peerId", kv.PeerId), zap.Error(decryptErr))
+			continue
+		}
+		arena.Reset()
+		if err = i.collection.UpsertOne(tx.Context(), indexDoc(arena, kv, value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDeleted removes the values hidden by the tombstone from the index
+func (i *indexer) removeDeleted(ctx context.Context, tombstone innerstorage.KeyValue) error {
+	_, err := i.collection.Find(query.And{
+		query.Key{Path: []string{keyField}, Filter: query.NewComp(query.CompOpEq, tombstone.Key)},
+		query.Key{Path: []string{timeField}, Filter: query.NewComp(query.CompOpLte, tombstone.TimestampMilli)},
+	}).Delete(ctx)
+	return err
+}
+
+// Query returns the keys which have at least one value matching the query
+func (i *indexer) Query(ctx context.Context, q keyvaluestorage.Query) (keys []string, err error) {
+	iter, err := i.collection.Find(queryFilter(q)).Sort(keyField).Iter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+	var (
+		doc      anystore.Doc
+		nowMicro = int(time.Now().UnixMicro())
+	)
+	for iter.Next() {
+		if doc, err = iter.Doc(); err != nil {
+			return nil, err
+		}
+		if expiresAt := doc.Value().GetInt(expiresField); expiresAt != 0 && expiresAt <= nowMicro {
+			continue
+		}
+		key := doc.Value().GetString(keyField)
+		if len(keys) != 0 && keys[len(keys)-1] == key {
+			continue
+		}
+		keys = append(keys, key)
+		if q.Limit != 0 && uint(len(keys)) == q.Limit {
+			break
+		}
+	}
+	return keys, nil
+}
+
+func indexDoc(arena *anyenc.Arena, kv innerstorage.KeyValue, value []byte) *anyenc.Value {
+	doc := arena.NewObject()
+	doc.Set("id", arena.NewString(kv.KeyPeerId))
+	doc.Set(keyField, arena.NewString(kv.Key))
+	doc.Set(timeField, arena.NewNumberInt(kv.TimestampMilli))
+	if kv.ExpiresAtMicro != 0 {
+		doc.Set(expiresField, arena.NewNumberInt(kv.ExpiresAtMicro))
+	}
+	var words []string
+	if jsonValue, err := anyenc.ParseJson(string(value)); err == nil {
+		if jsonValue.Type() == anyenc.TypeObject {
+			doc.Set(fieldsField, jsonValue)
+		}
+		words = collectWords(jsonValue, words)
+	} else if utf8.Valid(value) {
+		words = splitWords(string(value), words)
+	}
+	if len(words) != 0 {
+		wordsArr := arena.NewArray()
+		for idx, word := range uniqueWords(words) {
+			wordsArr.SetArrayItem(idx, arena.NewString(word))
+		}
+		doc.Set(wordsField, wordsArr)
+	}
+	return doc
+}
+
+func queryFilter(q keyvaluestorage.Query) query.Filter {
+	var filter query.And
+	for field, value := range q.Fields {
+		filter = append(filter, query.Key{
+			Path:   []string{fieldsField, field},
+			Filter: query.NewComp(query.CompOpEq, value),
+		})
+	}
+	for _, word := range uniqueWords(splitWords(q.Text, nil)) {
+		filter = append(filter, query.Key{
+			Path:   []string{wordsField},
+			Filter: query.NewComp(query.CompOpEq, word),
+		})
+	}
+	if len(filter) == 0 {
+		return query.All{}
+	}
+	return filter
+}
+
+func collectWords(v *anyenc.Value, words []string) []string {
+	switch v.Type() {
+	case anyenc.TypeString:
+		return splitWords(v.GetString(), words)
+	case anyenc.TypeArray:
+		arr, _ := v.Array()
+		for _, item := range arr {
+			words = collectWords(item, words)
+		}
+	case anyenc.TypeObject:
+		obj, _ := v.Object()
+		obj.Visit(func(_ []byte, item *anyenc.Value) {
+			words = collectWords(item, words)
+		})
+	}
+	return words
+}
+
+func splitWords(text string, words []string) []string {
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		words = append(words, strings.ToLower(word))
+	}
+	return words
+}
+
+func uniqueWords(words []string) []string {
+	seen := make(map[string]struct{}, len(words))
+	res := words[:0]
+	for _, word := range words {
+		if _, exists := seen[word]; exists {
+			continue
+		}
+		seen[word] = struct{}{}
+		res = append(res, word)
+	}
+	return res
+}
@@ -0,0 +1,135 @@
+package kvindexer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	anystore "github.com/anyproto/any-store"
+	"github.com/anyproto/any-store/anyenc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/mock_spacestorage"
+)
+
+var ctx = context.Background()
+
+func plainDecryptor(kv innerstorage.KeyValue) ([]byte, error) {
+	return kv.Value.Value, nil
+}
+
+func keyValue(key, peerId, value string) innerstorage.KeyValue {
+	return innerstorage.KeyValue{
+		KeyPeerId: key + "-" + peerId,
+		Key:       key,
+		PeerId:    peerId,
+		Value:     innerstorage.Value{Value: []byte(value)},
+	}
+}
+
+func TestIndexer_Query(t *testing.T) {
+	t.Run("query by fields", func(t *testing.T) {
+		fx := newFixture(t, "type")
+		require.NoError(t, fx.Index(plainDecryptor,
+			keyValue("a", "peer1", `{"type": "note", "title": "First note"}`),
+			keyValue("b", "peer1", `{"type": "task", "title": "Some task"}`),
+			keyValue("c", "peer1", `{"type": "note", "title": "Second note"}`),
+		))
+		keys, err := fx.Query(ctx, keyvaluestorage.Query{Fields: map[string]any{"type": "note"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "c"}, keys)
+		keys, err = fx.Query(ctx, keyvaluestorage.Query{Fields: map[string]any{"type": "note"}, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, keys)
+	})
+	t.Run("query by text", func(t *testing.T) {
+		fx := newFixture(t)
+		require.NoError(t, fx.Index(plainDecryptor,
+			keyValue("a", "peer1", `{"title": "Shopping list", "items": ["Milk", "bread"]}`),
+			keyValue("b", "peer1", "plain text with milk"),
+			keyValue("c", "peer1", string([]byte{0xff, 0xfe})),
+		))
+		keys, err := fx.Query(ctx, keyvaluestorage.Query{Text: "MILK"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, keys)
+		keys, err = fx.Query(ctx, keyvaluestorage.Query{Text: "milk, bread"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, keys)
+	})
+	t.Run("values of the same key are merged", func(t *testing.T) {
+		fx := newFixture(t)
+		require.NoError(t, fx.Index(plainDecryptor,
+			keyValue("a", "peer1", "red"),
+			keyValue("a", "peer2", "red green"),
+		))
+		keys, err := fx.Query(ctx, keyvaluestorage.Query{Text: "red"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, keys)
+		require.NoError(t, fx.Index(plainDecryptor, keyValue("a", "peer2", "blue")))
+		keys, err = fx.Query(ctx, keyvaluestorage.Query{Text: "green"})
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+	t.Run("decrypt error skips the value", func(t *testing.T) {
+		fx := newFixture(t)
+		err := fx.Index(func(kv innerstorage.KeyValue) ([]byte, error) {
+			if kv.Key == "a" {
+				return nil, fmt.Errorf("no read key")
+			}
+			return kv.Value.Value, nil
+		}, keyValue("a", "peer1", "value"), keyValue("b", "peer1", "value"))
+		require.NoError(t, err)
+		keys, err := fx.Query(ctx, keyvaluestorage.Query{Text: "value"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"b"}, keys)
+	})
+}
+
+func TestIndexDoc(t *testing.T) {
+	arena := &anyenc.Arena{}
+	t.Run("json object", func(t *testing.T) {
+		kv := keyValue("a", "peer1", `{"type": "note", "tags": ["Go", "sync"], "title": "Go notes"}`)
+		doc := indexDoc(arena, kv, kv.Value.Value)
+		require.Equal(t, "a-peer1", doc.GetString("id"))
+		require.Equal(t, "a", doc.GetString(keyField))
+		require.Equal(t, "note", doc.GetString(fieldsField, "type"))
+		var words []string
+		for _, w := range doc.GetArray(wordsField) {
+			words = append(words, w.GetString())
+		}
+		require.ElementsMatch(t, []string{"note", "go", "sync", "notes"}, words)
+	})
+	t.Run("binary value", func(t *testing.T) {
+		kv := keyValue("a", "peer1", string([]byte{0xff, 0xfe}))
+		doc := indexDoc(arena, kv, kv.Value.Value)
+		require.Nil(t, doc.Get(fieldsField))
+		require.Nil(t, doc.Get(wordsField))
+	})
+}
+
+type fixture struct {
+	*indexer
+}
+
+func newFixture(t *testing.T, indexedFields ...string) *fixture {
+	ctrl := gomock.NewController(t)
+	store, err := anystore.Open(ctx, filepath.Join(t.TempDir(), "store.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	spaceStorage := mock_spacestorage.NewMockSpaceStorage(ctrl)
+	spaceStorage.EXPECT().Name().Return(spacestorage.CName).AnyTimes()
+	spaceStorage.EXPECT().AnyStore().Return(store).AnyTimes()
+	a := new(app.App)
+	a.Register(spaceStorage)
+	fx := &fixture{indexer: New(indexedFields...).(*indexer)}
+	require.NoError(t, fx.Init(a))
+	return fx
+}
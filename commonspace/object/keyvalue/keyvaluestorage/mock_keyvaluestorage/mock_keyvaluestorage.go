@@ -11,7 +11,10 @@ package mock_keyvaluestorage
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	accountdata "github.com/anyproto/any-sync/commonspace/object/accountdata"
+	keyvaluestorage "github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
 	innerstorage "github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
 	spacesyncproto "github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +43,20 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockStorage) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStorageMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStorage)(nil).Delete), arg0, arg1)
+}
+
 // GetAll mocks base method.
 func (m *MockStorage) GetAll(arg0 context.Context, arg1 string, arg2 func(func(innerstorage.KeyValue) ([]byte, error), []innerstorage.KeyValue) error) error {
 	m.ctrl.T.Helper()
@@ -110,6 +127,49 @@ func (mr *MockStorageMockRecorder) Prepare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepare", reflect.TypeOf((*MockStorage)(nil).Prepare))
 }
 
+// Query mocks base method.
+func (m *MockStorage) Query(arg0 context.Context, arg1 keyvaluestorage.Query) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockStorageMockRecorder) Query(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockStorage)(nil).Query), arg0, arg1)
+}
+
+// RemoveExpired mocks base method.
+func (m *MockStorage) RemoveExpired(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveExpired", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveExpired indicates an expected call of RemoveExpired.
+func (mr *MockStorageMockRecorder) RemoveExpired(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExpired", reflect.TypeOf((*MockStorage)(nil).RemoveExpired), arg0)
+}
+
+// RemoveTombstones mocks base method.
+func (m *MockStorage) RemoveTombstones(arg0 context.Context, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTombstones", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTombstones indicates an expected call of RemoveTombstones.
+func (mr *MockStorageMockRecorder) RemoveTombstones(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTombstones", reflect.TypeOf((*MockStorage)(nil).RemoveTombstones), arg0, arg1)
+}
+
 // Set mocks base method.
 func (m *MockStorage) Set(arg0 context.Context, arg1 string, arg2 []byte) error {
 	m.ctrl.T.Helper()
@@ -124,6 +184,18 @@ func (mr *MockStorageMockRecorder) Set(arg0, arg1, arg2 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStorage)(nil).Set), arg0, arg1, arg2)
 }
 
+// SetAccountKeys mocks base method.
+func (m *MockStorage) SetAccountKeys(arg0 *accountdata.AccountKeys) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAccountKeys", arg0)
+}
+
+// SetAccountKeys indicates an expected call of SetAccountKeys.
+func (mr *MockStorageMockRecorder) SetAccountKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountKeys", reflect.TypeOf((*MockStorage)(nil).SetAccountKeys), arg0)
+}
+
 // SetRaw mocks base method.
 func (m *MockStorage) SetRaw(arg0 context.Context, arg1 ...*spacesyncproto.StoreKeyValue) error {
 	m.ctrl.T.Helper()
@@ -142,3 +214,17 @@ func (mr *MockStorageMockRecorder) SetRaw(arg0 any, arg1 ...any) *gomock.Call {
 	varargs := append([]any{arg0}, arg1...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRaw", reflect.TypeOf((*MockStorage)(nil).SetRaw), varargs...)
 }
+
+// SetWithExpiry mocks base method.
+func (m *MockStorage) SetWithExpiry(arg0 context.Context, arg1 string, arg2 []byte, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWithExpiry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWithExpiry indicates an expected call of SetWithExpiry.
+func (mr *MockStorageMockRecorder) SetWithExpiry(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithExpiry", reflect.TypeOf((*MockStorage)(nil).SetWithExpiry), arg0, arg1, arg2, arg3)
+}
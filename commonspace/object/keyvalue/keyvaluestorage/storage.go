@@ -4,6 +4,7 @@ package keyvaluestorage
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -28,6 +29,8 @@ var log = logger.NewNamed("common.keyvalue.keyvaluestorage")
 
 const IndexerCName = "common.keyvalue.indexer"
 
+var ErrQueryNotSupported = errors.New("indexer doesn't support queries")
+
 type Indexer interface {
 	app.Component
 	Index(decryptor Decryptor, keyValue ...innerstorage.KeyValue) error
@@ -49,14 +52,65 @@ func (n NoOpIndexer) Index(decryptor Decryptor, keyValue ...innerstorage.KeyValu
 	return nil
 }
 
+// Query selects keys by their decrypted values, all the conditions must match
+type Query struct {
+	// Fields are compared for equality with the top level fields of the JSON values
+	Fields map[string]any
+	// Text is split into words, each of them must be present in the value
+	Text string
+	// Limit is the maximum number of keys returned, zero means no limit
+	Limit uint
+}
+
+// QueryIndexer is an Indexer which can be queried for the keys it indexed
+type QueryIndexer interface {
+	Indexer
+	Query(ctx context.Context, query Query) (keys []string, err error)
+}
+
+const ObjectAclProviderCName = "common.keyvalue.objectaclprovider"
+
+// ObjectAclProvider maps keys to the objects they belong to,
+// so the object acl overlays are enforced for the key-value writes as well
+type ObjectAclProvider interface {
+	app.Component
+	// ObjectAcl returns the acl of the object the key belongs to, nil means that only the space permissions apply
+	ObjectAcl(key string) (*list.ObjectAcl, error)
+}
+
+type NoOpObjectAclProvider struct{}
+
+func (n NoOpObjectAclProvider) Init(a *app.App) (err error) {
+	return nil
+}
+
+func (n NoOpObjectAclProvider) Name() (name string) {
+	return ObjectAclProviderCName
+}
+
+func (n NoOpObjectAclProvider) ObjectAcl(key string) (*list.ObjectAcl, error) {
+	return nil, nil
+}
+
 type Storage interface {
 	Id() string
 	Prepare() error
 	Set(ctx context.Context, key string, value []byte) error
+	// SetWithExpiry sets the value which is hidden from the readers and removed after expiresAt
+	SetWithExpiry(ctx context.Context, key string, value []byte, expiresAt time.Time) error
 	SetRaw(ctx context.Context, keyValue ...*spacesyncproto.StoreKeyValue) error
+	// Delete writes a tombstone which hides the values of the key set before it
+	Delete(ctx context.Context, key string) error
 	GetAll(ctx context.Context, key string, get func(decryptor Decryptor, values []innerstorage.KeyValue) error) error
 	Iterate(ctx context.Context, f func(decryptor Decryptor, key string, values []innerstorage.KeyValue) (bool, error)) error
+	Query(ctx context.Context, query Query) (keys []string, err error)
+	// RemoveExpired removes the expired values from the storage
+	RemoveExpired(ctx context.Context) error
+	// RemoveTombstones removes the tombstones older than ttl together with the values they hide
+	RemoveTombstones(ctx context.Context, ttl time.Duration) error
 	InnerStorage() innerstorage.KeyValueStorage
+	// SetAccountKeys swaps the keys which sign the new values, e.g. after the key rotation
+	SetAccountKeys(keys *accountdata.AccountKeys)
 }
 
 type storage struct {
@@ -65,10 +119,12 @@ type storage struct {
 	aclList        list.AclList
 	syncClient     syncstorage.SyncClient
 	indexer        Indexer
+	objectAcls     ObjectAclProvider
 	storageId      string
 	byteRepr       []byte
 	readKeys       map[string]crypto.SymKey
 	currentReadKey crypto.SymKey
+	timeNow        func() time.Time
 	mx             sync.Mutex
 }
 
@@ -81,6 +137,7 @@ func New(
 	syncClient syncstorage.SyncClient,
 	aclList list.AclList,
 	indexer Indexer,
+	objectAcls ObjectAclProvider,
 ) (Storage, error) {
 	inner, err := innerstorage.New(ctx, storageId, headStorage, store)
 	if err != nil {
@@ -92,13 +149,21 @@ func New(
 		storageId:  storageId,
 		aclList:    aclList,
 		indexer:    indexer,
+		objectAcls: objectAcls,
 		syncClient: syncClient,
 		byteRepr:   make([]byte, 8),
 		readKeys:   make(map[string]crypto.SymKey),
+		timeNow:    time.Now,
 	}
 	return s, nil
 }
 
+func (s *storage) SetAccountKeys(keys *accountdata.AccountKeys) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.keys = keys
+}
+
 func (s *storage) Prepare() error {
 	s.aclList.RLock()
 	defer s.aclList.RUnlock()
@@ -110,17 +175,37 @@ func (s *storage) Id() string {
 }
 
 func (s *storage) Set(ctx context.Context, key string, value []byte) error {
+	return s.set(ctx, key, value, time.Time{}, false)
+}
+
+func (s *storage) SetWithExpiry(ctx context.Context, key string, value []byte, expiresAt time.Time) error {
+	if expiresAt.IsZero() {
+		return fmt.Errorf("expiry is not set")
+	}
+	return s.set(ctx, key, value, expiresAt, false)
+}
+
+func (s *storage) Delete(ctx context.Context, key string) error {
+	return s.set(ctx, key, nil, time.Time{}, true)
+}
+
+func (s *storage) set(ctx context.Context, key string, value []byte, expiresAt time.Time, deleted bool) error {
+	objectAcl, err := s.objectAcls.ObjectAcl(key)
+	if err != nil {
+		return err
+	}
 	s.mx.Lock()
 	defer s.mx.Unlock()
 	s.aclList.RLock()
 	headId := s.aclList.Head().Id
 	state := s.aclList.AclState()
-	if !s.aclList.AclState().Permissions(state.Identity()).CanWrite() {
+	capabilities := objectAcl.Capabilities(state.Identity(), state.Capabilities(state.Identity()))
+	if !capabilities.Has(list.CapabilityWriteKeyValue) {
 		s.aclList.RUnlock()
 		return list.ErrInsufficientPermissions
 	}
 	readKeyId := state.CurrentReadKeyId()
-	err := s.readKeysFromAclState(state)
+	err = s.readKeysFromAclState(state)
 	if err != nil {
 		s.aclList.RUnlock()
 		return err
@@ -140,7 +225,11 @@ func (s *storage) Set(ctx context.Context, key string, value []byte) error {
 	if err != nil {
 		return err
 	}
-	timestampMicro := time.Now().UnixMicro()
+	timestampMicro := s.timeNow().UnixMicro()
+	var expiresAtMicro int64
+	if !expiresAt.IsZero() {
+		expiresAtMicro = expiresAt.UnixMicro()
+	}
 	inner := spacesyncproto.StoreKeyInner{
 		Peer:           protoPeerKey,
 		Identity:       protoIdentityKey,
@@ -148,6 +237,8 @@ func (s *storage) Set(ctx context.Context, key string, value []byte) error {
 		TimestampMicro: timestampMicro,
 		AclHeadId:      headId,
 		Key:            key,
+		ExpiresAtMicro: expiresAtMicro,
+		Deleted:        deleted,
 	}
 	innerBytes, err := inner.Marshal()
 	if err != nil {
@@ -170,6 +261,8 @@ func (s *storage) Set(ctx context.Context, key string, value []byte) error {
 		PeerId:         peerIdKey.GetPublic().PeerId(),
 		AclId:          headId,
 		ReadKeyId:      readKeyId,
+		ExpiresAtMicro: int(expiresAtMicro),
+		Deleted:        deleted,
 		Value: innerstorage.Value{
 			Value:             innerBytes,
 			PeerSignature:     peerSig,
@@ -205,6 +298,12 @@ func (s *storage) SetRaw(ctx context.Context, keyValue ...*spacesyncproto.StoreK
 		}
 		keyValues = append(keyValues, innerKv)
 	}
+	objectAcls := make([]*list.ObjectAcl, len(keyValues))
+	for i := range keyValues {
+		if objectAcls[i], err = s.objectAcls.ObjectAcl(keyValues[i].Key); err != nil {
+			return err
+		}
+	}
 	s.aclList.RLock()
 	state := s.aclList.AclState()
 	err = s.readKeysFromAclState(state)
@@ -212,7 +311,12 @@ func (s *storage) SetRaw(ctx context.Context, keyValue ...*spacesyncproto.StoreK
 		s.aclList.RUnlock()
 		return err
 	}
+	now := s.timeNow()
 	for i := range keyValues {
+		if keyValues[i].IsExpired(now) {
+			keyValues[i].KeyPeerId = ""
+			continue
+		}
 		el, err := s.inner.Diff().Element(keyValues[i].KeyPeerId)
 		if err == nil {
 			binary.BigEndian.PutUint64(s.byteRepr, uint64(keyValues[i].TimestampMilli))
@@ -226,6 +330,10 @@ func (s *storage) SetRaw(ctx context.Context, keyValue ...*spacesyncproto.StoreK
 			keyValues[i].KeyPeerId = ""
 			continue
 		}
+		if !canWriteObject(state, objectAcls[i], keyValues[i]) {
+			keyValues[i].KeyPeerId = ""
+			continue
+		}
 	}
 	s.aclList.RUnlock()
 	keyValues = slice.DiscardFromSlice(keyValues, func(value innerstorage.KeyValue) bool {
@@ -249,6 +357,23 @@ func (s *storage) SetRaw(ctx context.Context, keyValue ...*spacesyncproto.StoreK
 	return nil
 }
 
+// canWriteObject checks the value received from the peer against the object acl,
+// the permissions are taken at the acl head the value was written with
+func canWriteObject(state *list.AclState, objectAcl *list.ObjectAcl, kv innerstorage.KeyValue) bool {
+	if objectAcl == nil {
+		return true
+	}
+	identity, err := crypto.DecodeAccountAddress(kv.Identity)
+	if err != nil {
+		return false
+	}
+	permissions, err := state.PermissionsAtRecord(kv.AclId, identity)
+	if err != nil {
+		return false
+	}
+	return objectAcl.Capabilities(identity, state.RoleCapabilities(permissions)).Has(list.CapabilityWriteKeyValue)
+}
+
 func (s *storage) GetAll(ctx context.Context, key string, get func(decryptor Decryptor, values []innerstorage.KeyValue) error) (err error) {
 	var values []innerstorage.KeyValue
 	err = s.inner.IteratePrefix(ctx, key, func(kv innerstorage.KeyValue) error {
@@ -261,11 +386,46 @@ func (s *storage) GetAll(ctx context.Context, key string, get func(decryptor Dec
 	if err != nil {
 		return err
 	}
+	values = visibleValues(values, s.timeNow())
 	s.mx.Lock()
 	defer s.mx.Unlock()
 	return get(s.decrypt, values)
 }
 
+func (s *storage) Query(ctx context.Context, query Query) (keys []string, err error) {
+	queryIndexer, ok := s.indexer.(QueryIndexer)
+	if !ok {
+		return nil, ErrQueryNotSupported
+	}
+	return queryIndexer.Query(ctx, query)
+}
+
+func (s *storage) RemoveExpired(ctx context.Context) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	removed, err := s.inner.RemoveExpired(ctx, s.timeNow())
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		log.Debug("removed expired values", zap.String("storageId", s.storageId), zap.Int("count", removed))
+	}
+	return nil
+}
+
+func (s *storage) RemoveTombstones(ctx context.Context, ttl time.Duration) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	removed, err := s.inner.RemoveTombstones(ctx, s.timeNow().Add(-ttl))
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		log.Debug("removed tombstones", zap.String("storageId", s.storageId), zap.Int("count", removed))
+	}
+	return nil
+}
+
 func (s *storage) InnerStorage() innerstorage.KeyValueStorage {
 	return s.inner
 }
@@ -306,12 +466,13 @@ func (s *storage) Iterate(ctx context.Context, f func(decryptor Decryptor, key s
 	defer s.mx.Unlock()
 	var (
 		curKey = ""
+		now    = s.timeNow()
 		// TODO: reuse buffer
 		values []innerstorage.KeyValue
 	)
 	err = s.inner.IterateValues(ctx, func(kv innerstorage.KeyValue) (bool, error) {
 		if kv.Key != curKey {
-			if curKey != "" {
+			if values = visibleValues(values, now); len(values) > 0 {
 				iter, err := f(s.decrypt, curKey, values)
 				if err != nil {
 					return false, err
@@ -333,12 +494,28 @@ func (s *storage) Iterate(ctx context.Context, f func(decryptor Decryptor, key s
 	if err != nil {
 		return err
 	}
-	if len(values) > 0 {
+	if values = visibleValues(values, now); len(values) > 0 {
 		_, err = f(s.decrypt, curKey, values)
 	}
 	return err
 }
 
+// visibleValues filters out the expired values, the tombstones and the values hidden by the tombstones
+func visibleValues(values []innerstorage.KeyValue, now time.Time) []innerstorage.KeyValue {
+	var deletedAt map[string]int
+	for _, kv := range values {
+		if kv.Deleted && kv.TimestampMilli > deletedAt[kv.Key] {
+			if deletedAt == nil {
+				deletedAt = make(map[string]int)
+			}
+			deletedAt[kv.Key] = kv.TimestampMilli
+		}
+	}
+	return slice.DiscardFromSlice(values, func(kv innerstorage.KeyValue) bool {
+		return kv.Deleted || kv.IsExpired(now) || kv.TimestampMilli <= deletedAt[kv.Key]
+	})
+}
+
 func (s *storage) decrypt(kv innerstorage.KeyValue) (value []byte, err error) {
 	if kv.ReadKeyId == "" {
 		return nil, fmt.Errorf("no read key id")
@@ -0,0 +1,168 @@
+package keyvaluestorage
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	anystore "github.com/anyproto/any-store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ctx = context.Background()
+
+func TestStorageExpiry(t *testing.T) {
+	t.Run("expired values are hidden and removed", func(t *testing.T) {
+		fxClient, fxServer := prepareFixtures(t)
+		require.NoError(t, fxClient.Set(ctx, "key1", []byte("value1")))
+		require.NoError(t, fxClient.SetWithExpiry(ctx, "key2", []byte("value2"), fxClient.clock.Now().Add(time.Minute)))
+		require.True(t, fxClient.check(t, "key2", []byte("value2")))
+		require.NoError(t, fxServer.SetRaw(ctx, fxClient.rawValues(t)...))
+		require.True(t, fxServer.check(t, "key2", []byte("value2")))
+
+		fxClient.clock.Add(2 * time.Minute)
+		require.False(t, fxClient.check(t, "key2", []byte("value2")))
+		require.False(t, fxServer.check(t, "key2", []byte("value2")))
+		var keys []string
+		err := fxServer.Iterate(ctx, func(decryptor Decryptor, key string, values []innerstorage.KeyValue) (bool, error) {
+			keys = append(keys, key)
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"key1"}, keys)
+
+		require.NoError(t, fxClient.RemoveExpired(ctx))
+		require.NoError(t, fxServer.RemoveExpired(ctx))
+		require.Equal(t, 1, fxClient.InnerStorage().Diff().Len())
+		require.Equal(t, fxClient.InnerStorage().Diff().Hash(), fxServer.InnerStorage().Diff().Hash())
+	})
+	t.Run("expired values are not accepted", func(t *testing.T) {
+		fxClient, fxServer := prepareFixtures(t)
+		require.NoError(t, fxClient.SetWithExpiry(ctx, "key1", []byte("value1"), fxClient.clock.Now().Add(time.Minute)))
+		fxClient.clock.Add(2 * time.Minute)
+		require.NoError(t, fxServer.SetRaw(ctx, fxClient.rawValues(t)...))
+		require.Equal(t, 0, fxServer.InnerStorage().Diff().Len())
+	})
+}
+
+type noOpSyncClient struct{}
+
+func (n noOpSyncClient) Broadcast(ctx context.Context, objectId string, keyValues ...innerstorage.KeyValue) error {
+	return nil
+}
+
+type fixture struct {
+	*storage
+	clock *testClock
+}
+
+// testClock is the clock of the storages in tests, the storages in the test share it.
+// Every call moves it forward by a microsecond, so the values written one after another have different timestamps
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Microsecond)
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// prepareFixtures creates the storages of two devices of the same account in the same space
+func prepareFixtures(t *testing.T) (fxClient *fixture, fxServer *fixture) {
+	firstKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	secondKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	secondKeys.SignKey = firstKeys.SignKey
+	payload := newStorageCreatePayload(t, firstKeys)
+	clock := &testClock{now: time.Now()}
+	return newFixture(t, firstKeys, payload, clock), newFixture(t, secondKeys, payload, clock)
+}
+
+func newFixture(t *testing.T, keys *accountdata.AccountKeys, spacePayload spacestorage.SpaceStorageCreatePayload, clock *testClock) *fixture {
+	anyStore, err := anystore.Open(ctx, filepath.Join(t.TempDir(), "store.db"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = anyStore.Close()
+	})
+	spaceStorage, err := spacestorage.Create(ctx, anyStore, spacePayload)
+	require.NoError(t, err)
+	aclStorage, err := spaceStorage.AclStorage()
+	require.NoError(t, err)
+	aclList, err := list.BuildAclListWithIdentity(keys, aclStorage, recordverifier.NewValidateFull())
+	require.NoError(t, err)
+	st, err := New(ctx, "kv.storage", anyStore, spaceStorage.HeadStorage(), keys, noOpSyncClient{}, aclList, NoOpIndexer{}, NoOpObjectAclProvider{})
+	require.NoError(t, err)
+	st.(*storage).timeNow = clock.Now
+	return &fixture{storage: st.(*storage), clock: clock}
+}
+
+func (fx *fixture) check(t *testing.T, key string, value []byte) (isFound bool) {
+	err := fx.GetAll(ctx, key, func(decryptor Decryptor, values []innerstorage.KeyValue) error {
+		for _, v := range values {
+			decryptedValue, err := decryptor(v)
+			require.NoError(t, err)
+			if bytes.Equal(value, decryptedValue) {
+				isFound = true
+				break
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return
+}
+
+// rawValues returns the values of the storage as they are sent to the other peers
+func (fx *fixture) rawValues(t *testing.T) (values []*spacesyncproto.StoreKeyValue) {
+	require.NoError(t, fx.InnerStorage().IterateValues(ctx, func(kv innerstorage.KeyValue) (bool, error) {
+		// the iterator reuses the buffers
+		data, err := kv.Proto().Marshal()
+		if err != nil {
+			return false, err
+		}
+		value := &spacesyncproto.StoreKeyValue{}
+		values = append(values, value)
+		return true, value.Unmarshal(data)
+	}))
+	return
+}
+
+func newStorageCreatePayload(t *testing.T, keys *accountdata.AccountKeys) spacestorage.SpaceStorageCreatePayload {
+	masterKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	metaKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	createSpace, err := spacepayloads.StoragePayloadForSpaceCreate(spacepayloads.SpaceCreatePayload{
+		SigningKey:     keys.SignKey,
+		SpaceType:      "space",
+		ReplicationKey: 10,
+		MasterKey:      masterKey,
+		ReadKey:        crypto.NewAES(),
+		MetadataKey:    metaKey,
+		Metadata:       []byte("account"),
+	})
+	require.NoError(t, err)
+	return createSpace
+}
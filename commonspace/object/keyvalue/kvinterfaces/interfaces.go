@@ -3,20 +3,28 @@ package kvinterfaces
 
 import (
 	"context"
+	"errors"
 
 	"storj.io/drpc"
 
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/kvresolver"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	"github.com/anyproto/any-sync/net/peer"
 )
 
 const CName = "common.object.keyvalue"
 
+var ErrKeyNotFound = errors.New("key not found")
+
 type KeyValueService interface {
 	app.ComponentRunnable
 	DefaultStore() keyvaluestorage.Storage
+	// RegisterResolver sets the resolver for the keys with the prefix, the longest matching prefix is used
+	RegisterResolver(prefix string, resolver kvresolver.Resolver)
+	// Get returns the value of the key chosen by its resolver, the last writer wins if no resolver is registered
+	Get(ctx context.Context, key string) (value []byte, err error)
 	HandleMessage(ctx context.Context, msg drpc.Message) (err error)
 	SyncWithPeer(p peer.Peer) (err error)
 	HandleStoreDiffRequest(ctx context.Context, req *spacesyncproto.StoreDiffRequest) (resp *spacesyncproto.StoreDiffResponse, err error)
@@ -14,6 +14,7 @@ import (
 
 	app "github.com/anyproto/any-sync/app"
 	keyvaluestorage "github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
+	kvresolver "github.com/anyproto/any-sync/commonspace/object/keyvalue/kvresolver"
 	spacesyncproto "github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	peer "github.com/anyproto/any-sync/net/peer"
 	gomock "go.uber.org/mock/gomock"
@@ -71,6 +72,21 @@ func (mr *MockKeyValueServiceMockRecorder) DefaultStore() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultStore", reflect.TypeOf((*MockKeyValueService)(nil).DefaultStore))
 }
 
+// Get mocks base method.
+func (m *MockKeyValueService) Get(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKeyValueServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeyValueService)(nil).Get), arg0, arg1)
+}
+
 // HandleMessage mocks base method.
 func (m *MockKeyValueService) HandleMessage(arg0 context.Context, arg1 drpc.Message) error {
 	m.ctrl.T.Helper()
@@ -142,6 +158,18 @@ func (mr *MockKeyValueServiceMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockKeyValueService)(nil).Name))
 }
 
+// RegisterResolver mocks base method.
+func (m *MockKeyValueService) RegisterResolver(arg0 string, arg1 kvresolver.Resolver) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterResolver", arg0, arg1)
+}
+
+// RegisterResolver indicates an expected call of RegisterResolver.
+func (mr *MockKeyValueServiceMockRecorder) RegisterResolver(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterResolver", reflect.TypeOf((*MockKeyValueService)(nil).RegisterResolver), arg0, arg1)
+}
+
 // Run mocks base method.
 func (m *MockKeyValueService) Run(arg0 context.Context) error {
 	m.ctrl.T.Helper()
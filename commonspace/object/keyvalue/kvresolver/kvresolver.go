@@ -0,0 +1,122 @@
+package kvresolver
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var ErrNoValues = errors.New("no values to resolve")
+
+// Value is a decrypted value of a key written by one of the peers
+type Value struct {
+	Value          []byte
+	TimestampMicro int
+	PeerId         string
+	Identity       string
+}
+
+// Resolver picks one value out of the values written for the same key by different peers.
+// The result must depend only on the values and not on their order, so all the clients agree on it
+type Resolver interface {
+	Resolve(key string, values []Value) (value []byte, err error)
+}
+
+type ResolverFunc func(key string, values []Value) (value []byte, err error)
+
+func (r ResolverFunc) Resolve(key string, values []Value) (value []byte, err error) {
+	return r(key, values)
+}
+
+// LastWriterWins returns the latest value, the ties are broken by the peer id
+func LastWriterWins() Resolver {
+	return ResolverFunc(func(key string, values []Value) ([]byte, error) {
+		if len(values) == 0 {
+			return nil, ErrNoValues
+		}
+		return slices.MaxFunc(values, compareWrites).Value, nil
+	})
+}
+
+// MaxValue returns the maximum value according to compare, bytes.Compare is used if compare is nil
+func MaxValue(compare func(a, b []byte) int) Resolver {
+	if compare == nil {
+		compare = bytes.Compare
+	}
+	return ResolverFunc(func(key string, values []Value) ([]byte, error) {
+		if len(values) == 0 {
+			return nil, ErrNoValues
+		}
+		return slices.MaxFunc(values, func(a, b Value) int {
+			if res := compare(a.Value, b.Value); res != 0 {
+				return res
+			}
+			return compareWrites(a, b)
+		}).Value, nil
+	})
+}
+
+// Merge folds the values with merge from the earliest write to the latest one
+func Merge(merge func(key string, a, b []byte) ([]byte, error)) Resolver {
+	return ResolverFunc(func(key string, values []Value) (res []byte, err error) {
+		if len(values) == 0 {
+			return nil, ErrNoValues
+		}
+		sorted := slices.Clone(values)
+		slices.SortFunc(sorted, compareWrites)
+		res = sorted[0].Value
+		for _, v := range sorted[1:] {
+			if res, err = merge(key, res, v.Value); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	})
+}
+
+func compareWrites(a, b Value) int {
+	if a.TimestampMicro != b.TimestampMicro {
+		if a.TimestampMicro < b.TimestampMicro {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.PeerId, b.PeerId)
+}
+
+// Registry keeps the resolvers by key prefixes, the longest matching prefix wins
+type Registry struct {
+	prefixes  []string
+	resolvers map[string]Resolver
+	mx        sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{resolvers: make(map[string]Resolver)}
+}
+
+func (r *Registry) Register(prefix string, resolver Resolver) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if _, exists := r.resolvers[prefix]; !exists {
+		r.prefixes = append(r.prefixes, prefix)
+		slices.SortFunc(r.prefixes, func(a, b string) int {
+			return len(b) - len(a)
+		})
+	}
+	r.resolvers[prefix] = resolver
+}
+
+// Resolver returns the resolver for the key or nil if none matches
+func (r *Registry) Resolver(key string) Resolver {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return r.resolvers[prefix]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package kvresolver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var values = []Value{
+	{Value: []byte("b"), TimestampMicro: 2, PeerId: "peer1"},
+	{Value: []byte("c"), TimestampMicro: 1, PeerId: "peer2"},
+	{Value: []byte("a"), TimestampMicro: 2, PeerId: "peer3"},
+}
+
+func TestLastWriterWins(t *testing.T) {
+	value, err := LastWriterWins().Resolve("key", values)
+	require.NoError(t, err)
+	require.Equal(t, "a", string(value))
+	_, err = LastWriterWins().Resolve("key", nil)
+	require.ErrorIs(t, err, ErrNoValues)
+}
+
+func TestMaxValue(t *testing.T) {
+	value, err := MaxValue(nil).Resolve("key", values)
+	require.NoError(t, err)
+	require.Equal(t, "c", string(value))
+	value, err = MaxValue(func(a, b []byte) int {
+		return int(b[0]) - int(a[0])
+	}).Resolve("key", values)
+	require.NoError(t, err)
+	require.Equal(t, "a", string(value))
+}
+
+func TestMerge(t *testing.T) {
+	concat := Merge(func(key string, a, b []byte) ([]byte, error) {
+		return append(append([]byte{}, a...), b...), nil
+	})
+	value, err := concat.Resolve("key", values)
+	require.NoError(t, err)
+	require.Equal(t, "cba", string(value))
+	reversed := []Value{values[2], values[1], values[0]}
+	value, err = concat.Resolve("key", reversed)
+	require.NoError(t, err)
+	require.Equal(t, "cba", string(value))
+
+	_, err = Merge(func(key string, a, b []byte) ([]byte, error) {
+		return nil, fmt.Errorf("merge failed")
+	}).Resolve("key", values)
+	require.Error(t, err)
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("a/", LastWriterWins())
+	r.Register("a/b/", MaxValue(nil))
+	value, err := r.Resolver("a/b/c").Resolve("a/b/c", values)
+	require.NoError(t, err)
+	require.Equal(t, "c", string(value))
+	value, err = r.Resolver("a/c").Resolve("a/c", values)
+	require.NoError(t, err)
+	require.Equal(t, "a", string(value))
+	require.Nil(t, r.Resolver("b/c"))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/anyproto/protobuf/proto"
 
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/util/crypto"
 )
@@ -25,7 +26,11 @@ type Change struct {
 	Timestamp   int64
 	ReadKeyId   string
 	Identity    crypto.PubKey
-	Data        []byte
+	// DeviceKey is set if the change is signed by the device on behalf of the identity
+	// with the DeviceCertificate issued by the identity
+	DeviceKey         crypto.PubKey
+	DeviceCertificate []byte
+	Data              []byte
 	// TODO: add call one time comment
 	Model           interface{}
 	Signature       []byte
@@ -35,6 +40,8 @@ type Change struct {
 	IsNew           bool
 	OrderId         string
 	SnapshotCounter int
+	// ObjectAcl is set only for the root change
+	ObjectAcl *list.ObjectAcl
 
 	// iterator helpers
 	visited          bool
@@ -5,6 +5,7 @@ import (
 
 	"github.com/anyproto/protobuf/proto"
 
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/util/cidutil"
 	"github.com/anyproto/any-sync/util/crypto"
@@ -33,6 +34,7 @@ type InitialContent struct {
 	ChangeType    string
 	ChangePayload []byte
 	Timestamp     int64
+	ObjectAcl     *list.ObjectAcl
 }
 
 type InitialDerivedContent struct {
@@ -105,7 +107,11 @@ func (c *changeBuilder) Unmarshall(rawIdChange *treechangeproto.RawTreeChangeWit
 	if verify && !ch.IsDerived {
 		// verifying signature
 		var res bool
-		res, err = ch.Identity.Verify(raw.Payload, raw.Signature)
+		signer := ch.Identity
+		if ch.DeviceKey != nil {
+			signer = ch.DeviceKey
+		}
+		res, err = signer.Verify(raw.Payload, raw.Signature)
 		if err != nil {
 			return
 		}
@@ -146,13 +152,18 @@ func (c *changeBuilder) BuildRoot(payload InitialContent) (ch *Change, rawIdChan
 		return
 	}
 	change := &treechangeproto.RootChange{
-		AclHeadId:     payload.AclHeadId,
-		Timestamp:     payload.Timestamp,
-		Identity:      identity,
-		ChangeType:    payload.ChangeType,
-		ChangePayload: payload.ChangePayload,
-		SpaceId:       payload.SpaceId,
-		Seed:          payload.Seed,
+		AclHeadId:         payload.AclHeadId,
+		Timestamp:         payload.Timestamp,
+		Identity:          identity,
+		ChangeType:        payload.ChangeType,
+		ChangePayload:     payload.ChangePayload,
+		SpaceId:           payload.SpaceId,
+		Seed:              payload.Seed,
+		DeviceCertificate: crypto.MarshalledDeviceCertificate(payload.PrivKey),
+	}
+	change.ObjectAcl, err = marshallObjectAcl(payload.ObjectAcl)
+	if err != nil {
+		return
 	}
 	marshalledChange, err := proto.Marshal(change)
 	if err != nil {
@@ -175,6 +186,8 @@ func (c *changeBuilder) BuildRoot(payload InitialContent) (ch *Change, rawIdChan
 		return
 	}
 	ch = NewChangeFromRoot(id, payload.PrivKey.GetPublic(), change, signature, false)
+	ch.ObjectAcl = payload.ObjectAcl
+	setDeviceKey(ch, payload.PrivKey)
 	rawIdChange = &treechangeproto.RawTreeChangeWithId{
 		RawChange: marshalledRawChange,
 		Id:        id,
@@ -218,14 +231,15 @@ func (c *changeBuilder) Build(payload BuilderContent) (ch *Change, rawIdChange *
 		return
 	}
 	change := &treechangeproto.TreeChange{
-		TreeHeadIds:    payload.TreeHeadIds,
-		AclHeadId:      payload.AclHeadId,
-		SnapshotBaseId: payload.SnapshotBaseId,
-		ReadKeyId:      payload.ReadKeyId,
-		Timestamp:      payload.Timestamp,
-		Identity:       identity,
-		IsSnapshot:     payload.IsSnapshot,
-		DataType:       payload.DataType,
+		TreeHeadIds:       payload.TreeHeadIds,
+		AclHeadId:         payload.AclHeadId,
+		SnapshotBaseId:    payload.SnapshotBaseId,
+		ReadKeyId:         payload.ReadKeyId,
+		Timestamp:         payload.Timestamp,
+		Identity:          identity,
+		IsSnapshot:        payload.IsSnapshot,
+		DataType:          payload.DataType,
+		DeviceCertificate: crypto.MarshalledDeviceCertificate(payload.PrivKey),
 	}
 	if payload.ReadKey != nil {
 		var encrypted []byte
@@ -270,6 +284,7 @@ func (c *changeBuilder) Build(payload BuilderContent) (ch *Change, rawIdChange *
 		DataType:    change.DataType,
 		IsSnapshot:  change.IsSnapshot,
 	}
+	setDeviceKey(ch, payload.PrivKey)
 	rawIdChange = &treechangeproto.RawTreeChangeWithId{
 		RawChange: marshalledRawChange,
 		Id:        id,
@@ -286,15 +301,16 @@ func (c *changeBuilder) Marshall(ch *Change) (raw *treechangeproto.RawTreeChange
 		return
 	}
 	treeChange := &treechangeproto.TreeChange{
-		TreeHeadIds:    ch.PreviousIds,
-		AclHeadId:      ch.AclHeadId,
-		SnapshotBaseId: ch.SnapshotId,
-		ChangesData:    ch.Data,
-		ReadKeyId:      ch.ReadKeyId,
-		Timestamp:      ch.Timestamp,
-		Identity:       identity,
-		IsSnapshot:     ch.IsSnapshot,
-		DataType:       ch.DataType,
+		TreeHeadIds:       ch.PreviousIds,
+		AclHeadId:         ch.AclHeadId,
+		SnapshotBaseId:    ch.SnapshotId,
+		ChangesData:       ch.Data,
+		ReadKeyId:         ch.ReadKeyId,
+		Timestamp:         ch.Timestamp,
+		Identity:          identity,
+		IsSnapshot:        ch.IsSnapshot,
+		DataType:          ch.DataType,
+		DeviceCertificate: ch.DeviceCertificate,
 	}
 	var marshalled []byte
 	marshalled, err = treeChange.Marshal()
@@ -334,6 +350,11 @@ func (c *changeBuilder) unmarshallRawChange(raw *treechangeproto.RawTreeChange,
 			}
 		}
 		ch = NewChangeFromRoot(id, key, unmarshalled, raw.Signature, unmarshalled.IsDerived)
+		ch.ObjectAcl, err = c.unmarshallObjectAcl(unmarshalled.ObjectAcl)
+		if err != nil {
+			return
+		}
+		err = unmarshallDeviceKey(ch, unmarshalled.DeviceCertificate)
 		return
 	}
 	if !c.hasData {
@@ -358,6 +379,7 @@ func (c *changeBuilder) unmarshallRawChange(raw *treechangeproto.RawTreeChange,
 			DataType:    change.DataType,
 			IsSnapshot:  change.IsSnapshot,
 		}
+		err = unmarshallDeviceKey(ch, change.DeviceCertificate)
 	} else {
 		change := &treechangeproto.TreeChange{}
 		err = proto.Unmarshal(raw.Payload, change)
@@ -381,6 +403,7 @@ func (c *changeBuilder) unmarshallRawChange(raw *treechangeproto.RawTreeChange,
 			DataType:    change.DataType,
 			IsSnapshot:  change.IsSnapshot,
 		}
+		err = unmarshallDeviceKey(ch, change.DeviceCertificate)
 	}
 	return
 }
@@ -401,6 +424,11 @@ func (c *changeBuilder) unmarshallReducedRawChange(raw *treechangeproto.RawTreeC
 			}
 		}
 		ch = NewChangeFromRoot(id, key, unmarshalled, raw.Signature, unmarshalled.IsDerived)
+		ch.ObjectAcl, err = c.unmarshallObjectAcl(unmarshalled.ObjectAcl)
+		if err != nil {
+			return
+		}
+		err = unmarshallDeviceKey(ch, unmarshalled.DeviceCertificate)
 		return
 	}
 	unmarshalled := &treechangeproto.ReducedTreeChange{}
@@ -415,6 +443,27 @@ func (c *changeBuilder) unmarshallReducedRawChange(raw *treechangeproto.RawTreeC
 	return
 }
 
+// setDeviceKey sets the device key of the built change if the key signs on behalf of the identity
+func setDeviceKey(ch *Change, key crypto.PrivKey) {
+	if deviceSigner, ok := key.(crypto.DeviceSigner); ok {
+		ch.DeviceKey = deviceSigner.DeviceCertificate().DeviceKey
+		ch.DeviceCertificate = deviceSigner.DeviceCertificate().Marshall()
+	}
+}
+
+// unmarshallDeviceKey checks that the certificate of the change is issued by its identity and sets the device key
+func unmarshallDeviceKey(ch *Change, deviceCert []byte) (err error) {
+	if len(deviceCert) == 0 {
+		return
+	}
+	ch.DeviceKey, err = crypto.DeviceKeyFromCertificate(ch.Identity, deviceCert)
+	if err != nil {
+		return ErrIncorrectSignature
+	}
+	ch.DeviceCertificate = deviceCert
+	return
+}
+
 func (c *changeBuilder) isRoot(id string) bool {
 	if c.rootChange != nil {
 		return c.rootChange.Id == id
@@ -422,6 +471,36 @@ func (c *changeBuilder) isRoot(id string) bool {
 	return false
 }
 
+func (c *changeBuilder) unmarshallObjectAcl(objectAcl *treechangeproto.ObjectAcl) (*list.ObjectAcl, error) {
+	if objectAcl == nil {
+		return nil, nil
+	}
+	writers := make([]crypto.PubKey, 0, len(objectAcl.Writers))
+	for _, w := range objectAcl.Writers {
+		key, err := c.keys.PubKeyFromProto(w)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, key)
+	}
+	return list.NewObjectAcl(writers), nil
+}
+
+func marshallObjectAcl(objectAcl *list.ObjectAcl) (*treechangeproto.ObjectAcl, error) {
+	if objectAcl == nil {
+		return nil, nil
+	}
+	writers := make([][]byte, 0, len(objectAcl.Writers()))
+	for _, w := range objectAcl.Writers() {
+		protoKey, err := w.Marshall()
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, protoKey)
+	}
+	return &treechangeproto.ObjectAcl{Writers: writers}, nil
+}
+
 func UnmarshallRoot(rawRoot *treechangeproto.RawTreeChangeWithId) (root *treechangeproto.RootChange, err error) {
 	raw := &treechangeproto.RawTreeChange{}
 	err = proto.Unmarshal(rawRoot.GetRawChange(), raw)
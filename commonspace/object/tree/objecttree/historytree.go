@@ -3,12 +3,20 @@ package objecttree
 import (
 	"context"
 	"errors"
+	"fmt"
+
+	anystore "github.com/anyproto/any-store"
 )
 
-var ErrLoadBeforeRoot = errors.New("can't load before root")
+var (
+	ErrLoadBeforeRoot = errors.New("can't load before root")
+	ErrHistoryPruned  = errors.New("history is pruned")
+)
 
 type HistoryTree interface {
 	ReadableObjectTree
+	// PrunedRange returns the part of the history which was removed from the storage
+	PrunedRange() (PrunedRange, error)
 }
 
 type historyTree struct {
@@ -27,7 +35,19 @@ func (h *historyTree) rebuildFromStorage(params HistoryTreeParams) (err error) {
 	return h.readKeysFromAclState(state)
 }
 
+func (h *historyTree) PrunedRange() (PrunedRange, error) {
+	return h.storage.PrunedRange(context.Background())
+}
+
 func (h *historyTree) rebuild(params HistoryTreeParams) (err error) {
+	defer func() {
+		if err != nil && errors.Is(err, anystore.ErrDocNotFound) {
+			// the requested changes may be removed by the pruning
+			if pruned, prunedErr := h.PrunedRange(); prunedErr == nil && !pruned.IsEmpty() {
+				err = fmt.Errorf("%w: %w", ErrHistoryPruned, err)
+			}
+		}
+	}()
 	switch len(params.Heads) {
 	case 0:
 		h.tree, err = h.treeBuilder.BuildFull()
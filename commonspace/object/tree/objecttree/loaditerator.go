@@ -26,6 +26,39 @@ type IteratorBatch struct {
 	Heads        []string
 	SnapshotPath []string
 	Root         *treechangeproto.RawTreeChangeWithId
+	// Cursor points to the last change of the batch, the loading can be resumed from it later
+	Cursor SyncCursor
+	// Sent is the number of changes returned so far including this batch
+	Sent int
+	// Total is the number of changes the iterator returns
+	Total int
+}
+
+// SyncCursor is a position in the storage of the peer which sends the changes,
+// it is only meaningful for the peer which produced it
+type SyncCursor struct {
+	ChangeId string
+	OrderId  string
+}
+
+func (c SyncCursor) IsEmpty() bool {
+	return c.OrderId == ""
+}
+
+// SyncProgress is the progress of the full sync with a peer
+type SyncProgress struct {
+	// Received is the number of changes received from the peer
+	Received int
+	// Total is the number of changes the peer needs to send
+	Total int
+}
+
+// Percent returns the progress in percents, the finished sync is always 100
+func (p SyncProgress) Percent() float64 {
+	if p.Total == 0 || p.Received >= p.Total {
+		return 100
+	}
+	return float64(p.Received) * 100 / float64(p.Total)
 }
 
 type loadIterator struct {
@@ -38,6 +71,9 @@ type loadIterator struct {
 	snapshotPath []string
 	orderId      string
 	root         *treechangeproto.RawTreeChangeWithId
+	cursor       SyncCursor
+	sent         int
+	total        int
 	isExhausted  bool
 }
 
@@ -54,6 +90,11 @@ func newLoadIterator(root *treechangeproto.RawTreeChangeWithId, snapshotPath []s
 func (l *loadIterator) NextBatch(maxSize int) (batch IteratorBatch, err error) {
 	batch.Root = l.root
 	batch.SnapshotPath = l.snapshotPath
+	defer func() {
+		batch.Cursor = l.cursor
+		batch.Sent = l.sent
+		batch.Total = l.total
+	}()
 	var curSize int
 	if l.isExhausted {
 		return
@@ -87,6 +128,8 @@ func (l *loadIterator) NextBatch(maxSize int) (batch IteratorBatch, err error) {
 			RawChange: cp,
 			Id:        c.Id,
 		})
+		l.cursor = SyncCursor{ChangeId: c.Id, OrderId: c.OrderId}
+		l.sent++
 		batch.Heads = slice.DiscardFromSlice(batch.Heads, func(s string) bool {
 			return slices.Contains(c.PrevIds, s)
 		})
@@ -103,12 +146,31 @@ func (l *loadIterator) NextBatch(maxSize int) (batch IteratorBatch, err error) {
 	return
 }
 
-func (l *loadIterator) load(commonSnapshot string, heads, breakpoints []string) (err error) {
+func (l *loadIterator) load(commonSnapshot string, heads, breakpoints []string, cursor SyncCursor) (err error) {
 	ctx := context.Background()
 	cs, err := l.storage.Get(ctx, commonSnapshot)
 	if err != nil {
 		return
 	}
+	// the changes between the common snapshot and the pruned snapshot are not in the storage anymore,
+	// the other side can't attach the rest of the history without them
+	pruned, err := l.storage.PrunedRange(ctx)
+	if err != nil {
+		return
+	}
+	if cs.OrderId < pruned.OrderId {
+		return fmt.Errorf("%w: snapshot %s is before %s", ErrHistoryPruned, commonSnapshot, pruned.SnapshotId)
+	}
+	// the cursor change and everything before it is already known to the other side,
+	// so we can start from it if it is still valid and comes after the common snapshot,
+	// the changes ordered before the cursor after it was sent are picked up by the next full sync
+	if !cursor.IsEmpty() && cursor.OrderId > cs.OrderId {
+		cursorChange, err := l.storage.Get(ctx, cursor.ChangeId)
+		if err == nil && cursorChange.OrderId == cursor.OrderId {
+			cs = cursorChange
+			breakpoints = append(breakpoints, cursorChange.Id)
+		}
+	}
 	rawCh := &treechangeproto.RawTreeChangeWithId{}
 	err = l.storage.GetAfterOrder(ctx, cs.OrderId, func(ctx context.Context, change StorageChange) (shouldContinue bool, err error) {
 		rawCh.Id = change.Id
@@ -186,6 +248,11 @@ func (l *loadIterator) load(commonSnapshot string, heads, breakpoints []string)
 	if err != nil {
 		return
 	}
+	for _, entry := range l.cache {
+		if !entry.removed {
+			l.total++
+		}
+	}
 	l.orderId = cs.OrderId
 	l.lastHeads = []string{cs.Id}
 	return nil
@@ -145,6 +145,35 @@ func (mr *MockObjectTreeMockRecorder) ChangesAfterCommonSnapshotLoader(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesAfterCommonSnapshotLoader", reflect.TypeOf((*MockObjectTree)(nil).ChangesAfterCommonSnapshotLoader), arg0, arg1)
 }
 
+// ChangesAfterCursorLoader mocks base method.
+func (m *MockObjectTree) ChangesAfterCursorLoader(arg0, arg1 []string, arg2 objecttree.SyncCursor) (objecttree.LoadIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangesAfterCursorLoader", arg0, arg1, arg2)
+	ret0, _ := ret[0].(objecttree.LoadIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangesAfterCursorLoader indicates an expected call of ChangesAfterCursorLoader.
+func (mr *MockObjectTreeMockRecorder) ChangesAfterCursorLoader(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesAfterCursorLoader", reflect.TypeOf((*MockObjectTree)(nil).ChangesAfterCursorLoader), arg0, arg1, arg2)
+}
+
+// ChangesSinceSnapshot mocks base method.
+func (m *MockObjectTree) ChangesSinceSnapshot() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangesSinceSnapshot")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ChangesSinceSnapshot indicates an expected call of ChangesSinceSnapshot.
+func (mr *MockObjectTreeMockRecorder) ChangesSinceSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesSinceSnapshot", reflect.TypeOf((*MockObjectTree)(nil).ChangesSinceSnapshot))
+}
+
 // Close mocks base method.
 func (m *MockObjectTree) Close() error {
 	m.ctrl.T.Helper()
@@ -346,6 +375,21 @@ func (mr *MockObjectTreeMockRecorder) PrepareChange(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareChange", reflect.TypeOf((*MockObjectTree)(nil).PrepareChange), arg0)
 }
 
+// Prune mocks base method.
+func (m *MockObjectTree) Prune(arg0 context.Context, arg1 string, arg2 objecttree.SnapshotConfirmer) (objecttree.PrunedRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", arg0, arg1, arg2)
+	ret0, _ := ret[0].(objecttree.PrunedRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockObjectTreeMockRecorder) Prune(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockObjectTree)(nil).Prune), arg0, arg1, arg2)
+}
+
 // Root mocks base method.
 func (m *MockObjectTree) Root() *objecttree.Change {
 	m.ctrl.T.Helper()
@@ -638,6 +682,36 @@ func (mr *MockStorageMockRecorder) Id() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Id", reflect.TypeOf((*MockStorage)(nil).Id))
 }
 
+// Prune mocks base method.
+func (m *MockStorage) Prune(arg0 context.Context, arg1 string) (objecttree.PrunedRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", arg0, arg1)
+	ret0, _ := ret[0].(objecttree.PrunedRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockStorageMockRecorder) Prune(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockStorage)(nil).Prune), arg0, arg1)
+}
+
+// PrunedRange mocks base method.
+func (m *MockStorage) PrunedRange(arg0 context.Context) (objecttree.PrunedRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrunedRange", arg0)
+	ret0, _ := ret[0].(objecttree.PrunedRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrunedRange indicates an expected call of PrunedRange.
+func (mr *MockStorageMockRecorder) PrunedRange(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrunedRange", reflect.TypeOf((*MockStorage)(nil).PrunedRange), arg0)
+}
+
 // Root mocks base method.
 func (m *MockStorage) Root(arg0 context.Context) (objecttree.StorageChange, error) {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -26,6 +27,9 @@ var (
 	ErrDerived           = errors.New("expect >= 2 changes in derived tree")
 	ErrDeleted           = errors.New("object tree is deleted")
 	ErrNoAclHead         = errors.New("no acl head")
+
+	ErrSnapshotNotInPath    = errors.New("snapshot is not in the snapshot path of the tree")
+	ErrSnapshotNotConfirmed = errors.New("snapshot is not confirmed by the responsible nodes")
 )
 
 type (
@@ -60,6 +64,11 @@ type RawChangesPayload struct {
 type ChangeIterateFunc = func(change *Change) bool
 type ChangeConvertFunc = func(change *Change, decrypted []byte) (any, error)
 
+// SnapshotConfirmer checks that all the nodes responsible for the tree have the snapshot
+type SnapshotConfirmer interface {
+	ConfirmSnapshot(ctx context.Context, treeId, snapshotId string) (ok bool, err error)
+}
+
 type TryLocker interface {
 	sync.Locker
 	TryLock() bool
@@ -75,6 +84,8 @@ type ReadableObjectTree interface {
 	Heads() []string
 	Root() *Change
 	Len() int
+	// ChangesSinceSnapshot returns the number of the changes after the latest snapshot of the tree
+	ChangesSinceSnapshot() int
 	IsDerived() bool
 
 	AclList() list.AclList
@@ -91,9 +102,16 @@ type ObjectTree interface {
 	ReadableObjectTree
 
 	SnapshotPath() ([]string, error)
+	// ChangesAfterCommonSnapshotLoader returns ErrHistoryPruned if the common snapshot was pruned
 	ChangesAfterCommonSnapshotLoader(snapshotPath, heads []string) (LoadIterator, error)
+	// ChangesAfterCursorLoader is the same as ChangesAfterCommonSnapshotLoader,
+	// but skips the changes up to the cursor returned in one of the previous batches
+	ChangesAfterCursorLoader(snapshotPath, heads []string, cursor SyncCursor) (LoadIterator, error)
 
 	Storage() Storage
+	// Prune removes the changes ordered before the snapshot from the storage.
+	// The snapshot should be in the snapshot path of the tree and should be confirmed by the confirmer
+	Prune(ctx context.Context, snapshotId string, confirmer SnapshotConfirmer) (PrunedRange, error)
 
 	AddContent(ctx context.Context, content SignableChangeContent) (AddResult, error)
 	AddContentWithValidator(ctx context.Context, content SignableChangeContent, validate ChangeValidator) (AddResult, error)
@@ -193,6 +211,10 @@ func (ot *objectTree) Len() int {
 	return ot.tree.Len()
 }
 
+func (ot *objectTree) ChangesSinceSnapshot() int {
+	return ot.tree.ChangesSinceSnapshot()
+}
+
 func (ot *objectTree) AclList() list.AclList {
 	return ot.aclList
 }
@@ -217,6 +239,36 @@ func (ot *objectTree) Storage() Storage {
 	return ot.storage
 }
 
+func (ot *objectTree) Prune(ctx context.Context, snapshotId string, confirmer SnapshotConfirmer) (pruned PrunedRange, err error) {
+	if ot.isDeleted {
+		return pruned, ErrDeleted
+	}
+	ot.logUseWhenUnlocked()
+	path, err := ot.SnapshotPath()
+	if err != nil {
+		return
+	}
+	if !slices.Contains(path, snapshotId) {
+		return pruned, ErrSnapshotNotInPath
+	}
+	if snapshotId == ot.id {
+		return ot.storage.PrunedRange(ctx)
+	}
+	ok, err := confirmer.ConfirmSnapshot(ctx, ot.id, snapshotId)
+	if err != nil {
+		return
+	}
+	if !ok {
+		return pruned, ErrSnapshotNotConfirmed
+	}
+	pruned, err = ot.storage.Prune(ctx, snapshotId)
+	if err != nil {
+		return
+	}
+	log.With("treeId", ot.id).With("snapshotId", snapshotId).With("count", pruned.Count).Debug("pruned tree history")
+	return
+}
+
 func (ot *objectTree) GetChange(id string) (*Change, error) {
 	if ot.isDeleted {
 		return nil, ErrDeleted
@@ -346,7 +398,7 @@ func (ot *objectTree) prepareBuilderContent(content SignableChangeContent) (cnt
 		pubKey    = content.Key.GetPublic()
 		readKeyId string
 	)
-	if !state.Permissions(pubKey).CanWrite() {
+	if !state.Capabilities(pubKey).Has(list.CapabilityWriteTrees) {
 		err = list.ErrInsufficientPermissions
 		return
 	}
@@ -775,6 +827,10 @@ func (ot *objectTree) SnapshotPath() ([]string, error) {
 }
 
 func (ot *objectTree) ChangesAfterCommonSnapshotLoader(theirPath, theirHeads []string) (LoadIterator, error) {
+	return ot.ChangesAfterCursorLoader(theirPath, theirHeads, SyncCursor{})
+}
+
+func (ot *objectTree) ChangesAfterCursorLoader(theirPath, theirHeads []string, cursor SyncCursor) (LoadIterator, error) {
 	if ot.isDeleted {
 		return nil, ErrDeleted
 	}
@@ -799,7 +855,7 @@ func (ot *objectTree) ChangesAfterCommonSnapshotLoader(theirPath, theirHeads []s
 	}
 
 	iter := newLoadIterator(ot.rawRoot, ourPath, ot.storage, ot.changeBuilder)
-	err = iter.load(commonSnapshot, ot.tree.headIds, theirHeads)
+	err = iter.load(commonSnapshot, ot.tree.headIds, theirHeads, cursor)
 	if err != nil {
 		return nil, err
 	}
@@ -833,10 +889,10 @@ func (ot *objectTree) validateTree(newChanges []*Change) error {
 		return err
 	}
 	if len(newChanges) == 0 {
-		return ot.validator.ValidateFullTree(ot.tree, ot.aclList)
+		return ot.validator.ValidateFullTree(ot.tree, ot.aclList, ot.root.ObjectAcl)
 	}
 
-	return ot.validator.ValidateNewChanges(ot.tree, ot.aclList, newChanges)
+	return ot.validator.ValidateNewChanges(ot.tree, ot.aclList, ot.root.ObjectAcl, newChanges)
 }
 
 func (ot *objectTree) readKeysFromAclState(state *list.AclState) (err error) {
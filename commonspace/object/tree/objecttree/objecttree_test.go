@@ -22,6 +22,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/util/crypto"
 )
 
 var ctx = context.Background()
@@ -359,6 +360,154 @@ func TestObjectTree(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("object acl restricts writers", func(t *testing.T) {
+		storeA := createNamedStore(ctx, t, "a")
+		exec := list.NewAclExecutor("spaceId")
+		cmds := []string{
+			"a.init::a",
+			"a.invite::invId",
+			"b.join::invId",
+			"a.approve::b,rw",
+			"c.join::invId",
+			"a.approve::c,rw",
+		}
+		for _, cmd := range cmds {
+			require.NoError(t, exec.Execute(cmd), cmd)
+		}
+		aAccount := exec.ActualAccounts()["a"]
+		bAccount := exec.ActualAccounts()["b"]
+		cAccount := exec.ActualAccounts()["c"]
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     aAccount.Keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+			ObjectAcl:   list.NewObjectAcl([]crypto.PubKey{bAccount.Keys.SignKey.GetPublic()}),
+		}, aAccount.Acl)
+		require.NoError(t, err)
+		aHeadsStorage, err := headstorage.New(ctx, storeA)
+		require.NoError(t, err)
+		aStore, err := CreateStorage(ctx, root, aHeadsStorage, storeA)
+		require.NoError(t, err)
+		aTree, err := BuildKeyFilterableObjectTree(aStore, aAccount.Acl)
+		require.NoError(t, err)
+		require.True(t, aTree.UnmarshalledHeader().ObjectAcl.IsWriter(bAccount.Keys.SignKey.GetPublic()))
+		require.False(t, aTree.UnmarshalledHeader().ObjectAcl.IsWriter(cAccount.Keys.SignKey.GetPublic()))
+		// the owner can always write
+		_, err = aTree.AddContent(ctx, SignableChangeContent{
+			Data:        []byte("some"),
+			Key:         aAccount.Keys.SignKey,
+			IsEncrypted: true,
+			DataType:    mockDataType,
+		})
+		require.NoError(t, err)
+
+		storeB := CopyStore(ctx, t, storeA.(TestStore), "b")
+		bHeadsStorage, err := headstorage.New(ctx, storeB)
+		require.NoError(t, err)
+		bStore, err := NewStorage(ctx, root.Id, bHeadsStorage, storeB)
+		require.NoError(t, err)
+		bTree, err := BuildKeyFilterableObjectTree(bStore, bAccount.Acl)
+		require.NoError(t, err)
+		_, err = bTree.AddContent(ctx, SignableChangeContent{
+			Data:        []byte("some"),
+			Key:         bAccount.Keys.SignKey,
+			IsEncrypted: true,
+			DataType:    mockDataType,
+		})
+		require.NoError(t, err)
+
+		storeC := CopyStore(ctx, t, storeA.(TestStore), "c")
+		cHeadsStorage, err := headstorage.New(ctx, storeC)
+		require.NoError(t, err)
+		cStore, err := NewStorage(ctx, root.Id, cHeadsStorage, storeC)
+		require.NoError(t, err)
+		cTree, err := BuildKeyFilterableObjectTree(cStore, cAccount.Acl)
+		require.NoError(t, err)
+		_, err = cTree.AddContent(ctx, SignableChangeContent{
+			Data:        []byte("some"),
+			Key:         cAccount.Keys.SignKey,
+			IsEncrypted: true,
+			DataType:    mockDataType,
+		})
+		require.ErrorIs(t, err, list.ErrInsufficientPermissions)
+	})
+
+	t.Run("device signs changes until it is revoked", func(t *testing.T) {
+		storeA := createNamedStore(ctx, t, "a")
+		exec := list.NewAclExecutor("spaceId")
+		cmds := []string{
+			"a.init::a",
+			"a.invite::invId",
+			"b.join::invId",
+			"a.approve::b,rw",
+		}
+		for _, cmd := range cmds {
+			require.NoError(t, exec.Execute(cmd), cmd)
+		}
+		aAccount := exec.ActualAccounts()["a"]
+		bAccount := exec.ActualAccounts()["b"]
+		deviceKey, devicePubKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		cert, err := crypto.NewDeviceCertificate(bAccount.Keys.SignKey, devicePubKey)
+		require.NoError(t, err)
+		deviceSignKey, err := crypto.NewDeviceSignKey(deviceKey, cert)
+		require.NoError(t, err)
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     aAccount.Keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aAccount.Acl)
+		require.NoError(t, err)
+		aHeadsStorage, err := headstorage.New(ctx, storeA)
+		require.NoError(t, err)
+		aStore, err := CreateStorage(ctx, root, aHeadsStorage, storeA)
+		require.NoError(t, err)
+		aTree, err := BuildKeyFilterableObjectTree(aStore, aAccount.Acl)
+		require.NoError(t, err)
+
+		storeB := CopyStore(ctx, t, storeA.(TestStore), "b")
+		bHeadsStorage, err := headstorage.New(ctx, storeB)
+		require.NoError(t, err)
+		bStore, err := NewStorage(ctx, root.Id, bHeadsStorage, storeB)
+		require.NoError(t, err)
+		bTree, err := BuildKeyFilterableObjectTree(bStore, bAccount.Acl)
+		require.NoError(t, err)
+		res, err := bTree.AddContent(ctx, SignableChangeContent{
+			Data:        []byte("some"),
+			Key:         deviceSignKey,
+			IsEncrypted: true,
+			DataType:    mockDataType,
+		})
+		require.NoError(t, err)
+
+		// the owner revokes the device, the change made before the revocation is still valid
+		rec, err := aAccount.Acl.RecordBuilder().BuildDeviceRevoke(list.DeviceRevokePayload{Certificate: cert})
+		require.NoError(t, err)
+		for _, acc := range exec.ActualAccounts() {
+			require.NoError(t, acc.Acl.AddRawRecord(list.WrapAclRecord(rec)))
+		}
+		_, err = aTree.AddRawChanges(ctx, RawChangesPayload{
+			NewHeads:   res.Heads,
+			RawChanges: res.RawChanges(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, res.Heads, aTree.Heads())
+		ch, err := aTree.GetChange(res.Heads[0])
+		require.NoError(t, err)
+		require.True(t, ch.Identity.Equals(bAccount.Keys.SignKey.GetPublic()))
+		require.True(t, ch.DeviceKey.Equals(devicePubKey))
+
+		_, err = bTree.AddContent(ctx, SignableChangeContent{
+			Data:        []byte("some"),
+			Key:         deviceSignKey,
+			IsEncrypted: true,
+			DataType:    mockDataType,
+		})
+		require.ErrorIs(t, err, list.ErrDeviceRevoked)
+	})
+
 	t.Run("reject root referring to unknown acl", func(t *testing.T) {
 		exec := list.NewAclExecutor("spaceId")
 		type cmdErr struct {
@@ -1721,6 +1870,66 @@ func TestObjectTree(t *testing.T) {
 		require.Equal(t, objTree.Heads(), otherTree.Heads())
 	})
 
+	t.Run("gen changes test load iterator resume from cursor", func(t *testing.T) {
+		ctx := prepareTreeContext(t, aclList)
+		changeCreator := ctx.changeCreator
+		objTree := ctx.objTree
+		result := genChanges(changeCreator, genParams{
+			prefix:     "id",
+			aclId:      aclList.Id(),
+			startIdx:   0,
+			levels:     100,
+			perLevel:   10,
+			snapshotId: objTree.Root().Id,
+			prevHeads:  []string{objTree.Root().Id},
+			isSnapshot: func() bool {
+				return false
+			},
+			hasData: false,
+		})
+		_, err := objTree.AddRawChanges(context.Background(), RawChangesPayload{
+			NewHeads:   result.heads,
+			RawChanges: result.changes,
+		})
+		require.NoError(t, err)
+		iter, err := objTree.ChangesAfterCommonSnapshotLoader([]string{objTree.Id()}, []string{objTree.Id()})
+		require.NoError(t, err)
+		otherTreeStorage := changeCreator.CreateNewTreeStorage(t, "0", aclList.Head().Id, false)
+		otherTree, err := BuildTestableTree(otherTreeStorage, aclList)
+		require.NoError(t, err)
+		// receiving only the first batch and then resuming with a new iterator
+		batch, err := iter.NextBatch(300)
+		require.NoError(t, err)
+		require.Equal(t, len(batch.Batch), batch.Sent)
+		require.Equal(t, len(result.changes), batch.Total)
+		require.False(t, batch.Cursor.IsEmpty())
+		_, err = otherTree.AddRawChanges(context.Background(), RawChangesPayload{
+			NewHeads:   batch.Heads,
+			RawChanges: batch.Batch,
+		})
+		require.NoError(t, err)
+		sent := batch.Sent
+		otherPath, err := otherTree.SnapshotPath()
+		require.NoError(t, err)
+		iter, err = objTree.ChangesAfterCursorLoader(otherPath, otherTree.Heads(), batch.Cursor)
+		require.NoError(t, err)
+		for {
+			batch, err = iter.NextBatch(300)
+			require.NoError(t, err)
+			if len(batch.Batch) == 0 {
+				break
+			}
+			require.Equal(t, len(result.changes)-sent, batch.Total)
+			res, err := otherTree.AddRawChanges(context.Background(), RawChangesPayload{
+				NewHeads:   batch.Heads,
+				RawChanges: batch.Batch,
+			})
+			require.NoError(t, err)
+			require.Equal(t, len(batch.Batch), len(res.Added))
+		}
+		require.Equal(t, objTree.Heads(), otherTree.Heads())
+	})
+
 	t.Run("gen changes test load iterator each change exceed max size", func(t *testing.T) {
 		ctx := prepareTreeContext(t, aclList)
 		changeCreator := ctx.changeCreator
@@ -1879,3 +2088,224 @@ func TestObjectTree(t *testing.T) {
 		require.Equal(t, objTree.Heads(), otherTree.Heads())
 	})
 }
+
+type testSnapshotConfirmer struct {
+	confirmed bool
+}
+
+func (c testSnapshotConfirmer) ConfirmSnapshot(ctx context.Context, treeId, snapshotId string) (bool, error) {
+	return c.confirmed, nil
+}
+
+func TestObjectTree_Prune(t *testing.T) {
+	aclList, _ := prepareAclList(t)
+	prune := func(objTree ObjectTree, snapshotId string, confirmed bool) (PrunedRange, error) {
+		objTree.Lock()
+		defer objTree.Unlock()
+		return objTree.Prune(ctx, snapshotId, testSnapshotConfirmer{confirmed: confirmed})
+	}
+	preparePrunedTree := func(t *testing.T) (objectTreeDeps, ObjectTree) {
+		changeCreator, deps := prepareHistoryTreeDeps(t, aclList)
+		// sequence of snapshots: 3->1->0
+		rawChanges := []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("1", aclList.Head().Id, "0", true, "0"),
+			changeCreator.CreateRaw("2", aclList.Head().Id, "1", false, "1"),
+			changeCreator.CreateRaw("3", aclList.Head().Id, "1", true, "2"),
+			changeCreator.CreateRaw("4", aclList.Head().Id, "3", false, "3"),
+		}
+		objTree, err := BuildTestableTree(deps.storage, deps.aclList)
+		require.NoError(t, err)
+		_, err = objTree.AddRawChanges(ctx, RawChangesPayload{
+			NewHeads:   []string{"4"},
+			RawChanges: rawChanges,
+		})
+		require.NoError(t, err)
+		return deps, objTree
+	}
+
+	t.Run("prune before common snapshot", func(t *testing.T) {
+		deps, objTree := preparePrunedTree(t)
+		pruned, err := prune(objTree, "3", true)
+		require.NoError(t, err)
+		assert.Equal(t, "3", pruned.SnapshotId)
+		assert.Equal(t, 1, pruned.Count)
+
+		for id, exists := range map[string]bool{"0": true, "1": true, "2": false, "3": true, "4": true} {
+			has, err := deps.storage.Has(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, exists, has, id)
+		}
+
+		st := deps.storage.(*testStorage).Storage.(*storage)
+		reopened, err := NewStorage(ctx, st.id, st.headStorage, st.store)
+		require.NoError(t, err)
+		reopenedRange, err := reopened.PrunedRange(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, pruned, reopenedRange)
+
+		objTree, err = BuildTestableTree(reopened, deps.aclList)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"4"}, objTree.Heads())
+		path, err := objTree.SnapshotPath()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3", "1", "0"}, path)
+	})
+	t.Run("concurrent branches are kept", func(t *testing.T) {
+		changeCreator, deps := prepareHistoryTreeDeps(t, aclList)
+		rawChanges := []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("1", aclList.Head().Id, "0", true, "0"),
+			changeCreator.CreateRaw("2", aclList.Head().Id, "1", false, "1"),
+			changeCreator.CreateRaw("3", aclList.Head().Id, "1", false, "2"),
+			changeCreator.CreateRaw("4", aclList.Head().Id, "1", true, "3"),
+			changeCreator.CreateRaw("5", aclList.Head().Id, "1", false, "2"),
+			changeCreator.CreateRaw("6", aclList.Head().Id, "1", false, "1"),
+		}
+		objTree, err := BuildTestableTree(deps.storage, deps.aclList)
+		require.NoError(t, err)
+		_, err = objTree.AddRawChanges(ctx, RawChangesPayload{
+			NewHeads:   []string{"4", "5", "6"},
+			RawChanges: rawChanges,
+		})
+		require.NoError(t, err)
+
+		pruned, err := deps.storage.Prune(ctx, "4")
+		require.NoError(t, err)
+		assert.Equal(t, 1, pruned.Count)
+		for id, exists := range map[string]bool{"0": true, "1": true, "2": true, "3": false, "4": true, "5": true, "6": true} {
+			has, err := deps.storage.Has(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, exists, has, id)
+		}
+	})
+	t.Run("new peer syncs after prune", func(t *testing.T) {
+		_, objTree := preparePrunedTree(t)
+		_, err := prune(objTree, "3", true)
+		require.NoError(t, err)
+
+		// the peer without the pruned history can't get it from the tree
+		for _, theirPath := range [][]string{nil, {"0"}, {"1", "0"}} {
+			_, err = objTree.ChangesAfterCommonSnapshotLoader(theirPath, []string{"0"})
+			require.ErrorIs(t, err, ErrHistoryPruned)
+		}
+
+		// the peer which has the pruned snapshot gets the rest of the history
+		changeCreator, peerDeps := prepareHistoryTreeDeps(t, aclList)
+		peerTree, err := BuildTestableTree(peerDeps.storage, peerDeps.aclList)
+		require.NoError(t, err)
+		_, err = peerTree.AddRawChanges(ctx, RawChangesPayload{
+			NewHeads: []string{"3"},
+			RawChanges: []*treechangeproto.RawTreeChangeWithId{
+				changeCreator.CreateRaw("1", aclList.Head().Id, "0", true, "0"),
+				changeCreator.CreateRaw("2", aclList.Head().Id, "1", false, "1"),
+				changeCreator.CreateRaw("3", aclList.Head().Id, "1", true, "2"),
+			},
+		})
+		require.NoError(t, err)
+		peerPath, err := peerTree.SnapshotPath()
+		require.NoError(t, err)
+		loader, err := objTree.ChangesAfterCommonSnapshotLoader(peerPath, peerTree.Heads())
+		require.NoError(t, err)
+		batch, err := loader.NextBatch(10 * 1024 * 1024)
+		require.NoError(t, err)
+		_, err = peerTree.AddRawChanges(ctx, RawChangesPayload{
+			NewHeads:     batch.Heads,
+			RawChanges:   batch.Batch,
+			SnapshotPath: batch.SnapshotPath,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"4"}, peerTree.Heads())
+	})
+	t.Run("snapshot is not confirmed", func(t *testing.T) {
+		deps, objTree := preparePrunedTree(t)
+		_, err := prune(objTree, "3", false)
+		require.ErrorIs(t, err, ErrSnapshotNotConfirmed)
+		has, err := deps.storage.Has(ctx, "2")
+		require.NoError(t, err)
+		assert.True(t, has)
+	})
+	t.Run("change is not a common snapshot", func(t *testing.T) {
+		_, objTree := preparePrunedTree(t)
+		_, err := prune(objTree, "2", true)
+		require.ErrorIs(t, err, ErrSnapshotNotInPath)
+	})
+	t.Run("history tree", func(t *testing.T) {
+		deps, objTree := preparePrunedTree(t)
+		_, err := prune(objTree, "3", true)
+		require.NoError(t, err)
+
+		hTree, err := buildHistoryTree(deps, HistoryTreeParams{})
+		require.NoError(t, err)
+		var iterChangesId []string
+		err = hTree.IterateFrom(hTree.Root().Id, nil, func(change *Change) bool {
+			iterChangesId = append(iterChangesId, change.Id)
+			return true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3", "4"}, iterChangesId)
+		pruned, err := hTree.PrunedRange()
+		require.NoError(t, err)
+		assert.Equal(t, "3", pruned.SnapshotId)
+
+		_, err = buildHistoryTree(deps, HistoryTreeParams{Heads: []string{"2"}, IncludeBeforeId: true})
+		require.ErrorIs(t, err, ErrHistoryPruned)
+		_, err = buildHistoryTree(deps, HistoryTreeParams{Heads: []string{"1"}, IncludeBeforeId: true})
+		require.ErrorIs(t, err, ErrHistoryPruned)
+		_, err = buildHistoryTree(deps, HistoryTreeParams{Heads: []string{"4"}, IncludeBeforeId: true})
+		require.NoError(t, err)
+	})
+}
+
+func TestObjectTree_ChangesSinceSnapshot(t *testing.T) {
+	aclList, _ := prepareAclList(t)
+	changeCreator, deps := prepareHistoryTreeDeps(t, aclList)
+	objTree, err := BuildTestableTree(deps.storage, deps.aclList)
+	require.NoError(t, err)
+	assert.Equal(t, 0, objTree.ChangesSinceSnapshot())
+	_, err = objTree.AddRawChanges(ctx, RawChangesPayload{
+		NewHeads: []string{"3"},
+		RawChanges: []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("1", aclList.Head().Id, "0", false, "0"),
+			changeCreator.CreateRaw("2", aclList.Head().Id, "0", false, "1"),
+			changeCreator.CreateRaw("3", aclList.Head().Id, "0", false, "2"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, objTree.ChangesSinceSnapshot())
+
+	_, err = objTree.AddRawChanges(ctx, RawChangesPayload{
+		NewHeads: []string{"5"},
+		RawChanges: []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("4", aclList.Head().Id, "0", true, "3"),
+			changeCreator.CreateRaw("5", aclList.Head().Id, "4", false, "4"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, objTree.ChangesSinceSnapshot())
+
+	// the concurrent change moves the tree back to the common snapshot, but the counter doesn't start over
+	_, err = objTree.AddRawChanges(ctx, RawChangesPayload{
+		NewHeads: []string{"6"},
+		RawChanges: []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("6", aclList.Head().Id, "0", false, "3"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5", "6"}, objTree.Heads())
+	assert.Equal(t, 7, objTree.Len())
+	assert.Equal(t, 1, objTree.ChangesSinceSnapshot())
+	assert.False(t, NewSnapshotPolicies(nil).DoSnapshot(objTree))
+	assert.True(t, SnapshotPoliciesFromIntervals(map[string]int{objTree.ChangeInfo().ChangeType: 1}).DoSnapshot(objTree))
+}
+
+func TestSnapshotPolicies(t *testing.T) {
+	policies := NewSnapshotPolicies(map[string]SnapshotPolicy{
+		"often": {Interval: 10},
+		"never": {},
+	})
+	assert.True(t, policies.Policy("often").DoSnapshot(10))
+	assert.False(t, policies.Policy("often").DoSnapshot(9))
+	assert.False(t, policies.Policy("never").DoSnapshot(1000))
+	assert.Equal(t, DefaultSnapshotPolicy, policies.Policy("other"))
+	assert.True(t, DoSnapshot(DefaultSnapshotPolicy.Interval))
+	assert.False(t, DoSnapshot(DefaultSnapshotPolicy.Interval-1))
+}
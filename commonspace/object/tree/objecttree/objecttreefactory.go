@@ -17,6 +17,8 @@ type ObjectTreeCreatePayload struct {
 	IsEncrypted   bool
 	Seed          []byte
 	Timestamp     int64
+	// ObjectAcl optionally restricts which space writers can write to the tree
+	ObjectAcl *list.ObjectAcl
 }
 
 type ObjectTreeDerivePayload struct {
@@ -223,6 +225,7 @@ func CreateObjectTreeRoot(payload ObjectTreeCreatePayload, aclList list.AclList)
 		ChangePayload: payload.ChangePayload,
 		Timestamp:     payload.Timestamp,
 		Seed:          payload.Seed,
+		ObjectAcl:     payload.ObjectAcl,
 	}
 
 	_, root, err = NewChangeBuilder(crypto.NewKeyStorage(), nil).BuildRoot(cnt)
@@ -255,11 +258,6 @@ func buildObjectTree(deps objectTreeDeps) (ObjectTree, error) {
 		flusher:         deps.flusher,
 	}
 
-	err := objTree.rebuildFromStorage(nil, nil, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to rebuild from storage: %w", err)
-	}
-
 	// TODO: think about contexts
 	root, err := objTree.storage.Root(context.Background())
 	if err != nil {
@@ -267,13 +265,17 @@ func buildObjectTree(deps objectTreeDeps) (ObjectTree, error) {
 	}
 	objTree.rawRoot = root.RawTreeChangeWithId()
 
-	// verifying root
+	// verifying root, we need it before the validation because it holds the object acl
 	header, err := objTree.changeBuilder.Unmarshall(objTree.rawRoot, true)
 	if err != nil {
 		return nil, err
 	}
 	objTree.root = header
 
+	err = objTree.rebuildFromStorage(nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild from storage: %w", err)
+	}
 	return objTree, nil
 }
 
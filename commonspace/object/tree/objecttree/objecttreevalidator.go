@@ -32,9 +32,9 @@ type ValidatorFunc func(payload treestorage.TreeStorageCreatePayload, storageCre
 
 type ObjectTreeValidator interface {
 	// ValidateFullTree should always be entered while holding a read lock on AclList
-	ValidateFullTree(tree *Tree, aclList list.AclList) error
+	ValidateFullTree(tree *Tree, aclList list.AclList, objectAcl *list.ObjectAcl) error
 	// ValidateNewChanges should always be entered while holding a read lock on AclList
-	ValidateNewChanges(tree *Tree, aclList list.AclList, newChanges []*Change) error
+	ValidateNewChanges(tree *Tree, aclList list.AclList, objectAcl *list.ObjectAcl, newChanges []*Change) error
 	FilterChanges(aclList list.AclList, changes []*Change, snapshots []*Change, indexes []int) (filteredHeads bool, filtered, filteredSnapshots []*Change, newIndexes []int)
 }
 
@@ -43,14 +43,14 @@ type noOpTreeValidator struct {
 	fail       bool
 }
 
-func (n *noOpTreeValidator) ValidateFullTree(tree *Tree, aclList list.AclList) error {
+func (n *noOpTreeValidator) ValidateFullTree(tree *Tree, aclList list.AclList, objectAcl *list.ObjectAcl) error {
 	if n.fail {
 		return fmt.Errorf("failed")
 	}
 	return nil
 }
 
-func (n *noOpTreeValidator) ValidateNewChanges(tree *Tree, aclList list.AclList, newChanges []*Change) error {
+func (n *noOpTreeValidator) ValidateNewChanges(tree *Tree, aclList list.AclList, objectAcl *list.ObjectAcl, newChanges []*Change) error {
 	if n.fail {
 		return fmt.Errorf("failed")
 	}
@@ -88,17 +88,17 @@ func newTreeValidator(validateKeys bool, filterChanges bool) ObjectTreeValidator
 	}
 }
 
-func (v *objectTreeValidator) ValidateFullTree(tree *Tree, aclList list.AclList) (err error) {
+func (v *objectTreeValidator) ValidateFullTree(tree *Tree, aclList list.AclList, objectAcl *list.ObjectAcl) (err error) {
 	tree.IterateSkip(tree.RootId(), func(c *Change) (isContinue bool) {
-		err = v.validateChange(tree, aclList, c)
+		err = v.validateChange(tree, aclList, objectAcl, c)
 		return err == nil
 	})
 	return err
 }
 
-func (v *objectTreeValidator) ValidateNewChanges(tree *Tree, aclList list.AclList, newChanges []*Change) (err error) {
+func (v *objectTreeValidator) ValidateNewChanges(tree *Tree, aclList list.AclList, objectAcl *list.ObjectAcl, newChanges []*Change) (err error) {
 	for _, c := range newChanges {
-		err = v.validateChange(tree, aclList, c)
+		err = v.validateChange(tree, aclList, objectAcl, c)
 		if err != nil {
 			return
 		}
@@ -137,7 +137,7 @@ func (v *objectTreeValidator) FilterChanges(aclList list.AclList, changes []*Cha
 	return
 }
 
-func (v *objectTreeValidator) validateChange(tree *Tree, aclList list.AclList, c *Change) (err error) {
+func (v *objectTreeValidator) validateChange(tree *Tree, aclList list.AclList, objectAcl *list.ObjectAcl, c *Change) (err error) {
 	var (
 		perms list.AclPermissions
 		state = aclList.AclState()
@@ -145,12 +145,29 @@ func (v *objectTreeValidator) validateChange(tree *Tree, aclList list.AclList, c
 	if c.IsDerived {
 		return nil
 	}
+	// checking if the device signing on behalf of the user wasn't revoked
+	if c.DeviceKey != nil {
+		var revoked bool
+		revoked, err = state.IsDeviceRevokedAtRecord(c.AclHeadId, c.Identity, c.DeviceKey)
+		if err != nil {
+			return
+		}
+		if revoked {
+			return list.ErrDeviceRevoked
+		}
+	}
 	// checking if the user could write
 	perms, err = state.PermissionsAtRecord(c.AclHeadId, c.Identity)
 	if err != nil {
 		return
 	}
-	if !perms.CanWrite() {
+	capabilities := state.RoleCapabilities(perms)
+	if !capabilities.Has(list.CapabilityWriteTrees) {
+		err = list.ErrInsufficientPermissions
+		return
+	}
+	// the root change defines the object acl, so it is checked only against the space permissions
+	if c.PreviousIds != nil && !objectAcl.Capabilities(c.Identity, capabilities).Has(list.CapabilityWriteTrees) {
 		err = list.ErrInsufficientPermissions
 		return
 	}
@@ -0,0 +1,52 @@
+package objecttree
+
+// DefaultSnapshotPolicy is used for the change types without own policy
+var DefaultSnapshotPolicy = SnapshotPolicy{Interval: 300}
+
+// SnapshotPolicy decides when the next change of the tree should be a snapshot.
+// The decision depends only on the tree, so all the peers make the snapshots at the same points
+type SnapshotPolicy struct {
+	// Interval is the number of the changes since the last snapshot after which the next change is a snapshot,
+	// zero disables the snapshots
+	Interval int
+}
+
+// DoSnapshot checks whether the change following the given number of the changes since the last snapshot should be a snapshot
+func (p SnapshotPolicy) DoSnapshot(changesSinceSnapshot int) bool {
+	return p.Interval > 0 && changesSinceSnapshot >= p.Interval
+}
+
+// SnapshotPolicies keeps the snapshot policies by the change types of the trees
+type SnapshotPolicies struct {
+	Default     SnapshotPolicy
+	ChangeTypes map[string]SnapshotPolicy
+}
+
+func NewSnapshotPolicies(changeTypes map[string]SnapshotPolicy) SnapshotPolicies {
+	return SnapshotPolicies{
+		Default:     DefaultSnapshotPolicy,
+		ChangeTypes: changeTypes,
+	}
+}
+
+// SnapshotPoliciesFromIntervals creates the policies from the snapshot intervals by the change types
+func SnapshotPoliciesFromIntervals(intervals map[string]int) SnapshotPolicies {
+	changeTypes := make(map[string]SnapshotPolicy, len(intervals))
+	for changeType, interval := range intervals {
+		changeTypes[changeType] = SnapshotPolicy{Interval: interval}
+	}
+	return NewSnapshotPolicies(changeTypes)
+}
+
+// Policy returns the policy for the trees with the change type
+func (p SnapshotPolicies) Policy(changeType string) SnapshotPolicy {
+	if policy, ok := p.ChangeTypes[changeType]; ok {
+		return policy
+	}
+	return p.Default
+}
+
+// DoSnapshot checks whether the next change added to the tree should be a snapshot
+func (p SnapshotPolicies) DoSnapshot(tree ReadableObjectTree) bool {
+	return p.Policy(tree.ChangeInfo().ChangeType).DoSnapshot(tree.ChangesSinceSnapshot())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	anystore "github.com/anyproto/any-store"
@@ -28,6 +29,10 @@ const (
 	prevIdsKey         = "p"
 	TreeKey            = "t"
 	CollName           = "changes"
+
+	prunedSnapshotKey = "ps"
+	prunedOrderKey    = "po"
+	prunedCountKey    = "pc"
 )
 
 type StorageChange struct {
@@ -48,6 +53,22 @@ func (c StorageChange) RawTreeChangeWithId() *treechangeproto.RawTreeChangeWithI
 	}
 }
 
+// PrunedRange describes the changes removed from the storage by Prune.
+// The ancestors of the snapshot were removed except the root, the snapshots the snapshot is based on
+// and the previous changes of the concurrent branches
+type PrunedRange struct {
+	// SnapshotId is the snapshot the history of the tree starts from, it is empty if the tree was never pruned
+	SnapshotId string
+	// OrderId is the order of the snapshot
+	OrderId string
+	// Count is the total number of the removed changes
+	Count int
+}
+
+func (r PrunedRange) IsEmpty() bool {
+	return r.SnapshotId == ""
+}
+
 type StorageIterator = func(ctx context.Context, change StorageChange) (shouldContinue bool, err error)
 
 type Storage interface {
@@ -60,6 +81,9 @@ type Storage interface {
 	GetAfterOrder(ctx context.Context, orderId string, iter StorageIterator) error
 	AddAll(ctx context.Context, changes []StorageChange, heads []string, commonSnapshot string) error
 	AddAllNoError(ctx context.Context, changes []StorageChange, heads []string, commonSnapshot string) error
+	// Prune removes the ancestors of the snapshot, the snapshot should be common for all the peers
+	Prune(ctx context.Context, snapshotId string) (PrunedRange, error)
+	PrunedRange(ctx context.Context) (PrunedRange, error)
 	Delete(ctx context.Context) error
 	Close() error
 }
@@ -72,6 +96,7 @@ type storage struct {
 	arena       *anyenc.Arena
 	parser      *anyenc.Parser
 	root        StorageChange
+	pruned      PrunedRange
 }
 
 var StorageChangeBuilder = NewChangeBuilder
@@ -86,7 +111,7 @@ func CreateStorage(ctx context.Context, root *treechangeproto.RawTreeChangeWithI
 		tx.Rollback()
 		return nil, err
 	}
-	return storage, tx.Commit()
+	return storage, headStorage.CommitTx(tx)
 }
 
 func CreateStorageTx(ctx context.Context, root *treechangeproto.RawTreeChangeWithId, headStorage headstorage.HeadStorage, store anystore.DB) (Storage, error) {
@@ -158,13 +183,20 @@ func NewStorage(ctx context.Context, id string, headStorage headstorage.HeadStor
 	st.changesColl = changesColl
 	st.arena = &anyenc.Arena{}
 	st.parser = &anyenc.Parser{}
-	st.root, err = st.getWithoutParser(ctx, st.id)
+	// root will be reused outside the lock, so we shouldn't use parser for it
+	rootDoc, err := st.changesColl.FindId(ctx, st.id)
 	if err != nil {
 		if errors.Is(err, anystore.ErrDocNotFound) {
 			return nil, treestorage.ErrUnknownTreeId
 		}
 		return nil, err
 	}
+	st.root = st.changeFromDoc(rootDoc)
+	st.pruned = PrunedRange{
+		SnapshotId: rootDoc.Value().GetString(prunedSnapshotKey),
+		OrderId:    rootDoc.Value().GetString(prunedOrderKey),
+		Count:      rootDoc.Value().GetInt(prunedCountKey),
+	}
 	return st, nil
 }
 
@@ -225,7 +257,7 @@ func (s *storage) AddAll(ctx context.Context, changes []StorageChange, heads []s
 		if err != nil {
 			tx.Rollback()
 		} else {
-			err = tx.Commit()
+			err = s.headStorage.CommitTx(tx)
 		}
 	}()
 	for _, ch := range changes {
@@ -256,7 +288,7 @@ func (s *storage) AddAllNoError(ctx context.Context, changes []StorageChange, he
 		if err != nil {
 			tx.Rollback()
 		} else {
-			err = tx.Commit()
+			err = s.headStorage.CommitTx(tx)
 		}
 	}()
 	for _, ch := range changes {
@@ -276,6 +308,122 @@ func (s *storage) AddAllNoError(ctx context.Context, changes []StorageChange, he
 	return s.headStorage.UpdateEntryTx(tx.Context(), update)
 }
 
+func (s *storage) Prune(ctx context.Context, snapshotId string) (pruned PrunedRange, err error) {
+	snapshot, err := s.Get(ctx, snapshotId)
+	if err != nil {
+		return s.pruned, fmt.Errorf("failed to get snapshot %s: %w", snapshotId, err)
+	}
+	if snapshot.OrderId <= s.pruned.OrderId {
+		return s.pruned, nil
+	}
+	// the root and the chain of the snapshots are kept, because they are used to find the common snapshots
+	keep := map[string]struct{}{s.id: {}, snapshotId: {}}
+	for id := snapshot.SnapshotId; id != ""; {
+		keep[id] = struct{}{}
+		ch, err := s.Get(ctx, id)
+		if err != nil {
+			return s.pruned, fmt.Errorf("failed to get snapshot %s: %w", id, err)
+		}
+		id = ch.SnapshotId
+	}
+	tx, err := s.store.WriteTx(ctx)
+	if err != nil {
+		return s.pruned, fmt.Errorf("failed to create write tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else if err = tx.Commit(); err == nil {
+			s.pruned = pruned
+		}
+	}()
+	prevIds, err := s.prevIdsTx(tx.Context())
+	if err != nil {
+		return s.pruned, err
+	}
+	ids := pruneIds(prevIds, snapshot, keep)
+	for _, id := range ids {
+		if err = s.changesColl.DeleteId(tx.Context(), id); err != nil {
+			return s.pruned, err
+		}
+	}
+	pruned = PrunedRange{
+		SnapshotId: snapshotId,
+		OrderId:    snapshot.OrderId,
+		Count:      s.pruned.Count + len(ids),
+	}
+	mod := query.ModifyFunc(func(a *anyenc.Arena, v *anyenc.Value) (result *anyenc.Value, modified bool, err error) {
+		v.Set(prunedSnapshotKey, a.NewString(pruned.SnapshotId))
+		v.Set(prunedOrderKey, a.NewString(pruned.OrderId))
+		v.Set(prunedCountKey, a.NewNumberInt(pruned.Count))
+		return v, true, nil
+	})
+	if _, err = s.changesColl.UpdateId(tx.Context(), s.id, mod); err != nil {
+		return s.pruned, err
+	}
+	return pruned, nil
+}
+
+// prevIdsTx returns the previous ids of all the changes of the tree in the storage
+func (s *storage) prevIdsTx(ctx context.Context) (prevIds map[string][]string, err error) {
+	filter := query.Key{Path: []string{TreeKey}, Filter: query.NewComp(query.CompOpEq, s.id)}
+	iter, err := s.changesColl.Find(filter).Iter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find iter: %w", err)
+	}
+	defer iter.Close()
+	prevIds = map[string][]string{}
+	for iter.Next() {
+		doc, err := iter.Doc()
+		if err != nil {
+			return nil, fmt.Errorf("doc not found: %w", err)
+		}
+		prevIds[doc.Value().GetString(idKey)] = storeutil.StringsFromArrayValue(doc.Value(), prevIdsKey)
+	}
+	return prevIds, nil
+}
+
+// pruneIds returns the ancestors of the snapshot which can be removed. The concurrent branches are not
+// the ancestors, so they are kept, because they can be merged later. The ancestors which are the previous
+// changes of the kept changes are kept as well, so the branches stay attached to the tree
+func pruneIds(prevIds map[string][]string, snapshot StorageChange, keep map[string]struct{}) (ids []string) {
+	ancestors := map[string]struct{}{}
+	stack := slices.Clone(snapshot.PrevIds)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := ancestors[id]; ok {
+			continue
+		}
+		prev, ok := prevIds[id]
+		if !ok {
+			// the change was pruned before
+			continue
+		}
+		ancestors[id] = struct{}{}
+		stack = append(stack, prev...)
+	}
+	for id, prev := range prevIds {
+		if _, ok := ancestors[id]; ok || id == snapshot.Id {
+			continue
+		}
+		for _, prevId := range prev {
+			keep[prevId] = struct{}{}
+		}
+	}
+	for id := range ancestors {
+		if _, ok := keep[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (s *storage) PrunedRange(ctx context.Context) (PrunedRange, error) {
+	return s.pruned, nil
+}
+
 func (s *storage) Delete(ctx context.Context) error {
 	tx, err := s.store.WriteTx(ctx)
 	if err != nil {
@@ -310,15 +458,6 @@ func (s *storage) CommonSnapshot(ctx context.Context) (string, error) {
 	return entry.CommonSnapshot, nil
 }
 
-func (s *storage) getWithoutParser(ctx context.Context, id string) (StorageChange, error) {
-	// root will be reused outside the lock, so we shouldn't use parser for it
-	doc, err := s.changesColl.FindId(ctx, id)
-	if err != nil {
-		return StorageChange{}, err
-	}
-	return s.changeFromDoc(doc), nil
-}
-
 func (s *storage) Get(ctx context.Context, id string) (StorageChange, error) {
 	doc, err := s.changesColl.FindIdWithParser(ctx, s.parser, id)
 	if err != nil {
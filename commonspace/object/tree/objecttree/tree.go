@@ -529,6 +529,38 @@ func (t *Tree) Len() int {
 	return len(t.attached)
 }
 
+// ChangesSinceSnapshot returns the number of the changes added after the latest snapshot the heads are based on.
+// Unlike Len it doesn't depend on the snapshot the tree was loaded from
+func (t *Tree) ChangesSinceSnapshot() int {
+	if t.root == nil {
+		return 0
+	}
+	snapshot := t.root
+	for _, id := range t.headIds {
+		head := t.attached[id]
+		if head == nil {
+			continue
+		}
+		if !head.IsSnapshot {
+			head = t.attached[head.SnapshotId]
+		}
+		if head != nil && head.SnapshotCounter > snapshot.SnapshotCounter {
+			snapshot = head
+		}
+	}
+	if snapshot == t.root {
+		return len(t.attached) - 1
+	}
+	count := 0
+	t.iterate(snapshot, func(c *Change) bool {
+		if c != snapshot {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
 func (t *Tree) Heads() []string {
 	return t.headIds
 }
@@ -121,11 +121,22 @@ func (tb *treeBuilder) build(opts treeBuilderOpts) (tr *Tree, err error) {
 	} else {
 		snapshot = tb.storage.Id()
 	}
+	pruned, err := tb.storage.PrunedRange(tb.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.full && !pruned.IsEmpty() {
+		// the changes between the root and the pruned snapshot are not in the storage anymore
+		snapshot = pruned.SnapshotId
+	}
 	totalSnapshots.Store(totalSnapshots.Load() + 1)
 	snapshotCh, err := tb.storage.Get(tb.ctx, snapshot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get common snapshot %s: %w", snapshot, err)
 	}
+	if snapshotCh.OrderId < pruned.OrderId {
+		return nil, fmt.Errorf("%w: snapshot %s is before %s", ErrHistoryPruned, snapshot, pruned.SnapshotId)
+	}
 	rawChange := &treechangeproto.RawTreeChangeWithId{}
 	var changes []*Change
 	err = tb.storage.GetAfterOrder(tb.ctx, snapshotCh.OrderId, func(ctx context.Context, storageChange StorageChange) (shouldContinue bool, err error) {
@@ -3,7 +3,6 @@ package objecttree
 import (
 	"fmt"
 	"github.com/anyproto/any-sync/util/crypto"
-	"math/rand"
 )
 
 func commonSnapshotForTwoPaths(ourPath []string, theirPath []string) (string, error) {
@@ -42,15 +41,8 @@ func deriveTreeKey(key crypto.SymKey, cid string) (crypto.SymKey, error) {
 	return crypto.DeriveSymmetricKey(raw, fmt.Sprintf(crypto.AnysyncTreePath, cid))
 }
 
-func DoSnapshot(treeLen int) bool {
-	if treeLen <= 100 {
-		return false
-	}
-
-	var (
-		delta    = treeLen/50 + 1
-		midPoint = 1000
-		val      = rand.Intn(midPoint * 2)
-	)
-	return midPoint-delta <= val && val <= midPoint+delta
+// DoSnapshot checks with DefaultSnapshotPolicy whether the next change should be a snapshot,
+// changesSinceSnapshot is taken from ReadableObjectTree.ChangesSinceSnapshot
+func DoSnapshot(changesSinceSnapshot int) bool {
+	return DefaultSnapshotPolicy.DoSnapshot(changesSinceSnapshot)
 }
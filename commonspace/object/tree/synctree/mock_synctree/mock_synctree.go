@@ -168,6 +168,35 @@ func (mr *MockSyncTreeMockRecorder) ChangesAfterCommonSnapshotLoader(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesAfterCommonSnapshotLoader", reflect.TypeOf((*MockSyncTree)(nil).ChangesAfterCommonSnapshotLoader), arg0, arg1)
 }
 
+// ChangesAfterCursorLoader mocks base method.
+func (m *MockSyncTree) ChangesAfterCursorLoader(arg0, arg1 []string, arg2 objecttree.SyncCursor) (objecttree.LoadIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangesAfterCursorLoader", arg0, arg1, arg2)
+	ret0, _ := ret[0].(objecttree.LoadIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangesAfterCursorLoader indicates an expected call of ChangesAfterCursorLoader.
+func (mr *MockSyncTreeMockRecorder) ChangesAfterCursorLoader(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesAfterCursorLoader", reflect.TypeOf((*MockSyncTree)(nil).ChangesAfterCursorLoader), arg0, arg1, arg2)
+}
+
+// ChangesSinceSnapshot mocks base method.
+func (m *MockSyncTree) ChangesSinceSnapshot() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangesSinceSnapshot")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ChangesSinceSnapshot indicates an expected call of ChangesSinceSnapshot.
+func (mr *MockSyncTreeMockRecorder) ChangesSinceSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesSinceSnapshot", reflect.TypeOf((*MockSyncTree)(nil).ChangesSinceSnapshot))
+}
+
 // Close mocks base method.
 func (m *MockSyncTree) Close() error {
 	m.ctrl.T.Helper()
@@ -413,6 +442,21 @@ func (mr *MockSyncTreeMockRecorder) PrepareChange(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareChange", reflect.TypeOf((*MockSyncTree)(nil).PrepareChange), arg0)
 }
 
+// Prune mocks base method.
+func (m *MockSyncTree) Prune(arg0 context.Context, arg1 string, arg2 objecttree.SnapshotConfirmer) (objecttree.PrunedRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", arg0, arg1, arg2)
+	ret0, _ := ret[0].(objecttree.PrunedRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockSyncTreeMockRecorder) Prune(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockSyncTree)(nil).Prune), arg0, arg1, arg2)
+}
+
 // ResponseCollector mocks base method.
 func (m *MockSyncTree) ResponseCollector() syncdeps.ResponseCollector {
 	m.ctrl.T.Helper()
@@ -465,6 +509,20 @@ func (mr *MockSyncTreeMockRecorder) SetListener(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetListener", reflect.TypeOf((*MockSyncTree)(nil).SetListener), arg0)
 }
 
+// SnapshotConfirmer mocks base method.
+func (m *MockSyncTree) SnapshotConfirmer() objecttree.SnapshotConfirmer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotConfirmer")
+	ret0, _ := ret[0].(objecttree.SnapshotConfirmer)
+	return ret0
+}
+
+// SnapshotConfirmer indicates an expected call of SnapshotConfirmer.
+func (mr *MockSyncTreeMockRecorder) SnapshotConfirmer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotConfirmer", reflect.TypeOf((*MockSyncTree)(nil).SnapshotConfirmer))
+}
+
 // SnapshotPath mocks base method.
 func (m *MockSyncTree) SnapshotPath() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -494,6 +552,36 @@ func (mr *MockSyncTreeMockRecorder) Storage() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Storage", reflect.TypeOf((*MockSyncTree)(nil).Storage))
 }
 
+// SubscribeChanges mocks base method.
+func (m *MockSyncTree) SubscribeChanges(arg0 []string) (*updatelistener.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeChanges", arg0)
+	ret0, _ := ret[0].(*updatelistener.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeChanges indicates an expected call of SubscribeChanges.
+func (mr *MockSyncTreeMockRecorder) SubscribeChanges(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChanges", reflect.TypeOf((*MockSyncTree)(nil).SubscribeChanges), arg0)
+}
+
+// SyncProgress mocks base method.
+func (m *MockSyncTree) SyncProgress(arg0 string) (objecttree.SyncProgress, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncProgress", arg0)
+	ret0, _ := ret[0].(objecttree.SyncProgress)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// SyncProgress indicates an expected call of SyncProgress.
+func (mr *MockSyncTreeMockRecorder) SyncProgress(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncProgress", reflect.TypeOf((*MockSyncTree)(nil).SyncProgress), arg0)
+}
+
 // SyncWithPeer mocks base method.
 func (m *MockSyncTree) SyncWithPeer(arg0 context.Context, arg1 peer.Peer) error {
 	m.ctrl.T.Helper()
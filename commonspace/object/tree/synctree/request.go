@@ -1,6 +1,7 @@
 package synctree
 
 import (
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/sync/objectsync/objectmessages"
 )
@@ -9,10 +10,12 @@ type InnerRequest struct {
 	heads        []string
 	snapshotPath []string
 	root         *treechangeproto.RawTreeChangeWithId
+	cursor       objecttree.SyncCursor
 }
 
 func (r *InnerRequest) MsgSize() uint64 {
 	size := uint64(len(r.heads)+len(r.snapshotPath)) * 59
+	size += uint64(len(r.cursor.ChangeId) + len(r.cursor.OrderId))
 	if r.root != nil {
 		size += uint64(len(r.root.Id) + len(r.root.RawChange))
 	}
@@ -20,12 +23,18 @@ func (r *InnerRequest) MsgSize() uint64 {
 }
 
 func NewRequest(peerId, spaceId, objectId string, heads []string, snapshotPath []string, root *treechangeproto.RawTreeChangeWithId) *objectmessages.Request {
+	return NewRequestWithCursor(peerId, spaceId, objectId, heads, snapshotPath, root, objecttree.SyncCursor{})
+}
+
+// NewRequestWithCursor creates a request which asks the peer to resume the sync after the cursor of its previous response
+func NewRequestWithCursor(peerId, spaceId, objectId string, heads []string, snapshotPath []string, root *treechangeproto.RawTreeChangeWithId, cursor objecttree.SyncCursor) *objectmessages.Request {
 	copyHeads := make([]string, len(heads))
 	copy(copyHeads, heads)
 	return objectmessages.NewRequest(peerId, spaceId, objectId, &InnerRequest{
 		heads:        copyHeads,
 		snapshotPath: snapshotPath,
 		root:         root,
+		cursor:       cursor,
 	})
 }
 
@@ -34,6 +43,12 @@ func (r *InnerRequest) Marshall() ([]byte, error) {
 		Heads:        r.heads,
 		SnapshotPath: r.snapshotPath,
 	}
+	if !r.cursor.IsEmpty() {
+		msg.Cursor = &treechangeproto.TreeSyncCursor{
+			ChangeId: r.cursor.ChangeId,
+			OrderId:  r.cursor.OrderId,
+		}
+	}
 	req := treechangeproto.WrapFullRequest(msg, r.root)
 	return req.Marshal()
 }
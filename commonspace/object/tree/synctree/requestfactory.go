@@ -59,7 +59,11 @@ func (r *requestFactory) CreateFullSyncRequest(peerId string, t objecttree.Objec
 	if err != nil {
 		return nil, err
 	}
-	return NewRequest(peerId, r.spaceId, t.Id(), t.Heads(), path, t.Header()), nil
+	var cursor objecttree.SyncCursor
+	if tracker, ok := t.(syncProgressTracker); ok {
+		cursor = tracker.syncCursor(peerId)
+	}
+	return NewRequestWithCursor(peerId, r.spaceId, t.Id(), t.Heads(), path, t.Header(), cursor), nil
 }
 
 func (r *requestFactory) CreateResponseProducer(t objecttree.ObjectTree, theirHeads, theirSnapshotPath []string) (response.ResponseProducer, error) {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/anyproto/protobuf/proto"
 
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
 )
@@ -16,6 +17,9 @@ type Response struct {
 	SnapshotPath []string
 	Changes      []*treechangeproto.RawTreeChangeWithId
 	Root         *treechangeproto.RawTreeChangeWithId
+	Cursor       objecttree.SyncCursor
+	Sent         int
+	Total        int
 }
 
 const cidLen = 59
@@ -27,6 +31,7 @@ func (r *Response) MsgSize() uint64 {
 		size += uint64(len(change.Id))
 		size += uint64(len(change.RawChange))
 	}
+	size += uint64(len(r.Cursor.ChangeId) + len(r.Cursor.OrderId))
 	return size + uint64(len(r.Heads))*cidLen
 }
 
@@ -38,6 +43,14 @@ func (r *Response) ProtoMessage() (proto.Message, error) {
 		Heads:        r.Heads,
 		SnapshotPath: r.SnapshotPath,
 		Changes:      r.Changes,
+		SentChanges:  uint32(r.Sent),
+		TotalChanges: uint32(r.Total),
+	}
+	if !r.Cursor.IsEmpty() {
+		resp.Cursor = &treechangeproto.TreeSyncCursor{
+			ChangeId: r.Cursor.ChangeId,
+			OrderId:  r.Cursor.OrderId,
+		}
 	}
 	wrapped := treechangeproto.WrapFullResponse(resp, r.Root)
 	return spacesyncproto.MarshallSyncMessage(wrapped, r.SpaceId, r.ObjectId)
@@ -64,6 +77,14 @@ func (r *Response) SetProtoMessage(message proto.Message) error {
 	r.Heads = headMsg.Heads
 	r.Changes = headMsg.Changes
 	r.SnapshotPath = headMsg.SnapshotPath
+	r.Sent = int(headMsg.SentChanges)
+	r.Total = int(headMsg.TotalChanges)
+	if headMsg.Cursor != nil {
+		r.Cursor = objecttree.SyncCursor{
+			ChangeId: headMsg.Cursor.ChangeId,
+			OrderId:  headMsg.Cursor.OrderId,
+		}
+	}
 	r.SpaceId = msg.SpaceId
 	r.ObjectId = msg.ObjectId
 	return nil
@@ -18,7 +18,12 @@ type responseProducer struct {
 }
 
 func NewResponseProducer(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string) (ResponseProducer, error) {
-	res, err := tree.ChangesAfterCommonSnapshotLoader(theirSnapshotPath, theirHeads)
+	return NewResponseProducerWithCursor(spaceId, tree, theirHeads, theirSnapshotPath, objecttree.SyncCursor{})
+}
+
+// NewResponseProducerWithCursor creates a producer which resumes the sync after the cursor of the previous response
+func NewResponseProducerWithCursor(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string, cursor objecttree.SyncCursor) (ResponseProducer, error) {
+	res, err := tree.ChangesAfterCursorLoader(theirSnapshotPath, theirHeads, cursor)
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +45,9 @@ func (r *responseProducer) NewResponse(batchSize int) (*Response, error) {
 		SnapshotPath: res.SnapshotPath,
 		Changes:      res.Batch,
 		Root:         res.Root,
+		Cursor:       res.Cursor,
+		Sent:         res.Sent,
+		Total:        res.Total,
 		SpaceId:      r.spaceId,
 		ObjectId:     r.objectId,
 	}, nil
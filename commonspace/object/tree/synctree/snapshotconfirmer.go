@@ -0,0 +1,75 @@
+package synctree
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/response"
+	"github.com/anyproto/any-sync/commonspace/sync/syncdeps"
+)
+
+var errSnapshotChecked = errors.New("snapshot checked")
+
+// snapshotConfirmer asks every responsible node for the snapshot path of the tree,
+// the snapshot is confirmed if it is in the snapshot paths of all the nodes
+type snapshotConfirmer struct {
+	spaceId    string
+	peerGetter ResponsiblePeersGetter
+	syncClient SyncClient
+}
+
+// NewSnapshotConfirmer creates the confirmer which checks the snapshot with the responsible nodes of the space
+func NewSnapshotConfirmer(spaceId string, peerGetter ResponsiblePeersGetter, syncClient SyncClient) objecttree.SnapshotConfirmer {
+	return &snapshotConfirmer{
+		spaceId:    spaceId,
+		peerGetter: peerGetter,
+		syncClient: syncClient,
+	}
+}
+
+func (c *snapshotConfirmer) ConfirmSnapshot(ctx context.Context, treeId, snapshotId string) (ok bool, err error) {
+	peers, err := c.peerGetter.GetResponsiblePeers(ctx)
+	if err != nil {
+		return
+	}
+	if len(peers) == 0 {
+		return false, ErrNoResponsiblePeers
+	}
+	for _, p := range peers {
+		if ok, err = c.confirmWithPeer(ctx, p.Id(), treeId, snapshotId); err != nil || !ok {
+			return
+		}
+	}
+	return true, nil
+}
+
+// confirmWithPeer requests the changes after the snapshot, the peer answers with its snapshot path
+// in every response, so only the first one is read
+func (c *snapshotConfirmer) confirmWithPeer(ctx context.Context, peerId, treeId, snapshotId string) (ok bool, err error) {
+	collector := &snapshotPathCollector{}
+	req := NewRequest(peerId, c.spaceId, treeId, []string{snapshotId}, []string{snapshotId, treeId}, nil)
+	err = c.syncClient.SendTreeRequest(ctx, req, collector)
+	if err != nil && !errors.Is(err, errSnapshotChecked) {
+		return false, err
+	}
+	return slices.Contains(collector.snapshotPath, snapshotId), nil
+}
+
+type snapshotPathCollector struct {
+	snapshotPath []string
+}
+
+func (r *snapshotPathCollector) CollectResponse(ctx context.Context, peerId, objectId string, resp syncdeps.Response) error {
+	treeResp, ok := resp.(*response.Response)
+	if !ok {
+		return ErrUnexpectedResponseType
+	}
+	r.snapshotPath = treeResp.SnapshotPath
+	return errSnapshotChecked
+}
+
+func (r *snapshotPathCollector) NewResponse() syncdeps.Response {
+	return &response.Response{}
+}
@@ -0,0 +1,67 @@
+package synctree
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/mock_synctree"
+	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/response"
+	"github.com/anyproto/any-sync/commonspace/peermanager/mock_peermanager"
+	"github.com/anyproto/any-sync/commonspace/sync/syncdeps"
+	"github.com/anyproto/any-sync/net/peer"
+	"github.com/anyproto/any-sync/net/peer/mock_peer"
+)
+
+func TestSnapshotConfirmer(t *testing.T) {
+	ctx := context.Background()
+	newConfirmer := func(t *testing.T, paths map[string][]string) *snapshotConfirmer {
+		ctrl := gomock.NewController(t)
+		syncClient := mock_synctree.NewMockSyncClient(ctrl)
+		peerGetter := mock_peermanager.NewMockPeerManager(ctrl)
+		var peers []peer.Peer
+		for peerId := range paths {
+			mockPeer := mock_peer.NewMockPeer(ctrl)
+			mockPeer.EXPECT().Id().AnyTimes().Return(peerId)
+			peers = append(peers, mockPeer)
+		}
+		peerGetter.EXPECT().GetResponsiblePeers(ctx).Return(peers, nil)
+		syncClient.EXPECT().SendTreeRequest(ctx, gomock.Any(), gomock.Any()).AnyTimes().
+			DoAndReturn(func(ctx context.Context, req syncdeps.Request, collector syncdeps.ResponseCollector) error {
+				path, ok := paths[req.PeerId()]
+				if !ok {
+					return fmt.Errorf("no tree")
+				}
+				require.Equal(t, "treeId", req.ObjectId())
+				return collector.CollectResponse(ctx, req.PeerId(), req.ObjectId(), &response.Response{SnapshotPath: path})
+			})
+		return NewSnapshotConfirmer("spaceId", peerGetter, syncClient).(*snapshotConfirmer)
+	}
+
+	t.Run("all nodes have the snapshot", func(t *testing.T) {
+		confirmer := newConfirmer(t, map[string][]string{
+			"node1": {"snapshot", "treeId"},
+			"node2": {"newSnapshot", "snapshot", "treeId"},
+		})
+		ok, err := confirmer.ConfirmSnapshot(ctx, "treeId", "snapshot")
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+	t.Run("one of the nodes doesn't have the snapshot", func(t *testing.T) {
+		confirmer := newConfirmer(t, map[string][]string{
+			"node1": {"snapshot", "treeId"},
+			"node2": {"treeId"},
+		})
+		ok, err := confirmer.ConfirmSnapshot(ctx, "treeId", "snapshot")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+	t.Run("no responsible peers", func(t *testing.T) {
+		confirmer := newConfirmer(t, nil)
+		_, err := confirmer.ConfirmSnapshot(ctx, "treeId", "snapshot")
+		require.ErrorIs(t, err, ErrNoResponsiblePeers)
+	})
+}
@@ -28,15 +28,18 @@ type syncHandler struct {
 	tree       SyncTree
 	syncClient SyncClient
 	spaceId    string
+	// priority is set to the requests of the tree
+	priority syncdeps.Priority
 }
 
-var createResponseProducer = response.NewResponseProducer
+var createResponseProducer = response.NewResponseProducerWithCursor
 
-func NewSyncHandler(tree SyncTree, syncClient SyncClient, spaceId string) syncdeps.ObjectSyncHandler {
+func NewSyncHandler(tree SyncTree, syncClient SyncClient, spaceId string, priority syncdeps.Priority) syncdeps.ObjectSyncHandler {
 	return &syncHandler{
 		tree:       tree,
 		syncClient: syncClient,
 		spaceId:    spaceId,
+		priority:   priority,
 	}
 }
 
@@ -80,7 +83,7 @@ func (s *syncHandler) HandleHeadUpdate(ctx context.Context, statusUpdater syncst
 			return nil, nil
 		}
 		statusUpdater.HeadsApply(peerId, update.ObjectId(), contentUpdate.Heads, false)
-		objectRequest, err = s.syncClient.CreateFullSyncRequest(peerId, s.tree)
+		objectRequest, err = s.fullSyncRequest(peerId)
 		return
 	}
 	rawChangesPayload := objecttree.RawChangesPayload{
@@ -90,10 +93,11 @@ func (s *syncHandler) HandleHeadUpdate(ctx context.Context, statusUpdater syncst
 	}
 	res, err := s.tree.AddRawChangesFromPeer(ctx, peerId, rawChangesPayload)
 	if err != nil {
+		statusUpdater.SyncError(peerId, update.ObjectId(), err)
 		return nil, err
 	}
 	if !slice.UnsortedEquals(res.Heads, contentUpdate.Heads) {
-		objectRequest, err = s.syncClient.CreateFullSyncRequest(peerId, s.tree)
+		objectRequest, err = s.fullSyncRequest(peerId)
 		return
 	}
 	return nil, nil
@@ -120,7 +124,14 @@ func (s *syncHandler) HandleStreamRequest(ctx context.Context, rq syncdeps.Reque
 		zap.String("peerId", rq.PeerId()),
 		zap.Strings("theirHeads", request.Heads),
 		zap.Strings("ourHeads", curHeads))
-	producer, err := createResponseProducer(s.spaceId, s.tree, request.Heads, request.SnapshotPath)
+	var cursor objecttree.SyncCursor
+	if request.Cursor != nil {
+		cursor = objecttree.SyncCursor{
+			ChangeId: request.Cursor.ChangeId,
+			OrderId:  request.Cursor.OrderId,
+		}
+	}
+	producer, err := createResponseProducer(s.spaceId, s.tree, request.Heads, request.SnapshotPath, cursor)
 	if err != nil {
 		s.tree.Unlock()
 		return nil, err
@@ -128,7 +139,7 @@ func (s *syncHandler) HandleStreamRequest(ctx context.Context, rq syncdeps.Reque
 	var returnReq syncdeps.Request
 	if slice.UnsortedEquals(curHeads, request.Heads) || slice.ContainsSorted(request.Heads, curHeads) {
 		if len(curHeads) != len(request.Heads) {
-			returnReq, err = s.syncClient.CreateFullSyncRequest(rq.PeerId(), s.tree)
+			returnReq, err = s.fullSyncRequest(rq.PeerId())
 			if err != nil {
 				s.tree.Unlock()
 				return nil, err
@@ -146,7 +157,7 @@ func (s *syncHandler) HandleStreamRequest(ctx context.Context, rq syncdeps.Reque
 		return returnReq, send(protoResp)
 	} else {
 		if len(request.Heads) != 0 {
-			returnReq, err = s.syncClient.CreateFullSyncRequest(rq.PeerId(), s.tree)
+			returnReq, err = s.fullSyncRequest(rq.PeerId())
 			if err != nil {
 				s.tree.Unlock()
 				return nil, err
@@ -178,12 +189,25 @@ func (s *syncHandler) HandleStreamRequest(ctx context.Context, rq syncdeps.Reque
 	return returnReq, nil
 }
 
+func (s *syncHandler) fullSyncRequest(peerId string) (*objectmessages.Request, error) {
+	req, err := s.syncClient.CreateFullSyncRequest(peerId, s.tree)
+	if err != nil {
+		return nil, err
+	}
+	req.SetPriority(s.priority)
+	return req, nil
+}
+
 func (s *syncHandler) HandleResponse(ctx context.Context, peerId, objectId string, resp syncdeps.Response) error {
 	rsp, ok := resp.(*response.Response)
 	if !ok {
 		return ErrUnexpectedResponseType
 	}
+	tracker, trackProgress := s.tree.(syncProgressTracker)
 	if len(rsp.Changes) == 0 {
+		if trackProgress {
+			tracker.updateSyncProgress(peerId, rsp.Cursor, rsp.Sent, rsp.Total)
+		}
 		return nil
 	}
 	s.tree.Lock()
@@ -194,7 +218,14 @@ func (s *syncHandler) HandleResponse(ctx context.Context, peerId, objectId strin
 		SnapshotPath: rsp.SnapshotPath,
 	}
 	_, err := s.tree.AddRawChangesFromPeer(ctx, peerId, rawChangesPayload)
-	return err
+	if err != nil {
+		return err
+	}
+	// the changes up to the cursor are stored now, so the interrupted sync can continue after them
+	if trackProgress {
+		tracker.updateSyncProgress(peerId, rsp.Cursor, rsp.Sent, rsp.Total)
+	}
+	return nil
 }
 
 func (s *syncHandler) ResponseCollector() syncdeps.ResponseCollector {
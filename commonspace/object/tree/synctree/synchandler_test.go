@@ -13,6 +13,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/response/mock_response"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/sync/objectsync/objectmessages"
+	"github.com/anyproto/any-sync/commonspace/sync/syncdeps"
 	"github.com/anyproto/any-sync/commonspace/syncstatus/mock_syncstatus"
 	"github.com/anyproto/any-sync/net/peer"
 )
@@ -199,7 +200,7 @@ func TestSyncHandler_HandleStreamRequest(t *testing.T) {
 		require.NoError(t, err)
 		request := objectmessages.NewByteRequest("peerId", "spaceId", "objectId", marshaled)
 		producer := mock_response.NewMockResponseProducer(fx.ctrl)
-		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string) (response.ResponseProducer, error) {
+		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string, cursor objecttree.SyncCursor) (response.ResponseProducer, error) {
 			return producer, nil
 		}
 		returnReq := &objectmessages.Request{
@@ -241,7 +242,7 @@ func TestSyncHandler_HandleStreamRequest(t *testing.T) {
 		require.NoError(t, err)
 		request := objectmessages.NewByteRequest("peerId", "spaceId", "objectId", marshaled)
 		producer := mock_response.NewMockResponseProducer(fx.ctrl)
-		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string) (response.ResponseProducer, error) {
+		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string, cursor objecttree.SyncCursor) (response.ResponseProducer, error) {
 			return producer, nil
 		}
 		fx.tree.EXPECT().Heads().Return([]string{"curHead"})
@@ -277,7 +278,7 @@ func TestSyncHandler_HandleStreamRequest(t *testing.T) {
 		require.NoError(t, err)
 		request := objectmessages.NewByteRequest("peerId", "spaceId", "objectId", marshaled)
 		producer := mock_response.NewMockResponseProducer(fx.ctrl)
-		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string) (response.ResponseProducer, error) {
+		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string, cursor objecttree.SyncCursor) (response.ResponseProducer, error) {
 			return producer, nil
 		}
 		fx.tree.EXPECT().Heads().Return([]string{"curHead"})
@@ -312,7 +313,7 @@ func TestSyncHandler_HandleStreamRequest(t *testing.T) {
 		require.NoError(t, err)
 		request := objectmessages.NewByteRequest("peerId", "spaceId", "objectId", marshaled)
 		producer := mock_response.NewMockResponseProducer(fx.ctrl)
-		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string) (response.ResponseProducer, error) {
+		createResponseProducer = func(spaceId string, tree objecttree.ObjectTree, theirHeads, theirSnapshotPath []string, cursor objecttree.SyncCursor) (response.ResponseProducer, error) {
 			return producer, nil
 		}
 		fx.tree.EXPECT().Heads().Return([]string{"curHead"})
@@ -392,6 +393,7 @@ func newSyncHandlerFixture(t *testing.T) *syncHandlerFixture {
 		tree:       tree,
 		syncClient: client,
 		spaceId:    "spaceId",
+		priority:   syncdeps.PriorityForeground,
 	}
 	return &syncHandlerFixture{
 		ctrl:        ctrl,
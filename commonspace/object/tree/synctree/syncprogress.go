@@ -0,0 +1,72 @@
+package synctree
+
+import (
+	"sync"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+)
+
+// syncProgressTracker is implemented by the trees which can resume the interrupted full syncs
+type syncProgressTracker interface {
+	syncCursor(peerId string) objecttree.SyncCursor
+	updateSyncProgress(peerId string, cursor objecttree.SyncCursor, sent, total int)
+}
+
+type peerSyncProgress struct {
+	objecttree.SyncProgress
+	cursor objecttree.SyncCursor
+	// received is the number of changes received before the current response stream started
+	received int
+}
+
+type syncProgresses struct {
+	peers map[string]*peerSyncProgress
+	mx    sync.Mutex
+}
+
+// syncCursor returns the cursor to resume the sync with the peer from,
+// it also starts a new response stream so the progress is counted from the previous one
+func (s *syncProgresses) syncCursor(peerId string) objecttree.SyncCursor {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	progress, ok := s.peers[peerId]
+	if !ok {
+		return objecttree.SyncCursor{}
+	}
+	if progress.cursor.IsEmpty() {
+		delete(s.peers, peerId)
+		return objecttree.SyncCursor{}
+	}
+	progress.received = progress.Received
+	return progress.cursor
+}
+
+func (s *syncProgresses) updateSyncProgress(peerId string, cursor objecttree.SyncCursor, sent, total int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.peers == nil {
+		s.peers = make(map[string]*peerSyncProgress)
+	}
+	progress, ok := s.peers[peerId]
+	if !ok {
+		progress = &peerSyncProgress{}
+		s.peers[peerId] = progress
+	}
+	progress.Received = progress.received + sent
+	progress.Total = progress.received + total
+	progress.cursor = cursor
+	if sent >= total {
+		// the sync is finished, the next one starts from scratch
+		progress.cursor = objecttree.SyncCursor{}
+	}
+}
+
+func (s *syncProgresses) SyncProgress(peerId string) (progress objecttree.SyncProgress, ok bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	peerProgress, ok := s.peers[peerId]
+	if !ok {
+		return
+	}
+	return peerProgress.SyncProgress, true
+}
@@ -0,0 +1,44 @@
+package synctree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+)
+
+func TestSyncProgresses(t *testing.T) {
+	t.Run("interrupted sync is resumed", func(t *testing.T) {
+		var progresses syncProgresses
+		require.True(t, progresses.syncCursor("peerId").IsEmpty())
+		cursor := objecttree.SyncCursor{ChangeId: "id", OrderId: "order"}
+		progresses.updateSyncProgress("peerId", cursor, 10, 40)
+		progress, ok := progresses.SyncProgress("peerId")
+		require.True(t, ok)
+		require.Equal(t, objecttree.SyncProgress{Received: 10, Total: 40}, progress)
+		require.Equal(t, float64(25), progress.Percent())
+
+		// the resumed stream counts only the remaining changes
+		require.Equal(t, cursor, progresses.syncCursor("peerId"))
+		progresses.updateSyncProgress("peerId", objecttree.SyncCursor{ChangeId: "id2", OrderId: "order2"}, 10, 30)
+		progress, _ = progresses.SyncProgress("peerId")
+		require.Equal(t, objecttree.SyncProgress{Received: 20, Total: 40}, progress)
+		progresses.updateSyncProgress("peerId", objecttree.SyncCursor{ChangeId: "id3", OrderId: "order3"}, 30, 30)
+		progress, _ = progresses.SyncProgress("peerId")
+		require.Equal(t, objecttree.SyncProgress{Received: 40, Total: 40}, progress)
+		require.Equal(t, float64(100), progress.Percent())
+
+		// the finished sync is not resumed
+		require.True(t, progresses.syncCursor("peerId").IsEmpty())
+		_, ok = progresses.SyncProgress("peerId")
+		require.False(t, ok)
+	})
+	t.Run("progresses are separate for peers", func(t *testing.T) {
+		var progresses syncProgresses
+		cursor := objecttree.SyncCursor{ChangeId: "id", OrderId: "order"}
+		progresses.updateSyncProgress("peerId1", cursor, 10, 40)
+		require.True(t, progresses.syncCursor("peerId2").IsEmpty())
+		require.Equal(t, cursor, progresses.syncCursor("peerId1"))
+	})
+}
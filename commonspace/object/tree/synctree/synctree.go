@@ -37,6 +37,13 @@ type ListenerSetter interface {
 	SetListener(listener updatelistener.UpdateListener)
 }
 
+type ChangeSubscriber interface {
+	// SubscribeChanges creates the subscription to the tree events, it should be called under lock.
+	// If seenHeads are set, the first event of the subscription contains the changes the subscriber missed
+	// after these heads, or it is EventRebuild if the tree doesn't know some of them
+	SubscribeChanges(seenHeads []string) (*updatelistener.Subscription, error)
+}
+
 type peerSendableObjectTree interface {
 	objecttree.ObjectTree
 	syncdeps.ObjectSyncHandler
@@ -46,21 +53,30 @@ type peerSendableObjectTree interface {
 type SyncTree interface {
 	peerSendableObjectTree
 	ListenerSetter
+	ChangeSubscriber
 	SyncWithPeer(ctx context.Context, p peer.Peer) (err error)
+	// SyncProgress returns the progress of the last full sync with the peer
+	SyncProgress(peerId string) (progress objecttree.SyncProgress, ok bool)
+	// SnapshotConfirmer returns the confirmer which checks the snapshot with the responsible nodes, it is passed to Prune
+	SnapshotConfirmer() objecttree.SnapshotConfirmer
 }
 
-// SyncTree sends head updates to sync service and also sends new changes to update listener
+// SyncTree sends head updates to sync service and also sends new changes to update listener and subscriptions
 type syncTree struct {
 	syncdeps.ObjectSyncHandler
 	objecttree.ObjectTree
+	syncProgresses
 	syncClient     SyncClient
+	confirmer      objecttree.SnapshotConfirmer
 	syncStatus     syncstatus.StatusUpdater
 	listener       updatelistener.UpdateListener
+	subscriptions  updatelistener.Subscriptions
 	statsCollector *TreeStatsCollector
 	onClose        func(id string)
 	isClosed       bool
 	isDeleted      bool
 	buildTime      time.Duration
+	priority       syncdeps.Priority
 }
 
 var log = logger.NewNamed("common.commonspace.synctree")
@@ -124,13 +140,19 @@ func buildSyncTree(ctx context.Context, peerId string, deps BuildDeps) (t SyncTr
 	syncTree := &syncTree{
 		ObjectTree:     objTree,
 		syncClient:     syncClient,
+		confirmer:      NewSnapshotConfirmer(deps.SpaceId, deps.PeerGetter, syncClient),
 		onClose:        deps.OnClose,
 		listener:       deps.Listener,
 		syncStatus:     deps.SyncStatus,
 		statsCollector: deps.StatsCollector,
 		buildTime:      time.Since(buildStart),
+		priority:       syncdeps.PriorityForeground,
+	}
+	// the trees opened by the user keep the interactive priority for their sync requests
+	if priority, ok := syncdeps.CtxPriority(ctx); ok && priority == syncdeps.PriorityInteractive {
+		syncTree.priority = priority
 	}
-	syncHandler := NewSyncHandler(syncTree, syncClient, deps.SpaceId)
+	syncHandler := NewSyncHandler(syncTree, syncClient, deps.SpaceId, syncTree.priority)
 	syncTree.ObjectSyncHandler = syncHandler
 	t = syncTree
 	syncTree.Lock()
@@ -163,6 +185,102 @@ func (s *syncTree) SetListener(listener updatelistener.UpdateListener) {
 	s.listener = listener
 }
 
+func (s *syncTree) SubscribeChanges(seenHeads []string) (sub *updatelistener.Subscription, err error) {
+	// this should be called under lock
+	if err = s.checkAlive(); err != nil {
+		return
+	}
+	if len(seenHeads) == 0 || slice.UnsortedEquals(seenHeads, s.Heads()) {
+		return s.subscriptions.Add(), nil
+	}
+	missed, err := s.missedEvent(seenHeads)
+	if err != nil {
+		return
+	}
+	return s.subscriptions.Add(missed), nil
+}
+
+// missedEvent returns the event with the changes which are not in the history of seenHeads
+func (s *syncTree) missedEvent(seenHeads []string) (ev updatelistener.Event, err error) {
+	ev = updatelistener.Event{
+		TreeId: s.Id(),
+		Heads:  slices.Clone(s.Heads()),
+	}
+	differ, err := objecttree.NewChangeDiffer(s, s.HasChanges)
+	if err != nil {
+		return
+	}
+	seen, notFound, _ := differ.RemoveBefore(seenHeads, nil)
+	if len(notFound) != 0 {
+		// the subscriber knows the changes we don't have, so it can't get the difference and should reread the tree
+		ev.Type = updatelistener.EventRebuild
+		return
+	}
+	seenIds := make(map[string]struct{}, len(seen))
+	for _, id := range seen {
+		seenIds[id] = struct{}{}
+	}
+	ev.Type = updatelistener.EventChangesAdded
+	err = s.ObjectTree.IterateRoot(nil, func(ch *objecttree.Change) bool {
+		if _, ok := seenIds[ch.Id]; !ok {
+			ev.Changes = append(ev.Changes, detachChange(ch))
+		}
+		return true
+	})
+	return
+}
+
+// detachChange copies the change for the subscribers, which read it without the tree lock.
+// The links to the other changes of the tree are not copied
+func detachChange(ch *objecttree.Change) *objecttree.Change {
+	return &objecttree.Change{
+		PreviousIds:     slices.Clone(ch.PreviousIds),
+		AclHeadId:       ch.AclHeadId,
+		Id:              ch.Id,
+		SnapshotId:      ch.SnapshotId,
+		Timestamp:       ch.Timestamp,
+		ReadKeyId:       ch.ReadKeyId,
+		Identity:        ch.Identity,
+		Data:            ch.Data,
+		Model:           ch.Model,
+		Signature:       ch.Signature,
+		DataType:        ch.DataType,
+		IsSnapshot:      ch.IsSnapshot,
+		IsDerived:       ch.IsDerived,
+		IsNew:           ch.IsNew,
+		OrderId:         ch.OrderId,
+		SnapshotCounter: ch.SnapshotCounter,
+	}
+}
+
+func (s *syncTree) notifySubscriptions(res objecttree.AddResult) {
+	if s.subscriptions.IsEmpty() {
+		return
+	}
+	ev := updatelistener.Event{
+		TreeId: s.Id(),
+		Heads:  slices.Clone(res.Heads),
+	}
+	switch res.Mode {
+	case objecttree.Nothing:
+		return
+	case objecttree.Append:
+		ev.Type = updatelistener.EventChangesAdded
+		ev.Changes = make([]*objecttree.Change, 0, len(res.Added))
+		for _, added := range res.Added {
+			ch, err := s.ObjectTree.GetChange(added.Id)
+			if err != nil {
+				log.Warn("failed to get added change", zap.String("treeId", s.Id()), zap.String("changeId", added.Id), zap.Error(err))
+				continue
+			}
+			ev.Changes = append(ev.Changes, detachChange(ch))
+		}
+	case objecttree.Rebuild:
+		ev.Type = updatelistener.EventRebuild
+	}
+	s.subscriptions.Send(ev)
+}
+
 func (s *syncTree) IterateFrom(id string, convert objecttree.ChangeConvertFunc, iterate objecttree.ChangeIterateFunc) (err error) {
 	if err = s.checkAlive(); err != nil {
 		return
@@ -189,6 +307,7 @@ func (s *syncTree) AddContentWithValidator(ctx context.Context, content objecttr
 	if err != nil {
 		return
 	}
+	s.notifySubscriptions(res)
 	s.syncStatus.HeadsChange(s.Id(), res.Heads)
 	headUpdate, err := s.syncClient.CreateHeadUpdate(s, "", res.RawChanges())
 	if err != nil {
@@ -246,7 +365,7 @@ func (s *syncTree) AddRawChanges(ctx context.Context, changesPayload objecttree.
 	if err = s.checkAlive(); err != nil {
 		return
 	}
-	return s.ObjectTree.AddRawChangesWithUpdater(ctx, changesPayload, func(tree objecttree.ObjectTree, md objecttree.Mode) error {
+	res, err = s.ObjectTree.AddRawChangesWithUpdater(ctx, changesPayload, func(tree objecttree.ObjectTree, md objecttree.Mode) error {
 		if s.listener != nil {
 			switch md {
 			case objecttree.Nothing:
@@ -259,6 +378,11 @@ func (s *syncTree) AddRawChanges(ctx context.Context, changesPayload objecttree.
 		}
 		return nil
 	})
+	if err != nil {
+		return
+	}
+	s.notifySubscriptions(res)
+	return
 }
 
 func (s *syncTree) Delete() (err error) {
@@ -276,6 +400,8 @@ func (s *syncTree) Delete() (err error) {
 		return
 	}
 	s.isDeleted = true
+	s.subscriptions.Send(updatelistener.Event{Type: updatelistener.EventDeleted, TreeId: s.Id()})
+	s.subscriptions.Close()
 	return
 }
 
@@ -309,6 +435,7 @@ func (s *syncTree) close() (err error) {
 	}
 	s.onClose(s.Id())
 	s.isClosed = true
+	s.subscriptions.Close()
 	return
 }
 
@@ -329,9 +456,14 @@ func (s *syncTree) SyncWithPeer(ctx context.Context, p peer.Peer) (err error) {
 	if err != nil {
 		return
 	}
+	req.SetPriority(s.priority)
 	return s.syncClient.QueueRequest(ctx, req)
 }
 
+func (s *syncTree) SnapshotConfirmer() objecttree.SnapshotConfirmer {
+	return s.confirmer
+}
+
 func (s *syncTree) afterBuild() {
 	if s.listener != nil {
 		s.listener.Rebuild(s)
@@ -2,13 +2,16 @@ package synctree
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
+	anystore "github.com/anyproto/any-store"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
 	"github.com/anyproto/any-sync/commonspace/headsync/headstorage/mock_headstorage"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree/mock_objecttree"
@@ -20,7 +23,9 @@ import (
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacestorage/mock_spacestorage"
 	"github.com/anyproto/any-sync/commonspace/sync/objectsync/objectmessages"
+	"github.com/anyproto/any-sync/commonspace/sync/syncdeps"
 	"github.com/anyproto/any-sync/commonspace/syncstatus/mock_syncstatus"
+	"github.com/anyproto/any-sync/net/rpc/rpctest"
 )
 
 type syncTreeMatcher struct {
@@ -149,6 +154,25 @@ func Test_BuildSyncTree(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, res)
 	})
+	t.Run("interactive build, requests are interactive", func(t *testing.T) {
+		fx := newFixture(t)
+		defer fx.finish()
+		newTreeGetter = func(deps BuildDeps, treeId string) treeGetter {
+			return testTreeGetter{treeStorage: nil, peerId: ""}
+		}
+		fx.objTree.EXPECT().Heads().AnyTimes().Return([]string{"headId"})
+		fx.objTree.EXPECT().Id().AnyTimes().Return("id")
+		fx.objTree.EXPECT().IsDerived().AnyTimes().Return(false)
+		fx.listener.EXPECT().Rebuild(gomock.Any())
+		res, err := BuildSyncTreeOrGetRemote(syncdeps.CtxWithPriority(ctx, syncdeps.PriorityInteractive), "id", fx.deps)
+		require.NoError(t, err)
+
+		req := objectmessages.NewByteRequest("peerId", "spaceId", "id", nil)
+		fx.syncClient.EXPECT().CreateFullSyncRequest("peerId", res).Return(req, nil)
+		fx.syncClient.EXPECT().QueueRequest(ctx, req).Return(nil)
+		require.NoError(t, res.SyncWithPeer(ctx, rpctest.MockPeer{}))
+		require.Equal(t, syncdeps.PriorityInteractive, req.Priority())
+	})
 }
 
 func Test_PutSyncTree(t *testing.T) {
@@ -363,3 +387,129 @@ func Test_SyncTree(t *testing.T) {
 		require.Equal(t, expectedRes, res)
 	})
 }
+
+func Test_SyncTreeSubscriptions(t *testing.T) {
+	prepare := func(t *testing.T) (*syncTree, *objecttree.MockChangeCreator, string) {
+		keys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		aclList, err := list.NewInMemoryDerivedAcl("spaceId", keys)
+		require.NoError(t, err)
+		changeCreator := objecttree.NewMockChangeCreator(func() anystore.DB {
+			store, err := anystore.Open(ctx, filepath.Join(t.TempDir(), "changes.db"), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = store.Close()
+			})
+			return store
+		})
+		treeStorage := changeCreator.CreateNewTreeStorage(t, "0", aclList.Head().Id, false)
+		objTree, err := objecttree.BuildTestableTree(treeStorage, aclList)
+		require.NoError(t, err)
+		return &syncTree{ObjectTree: objTree, onClose: func(string) {}}, changeCreator, aclList.Head().Id
+	}
+	addChanges := func(t *testing.T, tr *syncTree, heads []string, changes ...*treechangeproto.RawTreeChangeWithId) {
+		tr.Lock()
+		defer tr.Unlock()
+		_, err := tr.AddRawChanges(ctx, objecttree.RawChangesPayload{
+			NewHeads:   heads,
+			RawChanges: changes,
+		})
+		require.NoError(t, err)
+	}
+	subscribe := func(t *testing.T, tr *syncTree, seenHeads []string) *updatelistener.Subscription {
+		tr.Lock()
+		defer tr.Unlock()
+		sub, err := tr.SubscribeChanges(seenHeads)
+		require.NoError(t, err)
+		return sub
+	}
+	changeIds := func(ev updatelistener.Event) (ids []string) {
+		for _, ch := range ev.Changes {
+			ids = append(ids, ch.Id)
+		}
+		return
+	}
+
+	t.Run("event changes are detached from the tree", func(t *testing.T) {
+		tr, changeCreator, aclHeadId := prepare(t)
+		sub := subscribe(t, tr, nil)
+		addChanges(t, tr, []string{"1"}, changeCreator.CreateRaw("1", aclHeadId, "0", false, "0"))
+		ev, err := sub.Next(ctx)
+		require.NoError(t, err)
+		require.Len(t, ev.Changes, 1)
+		tr.Lock()
+		treeChange, err := tr.GetChange("1")
+		tr.Unlock()
+		require.NoError(t, err)
+		require.NotSame(t, treeChange, ev.Changes[0])
+		require.Equal(t, treeChange.Id, ev.Changes[0].Id)
+		require.Equal(t, []string{"0"}, ev.Changes[0].PreviousIds)
+		require.Empty(t, ev.Changes[0].Previous)
+		require.Empty(t, ev.Changes[0].Next)
+	})
+	t.Run("events are sent to every subscription", func(t *testing.T) {
+		tr, changeCreator, aclHeadId := prepare(t)
+		first := subscribe(t, tr, nil)
+		second := subscribe(t, tr, nil)
+		addChanges(t, tr, []string{"1"}, changeCreator.CreateRaw("1", aclHeadId, "0", false, "0"))
+		require.NoError(t, tr.Delete())
+		for _, sub := range []*updatelistener.Subscription{first, second} {
+			ev, err := sub.Next(ctx)
+			require.NoError(t, err)
+			require.Equal(t, updatelistener.EventChangesAdded, ev.Type)
+			require.Equal(t, []string{"1"}, ev.Heads)
+			require.Equal(t, []string{"1"}, changeIds(ev))
+			ev, err = sub.Next(ctx)
+			require.NoError(t, err)
+			require.Equal(t, updatelistener.EventDeleted, ev.Type)
+			_, err = sub.Next(ctx)
+			require.ErrorIs(t, err, updatelistener.ErrSubscriptionClosed)
+		}
+	})
+	t.Run("closed subscription doesn't get events", func(t *testing.T) {
+		tr, changeCreator, aclHeadId := prepare(t)
+		closed := subscribe(t, tr, nil)
+		active := subscribe(t, tr, nil)
+		require.NoError(t, closed.Close())
+		addChanges(t, tr, []string{"1"}, changeCreator.CreateRaw("1", aclHeadId, "0", false, "0"))
+		_, err := closed.Next(ctx)
+		require.ErrorIs(t, err, updatelistener.ErrSubscriptionClosed)
+		ev, err := active.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1"}, changeIds(ev))
+	})
+	t.Run("resume from heads", func(t *testing.T) {
+		tr, changeCreator, aclHeadId := prepare(t)
+		addChanges(t, tr, []string{"2", "3"},
+			changeCreator.CreateRaw("1", aclHeadId, "0", false, "0"),
+			changeCreator.CreateRaw("2", aclHeadId, "0", false, "1"),
+			changeCreator.CreateRaw("3", aclHeadId, "0", false, "1"),
+		)
+		sub := subscribe(t, tr, []string{"2"})
+		ev, err := sub.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, updatelistener.EventChangesAdded, ev.Type)
+		require.Equal(t, []string{"3"}, changeIds(ev))
+		require.ElementsMatch(t, []string{"2", "3"}, ev.Heads)
+
+		addChanges(t, tr, []string{"4"}, changeCreator.CreateRaw("4", aclHeadId, "0", false, "2", "3"))
+		ev, err = sub.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"4"}, changeIds(ev))
+	})
+	t.Run("resume from unknown heads", func(t *testing.T) {
+		tr, _, _ := prepare(t)
+		sub := subscribe(t, tr, []string{"unknown"})
+		ev, err := sub.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, updatelistener.EventRebuild, ev.Type)
+		require.Equal(t, []string{"0"}, ev.Heads)
+	})
+	t.Run("subscriptions are closed with the tree", func(t *testing.T) {
+		tr, _, _ := prepare(t)
+		sub := subscribe(t, tr, nil)
+		require.NoError(t, tr.Close())
+		_, err := sub.Next(ctx)
+		require.ErrorIs(t, err, updatelistener.ErrSubscriptionClosed)
+	})
+}
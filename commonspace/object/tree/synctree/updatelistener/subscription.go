@@ -0,0 +1,131 @@
+package updatelistener
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/cheggaaa/mb/v3"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+)
+
+var ErrSubscriptionClosed = errors.New("tree subscription is closed")
+
+type EventType int
+
+const (
+	// EventChangesAdded is sent when the changes are added to the tree locally or from the peers
+	EventChangesAdded EventType = iota
+	// EventRebuild is sent when the tree is rebuilt, the subscriber should reread the tree
+	EventRebuild
+	// EventDeleted is sent when the tree is deleted, it is the last event of the subscription
+	EventDeleted
+)
+
+func (e EventType) String() string {
+	switch e {
+	case EventChangesAdded:
+		return "changesAdded"
+	case EventRebuild:
+		return "rebuild"
+	case EventDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// Event describes the update of the tree
+type Event struct {
+	Type   EventType
+	TreeId string
+	// Heads are the new heads of the tree
+	Heads []string
+	// Changes are the added changes in the order they were applied, set only for EventChangesAdded.
+	// They are the copies of the tree changes without Next and Previous, so they can be read without the tree lock.
+	// The changes are not decrypted, the model of the change can be read from the tree
+	Changes []*objecttree.Change
+}
+
+// Subscription receives the events of the tree independently of the other subscriptions.
+// The events are queued, so the slow subscriber doesn't block the tree
+type Subscription struct {
+	id     uint64
+	events *mb.MB[Event]
+	subs   *Subscriptions
+}
+
+// Next waits for the next event, ErrSubscriptionClosed is returned after all the events of the closed subscription are read
+func (s *Subscription) Next(ctx context.Context) (Event, error) {
+	ev, err := s.events.WaitOne(ctx)
+	if errors.Is(err, mb.ErrClosed) {
+		return ev, ErrSubscriptionClosed
+	}
+	return ev, err
+}
+
+// Close stops the subscription, the events which are not read yet are dropped
+func (s *Subscription) Close() error {
+	s.subs.remove(s.id)
+	_ = s.events.Close()
+	return nil
+}
+
+// Subscriptions keeps the subscriptions of the tree, the zero value is ready to use
+type Subscriptions struct {
+	mu     sync.Mutex
+	nextId uint64
+	subs   map[uint64]*Subscription
+}
+
+// Add creates the subscription, the events are queued to it before any other events
+func (s *Subscriptions) Add(events ...Event) *Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs == nil {
+		s.subs = make(map[uint64]*Subscription)
+	}
+	s.nextId++
+	sub := &Subscription{
+		id:     s.nextId,
+		events: mb.New[Event](0),
+		subs:   s,
+	}
+	if len(events) != 0 {
+		_ = sub.events.TryAdd(events...)
+	}
+	s.subs[sub.id] = sub
+	return sub
+}
+
+func (s *Subscriptions) remove(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs, id)
+}
+
+func (s *Subscriptions) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subs) == 0
+}
+
+// Send queues the event to all the subscriptions
+func (s *Subscriptions) Send(ev Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subs {
+		// the queue is unbounded, so the subscriber never blocks the tree
+		_ = sub.events.TryAdd(ev)
+	}
+}
+
+// Close closes all the subscriptions, the subscribers can still read the queued events
+func (s *Subscriptions) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.subs {
+		_ = sub.events.Close()
+		delete(s.subs, id)
+	}
+}
@@ -71,6 +71,10 @@ type RootChange struct {
 	ChangePayload []byte `protobuf:"bytes,7,opt,name=changePayload,proto3" json:"changePayload,omitempty"`
 	// IsDerived tells if the tree is derived
 	IsDerived bool `protobuf:"varint,8,opt,name=isDerived,proto3" json:"isDerived,omitempty"`
+	// ObjectAcl is an optional permission overlay restricting the space permissions for this tree
+	ObjectAcl *ObjectAcl `protobuf:"bytes,9,opt,name=objectAcl,proto3" json:"objectAcl,omitempty"`
+	// DeviceCertificate is set if the root is signed by the device on behalf of the identity
+	DeviceCertificate []byte `protobuf:"bytes,10,opt,name=deviceCertificate,proto3" json:"deviceCertificate,omitempty"`
 }
 
 func (m *RootChange) Reset()         { *m = RootChange{} }
@@ -170,6 +174,75 @@ func (m *RootChange) GetIsDerived() bool {
 	return false
 }
 
+func (m *RootChange) GetObjectAcl() *ObjectAcl {
+	if m != nil {
+		return m.ObjectAcl
+	}
+	return nil
+}
+
+func (m *RootChange) GetDeviceCertificate() []byte {
+	if m != nil {
+		return m.DeviceCertificate
+	}
+	return nil
+}
+
+// ObjectAcl is a permission overlay of a single object inside the space
+type ObjectAcl struct {
+	// Writers are public keys of the space writers which are allowed to write to the object,
+	// space owners and admins can always write
+	Writers [][]byte `protobuf:"bytes,1,rep,name=writers,proto3" json:"writers,omitempty"`
+}
+
+func (m *ObjectAcl) Reset()         { *m = ObjectAcl{} }
+func (m *ObjectAcl) String() string { return proto.CompactTextString(m) }
+func (*ObjectAcl) ProtoMessage()    {}
+func (*ObjectAcl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5033f0301ef9b772, []int{1}
+}
+func (m *ObjectAcl) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ObjectAcl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ObjectAcl.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ObjectAcl) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *ObjectAcl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectAcl.Merge(m, src)
+}
+func (m *ObjectAcl) XXX_Size() int {
+	return m.Size()
+}
+func (m *ObjectAcl) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectAcl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectAcl proto.InternalMessageInfo
+
+func (m *ObjectAcl) GetWriters() [][]byte {
+	if m != nil {
+		return m.Writers
+	}
+	return nil
+}
+
 // TreeChange is a change of a tree
 type TreeChange struct {
 	// TreeHeadIds are previous ids for this TreeChange
@@ -190,13 +263,15 @@ type TreeChange struct {
 	IsSnapshot bool `protobuf:"varint,8,opt,name=isSnapshot,proto3" json:"isSnapshot,omitempty"`
 	// DataType indicates some special parameters of data for the client
 	DataType string `protobuf:"bytes,9,opt,name=dataType,proto3" json:"dataType,omitempty"`
+	// DeviceCertificate is set if the change is signed by the device on behalf of the identity
+	DeviceCertificate []byte `protobuf:"bytes,10,opt,name=deviceCertificate,proto3" json:"deviceCertificate,omitempty"`
 }
 
 func (m *TreeChange) Reset()         { *m = TreeChange{} }
 func (m *TreeChange) String() string { return proto.CompactTextString(m) }
 func (*TreeChange) ProtoMessage()    {}
 func (*TreeChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{1}
+	return fileDescriptor_5033f0301ef9b772, []int{2}
 }
 func (m *TreeChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -296,6 +371,13 @@ func (m *TreeChange) GetDataType() string {
 	return ""
 }
 
+func (m *TreeChange) GetDeviceCertificate() []byte {
+	if m != nil {
+		return m.DeviceCertificate
+	}
+	return nil
+}
+
 // TreeChange is a change of a tree
 type NoDataTreeChange struct {
 	// TreeHeadIds are previous ids for this TreeChange
@@ -314,13 +396,15 @@ type NoDataTreeChange struct {
 	IsSnapshot bool `protobuf:"varint,8,opt,name=isSnapshot,proto3" json:"isSnapshot,omitempty"`
 	// DataType indicates some special parameters of data for the client
 	DataType string `protobuf:"bytes,9,opt,name=dataType,proto3" json:"dataType,omitempty"`
+	// DeviceCertificate is set if the change is signed by the device on behalf of the identity
+	DeviceCertificate []byte `protobuf:"bytes,10,opt,name=deviceCertificate,proto3" json:"deviceCertificate,omitempty"`
 }
 
 func (m *NoDataTreeChange) Reset()         { *m = NoDataTreeChange{} }
 func (m *NoDataTreeChange) String() string { return proto.CompactTextString(m) }
 func (*NoDataTreeChange) ProtoMessage()    {}
 func (*NoDataTreeChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{2}
+	return fileDescriptor_5033f0301ef9b772, []int{3}
 }
 func (m *NoDataTreeChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -413,6 +497,13 @@ func (m *NoDataTreeChange) GetDataType() string {
 	return ""
 }
 
+func (m *NoDataTreeChange) GetDeviceCertificate() []byte {
+	if m != nil {
+		return m.DeviceCertificate
+	}
+	return nil
+}
+
 type ReducedTreeChange struct {
 	// TreeHeadIds are previous ids for this TreeChange
 	TreeHeadIds []string `protobuf:"bytes,1,rep,name=treeHeadIds,proto3" json:"treeHeadIds,omitempty"`
@@ -422,7 +513,7 @@ func (m *ReducedTreeChange) Reset()         { *m = ReducedTreeChange{} }
 func (m *ReducedTreeChange) String() string { return proto.CompactTextString(m) }
 func (*ReducedTreeChange) ProtoMessage()    {}
 func (*ReducedTreeChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{3}
+	return fileDescriptor_5033f0301ef9b772, []int{4}
 }
 func (m *ReducedTreeChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -478,7 +569,7 @@ func (m *RawTreeChange) Reset()         { *m = RawTreeChange{} }
 func (m *RawTreeChange) String() string { return proto.CompactTextString(m) }
 func (*RawTreeChange) ProtoMessage()    {}
 func (*RawTreeChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{4}
+	return fileDescriptor_5033f0301ef9b772, []int{5}
 }
 func (m *RawTreeChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -541,7 +632,7 @@ func (m *RawTreeChangeWithId) Reset()         { *m = RawTreeChangeWithId{} }
 func (m *RawTreeChangeWithId) String() string { return proto.CompactTextString(m) }
 func (*RawTreeChangeWithId) ProtoMessage()    {}
 func (*RawTreeChangeWithId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{5}
+	return fileDescriptor_5033f0301ef9b772, []int{6}
 }
 func (m *RawTreeChangeWithId) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -601,7 +692,7 @@ func (m *TreeSyncMessage) Reset()         { *m = TreeSyncMessage{} }
 func (m *TreeSyncMessage) String() string { return proto.CompactTextString(m) }
 func (*TreeSyncMessage) ProtoMessage()    {}
 func (*TreeSyncMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{6}
+	return fileDescriptor_5033f0301ef9b772, []int{7}
 }
 func (m *TreeSyncMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -667,7 +758,7 @@ func (m *TreeSyncContentValue) Reset()         { *m = TreeSyncContentValue{} }
 func (m *TreeSyncContentValue) String() string { return proto.CompactTextString(m) }
 func (*TreeSyncContentValue) ProtoMessage()    {}
 func (*TreeSyncContentValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{7}
+	return fileDescriptor_5033f0301ef9b772, []int{8}
 }
 func (m *TreeSyncContentValue) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -784,7 +875,7 @@ func (m *TreeHeadUpdate) Reset()         { *m = TreeHeadUpdate{} }
 func (m *TreeHeadUpdate) String() string { return proto.CompactTextString(m) }
 func (*TreeHeadUpdate) ProtoMessage()    {}
 func (*TreeHeadUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{8}
+	return fileDescriptor_5033f0301ef9b772, []int{9}
 }
 func (m *TreeHeadUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -847,13 +938,14 @@ type TreeFullSyncRequest struct {
 	Heads        []string               `protobuf:"bytes,1,rep,name=heads,proto3" json:"heads,omitempty"`
 	Changes      []*RawTreeChangeWithId `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
 	SnapshotPath []string               `protobuf:"bytes,3,rep,name=snapshotPath,proto3" json:"snapshotPath,omitempty"`
+	Cursor       *TreeSyncCursor        `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 }
 
 func (m *TreeFullSyncRequest) Reset()         { *m = TreeFullSyncRequest{} }
 func (m *TreeFullSyncRequest) String() string { return proto.CompactTextString(m) }
 func (*TreeFullSyncRequest) ProtoMessage()    {}
 func (*TreeFullSyncRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{9}
+	return fileDescriptor_5033f0301ef9b772, []int{10}
 }
 func (m *TreeFullSyncRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -911,18 +1003,28 @@ func (m *TreeFullSyncRequest) GetSnapshotPath() []string {
 	return nil
 }
 
+func (m *TreeFullSyncRequest) GetCursor() *TreeSyncCursor {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
 // TreeFullSyncResponse is a message sent as a response for a specific full sync
 type TreeFullSyncResponse struct {
 	Heads        []string               `protobuf:"bytes,1,rep,name=heads,proto3" json:"heads,omitempty"`
 	Changes      []*RawTreeChangeWithId `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
 	SnapshotPath []string               `protobuf:"bytes,3,rep,name=snapshotPath,proto3" json:"snapshotPath,omitempty"`
+	Cursor       *TreeSyncCursor        `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	SentChanges  uint32                 `protobuf:"varint,5,opt,name=sentChanges,proto3" json:"sentChanges,omitempty"`
+	TotalChanges uint32                 `protobuf:"varint,6,opt,name=totalChanges,proto3" json:"totalChanges,omitempty"`
 }
 
 func (m *TreeFullSyncResponse) Reset()         { *m = TreeFullSyncResponse{} }
 func (m *TreeFullSyncResponse) String() string { return proto.CompactTextString(m) }
 func (*TreeFullSyncResponse) ProtoMessage()    {}
 func (*TreeFullSyncResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{10}
+	return fileDescriptor_5033f0301ef9b772, []int{11}
 }
 func (m *TreeFullSyncResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -980,6 +1082,88 @@ func (m *TreeFullSyncResponse) GetSnapshotPath() []string {
 	return nil
 }
 
+func (m *TreeFullSyncResponse) GetCursor() *TreeSyncCursor {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+func (m *TreeFullSyncResponse) GetSentChanges() uint32 {
+	if m != nil {
+		return m.SentChanges
+	}
+	return 0
+}
+
+func (m *TreeFullSyncResponse) GetTotalChanges() uint32 {
+	if m != nil {
+		return m.TotalChanges
+	}
+	return 0
+}
+
+// TreeSyncCursor points to the last change sent in full sync response, so the sync can be resumed after it
+type TreeSyncCursor struct {
+	ChangeId string `protobuf:"bytes,1,opt,name=changeId,proto3" json:"changeId,omitempty"`
+	OrderId  string `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+}
+
+func (m *TreeSyncCursor) Reset()         { *m = TreeSyncCursor{} }
+func (m *TreeSyncCursor) String() string { return proto.CompactTextString(m) }
+func (*TreeSyncCursor) ProtoMessage()    {}
+func (*TreeSyncCursor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5033f0301ef9b772, []int{12}
+}
+func (m *TreeSyncCursor) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TreeSyncCursor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TreeSyncCursor.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TreeSyncCursor) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *TreeSyncCursor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TreeSyncCursor.Merge(m, src)
+}
+func (m *TreeSyncCursor) XXX_Size() int {
+	return m.Size()
+}
+func (m *TreeSyncCursor) XXX_DiscardUnknown() {
+	xxx_messageInfo_TreeSyncCursor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TreeSyncCursor proto.InternalMessageInfo
+
+func (m *TreeSyncCursor) GetChangeId() string {
+	if m != nil {
+		return m.ChangeId
+	}
+	return ""
+}
+
+func (m *TreeSyncCursor) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
 // TreeErrorResponse is an error sent as a response for a full sync request
 type TreeErrorResponse struct {
 	Error   string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
@@ -990,7 +1174,7 @@ func (m *TreeErrorResponse) Reset()         { *m = TreeErrorResponse{} }
 func (m *TreeErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*TreeErrorResponse) ProtoMessage()    {}
 func (*TreeErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{11}
+	return fileDescriptor_5033f0301ef9b772, []int{13}
 }
 func (m *TreeErrorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1051,7 +1235,7 @@ func (m *TreeChangeInfo) Reset()         { *m = TreeChangeInfo{} }
 func (m *TreeChangeInfo) String() string { return proto.CompactTextString(m) }
 func (*TreeChangeInfo) ProtoMessage()    {}
 func (*TreeChangeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{12}
+	return fileDescriptor_5033f0301ef9b772, []int{14}
 }
 func (m *TreeChangeInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1105,6 +1289,7 @@ func (m *TreeChangeInfo) GetChangePayload() []byte {
 func init() {
 	proto.RegisterEnum("treechange.ErrorCodes", ErrorCodes_name, ErrorCodes_value)
 	proto.RegisterType((*RootChange)(nil), "treechange.RootChange")
+	proto.RegisterType((*ObjectAcl)(nil), "treechange.ObjectAcl")
 	proto.RegisterType((*TreeChange)(nil), "treechange.TreeChange")
 	proto.RegisterType((*NoDataTreeChange)(nil), "treechange.NoDataTreeChange")
 	proto.RegisterType((*ReducedTreeChange)(nil), "treechange.ReducedTreeChange")
@@ -1115,6 +1300,7 @@ func init() {
 	proto.RegisterType((*TreeHeadUpdate)(nil), "treechange.TreeHeadUpdate")
 	proto.RegisterType((*TreeFullSyncRequest)(nil), "treechange.TreeFullSyncRequest")
 	proto.RegisterType((*TreeFullSyncResponse)(nil), "treechange.TreeFullSyncResponse")
+	proto.RegisterType((*TreeSyncCursor)(nil), "treechange.TreeSyncCursor")
 	proto.RegisterType((*TreeErrorResponse)(nil), "treechange.TreeErrorResponse")
 	proto.RegisterType((*TreeChangeInfo)(nil), "treechange.TreeChangeInfo")
 }
@@ -1124,57 +1310,65 @@ func init() {
 }
 
 var fileDescriptor_5033f0301ef9b772 = []byte{
-	// 797 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xbd, 0x8e, 0xdb, 0x46,
-	0x10, 0xe6, 0x52, 0x77, 0xa7, 0xd3, 0x9c, 0x4e, 0x96, 0xd7, 0x57, 0x10, 0x46, 0xc2, 0x10, 0x44,
-	0x90, 0x08, 0x29, 0x7c, 0x80, 0x83, 0x14, 0x09, 0x02, 0x18, 0x39, 0xf9, 0x47, 0x82, 0x11, 0xc7,
-	0x58, 0xff, 0x04, 0x70, 0xb7, 0xe6, 0x8e, 0x4e, 0x0c, 0x24, 0x92, 0xe1, 0xae, 0xec, 0xe8, 0x01,
-	0xd2, 0x24, 0x80, 0xe1, 0xe7, 0xc8, 0x5b, 0xa4, 0x4b, 0xe9, 0x32, 0x65, 0x70, 0xf7, 0x0e, 0xa9,
-	0x83, 0xdd, 0x25, 0xc5, 0x1f, 0xa9, 0x70, 0xaa, 0x73, 0x43, 0x71, 0x3e, 0xce, 0x7c, 0xf3, 0xf1,
-	0x9b, 0xe5, 0xae, 0xe0, 0x4e, 0x94, 0x2e, 0x97, 0x69, 0x22, 0x33, 0x1e, 0xe1, 0x69, 0xfa, 0xf2,
-	0x27, 0x8c, 0xd4, 0xa9, 0xca, 0x11, 0xcd, 0x25, 0x9a, 0xf3, 0xe4, 0x1c, 0xb3, 0x3c, 0x55, 0xe9,
-	0xa9, 0xb9, 0xca, 0x1a, 0x7c, 0xcb, 0x20, 0x14, 0x2a, 0x24, 0xfc, 0x97, 0x00, 0xb0, 0x34, 0x55,
-	0x63, 0x13, 0xd2, 0x8f, 0xa0, 0xc7, 0xa3, 0xc5, 0x04, 0xb9, 0x98, 0x0a, 0x8f, 0x04, 0x64, 0xd4,
-	0x63, 0x15, 0x40, 0x3d, 0xe8, 0x9a, 0xae, 0x53, 0xe1, 0xb9, 0xe6, 0x59, 0x19, 0x52, 0x1f, 0xc0,
-	0x12, 0x3e, 0x5d, 0x67, 0xe8, 0x75, 0xcc, 0xc3, 0x1a, 0xa2, 0x79, 0x55, 0xbc, 0x44, 0xa9, 0xf8,
-	0x32, 0xf3, 0xf6, 0x02, 0x32, 0xea, 0xb0, 0x0a, 0xa0, 0x14, 0xf6, 0x24, 0xa2, 0xf0, 0xf6, 0x03,
-	0x32, 0xea, 0x33, 0x73, 0x4f, 0x6f, 0xc2, 0x61, 0x2c, 0x30, 0x51, 0xb1, 0x5a, 0x7b, 0x07, 0x06,
-	0xdf, 0xc4, 0xf4, 0x53, 0x38, 0xb6, 0xdc, 0x8f, 0xf9, 0x7a, 0x91, 0x72, 0xe1, 0x75, 0x4d, 0x42,
-	0x13, 0xd4, 0x3d, 0x63, 0x79, 0x17, 0xf3, 0xf8, 0x15, 0x0a, 0xef, 0x30, 0x20, 0xa3, 0x43, 0x56,
-	0x01, 0xe1, 0x1f, 0x2e, 0xc0, 0xd3, 0x1c, 0xb1, 0x78, 0xf1, 0x00, 0x8e, 0xb4, 0x2b, 0xf6, 0x45,
-	0xa5, 0x47, 0x82, 0xce, 0xa8, 0xc7, 0xea, 0x50, 0xd3, 0x1a, 0xb7, 0x6d, 0xcd, 0x67, 0x30, 0x90,
-	0x09, 0xcf, 0xe4, 0x3c, 0x55, 0x67, 0x5c, 0x6a, 0x87, 0xac, 0x09, 0x2d, 0x54, 0xf7, 0xb1, 0x2a,
-	0xe5, 0x5d, 0xae, 0xb8, 0xb1, 0xa2, 0xcf, 0xea, 0x90, 0xee, 0x93, 0x23, 0x17, 0x0f, 0x71, 0x3d,
-	0xb5, 0x8e, 0xf4, 0x58, 0x05, 0x34, 0x8d, 0x3c, 0x68, 0x1b, 0x59, 0x37, 0xad, 0xdb, 0x32, 0xcd,
-	0x07, 0x88, 0xe5, 0x93, 0x42, 0x4d, 0xe1, 0x47, 0x0d, 0xd1, 0xb5, 0x82, 0x2b, 0x6e, 0x06, 0xd8,
-	0x33, 0x6d, 0x37, 0x71, 0xf8, 0xc6, 0x85, 0xe1, 0xa3, 0x54, 0xcb, 0xbb, 0x02, 0xcb, 0x3e, 0x44,
-	0x43, 0xbe, 0x82, 0xeb, 0x0c, 0xc5, 0x2a, 0x42, 0xf1, 0x7f, 0x0c, 0x09, 0x1f, 0xc0, 0x31, 0xe3,
-	0xaf, 0x6b, 0x25, 0x1e, 0x74, 0xb3, 0x62, 0x0d, 0x13, 0x23, 0xaf, 0x0c, 0xf5, 0x7b, 0xc9, 0xf8,
-	0x3c, 0xe1, 0x6a, 0x95, 0xa3, 0xf1, 0xae, 0xcf, 0x2a, 0x20, 0x1c, 0xc3, 0x8d, 0x06, 0xd1, 0x8f,
-	0xb1, 0x9a, 0x17, 0x56, 0xf1, 0xd7, 0x16, 0x2a, 0x08, 0x2b, 0x80, 0x0e, 0xc0, 0x8d, 0xcb, 0x39,
-	0xb8, 0xb1, 0x08, 0xdf, 0x10, 0xb8, 0xa6, 0x29, 0x9e, 0xac, 0x93, 0xe8, 0x7b, 0x94, 0x92, 0x9f,
-	0x23, 0xfd, 0x06, 0xba, 0x51, 0x9a, 0x28, 0x4c, 0x94, 0xa9, 0x3f, 0xba, 0x1d, 0xdc, 0xaa, 0xed,
-	0x1f, 0x65, 0xf6, 0xd8, 0xa6, 0x3c, 0xe7, 0x8b, 0x15, 0xb2, 0xb2, 0x80, 0xde, 0x01, 0xc8, 0x37,
-	0x5b, 0x89, 0xe9, 0x73, 0x74, 0xfb, 0x93, 0x7a, 0xf9, 0x0e, 0xc9, 0xac, 0x56, 0x12, 0xfe, 0xe9,
-	0xc2, 0xc9, 0xae, 0x16, 0xf4, 0x5b, 0x80, 0x39, 0x72, 0xf1, 0x2c, 0x13, 0x5c, 0x61, 0x21, 0xec,
-	0x66, 0x5b, 0xd8, 0x64, 0x93, 0x31, 0x71, 0x58, 0x2d, 0x9f, 0x3e, 0x84, 0x6b, 0xb3, 0xd5, 0x62,
-	0xa1, 0x59, 0x19, 0xfe, 0xbc, 0x42, 0xa9, 0x76, 0x89, 0xd3, 0x14, 0xf7, 0x9b, 0x69, 0x13, 0x87,
-	0xb5, 0x2b, 0xe9, 0x23, 0x18, 0x56, 0x90, 0xcc, 0xd2, 0x44, 0xda, 0xfd, 0x6e, 0x87, 0x53, 0xf7,
-	0x5b, 0x79, 0x13, 0x87, 0x6d, 0xd5, 0xd2, 0x7b, 0x70, 0x8c, 0x79, 0x9e, 0xe6, 0x1b, 0xb2, 0x3d,
-	0x43, 0xf6, 0x71, 0x9b, 0xec, 0x5e, 0x3d, 0x69, 0xe2, 0xb0, 0x66, 0xd5, 0x59, 0x17, 0xf6, 0x5f,
-	0x69, 0xab, 0xc2, 0x5f, 0x09, 0x0c, 0x9a, 0x6e, 0xd0, 0x13, 0xd8, 0xd7, 0x6e, 0x94, 0x2b, 0xd2,
-	0x06, 0xf4, 0x6b, 0xe8, 0x16, 0xdb, 0x8e, 0xe7, 0x06, 0x9d, 0xf7, 0x19, 0x55, 0x99, 0x4f, 0x43,
-	0xe8, 0x97, 0xdf, 0xe8, 0x63, 0xae, 0xe6, 0x5e, 0xc7, 0xf0, 0x36, 0xb0, 0xf0, 0x37, 0x02, 0x37,
-	0x76, 0x58, 0x7a, 0x35, 0x62, 0x7e, 0x27, 0x76, 0x61, 0xb5, 0x27, 0x72, 0x35, 0x6a, 0xc6, 0x70,
-	0x7d, 0x6b, 0xa2, 0x5a, 0x89, 0x99, 0x68, 0x71, 0xea, 0xda, 0x40, 0xef, 0x0f, 0x98, 0xe7, 0xe3,
-	0x54, 0xd8, 0xef, 0x69, 0x8f, 0x95, 0x61, 0xf8, 0xdc, 0x8e, 0xd9, 0xaa, 0x98, 0x26, 0xb3, 0xb4,
-	0x75, 0x06, 0x93, 0xad, 0x33, 0x78, 0xeb, 0xd4, 0x74, 0x77, 0x9c, 0x9a, 0x5f, 0xbc, 0x00, 0x30,
-	0xc2, 0x74, 0x13, 0x49, 0x07, 0x00, 0xcf, 0x12, 0xfc, 0x25, 0xc3, 0x48, 0xa1, 0x18, 0x3a, 0x74,
-	0x08, 0xfd, 0x07, 0xa8, 0x36, 0xea, 0x87, 0x84, 0x7a, 0x70, 0xd2, 0x1a, 0xb1, 0x7d, 0xe2, 0xd2,
-	0x21, 0x1c, 0x99, 0xdb, 0x1f, 0x66, 0x33, 0x89, 0x6a, 0xf8, 0xb6, 0x73, 0xf6, 0xdd, 0x5f, 0x17,
-	0x3e, 0x79, 0x77, 0xe1, 0x93, 0x7f, 0x2e, 0x7c, 0xf2, 0xf6, 0xd2, 0x77, 0xde, 0x5d, 0xfa, 0xce,
-	0xdf, 0x97, 0xbe, 0xf3, 0xe2, 0xf3, 0xf7, 0xfc, 0x4f, 0xf3, 0xf2, 0xc0, 0xfc, 0x7c, 0xf9, 0x5f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x59, 0x45, 0x6c, 0x05, 0x09, 0x00, 0x00,
+	// 920 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x4d, 0x6f, 0x1b, 0x37,
+	0x10, 0xd5, 0xae, 0x6c, 0xc9, 0x1a, 0xc9, 0x8a, 0xcc, 0xb8, 0xc0, 0x22, 0x68, 0x55, 0x61, 0xd1,
+	0x0f, 0xa1, 0x28, 0x62, 0xc0, 0x41, 0x0f, 0x2d, 0x0a, 0x04, 0xb1, 0x12, 0x47, 0x46, 0xd0, 0x24,
+	0x60, 0x3e, 0x0a, 0xe4, 0xc6, 0x2c, 0x47, 0xd6, 0x16, 0xf2, 0x52, 0x25, 0x29, 0xbb, 0xfa, 0x01,
+	0xb9, 0x16, 0xf9, 0x41, 0x45, 0x81, 0xde, 0x7a, 0xcc, 0x31, 0xbd, 0x15, 0xf6, 0x1f, 0x09, 0x48,
+	0xee, 0x6a, 0x3f, 0xa4, 0x43, 0x72, 0x0a, 0x72, 0x91, 0x76, 0x1e, 0x67, 0xde, 0xcc, 0xbe, 0xc7,
+	0x25, 0x08, 0xb7, 0x23, 0x71, 0x76, 0x26, 0x12, 0x35, 0x67, 0x11, 0x1e, 0x88, 0x97, 0xbf, 0x61,
+	0xa4, 0x0f, 0xb4, 0x44, 0xb4, 0x3f, 0xd1, 0x94, 0x25, 0xa7, 0x38, 0x97, 0x42, 0x8b, 0x03, 0xfb,
+	0xab, 0x0a, 0xf0, 0x4d, 0x8b, 0x10, 0xc8, 0x91, 0xf0, 0x3f, 0x1f, 0x80, 0x0a, 0xa1, 0x47, 0x36,
+	0x24, 0x9f, 0x43, 0x8b, 0x45, 0xb3, 0x31, 0x32, 0x7e, 0xc2, 0x03, 0x6f, 0xe0, 0x0d, 0x5b, 0x34,
+	0x07, 0x48, 0x00, 0x4d, 0xdb, 0xf5, 0x84, 0x07, 0xbe, 0x5d, 0xcb, 0x42, 0xd2, 0x07, 0x70, 0x84,
+	0x4f, 0x97, 0x73, 0x0c, 0xea, 0x76, 0xb1, 0x80, 0x18, 0x5e, 0x1d, 0x9f, 0xa1, 0xd2, 0xec, 0x6c,
+	0x1e, 0x6c, 0x0d, 0xbc, 0x61, 0x9d, 0xe6, 0x00, 0x21, 0xb0, 0xa5, 0x10, 0x79, 0xb0, 0x3d, 0xf0,
+	0x86, 0x1d, 0x6a, 0x9f, 0xc9, 0x0d, 0xd8, 0x89, 0x39, 0x26, 0x3a, 0xd6, 0xcb, 0xa0, 0x61, 0xf1,
+	0x55, 0x4c, 0xbe, 0x82, 0x5d, 0xc7, 0xfd, 0x98, 0x2d, 0x67, 0x82, 0xf1, 0xa0, 0x69, 0x13, 0xca,
+	0xa0, 0xe9, 0x19, 0xab, 0xbb, 0x28, 0xe3, 0x73, 0xe4, 0xc1, 0xce, 0xc0, 0x1b, 0xee, 0xd0, 0x1c,
+	0x20, 0xb7, 0xa0, 0xe5, 0xb4, 0xbb, 0x13, 0xcd, 0x82, 0xd6, 0xc0, 0x1b, 0xb6, 0x0f, 0x3f, 0xbb,
+	0x59, 0x90, 0xea, 0x51, 0xb6, 0x48, 0xf3, 0x3c, 0xf2, 0x3d, 0xec, 0x71, 0x3c, 0x8f, 0x23, 0x1c,
+	0xa1, 0xd4, 0xf1, 0x24, 0x8e, 0x98, 0xc6, 0x00, 0x6c, 0xf3, 0xf5, 0x85, 0xf0, 0x6b, 0x68, 0xad,
+	0x58, 0x8c, 0x76, 0x17, 0x32, 0xd6, 0x28, 0x55, 0xe0, 0x0d, 0xea, 0xc3, 0x0e, 0xcd, 0xc2, 0xf0,
+	0xad, 0x0f, 0xf0, 0x54, 0x22, 0xa6, 0x16, 0x0c, 0xa0, 0x6d, 0xc6, 0x70, 0x92, 0xbb, 0xe4, 0x16,
+	0x2d, 0x42, 0x65, 0x93, 0xfc, 0xaa, 0x49, 0xdf, 0x40, 0x57, 0x25, 0x6c, 0xae, 0xa6, 0x42, 0x1f,
+	0x31, 0x65, 0xbc, 0x72, 0x76, 0x54, 0x50, 0xd3, 0xc7, 0xbd, 0xaa, 0xba, 0xcb, 0x34, 0xb3, 0xa6,
+	0x74, 0x68, 0x11, 0x32, 0x7d, 0x24, 0x32, 0xfe, 0x00, 0x97, 0x27, 0xce, 0x9b, 0x16, 0xcd, 0x81,
+	0xb2, 0xa5, 0x8d, 0xaa, 0xa5, 0x45, 0xfb, 0x9a, 0x15, 0xfb, 0xfa, 0x00, 0xb1, 0x7a, 0x92, 0x4e,
+	0x93, 0x3a, 0x53, 0x40, 0x4c, 0x2d, 0x67, 0x9a, 0xd9, 0xad, 0xd4, 0xb2, 0x6d, 0x57, 0xf1, 0x07,
+	0x3a, 0xf0, 0x97, 0x0f, 0xbd, 0x87, 0xc2, 0xbc, 0xcc, 0x47, 0x10, 0xf8, 0xd3, 0x97, 0xef, 0x07,
+	0xd8, 0xa3, 0xc8, 0x17, 0x11, 0xf2, 0x0f, 0x91, 0x2f, 0xbc, 0x0f, 0xbb, 0x94, 0x5d, 0x14, 0x4a,
+	0x02, 0x68, 0xce, 0xd3, 0x2f, 0xd5, 0xb3, 0xbd, 0xb2, 0xd0, 0xa8, 0xa0, 0xe2, 0xd3, 0x84, 0xe9,
+	0x85, 0x44, 0xab, 0x74, 0x87, 0xe6, 0x40, 0x38, 0x82, 0xeb, 0x25, 0xa2, 0x5f, 0x63, 0x3d, 0x4d,
+	0x85, 0x65, 0x17, 0x0e, 0x4a, 0x09, 0x73, 0x80, 0x74, 0xc1, 0x8f, 0x33, 0xd7, 0xfc, 0x98, 0x87,
+	0x7f, 0x7a, 0x70, 0xcd, 0x50, 0x3c, 0x59, 0x26, 0xd1, 0x2f, 0xa8, 0x14, 0x3b, 0x45, 0xf2, 0x13,
+	0x34, 0x23, 0x91, 0x68, 0x4c, 0xb4, 0xad, 0x6f, 0x1f, 0x0e, 0x8a, 0x9f, 0x7e, 0x96, 0x3d, 0x72,
+	0x29, 0xcf, 0xd9, 0x6c, 0x81, 0x34, 0x2b, 0x20, 0xb7, 0x01, 0xe4, 0xea, 0xc0, 0xb4, 0x7d, 0xda,
+	0x87, 0x5f, 0x16, 0xcb, 0x37, 0x8c, 0x4c, 0x0b, 0x25, 0xe1, 0x3f, 0x3e, 0xec, 0x6f, 0x6a, 0x41,
+	0x7e, 0x06, 0x98, 0x22, 0xe3, 0xcf, 0xe6, 0xdc, 0xb8, 0xe2, 0x06, 0xbb, 0x51, 0x1d, 0x6c, 0xbc,
+	0xca, 0x18, 0xd7, 0x68, 0x21, 0x9f, 0x3c, 0x80, 0x6b, 0x93, 0xc5, 0x6c, 0x66, 0x58, 0x29, 0xfe,
+	0xbe, 0x40, 0xa5, 0x37, 0x0d, 0x67, 0x28, 0x8e, 0xcb, 0x69, 0xe3, 0x1a, 0xad, 0x56, 0x92, 0x87,
+	0xd0, 0xcb, 0x21, 0x35, 0x17, 0x89, 0x72, 0xa7, 0xfa, 0x06, 0xa5, 0x8e, 0x2b, 0x79, 0xe3, 0x1a,
+	0x5d, 0xab, 0x25, 0xf7, 0x60, 0x17, 0xa5, 0x14, 0x72, 0x45, 0xb6, 0x65, 0xc9, 0xbe, 0xa8, 0x92,
+	0xdd, 0x2b, 0x26, 0x8d, 0x6b, 0xb4, 0x5c, 0x75, 0xd4, 0x84, 0xed, 0x73, 0x23, 0x55, 0xf8, 0xca,
+	0x83, 0x6e, 0x59, 0x0d, 0xb2, 0x0f, 0xdb, 0x46, 0x8d, 0x6c, 0x47, 0xba, 0x80, 0xfc, 0x08, 0xcd,
+	0xf4, 0x48, 0x0b, 0xfc, 0x41, 0xfd, 0x7d, 0xac, 0xca, 0xf2, 0x49, 0x08, 0x9d, 0xec, 0x8b, 0x7e,
+	0xcc, 0xf4, 0x34, 0xa8, 0x5b, 0xde, 0x12, 0x16, 0xfe, 0xed, 0xc1, 0xf5, 0x0d, 0x92, 0x7e, 0x94,
+	0x61, 0xc8, 0x21, 0x34, 0xa2, 0x85, 0x54, 0x42, 0x06, 0x5b, 0x9b, 0xf7, 0x8e, 0xdd, 0x71, 0x36,
+	0x83, 0xa6, 0x99, 0xe1, 0xab, 0x74, 0x33, 0x56, 0x5d, 0xfc, 0x64, 0xde, 0xc0, 0x9c, 0x47, 0x0a,
+	0x93, 0xf4, 0xe3, 0x52, 0xf6, 0xa0, 0xdd, 0xa5, 0x45, 0xc8, 0x74, 0xd6, 0x42, 0xb3, 0x59, 0x96,
+	0xd2, 0xb0, 0x29, 0x25, 0x2c, 0x3c, 0x86, 0x6e, 0x99, 0xdf, 0x1c, 0xa3, 0xae, 0xf1, 0xea, 0x26,
+	0xb4, 0x8a, 0xcd, 0x81, 0x26, 0x24, 0x47, 0x99, 0x5f, 0x84, 0xd2, 0x30, 0x1c, 0xc1, 0xde, 0xda,
+	0x3e, 0x36, 0x5a, 0xda, 0x7d, 0x9c, 0xf2, 0xb8, 0xc0, 0x90, 0xa0, 0x94, 0x23, 0xc1, 0xdd, 0x29,
+	0xb2, 0x45, 0xb3, 0x30, 0x7c, 0xee, 0x86, 0x71, 0xb3, 0x9d, 0x24, 0x13, 0x51, 0xb9, 0x5f, 0x79,
+	0x6b, 0xf7, 0xab, 0xb5, 0x1b, 0x91, 0xbf, 0xe1, 0x46, 0xf4, 0xdd, 0x0b, 0x00, 0x3b, 0x98, 0x69,
+	0xa2, 0x48, 0x17, 0xe0, 0x59, 0x82, 0x7f, 0xcc, 0x31, 0xd2, 0xc8, 0x7b, 0x35, 0xd2, 0x83, 0xce,
+	0x7d, 0xd4, 0xab, 0xe9, 0x7b, 0x1e, 0x09, 0x60, 0xbf, 0xb2, 0xb1, 0xdd, 0x8a, 0x4f, 0x7a, 0xd0,
+	0xb6, 0x8f, 0x8f, 0x26, 0x13, 0x85, 0xba, 0xf7, 0xba, 0x7e, 0x74, 0xe7, 0xdf, 0xcb, 0xbe, 0xf7,
+	0xe6, 0xb2, 0xef, 0xfd, 0x7f, 0xd9, 0xf7, 0x5e, 0x5f, 0xf5, 0x6b, 0x6f, 0xae, 0xfa, 0xb5, 0xb7,
+	0x57, 0xfd, 0xda, 0x8b, 0x6f, 0xdf, 0xf3, 0xbe, 0xfa, 0xb2, 0x61, 0xff, 0x6e, 0xbd, 0x1b, 0x00,
+	0x9c, 0x83, 0xb4, 0x43, 0xe1, 0x0a, 0x00, 0x00,
 }
 
 func (m *RootChange) Marshal() (dAtA []byte, err error) {
@@ -1197,6 +1391,25 @@ func (m *RootChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeviceCertificate) > 0 {
+		i -= len(m.DeviceCertificate)
+		copy(dAtA[i:], m.DeviceCertificate)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.DeviceCertificate)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.ObjectAcl != nil {
+		{
+			size, err := m.ObjectAcl.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTreechange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
 	if m.IsDerived {
 		i--
 		if m.IsDerived {
@@ -1257,6 +1470,38 @@ func (m *RootChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ObjectAcl) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectAcl) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObjectAcl) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Writers) > 0 {
+		for iNdEx := len(m.Writers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Writers[iNdEx])
+			copy(dAtA[i:], m.Writers[iNdEx])
+			i = encodeVarintTreechange(dAtA, i, uint64(len(m.Writers[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TreeChange) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1277,6 +1522,13 @@ func (m *TreeChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeviceCertificate) > 0 {
+		i -= len(m.DeviceCertificate)
+		copy(dAtA[i:], m.DeviceCertificate)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.DeviceCertificate)))
+		i--
+		dAtA[i] = 0x52
+	}
 	if len(m.DataType) > 0 {
 		i -= len(m.DataType)
 		copy(dAtA[i:], m.DataType)
@@ -1366,6 +1618,13 @@ func (m *NoDataTreeChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeviceCertificate) > 0 {
+		i -= len(m.DeviceCertificate)
+		copy(dAtA[i:], m.DeviceCertificate)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.DeviceCertificate)))
+		i--
+		dAtA[i] = 0x52
+	}
 	if len(m.DataType) > 0 {
 		i -= len(m.DataType)
 		copy(dAtA[i:], m.DataType)
@@ -1772,6 +2031,18 @@ func (m *TreeFullSyncRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Cursor != nil {
+		{
+			size, err := m.Cursor.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTreechange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.SnapshotPath) > 0 {
 		for iNdEx := len(m.SnapshotPath) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.SnapshotPath[iNdEx])
@@ -1827,6 +2098,28 @@ func (m *TreeFullSyncResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.TotalChanges != 0 {
+		i = encodeVarintTreechange(dAtA, i, uint64(m.TotalChanges))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.SentChanges != 0 {
+		i = encodeVarintTreechange(dAtA, i, uint64(m.SentChanges))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Cursor != nil {
+		{
+			size, err := m.Cursor.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTreechange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.SnapshotPath) > 0 {
 		for iNdEx := len(m.SnapshotPath) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.SnapshotPath[iNdEx])
@@ -1862,6 +2155,43 @@ func (m *TreeFullSyncResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *TreeSyncCursor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TreeSyncCursor) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TreeSyncCursor) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.OrderId) > 0 {
+		i -= len(m.OrderId)
+		copy(dAtA[i:], m.OrderId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.OrderId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChangeId) > 0 {
+		i -= len(m.ChangeId)
+		copy(dAtA[i:], m.ChangeId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.ChangeId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TreeErrorResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1981,6 +2311,29 @@ func (m *RootChange) Size() (n int) {
 	if m.IsDerived {
 		n += 2
 	}
+	if m.ObjectAcl != nil {
+		l = m.ObjectAcl.Size()
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.DeviceCertificate)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	return n
+}
+
+func (m *ObjectAcl) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Writers) > 0 {
+		for _, b := range m.Writers {
+			l = len(b)
+			n += 1 + l + sovTreechange(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2026,6 +2379,10 @@ func (m *TreeChange) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTreechange(uint64(l))
 	}
+	l = len(m.DeviceCertificate)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
 	return n
 }
 
@@ -2067,6 +2424,10 @@ func (m *NoDataTreeChange) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTreechange(uint64(l))
 	}
+	l = len(m.DeviceCertificate)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
 	return n
 }
 
@@ -2247,6 +2608,10 @@ func (m *TreeFullSyncRequest) Size() (n int) {
 			n += 1 + l + sovTreechange(uint64(l))
 		}
 	}
+	if m.Cursor != nil {
+		l = m.Cursor.Size()
+		n += 1 + l + sovTreechange(uint64(l))
+	}
 	return n
 }
 
@@ -2274,16 +2639,43 @@ func (m *TreeFullSyncResponse) Size() (n int) {
 			n += 1 + l + sovTreechange(uint64(l))
 		}
 	}
+	if m.Cursor != nil {
+		l = m.Cursor.Size()
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	if m.SentChanges != 0 {
+		n += 1 + sovTreechange(uint64(m.SentChanges))
+	}
+	if m.TotalChanges != 0 {
+		n += 1 + sovTreechange(uint64(m.TotalChanges))
+	}
 	return n
 }
 
-func (m *TreeErrorResponse) Size() (n int) {
+func (m *TreeSyncCursor) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Error)
+	l = len(m.ChangeId)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.OrderId)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	return n
+}
+
+func (m *TreeErrorResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Error)
 	if l > 0 {
 		n += 1 + l + sovTreechange(uint64(l))
 	}
@@ -2582,6 +2974,158 @@ func (m *RootChange) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.IsDerived = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectAcl", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectAcl == nil {
+				m.ObjectAcl = &ObjectAcl{}
+			}
+			if err := m.ObjectAcl.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceCertificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceCertificate = append(m.DeviceCertificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceCertificate == nil {
+				m.DeviceCertificate = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTreechange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ObjectAcl) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTreechange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ObjectAcl: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ObjectAcl: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Writers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Writers = append(m.Writers, make([]byte, postIndex-iNdEx))
+			copy(m.Writers[len(m.Writers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTreechange(dAtA[iNdEx:])
@@ -2899,6 +3443,40 @@ func (m *TreeChange) Unmarshal(dAtA []byte) error {
 			}
 			m.DataType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceCertificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceCertificate = append(m.DeviceCertificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceCertificate == nil {
+				m.DeviceCertificate = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTreechange(dAtA[iNdEx:])
@@ -3182,6 +3760,40 @@ func (m *NoDataTreeChange) Unmarshal(dAtA []byte) error {
 			}
 			m.DataType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceCertificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceCertificate = append(m.DeviceCertificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceCertificate == nil {
+				m.DeviceCertificate = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTreechange(dAtA[iNdEx:])
@@ -4106,6 +4718,42 @@ func (m *TreeFullSyncRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.SnapshotPath = append(m.SnapshotPath, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cursor == nil {
+				m.Cursor = &TreeSyncCursor{}
+			}
+			if err := m.Cursor.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTreechange(dAtA[iNdEx:])
@@ -4254,6 +4902,194 @@ func (m *TreeFullSyncResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.SnapshotPath = append(m.SnapshotPath, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cursor == nil {
+				m.Cursor = &TreeSyncCursor{}
+			}
+			if err := m.Cursor.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SentChanges", wireType)
+			}
+			m.SentChanges = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SentChanges |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalChanges", wireType)
+			}
+			m.TotalChanges = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalChanges |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTreechange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TreeSyncCursor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTreechange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TreeSyncCursor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TreeSyncCursor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChangeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTreechange(dAtA[iNdEx:])
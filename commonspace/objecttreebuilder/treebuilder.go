@@ -22,6 +22,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/spacestate"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/sync"
+	"github.com/anyproto/any-sync/commonspace/sync/syncdeps"
 	"github.com/anyproto/any-sync/commonspace/syncstatus"
 	"github.com/anyproto/any-sync/nodeconf"
 )
@@ -155,6 +156,10 @@ func (t *treeBuilder) BuildTree(ctx context.Context, id string, opts BuildTreeOp
 		ValidateObjectTree: opts.TreeValidator,
 		StatsCollector:     t.treeStats,
 	}
+	// the tree is built on demand, unless the caller like the bulk sync sets the priority
+	if _, ok := syncdeps.CtxPriority(ctx); !ok {
+		ctx = syncdeps.CtxWithPriority(ctx, syncdeps.PriorityInteractive)
+	}
 	t.treesUsed.Add(1)
 	t.log.Debug("incrementing counter", zap.String("id", id), zap.Int32("trees", t.treesUsed.Load()))
 	if ot, err = synctree.BuildSyncTreeOrGetRemote(ctx, id, deps); err != nil {
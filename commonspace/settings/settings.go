@@ -7,6 +7,7 @@ import (
 
 	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/config"
 	"github.com/anyproto/any-sync/commonspace/deletionmanager"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree"
@@ -64,6 +65,9 @@ func (s *settings) Init(a *app.App) (err error) {
 		Configuration: s.configuration,
 		DelManager:    a.MustComponent(deletionmanager.CName).(deletionmanager.DeletionManager),
 	}
+	if cfg, ok := a.Component("config").(config.ConfigGetter); ok {
+		deps.SnapshotPolicies = objecttree.SnapshotPoliciesFromIntervals(cfg.GetSpace().SnapshotIntervals)
+	}
 	s.settingsObject = NewSettingsObject(deps, sharedState.SpaceId)
 	return nil
 }
@@ -13,12 +13,14 @@ import (
 
 	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/updatelistener"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/object/treemanager"
 	"github.com/anyproto/any-sync/commonspace/settings/settingsstate"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	"github.com/anyproto/any-sync/nodeconf"
@@ -41,7 +43,7 @@ var (
 )
 
 var (
-	DoSnapshot       = objecttree.DoSnapshot
+	DoSnapshot       = objecttree.SnapshotPolicy.DoSnapshot
 	buildHistoryTree = func(objTree objecttree.ObjectTree) (objecttree.ReadableObjectTree, error) {
 		return objecttree.BuildHistoryTree(objecttree.HistoryTreeParams{
 			Storage: objTree.Storage(),
@@ -59,6 +61,8 @@ type Deps struct {
 	Store         spacestorage.SpaceStorage
 	Configuration nodeconf.NodeConf
 	DelManager    deletionmanager.DeletionManager
+	// SnapshotPolicies are the snapshot policies of the space, objecttree.DefaultSnapshotPolicy is used if they are empty
+	SnapshotPolicies objecttree.SnapshotPolicies
 	// testing dependencies
 	builder       settingsstate.StateBuilder
 	changeFactory settingsstate.ChangeFactory
@@ -75,6 +79,7 @@ type settingsObject struct {
 	state           *settingsstate.State
 	deletionManager deletionmanager.DeletionManager
 	changeFactory   settingsstate.ChangeFactory
+	snapshotPolicy  objecttree.SnapshotPolicy
 }
 
 func NewSettingsObject(deps Deps, spaceId string) (obj SettingsObject) {
@@ -92,6 +97,10 @@ func NewSettingsObject(deps Deps, spaceId string) (obj SettingsObject) {
 	} else {
 		changeFactory = deps.changeFactory
 	}
+	policies := deps.SnapshotPolicies
+	if policies.Default == (objecttree.SnapshotPolicy{}) && policies.ChangeTypes == nil {
+		policies = objecttree.NewSnapshotPolicies(nil)
+	}
 
 	s := &settingsObject{
 		spaceId:         spaceId,
@@ -101,6 +110,7 @@ func NewSettingsObject(deps Deps, spaceId string) (obj SettingsObject) {
 		builder:         builder,
 		deletionManager: deps.DelManager,
 		changeFactory:   changeFactory,
+		snapshotPolicy:  policies.Policy(spacepayloads.SpaceReserved),
 	}
 	obj = s
 	return
@@ -194,7 +204,10 @@ func (s *settingsObject) DeleteObject(ctx context.Context, id string) (err error
 	if entry.IsDerived {
 		return ErrCantDeleteDerivedObject
 	}
-	isSnapshot := DoSnapshot(s.Len())
+	if !s.canDeleteObjects() {
+		return list.ErrInsufficientPermissions
+	}
+	isSnapshot := DoSnapshot(s.snapshotPolicy, s.ChangesSinceSnapshot())
 	res, err := s.changeFactory.CreateObjectDeleteChange(id, s.state, isSnapshot)
 	if err != nil {
 		return
@@ -203,6 +216,13 @@ func (s *settingsObject) DeleteObject(ctx context.Context, id string) (err error
 	return s.addContent(res, isSnapshot)
 }
 
+func (s *settingsObject) canDeleteObjects() bool {
+	aclList := s.AclList()
+	aclList.RLock()
+	defer aclList.RUnlock()
+	return aclList.AclState().Capabilities(s.account.Account().SignKey.GetPublic()).Has(list.CapabilityDeleteObjects)
+}
+
 func (s *settingsObject) addContent(data []byte, isSnapshot bool) (err error) {
 	accountData := s.account.Account()
 	res, err := s.AddContent(context.Background(), objecttree.SignableChangeContent{
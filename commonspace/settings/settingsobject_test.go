@@ -16,6 +16,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/headsync/statestorage"
 	"github.com/anyproto/any-sync/commonspace/headsync/statestorage/mock_statestorage"
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree/mock_objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree"
@@ -24,6 +25,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/object/treemanager/mock_treemanager"
 	"github.com/anyproto/any-sync/commonspace/settings/settingsstate"
 	"github.com/anyproto/any-sync/commonspace/settings/settingsstate/mock_settingsstate"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
 	"github.com/anyproto/any-sync/commonspace/spacestorage/mock_spacestorage"
 )
 
@@ -150,11 +152,11 @@ func TestSettingsObject_DeleteObject_NoSnapshot(t *testing.T) {
 	defer fx.stop(t)
 	fx.init(t)
 	delId := "delId"
-	DoSnapshot = func(len int) bool {
+	DoSnapshot = func(policy objecttree.SnapshotPolicy, changes int) bool {
 		return false
 	}
 	fx.syncTree.EXPECT().Id().Return("syncId")
-	fx.syncTree.EXPECT().Len().Return(10)
+	fx.syncTree.EXPECT().ChangesSinceSnapshot().Return(10)
 	fx.headStorage.EXPECT().GetEntry(gomock.Any(), gomock.Any()).Return(headstorage.HeadsEntry{
 		IsDerived: false,
 	}, nil)
@@ -164,7 +166,10 @@ func TestSettingsObject_DeleteObject_NoSnapshot(t *testing.T) {
 
 	accountData, err := accountdata.NewRandom()
 	require.NoError(t, err)
-	fx.account.EXPECT().Account().Return(accountData)
+	aclList, err := list.NewInMemoryDerivedAcl(fx.spaceId, accountData)
+	require.NoError(t, err)
+	fx.syncTree.EXPECT().AclList().Return(aclList)
+	fx.account.EXPECT().Account().Return(accountData).Times(2)
 	fx.syncTree.EXPECT().AddContent(gomock.Any(), objecttree.SignableChangeContent{
 		Data:        res,
 		Key:         accountData.SignKey,
@@ -183,11 +188,11 @@ func TestSettingsObject_DeleteObject_WithSnapshot(t *testing.T) {
 	defer fx.stop(t)
 	fx.init(t)
 	delId := "delId"
-	DoSnapshot = func(len int) bool {
+	DoSnapshot = func(policy objecttree.SnapshotPolicy, changes int) bool {
 		return true
 	}
 	fx.syncTree.EXPECT().Id().Return("syncId")
-	fx.syncTree.EXPECT().Len().Return(10)
+	fx.syncTree.EXPECT().ChangesSinceSnapshot().Return(10)
 	fx.headStorage.EXPECT().GetEntry(gomock.Any(), gomock.Any()).Return(headstorage.HeadsEntry{
 		IsDerived: false,
 	}, nil)
@@ -197,7 +202,10 @@ func TestSettingsObject_DeleteObject_WithSnapshot(t *testing.T) {
 
 	accountData, err := accountdata.NewRandom()
 	require.NoError(t, err)
-	fx.account.EXPECT().Account().Return(accountData)
+	aclList, err := list.NewInMemoryDerivedAcl(fx.spaceId, accountData)
+	require.NoError(t, err)
+	fx.syncTree.EXPECT().AclList().Return(aclList)
+	fx.account.EXPECT().Account().Return(accountData).Times(2)
 	fx.syncTree.EXPECT().AddContent(gomock.Any(), objecttree.SignableChangeContent{
 		Data:        res,
 		Key:         accountData.SignKey,
@@ -216,7 +224,7 @@ func TestSettingsObject_DeleteDerivedObject(t *testing.T) {
 	defer fx.stop(t)
 	fx.init(t)
 	delId := "delId"
-	DoSnapshot = func(len int) bool {
+	DoSnapshot = func(policy objecttree.SnapshotPolicy, changes int) bool {
 		return false
 	}
 	fx.syncTree.EXPECT().Id().Return("syncId")
@@ -256,3 +264,34 @@ func TestSettingsObject_Update(t *testing.T) {
 
 	fx.doc.Update(fx.doc)
 }
+
+func TestSettingsObject_DeleteObject_NoPermissions(t *testing.T) {
+	fx := newSettingsFixture(t)
+	defer fx.stop(t)
+	fx.init(t)
+	fx.syncTree.EXPECT().Id().Return("syncId")
+	fx.headStorage.EXPECT().GetEntry(gomock.Any(), gomock.Any()).Return(headstorage.HeadsEntry{
+		IsDerived: false,
+	}, nil)
+	fx.doc.state = &settingsstate.State{LastIteratedId: "someId"}
+
+	ownerData, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	aclList, err := list.NewInMemoryDerivedAcl(fx.spaceId, ownerData)
+	require.NoError(t, err)
+	accountData, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	fx.syncTree.EXPECT().AclList().Return(aclList)
+	fx.account.EXPECT().Account().Return(accountData)
+	err = fx.doc.DeleteObject(ctx, "delId")
+	require.ErrorIs(t, err, list.ErrInsufficientPermissions)
+}
+
+func TestSettingsObject_SnapshotPolicy(t *testing.T) {
+	doc := NewSettingsObject(Deps{}, "spaceId").(*settingsObject)
+	require.Equal(t, objecttree.DefaultSnapshotPolicy, doc.snapshotPolicy)
+	doc = NewSettingsObject(Deps{
+		SnapshotPolicies: objecttree.SnapshotPoliciesFromIntervals(map[string]int{spacepayloads.SpaceReserved: 10}),
+	}, "spaceId").(*settingsObject)
+	require.Equal(t, objecttree.SnapshotPolicy{Interval: 10}, doc.snapshotPolicy)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/commonspace/acl/aclclient"
+	"github.com/anyproto/any-sync/commonspace/changefeed"
 	"github.com/anyproto/any-sync/commonspace/headsync"
 	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
@@ -58,6 +59,8 @@ type Space interface {
 	SyncStatus() syncstatus.StatusUpdater
 	Storage() spacestorage.SpaceStorage
 	KeyValue() kvinterfaces.KeyValueService
+	// ChangeFeed returns nil if the feed is not enabled in Deps
+	ChangeFeed() changefeed.ChangeFeed
 
 	DeleteTree(ctx context.Context, id string) (err error)
 	GetNodePeers(ctx context.Context) (peer []peer.Peer, err error)
@@ -86,6 +89,7 @@ type space struct {
 	storage      spacestorage.SpaceStorage
 	aclClient    aclclient.AclSpaceClient
 	keyValue     kvinterfaces.KeyValueService
+	changeFeed   changefeed.ChangeFeed
 	aclList      list.AclList
 	creationTime time.Time
 }
@@ -140,10 +144,16 @@ func (s *space) DeleteTree(ctx context.Context, id string) (err error) {
 }
 
 func (s *space) HandleMessage(peerCtx context.Context, msg *objectmessages.HeadUpdate) (err error) {
+	if err = list.CheckPeerDevice(peerCtx, s.aclList); err != nil {
+		return
+	}
 	return s.syncService.HandleMessage(peerCtx, msg)
 }
 
 func (s *space) HandleStreamSyncRequest(ctx context.Context, req *spacesyncproto.ObjectSyncMessage, stream drpc.Stream) (err error) {
+	if err = list.CheckPeerDevice(ctx, s.aclList); err != nil {
+		return
+	}
 	peerId, err := peer.CtxPeerId(ctx)
 	if err != nil {
 		return
@@ -153,6 +163,9 @@ func (s *space) HandleStreamSyncRequest(ctx context.Context, req *spacesyncproto
 }
 
 func (s *space) HandleRangeRequest(ctx context.Context, req *spacesyncproto.HeadSyncRequest) (resp *spacesyncproto.HeadSyncResponse, err error) {
+	if err = list.CheckPeerDevice(ctx, s.aclList); err != nil {
+		return
+	}
 	return s.headSync.HandleRangeRequest(ctx, req)
 }
 
@@ -198,6 +211,7 @@ func (s *space) Init(ctx context.Context) (err error) {
 	s.treeSyncer = s.app.MustComponent(treesyncer.CName).(treesyncer.TreeSyncer)
 	s.aclClient = s.app.MustComponent(aclclient.CName).(aclclient.AclSpaceClient)
 	s.keyValue = s.app.MustComponent(kvinterfaces.CName).(kvinterfaces.KeyValueService)
+	s.changeFeed, _ = s.app.Component(changefeed.CName).(changefeed.ChangeFeed)
 	return
 }
 
@@ -209,6 +223,10 @@ func (s *space) KeyValue() kvinterfaces.KeyValueService {
 	return s.keyValue
 }
 
+func (s *space) ChangeFeed() changefeed.ChangeFeed {
+	return s.changeFeed
+}
+
 func (s *space) Storage() spacestorage.SpaceStorage {
 	return s.storage
 }
@@ -0,0 +1,103 @@
+package commonspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/memstorage"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/net/peer"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestSpaceRejectsRevokedDevice(t *testing.T) {
+	fx := newFixture(t)
+	acc := fx.account.Account()
+	ctx := context.Background()
+	metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	sp, err := fx.spaceService.CreateSpace(ctx, spacepayloads.SpaceCreatePayload{
+		SigningKey:     acc.SignKey,
+		SpaceType:      "type",
+		ReadKey:        crypto.NewAES(),
+		MetadataKey:    metadataKey,
+		ReplicationKey: 10,
+		MasterKey:      acc.PeerKey,
+	})
+	require.NoError(t, err)
+	spc, err := fx.spaceService.NewSpace(ctx, sp, mockDeps())
+	require.NoError(t, err)
+	fx.treeManager.space = spc
+	require.NoError(t, spc.Init(ctx))
+	close(fx.treeManager.waitLoad)
+	defer spc.Close()
+
+	// the account revokes one of its devices
+	_, revokedKey, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	cert, err := crypto.NewDeviceCertificate(acc.SignKey, revokedKey)
+	require.NoError(t, err)
+	acl := spc.Acl()
+	acl.Lock()
+	rec, err := acl.RecordBuilder().BuildDeviceRevoke(list.DeviceRevokePayload{Certificate: cert})
+	require.NoError(t, err)
+	err = acl.AddRawRecord(list.WrapAclRecord(rec))
+	acl.Unlock()
+	require.NoError(t, err)
+
+	identity, err := acc.SignKey.GetPublic().Marshall()
+	require.NoError(t, err)
+	deviceCtx := func(deviceKey crypto.PubKey) context.Context {
+		protoKey, err := deviceKey.Marshall()
+		require.NoError(t, err)
+		return peer.CtxWithDeviceKey(peer.CtxWithIdentity(peer.CtxWithPeerId(ctx, "peerId"), identity), protoKey)
+	}
+	req := &spacesyncproto.HeadSyncRequest{SpaceId: spc.Id()}
+	_, err = spc.HandleRangeRequest(deviceCtx(revokedKey), req)
+	require.ErrorIs(t, err, spacesyncproto.ErrDeviceRevoked)
+	err = spc.HandleStreamSyncRequest(deviceCtx(revokedKey), &spacesyncproto.ObjectSyncMessage{SpaceId: spc.Id()}, nil)
+	require.ErrorIs(t, err, spacesyncproto.ErrDeviceRevoked)
+	_, err = spc.KeyValue().HandleStoreDiffRequest(deviceCtx(revokedKey), &spacesyncproto.StoreDiffRequest{SpaceId: spc.Id()})
+	require.ErrorIs(t, err, spacesyncproto.ErrDeviceRevoked)
+
+	// the other devices and the account itself are served
+	_, otherKey, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	_, err = spc.HandleRangeRequest(deviceCtx(otherKey), req)
+	require.NoError(t, err)
+	_, err = spc.HandleRangeRequest(peer.CtxWithPeerId(ctx, "peerId"), req)
+	require.NoError(t, err)
+}
+
+func TestSpaceService_NewSpaceEphemeral(t *testing.T) {
+	deps := mockDeps()
+	deps.Ephemeral = true
+	payload := newStorageCreatePayload(t)
+	spaceId := payload.SpaceHeaderWithId.Id
+	addCtx := context.WithValue(ctx, AddSpaceCtxKey, SpaceDescription{
+		SpaceHeader:          payload.SpaceHeaderWithId,
+		AclId:                payload.AclWithId.Id,
+		AclPayload:           payload.AclWithId.Payload,
+		SpaceSettingsId:      payload.SpaceSettingsWithId.Id,
+		SpaceSettingsPayload: payload.SpaceSettingsWithId.RawChange,
+	})
+	t.Run("storage is kept in memory", func(t *testing.T) {
+		ephemeral := memstorage.NewEphemeral()
+		fx := newFixture(t, ephemeral)
+		defer fx.app.Close(ctx)
+		_, err := fx.spaceService.NewSpace(addCtx, spaceId, deps)
+		require.NoError(t, err)
+		require.True(t, ephemeral.SpaceExists(spaceId))
+		require.False(t, fx.storageProvider.SpaceExists(spaceId))
+	})
+	t.Run("ephemeral provider is not registered", func(t *testing.T) {
+		fx := newFixture(t)
+		defer fx.app.Close(ctx)
+		_, err := fx.spaceService.NewSpace(addCtx, spaceId, deps)
+		require.ErrorIs(t, err, ErrNoEphemeralStorage)
+	})
+}
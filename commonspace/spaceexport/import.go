@@ -0,0 +1,298 @@
+package spaceexport
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/memstorage"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/cidutil"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/any-sync/util/slice"
+)
+
+type archive struct {
+	manifest Manifest
+	files    map[string]*zip.File
+}
+
+// ReadManifest reads the manifest of the archive and checks its signature, the entries are not verified
+func ReadManifest(r io.ReaderAt, size int64) (Manifest, error) {
+	a, err := openArchive(r, size)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return a.manifest, nil
+}
+
+// ImportStorageProvider is the provider the space is imported with, it must be able to remove
+// the partially imported space
+type ImportStorageProvider interface {
+	spacestorage.SpaceStorageProvider
+	RemoveSpaceStorage(ctx context.Context, spaceId string) error
+}
+
+// Import restores the space from the archive. All the entries are checked against their cids
+// and validated in memory first, so nothing is written with provider if the archive is not valid.
+// If writing with provider fails, the created space is removed.
+// The keys are used to build the acl state of the importing account, objectAcls are used to check the key values
+func Import(ctx context.Context, r io.ReaderAt, size int64, keys *accountdata.AccountKeys, provider ImportStorageProvider, objectAcls keyvaluestorage.ObjectAclProvider) (spaceId string, err error) {
+	a, err := openArchive(r, size)
+	if err != nil {
+		return
+	}
+	payload, err := a.storageCreatePayload()
+	if err != nil {
+		return
+	}
+	if err = spacepayloads.ValidateSpaceStorageCreatePayload(payload); err != nil {
+		return
+	}
+	memProvider := memstorage.New()
+	defer func() {
+		_ = memProvider.Close(ctx)
+	}()
+	memSt, err := memProvider.CreateSpaceStorage(ctx, payload)
+	if err != nil {
+		return
+	}
+	if err = a.restore(ctx, memSt, keys, objectAcls); err != nil {
+		return
+	}
+	st, err := provider.CreateSpaceStorage(ctx, payload)
+	if err != nil {
+		return
+	}
+	if err = a.restore(ctx, st, keys, objectAcls); err != nil {
+		if removeErr := provider.RemoveSpaceStorage(ctx, payload.SpaceHeaderWithId.Id); removeErr != nil {
+			err = fmt.Errorf("%w, failed to remove the space: %w", err, removeErr)
+		}
+		return
+	}
+	return payload.SpaceHeaderWithId.Id, nil
+}
+
+func openArchive(r io.ReaderAt, size int64) (a *archive, err error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return
+	}
+	a = &archive{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		a.files[f.Name] = f
+	}
+	manifestBytes, err := a.readFile(manifestPath)
+	if err != nil {
+		return
+	}
+	signature, err := a.readFile(signaturePath)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(manifestBytes, &a.manifest); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+	if a.manifest.Version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+	identity, err := crypto.DecodeAccountAddress(a.manifest.Identity)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+	if ok, _ := identity.Verify(manifestBytes, signature); !ok {
+		return nil, ErrInvalidSignature
+	}
+	if len(a.manifest.Acl) == 0 || len(a.manifest.Trees) == 0 || a.manifest.Trees[0].Type != settingsTreeType {
+		return nil, ErrInvalidManifest
+	}
+	return a, nil
+}
+
+func (a *archive) readFile(name string) ([]byte, error) {
+	f, ok := a.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingArchiveEntry, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// readEntry reads the file and checks that its content matches the cid
+func (a *archive) readEntry(name, cid string) ([]byte, error) {
+	data, err := a.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if !cidutil.VerifyCid(data, cid) {
+		return nil, fmt.Errorf("%w: %s", objecttree.ErrIncorrectCid, name)
+	}
+	return data, nil
+}
+
+func (a *archive) aclRecord(id string) (*consensusproto.RawRecordWithId, error) {
+	data, err := a.readEntry(path.Join(aclDir, id), id)
+	if err != nil {
+		return nil, err
+	}
+	return &consensusproto.RawRecordWithId{Payload: data, Id: id}, nil
+}
+
+func (a *archive) treeChange(treeId, id string) (*treechangeproto.RawTreeChangeWithId, error) {
+	data, err := a.readEntry(path.Join(treesDir, treeId, id), id)
+	if err != nil {
+		return nil, err
+	}
+	return &treechangeproto.RawTreeChangeWithId{RawChange: data, Id: id}, nil
+}
+
+func (a *archive) storageCreatePayload() (payload spacestorage.SpaceStorageCreatePayload, err error) {
+	rawHeader, err := a.readEntry(headerPath, a.manifest.Header)
+	if err != nil {
+		return
+	}
+	payload.SpaceHeaderWithId = &spacesyncproto.RawSpaceHeaderWithId{RawHeader: rawHeader, Id: a.manifest.SpaceId}
+	if payload.AclWithId, err = a.aclRecord(a.manifest.Acl[0]); err != nil {
+		return
+	}
+	payload.SpaceSettingsWithId, err = a.treeChange(a.manifest.Trees[0].Id, a.manifest.Trees[0].Id)
+	return
+}
+
+// restore adds the entries of the archive to the created space storage through the validating code paths and closes it
+func (a *archive) restore(ctx context.Context, st spacestorage.SpaceStorage, keys *accountdata.AccountKeys, objectAcls keyvaluestorage.ObjectAclProvider) (err error) {
+	defer func() {
+		if closeErr := st.Close(ctx); err == nil {
+			err = closeErr
+		}
+	}()
+	aclStorage, err := st.AclStorage()
+	if err != nil {
+		return
+	}
+	aclList, err := list.BuildAclListWithIdentity(keys, aclStorage, recordverifier.NewValidateFull())
+	if err != nil {
+		return
+	}
+	records := make([]*consensusproto.RawRecordWithId, 0, len(a.manifest.Acl)-1)
+	for _, id := range a.manifest.Acl[1:] {
+		rec, err := a.aclRecord(id)
+		if err != nil {
+			return err
+		}
+		records = append(records, rec)
+	}
+	if err = aclList.AddRawRecords(records); err != nil {
+		return
+	}
+	for i, tree := range a.manifest.Trees {
+		if err = a.restoreTree(ctx, st, aclList, tree, i == 0); err != nil {
+			return fmt.Errorf("failed to import tree %s: %w", tree.Id, err)
+		}
+	}
+	return a.restoreKeyValues(ctx, st, aclList, keys, objectAcls)
+}
+
+func (a *archive) restoreTree(ctx context.Context, st spacestorage.SpaceStorage, aclList list.AclList, tree ManifestTree, isSettings bool) (err error) {
+	var treeStorage objecttree.Storage
+	if isSettings {
+		// the settings tree is created together with the space
+		treeStorage, err = st.TreeStorage(ctx, tree.Id)
+	} else {
+		var root *treechangeproto.RawTreeChangeWithId
+		if root, err = a.treeChange(tree.Id, tree.Id); err != nil {
+			return
+		}
+		treeStorage, err = st.CreateTreeStorage(ctx, treestorage.TreeStorageCreatePayload{
+			RootRawChange: root,
+			Heads:         []string{root.Id},
+		})
+	}
+	if err != nil {
+		return
+	}
+	objTree, err := objecttree.BuildEmptyDataObjectTree(treeStorage, aclList)
+	if err != nil {
+		return
+	}
+	if len(tree.Changes) == 0 {
+		return
+	}
+	changes := make([]*treechangeproto.RawTreeChangeWithId, 0, len(tree.Changes))
+	for _, id := range tree.Changes {
+		change, err := a.treeChange(tree.Id, id)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change)
+	}
+	objTree.Lock()
+	defer objTree.Unlock()
+	res, err := objTree.AddRawChanges(ctx, objecttree.RawChangesPayload{
+		NewHeads:   tree.Heads,
+		RawChanges: changes,
+	})
+	if err != nil {
+		return
+	}
+	if !slice.UnsortedEquals(res.Heads, tree.Heads) {
+		return fmt.Errorf("heads mismatch: %v != %v, %w", res.Heads, tree.Heads, objecttree.ErrHasInvalidChanges)
+	}
+	return
+}
+
+// restoreKeyValues adds the key values through keyvaluestorage.SetRaw, so they are checked against the acl,
+// the object acls and the expiration in the same way as the values received from the peers
+func (a *archive) restoreKeyValues(ctx context.Context, st spacestorage.SpaceStorage, aclList list.AclList, keys *accountdata.AccountKeys, objectAcls keyvaluestorage.ObjectAclProvider) (err error) {
+	if len(a.manifest.KeyValues) == 0 {
+		return
+	}
+	storageId, err := keyvalue.StorageIdFromSpace(a.manifest.SpaceId)
+	if err != nil {
+		return
+	}
+	keyValues := make([]*spacesyncproto.StoreKeyValue, 0, len(a.manifest.KeyValues))
+	for _, cid := range a.manifest.KeyValues {
+		data, err := a.readEntry(path.Join(keyValuesDir, cid), cid)
+		if err != nil {
+			return err
+		}
+		protoKv := &spacesyncproto.StoreKeyValue{}
+		if err = protoKv.Unmarshal(data); err != nil {
+			return err
+		}
+		keyValues = append(keyValues, protoKv)
+	}
+	kvStorage, err := keyvaluestorage.New(ctx, storageId, st.AnyStore(), st.HeadStorage(), keys,
+		noOpSyncClient{}, aclList, keyvaluestorage.NoOpIndexer{}, objectAcls)
+	if err != nil {
+		return
+	}
+	return kvStorage.SetRaw(ctx, keyValues...)
+}
+
+// noOpSyncClient doesn't broadcast the imported values, the space isn't opened yet
+type noOpSyncClient struct{}
+
+func (n noOpSyncClient) Broadcast(ctx context.Context, objectId string, keyValues ...innerstorage.KeyValue) error {
+	return nil
+}
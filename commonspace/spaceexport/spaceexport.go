@@ -0,0 +1,219 @@
+// Package spaceexport dumps a space to a portable archive and restores it back.
+// The archive is a zip file with a signed manifest, every other file in it is addressed by the cid of its content,
+// so the archive can be verified before anything is written to the storage.
+package spaceexport
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	anystore "github.com/anyproto/any-store"
+
+	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/util/cidutil"
+)
+
+const (
+	Version = 1
+
+	manifestPath     = "manifest.json"
+	signaturePath    = "manifest.sig"
+	headerPath       = "header"
+	aclDir           = "acl"
+	treesDir         = "trees"
+	keyValuesDir     = "keyvalues"
+	settingsTreeType = "settings"
+)
+
+var (
+	ErrInvalidManifest     = errors.New("invalid archive manifest")
+	ErrInvalidSignature    = errors.New("invalid archive signature")
+	ErrUnsupportedVersion  = errors.New("unsupported archive version")
+	ErrMissingArchiveEntry = errors.New("missing archive entry")
+)
+
+// Manifest describes the content of the archive, all the entries are referenced by their cids
+type Manifest struct {
+	Version   int       `json:"version"`
+	SpaceId   string    `json:"spaceId"`
+	Identity  string    `json:"identity"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Header is the cid of the raw space header
+	Header string `json:"header"`
+	// Acl is the list of the acl record ids from the root to the head
+	Acl []string `json:"acl"`
+	// Trees are the object trees of the space, the first one is the settings tree
+	Trees []ManifestTree `json:"trees"`
+	// KeyValues are the cids of the entries of the key-value store
+	KeyValues []string `json:"keyValues"`
+}
+
+type ManifestTree struct {
+	Id    string   `json:"id"`
+	Type  string   `json:"type,omitempty"`
+	Heads []string `json:"heads"`
+	// Changes are the ids of the changes excluding the root one, which has the id of the tree
+	Changes []string `json:"changes"`
+}
+
+// Export writes all the data of the space to w, the manifest is signed by the account keys
+func Export(ctx context.Context, st spacestorage.SpaceStorage, keys *accountdata.AccountKeys, w io.Writer) (err error) {
+	state, err := st.StateStorage().GetState(ctx)
+	if err != nil {
+		return
+	}
+	headerCid, err := cidutil.NewCidFromBytes(state.SpaceHeader)
+	if err != nil {
+		return
+	}
+	manifest := Manifest{
+		Version:   Version,
+		SpaceId:   state.SpaceId,
+		Identity:  keys.SignKey.GetPublic().Account(),
+		CreatedAt: time.Now().UTC(),
+		Header:    headerCid,
+	}
+	zw := zip.NewWriter(w)
+	defer func() {
+		if closeErr := zw.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	if err = writeFile(zw, headerPath, state.SpaceHeader); err != nil {
+		return
+	}
+	if manifest.Acl, err = exportAcl(ctx, zw, st); err != nil {
+		return
+	}
+	kvStorageId, err := keyvalue.StorageIdFromSpace(state.SpaceId)
+	if err != nil {
+		return
+	}
+	var treeIds []string
+	err = st.HeadStorage().IterateEntries(ctx, headstorage.IterOpts{}, func(entry headstorage.HeadsEntry) (bool, error) {
+		if entry.Id != state.AclId && entry.Id != state.SettingsId && entry.Id != kvStorageId {
+			treeIds = append(treeIds, entry.Id)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return
+	}
+	slices.Sort(treeIds)
+	for _, id := range append([]string{state.SettingsId}, treeIds...) {
+		tree, err := exportTree(ctx, zw, st, id)
+		if err != nil {
+			return fmt.Errorf("failed to export tree %s: %w", id, err)
+		}
+		if id == state.SettingsId {
+			tree.Type = settingsTreeType
+		}
+		manifest.Trees = append(manifest.Trees, tree)
+	}
+	if manifest.KeyValues, err = exportKeyValues(ctx, zw, st, kvStorageId); err != nil {
+		return
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return
+	}
+	signature, err := keys.SignKey.Sign(manifestBytes)
+	if err != nil {
+		return
+	}
+	if err = writeFile(zw, manifestPath, manifestBytes); err != nil {
+		return
+	}
+	return writeFile(zw, signaturePath, signature)
+}
+
+func exportAcl(ctx context.Context, zw *zip.Writer, st spacestorage.SpaceStorage) (ids []string, err error) {
+	aclStorage, err := st.AclStorage()
+	if err != nil {
+		return
+	}
+	err = aclStorage.GetAfterOrder(ctx, 0, func(ctx context.Context, record list.StorageRecord) (bool, error) {
+		if err := writeFile(zw, path.Join(aclDir, record.Id), record.RawRecord); err != nil {
+			return false, err
+		}
+		ids = append(ids, record.Id)
+		return true, nil
+	})
+	return
+}
+
+func exportTree(ctx context.Context, zw *zip.Writer, st spacestorage.SpaceStorage, id string) (tree ManifestTree, err error) {
+	treeStorage, err := st.TreeStorage(ctx, id)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = treeStorage.Close()
+	}()
+	tree.Id = id
+	if tree.Heads, err = treeStorage.Heads(ctx); err != nil {
+		return
+	}
+	err = treeStorage.GetAfterOrder(ctx, "", func(ctx context.Context, change objecttree.StorageChange) (bool, error) {
+		if err := writeFile(zw, path.Join(treesDir, id, change.Id), change.RawChange); err != nil {
+			return false, err
+		}
+		if change.Id != id {
+			tree.Changes = append(tree.Changes, change.Id)
+		}
+		return true, nil
+	})
+	return
+}
+
+func exportKeyValues(ctx context.Context, zw *zip.Writer, st spacestorage.SpaceStorage, storageId string) (cids []string, err error) {
+	// the key-value storage is created only when the space is opened for the first time
+	if _, err = st.HeadStorage().GetEntry(ctx, storageId); err != nil {
+		if errors.Is(err, anystore.ErrDocNotFound) {
+			return nil, nil
+		}
+		return
+	}
+	kvStorage, err := innerstorage.New(ctx, storageId, st.HeadStorage(), st.AnyStore())
+	if err != nil {
+		return
+	}
+	err = kvStorage.IterateValues(ctx, func(kv innerstorage.KeyValue) (bool, error) {
+		data, err := kv.Proto().Marshal()
+		if err != nil {
+			return false, err
+		}
+		cid, err := cidutil.NewCidFromBytes(data)
+		if err != nil {
+			return false, err
+		}
+		if err = writeFile(zw, path.Join(keyValuesDir, cid), data); err != nil {
+			return false, err
+		}
+		cids = append(cids, cid)
+		return true, nil
+	})
+	return
+}
+
+func writeFile(zw *zip.Writer, name string, data []byte) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
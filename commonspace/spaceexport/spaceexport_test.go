@@ -0,0 +1,261 @@
+package spaceexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/recordverifier"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue/keyvaluestorage/innerstorage"
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/memstorage"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ctx = context.Background()
+
+func TestExportImport(t *testing.T) {
+	t.Run("space is restored", func(t *testing.T) {
+		fx := newFixture(t)
+		data := fx.export(t)
+
+		manifest, err := ReadManifest(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Equal(t, fx.spaceId, manifest.SpaceId)
+		require.Len(t, manifest.Acl, 2)
+		require.Len(t, manifest.Trees, 2)
+		require.Len(t, manifest.KeyValues, 1)
+
+		provider := memstorage.New()
+		defer provider.Close(ctx)
+		spaceId, err := Import(ctx, bytes.NewReader(data), int64(len(data)), fx.keys, provider, keyvaluestorage.NoOpObjectAclProvider{})
+		require.NoError(t, err)
+		require.Equal(t, fx.spaceId, spaceId)
+
+		st, err := provider.WaitSpaceStorage(ctx, spaceId)
+		require.NoError(t, err)
+		aclStorage, err := st.AclStorage()
+		require.NoError(t, err)
+		aclHead, err := aclStorage.Head(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fx.aclList.Head().Id, aclHead)
+		treeStorage, err := st.TreeStorage(ctx, fx.treeId)
+		require.NoError(t, err)
+		heads, err := treeStorage.Heads(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fx.treeHeads, heads)
+
+		aclList, err := list.BuildAclListWithIdentity(fx.keys, aclStorage, recordverifier.NewValidateFull())
+		require.NoError(t, err)
+		kvStorage, err := keyvaluestorage.New(ctx, fx.kvStorageId, st.AnyStore(), st.HeadStorage(), fx.keys,
+			noOpSyncClient{}, aclList, keyvaluestorage.NoOpIndexer{}, keyvaluestorage.NoOpObjectAclProvider{})
+		require.NoError(t, err)
+		require.NoError(t, kvStorage.Prepare())
+		err = kvStorage.GetAll(ctx, "key", func(decryptor keyvaluestorage.Decryptor, values []innerstorage.KeyValue) error {
+			require.Len(t, values, 1)
+			value, err := decryptor(values[0])
+			require.NoError(t, err)
+			require.Equal(t, "value", string(value))
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = Import(ctx, bytes.NewReader(data), int64(len(data)), fx.keys, provider, keyvaluestorage.NoOpObjectAclProvider{})
+		require.ErrorIs(t, err, spacestorage.ErrSpaceStorageExists)
+	})
+	t.Run("tampered entry", func(t *testing.T) {
+		fx := newFixture(t)
+		data := rewriteArchive(t, fx.export(t), func(name string, content []byte) []byte {
+			if name == "trees/"+fx.treeId+"/"+fx.treeHeads[0] {
+				return append(content, 0)
+			}
+			return content
+		})
+		provider := memstorage.New()
+		defer provider.Close(ctx)
+		_, err := Import(ctx, bytes.NewReader(data), int64(len(data)), fx.keys, provider, keyvaluestorage.NoOpObjectAclProvider{})
+		require.ErrorIs(t, err, objecttree.ErrIncorrectCid)
+		require.False(t, provider.SpaceExists(fx.spaceId))
+	})
+	t.Run("failed write removes the space", func(t *testing.T) {
+		fx := newFixture(t)
+		data := fx.export(t)
+		provider := failingProvider{memstorage.New()}
+		defer provider.Close(ctx)
+		_, err := Import(ctx, bytes.NewReader(data), int64(len(data)), fx.keys, provider, keyvaluestorage.NoOpObjectAclProvider{})
+		require.ErrorIs(t, err, errCreateTree)
+		require.False(t, provider.SpaceExists(fx.spaceId))
+	})
+	t.Run("key values are validated", func(t *testing.T) {
+		fx := newFixture(t)
+		kvStorage, err := keyvaluestorage.New(ctx, fx.kvStorageId, fx.st.AnyStore(), fx.st.HeadStorage(), fx.keys,
+			noOpSyncClient{}, fx.aclList, keyvaluestorage.NoOpIndexer{}, keyvaluestorage.NoOpObjectAclProvider{})
+		require.NoError(t, err)
+		require.NoError(t, kvStorage.SetWithExpiry(ctx, "expired", []byte("value"), time.Now().Add(-time.Minute)))
+		data := fx.export(t)
+		manifest, err := ReadManifest(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Len(t, manifest.KeyValues, 2)
+
+		provider := memstorage.New()
+		defer provider.Close(ctx)
+		spaceId, err := Import(ctx, bytes.NewReader(data), int64(len(data)), fx.keys, provider, keyvaluestorage.NoOpObjectAclProvider{})
+		require.NoError(t, err)
+		st, err := provider.WaitSpaceStorage(ctx, spaceId)
+		require.NoError(t, err)
+		inner, err := innerstorage.New(ctx, fx.kvStorageId, st.HeadStorage(), st.AnyStore())
+		require.NoError(t, err)
+		var keys []string
+		err = inner.IterateValues(ctx, func(kv innerstorage.KeyValue) (bool, error) {
+			keys = append(keys, kv.Key)
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"key"}, keys)
+	})
+	t.Run("tampered manifest", func(t *testing.T) {
+		fx := newFixture(t)
+		data := rewriteArchive(t, fx.export(t), func(name string, content []byte) []byte {
+			if name == manifestPath {
+				return bytes.Replace(content, []byte(`"version":1`), []byte(`"version":1 `), 1)
+			}
+			return content
+		})
+		_, err := ReadManifest(bytes.NewReader(data), int64(len(data)))
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+var errCreateTree = errors.New("create tree failed")
+
+type failingProvider struct {
+	memstorage.SpaceStorageProvider
+}
+
+func (p failingProvider) CreateSpaceStorage(ctx context.Context, payload spacestorage.SpaceStorageCreatePayload) (spacestorage.SpaceStorage, error) {
+	st, err := p.SpaceStorageProvider.CreateSpaceStorage(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	return failingStorage{st}, nil
+}
+
+type failingStorage struct {
+	spacestorage.SpaceStorage
+}
+
+func (failingStorage) CreateTreeStorage(context.Context, treestorage.TreeStorageCreatePayload) (objecttree.Storage, error) {
+	return nil, errCreateTree
+}
+
+type fixture struct {
+	keys        *accountdata.AccountKeys
+	st          spacestorage.SpaceStorage
+	aclList     list.AclList
+	spaceId     string
+	treeId      string
+	treeHeads   []string
+	kvStorageId string
+}
+
+func newFixture(t *testing.T) *fixture {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	masterKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	metaKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	payload, err := spacepayloads.StoragePayloadForSpaceCreate(spacepayloads.SpaceCreatePayload{
+		SigningKey:     keys.SignKey,
+		SpaceType:      "space",
+		ReplicationKey: 10,
+		MasterKey:      masterKey,
+		ReadKey:        crypto.NewAES(),
+		MetadataKey:    metaKey,
+		Metadata:       []byte("account"),
+	})
+	require.NoError(t, err)
+	provider := memstorage.New()
+	t.Cleanup(func() {
+		_ = provider.Close(ctx)
+	})
+	fx := &fixture{keys: keys, spaceId: payload.SpaceHeaderWithId.Id}
+	fx.st, err = provider.CreateSpaceStorage(ctx, payload)
+	require.NoError(t, err)
+
+	aclStorage, err := fx.st.AclStorage()
+	require.NoError(t, err)
+	fx.aclList, err = list.BuildAclListWithIdentity(keys, aclStorage, recordverifier.NewValidateFull())
+	require.NoError(t, err)
+	inv, err := fx.aclList.RecordBuilder().BuildInvite()
+	require.NoError(t, err)
+	require.NoError(t, fx.aclList.AddRawRecord(list.WrapAclRecord(inv.InviteRec)))
+
+	root, err := objecttree.CreateObjectTreeRoot(objecttree.ObjectTreeCreatePayload{
+		PrivKey:     keys.SignKey,
+		ChangeType:  "changeType",
+		SpaceId:     fx.spaceId,
+		IsEncrypted: true,
+	}, fx.aclList)
+	require.NoError(t, err)
+	treeStorage, err := fx.st.CreateTreeStorage(ctx, treestorage.TreeStorageCreatePayload{
+		RootRawChange: root,
+		Heads:         []string{root.Id},
+	})
+	require.NoError(t, err)
+	tree, err := objecttree.BuildObjectTree(treeStorage, fx.aclList)
+	require.NoError(t, err)
+	tree.Lock()
+	res, err := tree.AddContent(ctx, objecttree.SignableChangeContent{
+		Data:        []byte("data"),
+		Key:         keys.SignKey,
+		IsEncrypted: true,
+	})
+	tree.Unlock()
+	require.NoError(t, err)
+	fx.treeId, fx.treeHeads = root.Id, res.Heads
+
+	fx.kvStorageId, err = keyvalue.StorageIdFromSpace(fx.spaceId)
+	require.NoError(t, err)
+	kvStorage, err := keyvaluestorage.New(ctx, fx.kvStorageId, fx.st.AnyStore(), fx.st.HeadStorage(), keys,
+		noOpSyncClient{}, fx.aclList, keyvaluestorage.NoOpIndexer{}, keyvaluestorage.NoOpObjectAclProvider{})
+	require.NoError(t, err)
+	require.NoError(t, kvStorage.Set(ctx, "key", []byte("value")))
+	return fx
+}
+
+func (fx *fixture) export(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	require.NoError(t, Export(ctx, fx.st, fx.keys, buf))
+	return buf.Bytes()
+}
+
+func rewriteArchive(t *testing.T, data []byte, rewrite func(name string, content []byte) []byte) []byte {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		require.NoError(t, writeFile(zw, f.Name, rewrite(f.Name, content)))
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
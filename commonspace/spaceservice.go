@@ -27,6 +27,7 @@ import (
 	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/changefeed"
 	"github.com/anyproto/any-sync/commonspace/config"
 	"github.com/anyproto/any-sync/commonspace/credentialprovider"
 	"github.com/anyproto/any-sync/commonspace/deletionstate"
@@ -41,7 +42,9 @@ import (
 	"github.com/anyproto/any-sync/commonspace/settings"
 	"github.com/anyproto/any-sync/commonspace/spacestate"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/memstorage"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/commonspace/syncfilter"
 	"github.com/anyproto/any-sync/commonspace/syncstatus"
 	"github.com/anyproto/any-sync/consensus/consensusproto"
 	"github.com/anyproto/any-sync/metric"
@@ -53,6 +56,8 @@ const CName = "common.commonspace"
 
 var log = logger.NewNamed(CName)
 
+var ErrNoEphemeralStorage = errors.New("ephemeral space storage is not registered")
+
 func New() SpaceService {
 	return &spaceService{}
 }
@@ -75,6 +80,14 @@ type Deps struct {
 	AccountService accountservice.Service
 	recordVerifier recordverifier.RecordVerifier
 	Indexer        keyvaluestorage.Indexer
+	// SyncFilter limits the trees synced through headsync, nil syncs the whole space
+	SyncFilter syncfilter.Filter
+	// Ephemeral keeps the space storage in memory (e.g. for the previews),
+	// the memstorage.NewEphemeral provider must be registered in the app
+	Ephemeral bool
+	// ChangeFeed enables the persistent log of the object changes, the owner of the space
+	// should truncate it, because the feed keeps all the records otherwise
+	ChangeFeed bool
 }
 
 type spaceService struct {
@@ -82,6 +95,7 @@ type spaceService struct {
 	account              accountservice.Service
 	configurationService nodeconf.Service
 	storageProvider      spacestorage.SpaceStorageProvider
+	ephemeralProvider    spacestorage.SpaceStorageProvider
 	peerManagerProvider  peermanager.PeerManagerProvider
 	credentialProvider   credentialprovider.CredentialProvider
 	treeManager          treemanager.TreeManager
@@ -94,6 +108,7 @@ func (s *spaceService) Init(a *app.App) (err error) {
 	s.config = a.MustComponent("config").(config.ConfigGetter).GetSpace()
 	s.account = a.MustComponent(accountservice.CName).(accountservice.Service)
 	s.storageProvider = a.MustComponent(spacestorage.CName).(spacestorage.SpaceStorageProvider)
+	s.ephemeralProvider, _ = a.Component(memstorage.EphemeralCName).(spacestorage.SpaceStorageProvider)
 	s.configurationService = a.MustComponent(nodeconf.CName).(nodeconf.Service)
 	s.treeManager = a.MustComponent(treemanager.CName).(treemanager.TreeManager)
 	s.peerManagerProvider = a.MustComponent(peermanager.CName).(peermanager.PeerManagerProvider)
@@ -112,7 +127,7 @@ func (s *spaceService) CreateSpace(ctx context.Context, payload spacepayloads.Sp
 	if err != nil {
 		return
 	}
-	store, err := s.createSpaceStorage(ctx, storageCreate)
+	store, err := s.createSpaceStorage(ctx, s.storageProvider, storageCreate)
 	if err != nil {
 		if errors.Is(err, spacestorage.ErrSpaceStorageExists) {
 			return storageCreate.SpaceHeaderWithId.Id, nil
@@ -137,7 +152,7 @@ func (s *spaceService) DeriveSpace(ctx context.Context, payload spacepayloads.Sp
 	if err != nil {
 		return
 	}
-	store, err := s.createSpaceStorage(ctx, storageCreate)
+	store, err := s.createSpaceStorage(ctx, s.storageProvider, storageCreate)
 	if err != nil {
 		if errors.Is(err, spacestorage.ErrSpaceStorageExists) {
 			return storageCreate.SpaceHeaderWithId.Id, nil
@@ -149,19 +164,26 @@ func (s *spaceService) DeriveSpace(ctx context.Context, payload spacepayloads.Sp
 }
 
 func (s *spaceService) NewSpace(ctx context.Context, id string, deps Deps) (Space, error) {
-	st, err := s.storageProvider.WaitSpaceStorage(ctx, id)
+	provider := s.storageProvider
+	if deps.Ephemeral {
+		if s.ephemeralProvider == nil {
+			return nil, ErrNoEphemeralStorage
+		}
+		provider = s.ephemeralProvider
+	}
+	st, err := provider.WaitSpaceStorage(ctx, id)
 	if err != nil {
 		if !errors.Is(err, spacestorage.ErrSpaceStorageMissing) {
 			return nil, err
 		}
 
 		if description, ok := ctx.Value(AddSpaceCtxKey).(SpaceDescription); ok {
-			st, err = s.addSpaceStorage(ctx, description)
+			st, err = s.addSpaceStorage(ctx, provider, description)
 			if err != nil {
 				return nil, err
 			}
 		} else {
-			st, err = s.getSpaceStorageFromRemote(ctx, id)
+			st, err = s.getSpaceStorageFromRemote(ctx, provider, id)
 			if err != nil {
 				return nil, err
 			}
@@ -198,8 +220,12 @@ func (s *spaceService) NewSpace(ctx context.Context, id string, deps Deps) (Spac
 		Register(deps.SyncStatus).
 		Register(recordVerifier).
 		Register(peerManager).
-		Register(st).
-		Register(keyValueIndexer).
+		Register(st)
+	if deps.ChangeFeed {
+		// the feed is registered right after the storage, so it observes the writes of the other components
+		spaceApp.Register(changefeed.New())
+	}
+	spaceApp.Register(keyValueIndexer).
 		Register(objectsync.New()).
 		Register(sync.NewSyncService()).
 		Register(syncacl.New()).
@@ -211,6 +237,7 @@ func (s *spaceService) NewSpace(ctx context.Context, id string, deps Deps) (Spac
 		Register(deps.TreeSyncer).
 		Register(objecttreebuilder.New()).
 		Register(aclclient.NewAclSpaceClient()).
+		Register(syncfilter.New(deps.SyncFilter)).
 		Register(headsync.New())
 	sp := &space{
 		state:   state,
@@ -220,7 +247,7 @@ func (s *spaceService) NewSpace(ctx context.Context, id string, deps Deps) (Spac
 	return sp, nil
 }
 
-func (s *spaceService) addSpaceStorage(ctx context.Context, spaceDescription SpaceDescription) (st spacestorage.SpaceStorage, err error) {
+func (s *spaceService) addSpaceStorage(ctx context.Context, provider spacestorage.SpaceStorageProvider, spaceDescription SpaceDescription) (st spacestorage.SpaceStorage, err error) {
 	payload := spacestorage.SpaceStorageCreatePayload{
 		AclWithId: &consensusproto.RawRecordWithId{
 			Payload: spaceDescription.AclPayload,
@@ -232,7 +259,7 @@ func (s *spaceService) addSpaceStorage(ctx context.Context, spaceDescription Spa
 			Id:        spaceDescription.SpaceSettingsId,
 		},
 	}
-	st, err = s.createSpaceStorage(ctx, payload)
+	st, err = s.createSpaceStorage(ctx, provider, payload)
 	if err != nil {
 		err = spacesyncproto.ErrUnexpected
 		if errors.Is(err, spacestorage.ErrSpaceStorageExists) {
@@ -243,7 +270,7 @@ func (s *spaceService) addSpaceStorage(ctx context.Context, spaceDescription Spa
 	return
 }
 
-func (s *spaceService) getSpaceStorageFromRemote(ctx context.Context, id string) (st spacestorage.SpaceStorage, err error) {
+func (s *spaceService) getSpaceStorageFromRemote(ctx context.Context, provider spacestorage.SpaceStorageProvider, id string) (st spacestorage.SpaceStorage, err error) {
 	// we can't connect to client if it is a node
 	if s.configurationService.IsResponsible(id) {
 		err = spacesyncproto.ErrSpaceMissing
@@ -282,7 +309,7 @@ func (s *spaceService) getSpaceStorageFromRemote(ctx context.Context, id string)
 		}
 	}
 	for i, p := range peers {
-		if st, err = s.spacePullWithPeer(ctx, p, id); err != nil {
+		if st, err = s.spacePullWithPeer(ctx, provider, p, id); err != nil {
 			if i+1 == len(peers) {
 				return
 			} else {
@@ -295,7 +322,7 @@ func (s *spaceService) getSpaceStorageFromRemote(ctx context.Context, id string)
 	return nil, net.ErrUnableToConnect
 }
 
-func (s *spaceService) spacePullWithPeer(ctx context.Context, p peer.Peer, id string) (st spacestorage.SpaceStorage, err error) {
+func (s *spaceService) spacePullWithPeer(ctx context.Context, provider spacestorage.SpaceStorageProvider, p peer.Peer, id string) (st spacestorage.SpaceStorage, err error) {
 	var res *spacesyncproto.SpacePullResponse
 	err = p.DoDrpc(ctx, func(conn drpc.Conn) error {
 		cl := spacesyncproto.NewDRPCSpaceSyncClient(conn)
@@ -307,7 +334,7 @@ func (s *spaceService) spacePullWithPeer(ctx context.Context, p peer.Peer, id st
 		return
 	}
 
-	return s.createSpaceStorage(ctx, spacestorage.SpaceStorageCreatePayload{
+	return s.createSpaceStorage(ctx, provider, spacestorage.SpaceStorageCreatePayload{
 		AclWithId: &consensusproto.RawRecordWithId{
 			Payload: res.Payload.AclPayload,
 			Id:      res.Payload.AclPayloadId,
@@ -320,10 +347,10 @@ func (s *spaceService) spacePullWithPeer(ctx context.Context, p peer.Peer, id st
 	})
 }
 
-func (s *spaceService) createSpaceStorage(ctx context.Context, payload spacestorage.SpaceStorageCreatePayload) (spacestorage.SpaceStorage, error) {
+func (s *spaceService) createSpaceStorage(ctx context.Context, provider spacestorage.SpaceStorageProvider, payload spacestorage.SpaceStorageCreatePayload) (spacestorage.SpaceStorage, error) {
 	err := spacepayloads.ValidateSpaceStorageCreatePayload(payload)
 	if err != nil {
 		return nil, err
 	}
-	return s.storageProvider.CreateSpaceStorage(ctx, payload)
+	return provider.CreateSpaceStorage(ctx, payload)
 }
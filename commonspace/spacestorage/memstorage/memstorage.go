@@ -0,0 +1,195 @@
+// Package memstorage provides the space storage provider which keeps all the data in memory.
+// It can be registered instead of the disk provider for the tests (New) or along with it for the ephemeral spaces
+// such as previews (NewEphemeral), the storages are the same as on disk, so the spaces behave exactly the same way.
+package memstorage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+
+	anystore "github.com/anyproto/any-store"
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+)
+
+var log = logger.NewNamed("common.commonspace.memstorage")
+
+const EphemeralCName = "common.commonspace.memstorage.ephemeral"
+
+// dbCounter makes the in-memory databases of different providers in one process distinct
+var dbCounter atomic.Uint64
+
+type SpaceStorageProvider interface {
+	spacestorage.SpaceStorageProvider
+	// Close of the app drops all the spaces
+	app.ComponentRunnable
+	// SnapshotToDisk writes the consistent copy of the space storage to the file,
+	// the file can be opened later with anystore.Open and spacestorage.New
+	SnapshotToDisk(ctx context.Context, spaceId, path string) error
+	// RemoveSpaceStorage drops all the data of the space, the data is released when all the storages of the space are closed
+	RemoveSpaceStorage(ctx context.Context, spaceId string) error
+}
+
+// New returns the provider which replaces the disk one, it is registered under spacestorage.CName
+func New() SpaceStorageProvider {
+	return &storageProvider{
+		name:   spacestorage.CName,
+		stores: make(map[string]*memStore),
+	}
+}
+
+// NewEphemeral returns the provider which is registered along with the disk one,
+// the space service keeps the spaces opened with commonspace.Deps.Ephemeral in it
+func NewEphemeral() SpaceStorageProvider {
+	return &storageProvider{
+		name:   EphemeralCName,
+		stores: make(map[string]*memStore),
+	}
+}
+
+type memStore struct {
+	anystore.DB
+	// refs is the number of the opened space storages which use the store
+	refs    int
+	removed bool
+}
+
+type storageProvider struct {
+	name   string
+	stores map[string]*memStore
+	mx     sync.Mutex
+}
+
+func (s *storageProvider) Init(a *app.App) (err error) {
+	return nil
+}
+
+func (s *storageProvider) Name() (name string) {
+	return s.name
+}
+
+func (s *storageProvider) Run(ctx context.Context) (err error) {
+	return nil
+}
+
+func (s *storageProvider) Close(ctx context.Context) (err error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for id, store := range s.stores {
+		if closeErr := store.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(s.stores, id)
+	}
+	return err
+}
+
+func (s *storageProvider) WaitSpaceStorage(ctx context.Context, id string) (spacestorage.SpaceStorage, error) {
+	s.mx.Lock()
+	store, ok := s.stores[id]
+	if ok {
+		store.refs++
+	}
+	s.mx.Unlock()
+	if !ok {
+		return nil, spacestorage.ErrSpaceStorageMissing
+	}
+	st, err := spacestorage.New(ctx, id, store.DB)
+	if err != nil {
+		s.release(store)
+		return nil, err
+	}
+	return &spaceStorage{SpaceStorage: st, release: func() { s.release(store) }}, nil
+}
+
+func (s *storageProvider) SpaceExists(id string) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	_, ok := s.stores[id]
+	return ok
+}
+
+func (s *storageProvider) CreateSpaceStorage(ctx context.Context, payload spacestorage.SpaceStorageCreatePayload) (spacestorage.SpaceStorage, error) {
+	id := payload.SpaceHeaderWithId.Id
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, ok := s.stores[id]; ok {
+		return nil, spacestorage.ErrSpaceStorageExists
+	}
+	db, err := openMemoryStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	st, err := spacestorage.Create(ctx, db, payload)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	store := &memStore{DB: db, refs: 1}
+	s.stores[id] = store
+	return &spaceStorage{SpaceStorage: st, release: func() { s.release(store) }}, nil
+}
+
+func (s *storageProvider) SnapshotToDisk(ctx context.Context, spaceId, path string) error {
+	s.mx.Lock()
+	store, ok := s.stores[spaceId]
+	s.mx.Unlock()
+	if !ok {
+		return spacestorage.ErrSpaceStorageMissing
+	}
+	return store.Backup(ctx, path)
+}
+
+func (s *storageProvider) RemoveSpaceStorage(ctx context.Context, spaceId string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	store, ok := s.stores[spaceId]
+	if !ok {
+		return spacestorage.ErrSpaceStorageMissing
+	}
+	delete(s.stores, spaceId)
+	store.removed = true
+	if store.refs > 0 {
+		// the opened storages keep using the data until they are closed
+		return nil
+	}
+	return store.Close()
+}
+
+func (s *storageProvider) release(store *memStore) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	store.refs--
+	if store.refs == 0 && store.removed {
+		if err := store.Close(); err != nil {
+			log.Warn("failed to close removed space store", zap.Error(err))
+		}
+	}
+}
+
+// spaceStorage releases the store of the provider on close
+type spaceStorage struct {
+	spacestorage.SpaceStorage
+	closeOnce sync.Once
+	release   func()
+}
+
+func (s *spaceStorage) Close(ctx context.Context) (err error) {
+	err = s.SpaceStorage.Close(ctx)
+	s.closeOnce.Do(s.release)
+	return
+}
+
+// openMemoryStore opens the sqlite database which lives only while its connections are open.
+// The cache is shared so the read connections see the same database as the write one,
+// the readers wait for the write transaction to finish, so they never see the uncommitted data
+func openMemoryStore(ctx context.Context, spaceId string) (anystore.DB, error) {
+	path := fmt.Sprintf("file:%s-%d?mode=memory&cache=shared", url.PathEscape(spaceId), dbCounter.Add(1))
+	return anystore.Open(ctx, path, nil)
+}
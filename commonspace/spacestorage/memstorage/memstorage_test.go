@@ -0,0 +1,114 @@
+package memstorage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	anystore "github.com/anyproto/any-store"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ctx = context.Background()
+
+func newStorageCreatePayload(t *testing.T) spacestorage.SpaceStorageCreatePayload {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	masterKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	metaKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	createSpace, err := spacepayloads.StoragePayloadForSpaceCreate(spacepayloads.SpaceCreatePayload{
+		SigningKey:     keys.SignKey,
+		SpaceType:      "space",
+		ReplicationKey: 10,
+		MasterKey:      masterKey,
+		ReadKey:        crypto.NewAES(),
+		MetadataKey:    metaKey,
+		Metadata:       []byte("account"),
+	})
+	require.NoError(t, err)
+	return createSpace
+}
+
+func TestStorageProvider(t *testing.T) {
+	t.Run("create and wait", func(t *testing.T) {
+		provider := New()
+		defer provider.Close(ctx)
+		payload := newStorageCreatePayload(t)
+		spaceId := payload.SpaceHeaderWithId.Id
+		require.False(t, provider.SpaceExists(spaceId))
+		_, err := provider.WaitSpaceStorage(ctx, spaceId)
+		require.ErrorIs(t, err, spacestorage.ErrSpaceStorageMissing)
+
+		_, err = provider.CreateSpaceStorage(ctx, payload)
+		require.NoError(t, err)
+		require.True(t, provider.SpaceExists(spaceId))
+		_, err = provider.CreateSpaceStorage(ctx, payload)
+		require.ErrorIs(t, err, spacestorage.ErrSpaceStorageExists)
+
+		st, err := provider.WaitSpaceStorage(ctx, spaceId)
+		require.NoError(t, err)
+		state, err := st.StateStorage().GetState(ctx)
+		require.NoError(t, err)
+		require.Equal(t, payload.AclWithId.Id, state.AclId)
+		_, err = st.TreeStorage(ctx, payload.SpaceSettingsWithId.Id)
+		require.NoError(t, err)
+
+		require.NoError(t, provider.RemoveSpaceStorage(ctx, spaceId))
+		require.False(t, provider.SpaceExists(spaceId))
+	})
+	t.Run("removed space stays readable until closed", func(t *testing.T) {
+		provider := New()
+		defer provider.Close(ctx)
+		payload := newStorageCreatePayload(t)
+		spaceId := payload.SpaceHeaderWithId.Id
+		created, err := provider.CreateSpaceStorage(ctx, payload)
+		require.NoError(t, err)
+		require.NoError(t, created.Close(ctx))
+		st, err := provider.WaitSpaceStorage(ctx, spaceId)
+		require.NoError(t, err)
+
+		require.NoError(t, provider.RemoveSpaceStorage(ctx, spaceId))
+		require.False(t, provider.SpaceExists(spaceId))
+		_, err = st.StateStorage().GetState(ctx)
+		require.NoError(t, err)
+		require.NoError(t, st.Close(ctx))
+		_, err = st.StateStorage().GetState(ctx)
+		require.Error(t, err)
+	})
+	t.Run("providers do not share spaces", func(t *testing.T) {
+		payload := newStorageCreatePayload(t)
+		provider1, provider2 := New(), New()
+		defer provider1.Close(ctx)
+		defer provider2.Close(ctx)
+		_, err := provider1.CreateSpaceStorage(ctx, payload)
+		require.NoError(t, err)
+		_, err = provider2.CreateSpaceStorage(ctx, payload)
+		require.NoError(t, err)
+	})
+	t.Run("snapshot to disk", func(t *testing.T) {
+		provider := New()
+		defer provider.Close(ctx)
+		payload := newStorageCreatePayload(t)
+		spaceId := payload.SpaceHeaderWithId.Id
+		_, err := provider.CreateSpaceStorage(ctx, payload)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "store.db")
+		require.NoError(t, provider.SnapshotToDisk(ctx, spaceId, path))
+
+		store, err := anystore.Open(ctx, path, nil)
+		require.NoError(t, err)
+		defer store.Close()
+		st, err := spacestorage.New(ctx, spaceId, store)
+		require.NoError(t, err)
+		state, err := st.StateStorage().GetState(ctx)
+		require.NoError(t, err)
+		require.Equal(t, payload.SpaceSettingsWithId.Id, state.SettingsId)
+	})
+}
@@ -35,6 +35,7 @@ const (
 	ErrCodes_InvalidPayload          ErrCodes = 7
 	ErrCodes_DuplicateRequest        ErrCodes = 8
 	ErrCodes_TooManyRequestsFromPeer ErrCodes = 9
+	ErrCodes_DeviceRevoked           ErrCodes = 10
 	ErrCodes_ErrorOffset             ErrCodes = 100
 )
 
@@ -49,6 +50,7 @@ var ErrCodes_name = map[int32]string{
 	7:   "InvalidPayload",
 	8:   "DuplicateRequest",
 	9:   "TooManyRequestsFromPeer",
+	10:  "DeviceRevoked",
 	100: "ErrorOffset",
 }
 
@@ -63,6 +65,7 @@ var ErrCodes_value = map[string]int32{
 	"InvalidPayload":          7,
 	"DuplicateRequest":        8,
 	"TooManyRequestsFromPeer": 9,
+	"DeviceRevoked":           10,
 	"ErrorOffset":             100,
 }
 
@@ -2036,6 +2039,10 @@ type StoreKeyInner struct {
 	TimestampMicro int64  `protobuf:"varint,4,opt,name=timestampMicro,proto3" json:"timestampMicro,omitempty"`
 	AclHeadId      string `protobuf:"bytes,5,opt,name=aclHeadId,proto3" json:"aclHeadId,omitempty"`
 	Key            string `protobuf:"bytes,6,opt,name=key,proto3" json:"key,omitempty"`
+	// expiresAtMicro is the time after which the value is expired and removed, zero means no expiry
+	ExpiresAtMicro int64 `protobuf:"varint,7,opt,name=expiresAtMicro,proto3" json:"expiresAtMicro,omitempty"`
+	// deleted marks the tombstone which hides the earlier values of the key
+	Deleted bool `protobuf:"varint,8,opt,name=deleted,proto3" json:"deleted,omitempty"`
 }
 
 func (m *StoreKeyInner) Reset()         { *m = StoreKeyInner{} }
@@ -2121,6 +2128,20 @@ func (m *StoreKeyInner) GetKey() string {
 	return ""
 }
 
+func (m *StoreKeyInner) GetExpiresAtMicro() int64 {
+	if m != nil {
+		return m.ExpiresAtMicro
+	}
+	return 0
+}
+
+func (m *StoreKeyInner) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
 type StorageHeader struct {
 	SpaceId     string `protobuf:"bytes,1,opt,name=spaceId,proto3" json:"spaceId,omitempty"`
 	StorageName string `protobuf:"bytes,2,opt,name=storageName,proto3" json:"storageName,omitempty"`
@@ -2224,106 +2245,108 @@ func init() {
 }
 
 var fileDescriptor_80e49f1f4ac27799 = []byte{
-	// 1572 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x18, 0x4d, 0x6f, 0x1b, 0x45,
-	0x3b, 0xbb, 0x4e, 0x1c, 0xfb, 0x89, 0xe3, 0x6e, 0x26, 0x49, 0xe3, 0xd7, 0xad, 0x5c, 0x6b, 0xf4,
-	0xaa, 0x6f, 0x14, 0xbd, 0xb4, 0x4d, 0x0a, 0x95, 0x5a, 0xe0, 0x90, 0x26, 0x69, 0x63, 0x4a, 0x9a,
-	0x68, 0xdc, 0x0f, 0x09, 0x09, 0xa4, 0xcd, 0xee, 0x24, 0x59, 0xba, 0xde, 0x35, 0x3b, 0xe3, 0x36,
-	0x3e, 0x72, 0xe2, 0x04, 0xe2, 0xcc, 0x7f, 0xe0, 0xc0, 0xbf, 0xe0, 0x58, 0x38, 0x71, 0x44, 0xed,
-	0x9d, 0xdf, 0x80, 0x66, 0x76, 0x76, 0x76, 0xd6, 0x1f, 0xa5, 0xa8, 0x70, 0x89, 0xe7, 0x79, 0xe6,
-	0xf9, 0xfe, 0x9c, 0x0d, 0x6c, 0x7a, 0x71, 0xaf, 0x17, 0x47, 0xac, 0xef, 0x7a, 0xf4, 0xba, 0xfc,
-	0xcb, 0x86, 0x91, 0xd7, 0x4f, 0x62, 0x1e, 0x5f, 0x97, 0x7f, 0x59, 0x8e, 0xbd, 0x26, 0x11, 0xa8,
-	0xaa, 0x11, 0x98, 0xc2, 0xe2, 0x3e, 0x75, 0xfd, 0xee, 0x30, 0xf2, 0x88, 0x1b, 0x9d, 0x52, 0x84,
-	0x60, 0xf6, 0x24, 0x89, 0x7b, 0x0d, 0xab, 0x6d, 0xad, 0xcf, 0x12, 0x79, 0x46, 0x75, 0xb0, 0x79,
-	0xdc, 0xb0, 0x25, 0xc6, 0xe6, 0x31, 0x5a, 0x81, 0xb9, 0x30, 0xe8, 0x05, 0xbc, 0x51, 0x6a, 0x5b,
-	0xeb, 0x8b, 0x24, 0x05, 0x50, 0x13, 0x2a, 0x34, 0xa4, 0x3d, 0x1a, 0x71, 0xd6, 0x98, 0x6d, 0x5b,
-	0xeb, 0x15, 0xa2, 0x61, 0x7c, 0x0e, 0x75, 0xad, 0x86, 0xb2, 0x41, 0xc8, 0x85, 0x9e, 0x33, 0x97,
-	0x9d, 0x49, 0x3d, 0x35, 0x22, 0xcf, 0xe8, 0x23, 0x43, 0x82, 0xdd, 0x2e, 0xad, 0x2f, 0x6c, 0xb5,
-	0xaf, 0xe5, 0xb6, 0x17, 0x05, 0xec, 0xa5, 0x84, 0xb9, 0x0e, 0x61, 0x95, 0x17, 0x0f, 0x22, 0x6d,
-	0x95, 0x04, 0xf0, 0x87, 0xb0, 0x3a, 0x91, 0x51, 0x38, 0x15, 0xf8, 0x52, 0x7d, 0x95, 0xd8, 0x81,
-	0x2f, 0x0d, 0xa2, 0xae, 0x2f, 0xdd, 0xac, 0x12, 0x79, 0xc6, 0xdf, 0x59, 0x70, 0x21, 0xe7, 0xfe,
-	0x6a, 0x40, 0x19, 0x47, 0x0d, 0x98, 0x97, 0x36, 0x75, 0x32, 0xe6, 0x0c, 0x44, 0x37, 0xa0, 0x9c,
-	0x88, 0x18, 0x66, 0xc6, 0x37, 0x26, 0x19, 0x2f, 0x08, 0x88, 0xa2, 0x43, 0xd7, 0xa1, 0xe2, 0x07,
-	0x27, 0x27, 0x8f, 0x86, 0x7d, 0x2a, 0xad, 0xae, 0x6f, 0x2d, 0x1b, 0x3c, 0xbb, 0xea, 0x8a, 0x68,
-	0x22, 0x7c, 0x0e, 0x8e, 0xe1, 0x4d, 0x3f, 0x8e, 0x18, 0x45, 0x37, 0x61, 0x3e, 0x91, 0x9e, 0xb1,
-	0x86, 0x25, 0xf5, 0xfe, 0x67, 0x6a, 0xd0, 0x48, 0x46, 0x59, 0xd0, 0x6c, 0xbf, 0x8d, 0xe6, 0x5f,
-	0x2d, 0x58, 0x3a, 0x3c, 0xfe, 0x92, 0x7a, 0x5c, 0x88, 0x3b, 0xa0, 0x8c, 0xb9, 0xa7, 0xf4, 0x0d,
-	0xc1, 0xb8, 0x0c, 0xd5, 0x24, 0x8d, 0x58, 0x27, 0x8b, 0x69, 0x8e, 0x10, 0x7c, 0x09, 0xed, 0x87,
-	0xc3, 0x8e, 0x2f, 0xfd, 0xae, 0x92, 0x0c, 0x14, 0x37, 0x7d, 0x77, 0x18, 0xc6, 0xae, 0x2f, 0x8b,
-	0xa8, 0x46, 0x32, 0x50, 0xd4, 0x57, 0x2c, 0x0d, 0xe8, 0xf8, 0x8d, 0x39, 0xc9, 0xa4, 0x61, 0xf4,
-	0x01, 0x40, 0x7a, 0x96, 0x0e, 0x95, 0xa5, 0x43, 0xab, 0x86, 0x43, 0x87, 0xfa, 0x92, 0x18, 0x84,
-	0x98, 0x82, 0xd3, 0x15, 0x34, 0x47, 0x03, 0x76, 0x96, 0xe5, 0x77, 0x33, 0x37, 0x40, 0xb8, 0xb4,
-	0xb0, 0xb5, 0x66, 0xc8, 0x49, 0xa9, 0xd3, 0xeb, 0xdc, 0xb2, 0x16, 0xc0, 0x4e, 0x42, 0x7d, 0x1a,
-	0xf1, 0xc0, 0x0d, 0xa5, 0xb3, 0x35, 0x62, 0x60, 0xf0, 0x32, 0x2c, 0x19, 0x6a, 0xd2, 0xb4, 0x61,
-	0xac, 0x75, 0x87, 0x61, 0xa6, 0x7b, 0xa4, 0x26, 0xf1, 0x3d, 0xcd, 0x28, 0x68, 0x54, 0xbe, 0xff,
-	0xbe, 0x81, 0xf8, 0x6b, 0x1b, 0x6a, 0xe6, 0x0d, 0xda, 0x86, 0x05, 0xc9, 0x23, 0xca, 0x83, 0x26,
-	0x4a, 0xce, 0x15, 0x43, 0x0e, 0x71, 0x5f, 0x74, 0x73, 0x82, 0xa7, 0x01, 0x3f, 0xeb, 0xf8, 0xc4,
-	0xe4, 0x11, 0x4e, 0xbb, 0x5e, 0xa8, 0x04, 0x66, 0x4e, 0xe7, 0x18, 0x84, 0xa1, 0x96, 0x43, 0x3a,
-	0xcf, 0x05, 0x1c, 0xda, 0x82, 0x15, 0x29, 0xb2, 0x4b, 0x39, 0x0f, 0xa2, 0x53, 0x76, 0x54, 0xc8,
-	0xfc, 0xc4, 0x3b, 0x74, 0x0b, 0x2e, 0x4e, 0xc2, 0xeb, 0xa2, 0x98, 0x72, 0x8b, 0x7f, 0xb1, 0x60,
-	0xc1, 0x70, 0x49, 0x94, 0x53, 0x20, 0x13, 0xc4, 0x87, 0x6a, 0x08, 0x69, 0x58, 0x14, 0x2f, 0x0f,
-	0x7a, 0x94, 0x71, 0xb7, 0xd7, 0x97, 0xae, 0x95, 0x48, 0x8e, 0x10, 0xb7, 0x52, 0x87, 0x6e, 0xdb,
-	0x2a, 0xc9, 0x11, 0xe8, 0x2a, 0xd4, 0x45, 0x2d, 0x07, 0x9e, 0xcb, 0x83, 0x38, 0x7a, 0x40, 0x87,
-	0xd2, 0x9b, 0x59, 0x32, 0x82, 0x15, 0xf3, 0x86, 0x51, 0x9a, 0x5a, 0x5d, 0x23, 0xf2, 0x8c, 0xae,
-	0x01, 0x32, 0x42, 0x9c, 0x45, 0xa3, 0x2c, 0x29, 0x26, 0xdc, 0xe0, 0x23, 0xa8, 0x17, 0x13, 0x85,
-	0xda, 0xe3, 0x89, 0xad, 0x15, 0xf3, 0x26, 0xac, 0x0f, 0x4e, 0x23, 0x97, 0x0f, 0x12, 0xaa, 0xd2,
-	0x96, 0x23, 0xf0, 0x2e, 0xac, 0x4c, 0x4a, 0xbd, 0x6c, 0x67, 0xf7, 0x45, 0x41, 0x6a, 0x8e, 0x50,
-	0x75, 0x6b, 0xeb, 0xba, 0xfd, 0xc1, 0x82, 0x95, 0xae, 0x99, 0x86, 0x9d, 0x38, 0xe2, 0x62, 0xe8,
-	0x7e, 0x0c, 0xb5, 0xb4, 0xfd, 0x76, 0x69, 0x48, 0x39, 0x9d, 0x50, 0xc0, 0x87, 0xc6, 0xf5, 0xfe,
-	0x0c, 0x29, 0x90, 0xa3, 0x3b, 0xca, 0x3b, 0xc5, 0x6d, 0x4b, 0xee, 0x8b, 0xa3, 0xe5, 0xaf, 0x99,
-	0x4d, 0xe2, 0xbb, 0xf3, 0x30, 0xf7, 0xdc, 0x0d, 0x07, 0x14, 0xb7, 0xa0, 0x66, 0x2a, 0x19, 0x6b,
-	0xba, 0x0e, 0x2c, 0x74, 0x79, 0x9c, 0x64, 0xf1, 0x9a, 0x3e, 0xe2, 0x44, 0xac, 0x79, 0x9c, 0xb8,
-	0xa7, 0xf4, 0xa1, 0xdb, 0xa3, 0xca, 0x7d, 0x13, 0x85, 0x6f, 0xaa, 0x92, 0x53, 0x9a, 0xfe, 0x0b,
-	0x8b, 0xbe, 0x3c, 0x25, 0x47, 0x94, 0x26, 0x5a, 0x60, 0x11, 0x89, 0x3f, 0x87, 0xd5, 0x42, 0xec,
-	0xba, 0x91, 0xdb, 0x67, 0x67, 0x31, 0x17, 0x1d, 0x97, 0x52, 0xfa, 0x1d, 0x3f, 0x9d, 0xf5, 0x55,
-	0x62, 0x60, 0xc6, 0xc5, 0xdb, 0x93, 0xc4, 0x7f, 0x63, 0x41, 0x2d, 0x13, 0xbd, 0xeb, 0x72, 0x17,
-	0xdd, 0x86, 0x79, 0x2f, 0x4d, 0x8f, 0xda, 0x1f, 0x57, 0x46, 0x03, 0x3a, 0x92, 0x45, 0x92, 0xd1,
-	0x8b, 0x85, 0xcd, 0x94, 0x75, 0x2a, 0x19, 0xed, 0x69, 0xbc, 0x99, 0x17, 0x44, 0x73, 0xe0, 0x67,
-	0x6a, 0xba, 0x75, 0x07, 0xc7, 0xcc, 0x4b, 0x82, 0xbe, 0xe8, 0x0c, 0xd1, 0x96, 0x2a, 0xbe, 0x99,
-	0x8b, 0x1a, 0x46, 0x77, 0xa0, 0xec, 0x7a, 0x82, 0x4a, 0xad, 0x2c, 0x3c, 0xa6, 0xcc, 0x90, 0xb4,
-	0x2d, 0x29, 0x89, 0xe2, 0xc0, 0x1d, 0x58, 0xde, 0xf6, 0xc2, 0x6d, 0xdf, 0x27, 0xd4, 0x8b, 0x13,
-	0xff, 0xaf, 0xb7, 0xb9, 0xb1, 0x88, 0xec, 0xc2, 0x22, 0xc2, 0x9f, 0xc2, 0x4a, 0x51, 0x94, 0x1a,
-	0xcc, 0x4d, 0xa8, 0x24, 0x12, 0xa3, 0x85, 0x69, 0xf8, 0x0d, 0xd2, 0x3e, 0x91, 0xd2, 0xee, 0x53,
-	0x9e, 0x4a, 0x63, 0x6f, 0x65, 0x99, 0xeb, 0x85, 0xfb, 0xf9, 0x63, 0x25, 0x03, 0xf1, 0x26, 0xac,
-	0x8e, 0xc8, 0x52, 0xa6, 0xc9, 0x7d, 0x2b, 0x51, 0x32, 0xa8, 0x35, 0x92, 0x81, 0xf8, 0x0b, 0x70,
-	0x64, 0xb5, 0x8b, 0x95, 0xff, 0x2f, 0x3c, 0x71, 0xf0, 0x3e, 0x2c, 0x19, 0xf2, 0xdf, 0xe1, 0xc9,
-	0x82, 0x7f, 0xb2, 0x60, 0x51, 0x8a, 0x7a, 0x40, 0x87, 0x4f, 0x44, 0x27, 0x8b, 0xa1, 0xf4, 0x8c,
-	0x0e, 0x0b, 0xbd, 0x94, 0x23, 0xc4, 0x7b, 0x50, 0x36, 0xbc, 0x0a, 0x78, 0x0a, 0xa0, 0xff, 0xc3,
-	0x52, 0x36, 0xe6, 0xbb, 0x7a, 0x0c, 0x96, 0x24, 0xc5, 0xf8, 0x85, 0x68, 0xa9, 0x3e, 0xa5, 0x49,
-	0x4e, 0x99, 0x6e, 0xa6, 0x22, 0xd2, 0x8c, 0xd7, 0x5c, 0x21, 0x5e, 0x78, 0x1f, 0xea, 0x05, 0x93,
-	0x19, 0xba, 0x25, 0x6d, 0x4e, 0x01, 0xe5, 0xbc, 0x19, 0xc4, 0x02, 0x35, 0xc9, 0x49, 0xf1, 0x8f,
-	0x86, 0xf7, 0x9d, 0x28, 0xa2, 0x89, 0x58, 0x20, 0xc2, 0x8c, 0xec, 0x05, 0x2d, 0xce, 0x85, 0xa5,
-	0x66, 0x8f, 0x2c, 0x35, 0x1d, 0x8f, 0x92, 0x19, 0x8f, 0xab, 0x50, 0xd7, 0x9b, 0xed, 0x20, 0xf0,
-	0x92, 0x58, 0xba, 0x58, 0x22, 0x23, 0x58, 0x11, 0x6b, 0x55, 0x65, 0xda, 0xcb, 0x1c, 0x81, 0x1c,
-	0x28, 0x3d, 0xa3, 0x43, 0xb9, 0xa9, 0xaa, 0x44, 0x1c, 0xf1, 0x83, 0xd4, 0x5c, 0xf7, 0xf4, 0x1f,
-	0x98, 0xa3, 0x1b, 0x7f, 0x58, 0x50, 0xd9, 0x4b, 0x92, 0x9d, 0xd8, 0xa7, 0x0c, 0xd5, 0x01, 0x1e,
-	0x47, 0xf4, 0xbc, 0x4f, 0x3d, 0x4e, 0x7d, 0x67, 0x06, 0x39, 0xea, 0x6d, 0x73, 0x10, 0x30, 0x16,
-	0x44, 0xa7, 0x8e, 0x85, 0x2e, 0xa8, 0xb1, 0xbb, 0x77, 0x1e, 0x30, 0xce, 0x1c, 0x1b, 0x2d, 0xc3,
-	0x05, 0x89, 0x78, 0x18, 0xf3, 0x4e, 0xb4, 0xe3, 0x7a, 0x67, 0xd4, 0x29, 0x21, 0x04, 0x75, 0x89,
-	0xec, 0xb0, 0x74, 0x3c, 0xfb, 0xce, 0x2c, 0x6a, 0xc0, 0x8a, 0xac, 0x1e, 0xf6, 0x30, 0xe6, 0xaa,
-	0x5a, 0x83, 0xe3, 0x90, 0x3a, 0x73, 0x68, 0x05, 0x1c, 0x42, 0x3d, 0x1a, 0xf4, 0x79, 0x87, 0x75,
-	0xa2, 0xe7, 0x6e, 0x18, 0xf8, 0x4e, 0x59, 0xc8, 0x50, 0x80, 0x5a, 0xc9, 0xce, 0xbc, 0xa0, 0xdc,
-	0x1d, 0xa4, 0xab, 0x9e, 0xaa, 0x8e, 0x72, 0x2a, 0xe8, 0x12, 0xac, 0x3d, 0x8a, 0xe3, 0x03, 0x37,
-	0x1a, 0x2a, 0x1c, 0xbb, 0x97, 0xc4, 0x3d, 0xa1, 0xcc, 0xa9, 0x0a, 0x83, 0xf7, 0x92, 0x24, 0x4e,
-	0x0e, 0x4f, 0x4e, 0x18, 0xe5, 0x8e, 0xbf, 0x71, 0x1b, 0xd6, 0xa6, 0x0c, 0x34, 0xb4, 0x08, 0x55,
-	0x85, 0x3d, 0xa6, 0xce, 0x8c, 0x60, 0x7d, 0x1c, 0x31, 0x8d, 0xb0, 0x36, 0xfe, 0x07, 0x95, 0xec,
-	0xf9, 0x8e, 0x16, 0x60, 0xbe, 0x13, 0x05, 0xe2, 0x0d, 0xea, 0xcc, 0xa0, 0x32, 0xd8, 0x4f, 0x36,
-	0x1d, 0x4b, 0xfe, 0x6e, 0x39, 0xf6, 0xc6, 0x7b, 0x00, 0xf9, 0xb3, 0x18, 0x55, 0x60, 0xf6, 0x51,
-	0x42, 0x85, 0xc4, 0x79, 0x28, 0x6d, 0x7b, 0xa1, 0x63, 0xa1, 0x1a, 0x54, 0xb2, 0x4a, 0x74, 0xec,
-	0xad, 0x6f, 0xcb, 0x50, 0x4d, 0x6d, 0x1a, 0x46, 0x1e, 0xda, 0x81, 0x4a, 0xd6, 0xa7, 0xa8, 0x39,
-	0xb1, 0x79, 0xa5, 0x93, 0xcd, 0x4b, 0x93, 0x1b, 0x3b, 0x1d, 0x03, 0xf7, 0xa0, 0xaa, 0x67, 0x03,
-	0xba, 0x34, 0xda, 0x05, 0xc6, 0x44, 0x6a, 0x5e, 0x9e, 0x7c, 0xa9, 0xe4, 0xdc, 0x57, 0xad, 0xb1,
-	0x97, 0x7d, 0x0a, 0x4e, 0xed, 0xa8, 0xe6, 0xd4, 0x9b, 0x75, 0xeb, 0x86, 0x25, 0x0d, 0xca, 0x1e,
-	0xea, 0x45, 0x83, 0x46, 0xbe, 0x12, 0x8a, 0x06, 0x8d, 0xbe, 0xed, 0x0d, 0x39, 0x61, 0x38, 0x49,
-	0x8e, 0x7e, 0xf1, 0x4f, 0x92, 0x63, 0x3c, 0xf5, 0x09, 0x38, 0xf9, 0x37, 0x57, 0x97, 0x27, 0xd4,
-	0xed, 0xa1, 0xcb, 0x63, 0x8f, 0x25, 0xe3, 0x83, 0xac, 0xf9, 0xc6, 0x5b, 0xe9, 0xe3, 0x7e, 0x96,
-	0x76, 0x99, 0xbb, 0x77, 0x90, 0x86, 0x9e, 0xc2, 0x5a, 0x8e, 0x54, 0x0e, 0xbd, 0xbb, 0x91, 0x37,
-	0x2c, 0x74, 0x08, 0x35, 0x73, 0xc1, 0xa2, 0x96, 0x41, 0x3f, 0x61, 0x89, 0x37, 0xaf, 0x4c, 0xbd,
-	0xd7, 0x71, 0x5c, 0x2c, 0xec, 0x45, 0x34, 0xc2, 0x31, 0xb6, 0x7d, 0x9b, 0xed, 0xe9, 0x04, 0xa9,
-	0xcc, 0xbb, 0xef, 0xff, 0xfc, 0xaa, 0x65, 0xbd, 0x7c, 0xd5, 0xb2, 0x7e, 0x7f, 0xd5, 0xb2, 0xbe,
-	0x7f, 0xdd, 0x9a, 0x79, 0xf9, 0xba, 0x35, 0xf3, 0xdb, 0xeb, 0xd6, 0xcc, 0x67, 0xcd, 0xe9, 0xff,
-	0x91, 0x39, 0x2e, 0xcb, 0x9f, 0x9b, 0x7f, 0x06, 0x00, 0x00, 0xff, 0xff, 0xdc, 0x8f, 0x50, 0x36,
-	0xb6, 0x11, 0x00, 0x00,
+	// 1607 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xdc, 0x4e,
+	0x15, 0x8f, 0xbd, 0xc9, 0x5e, 0x4e, 0x36, 0x5b, 0x67, 0xb2, 0xf9, 0x67, 0xd9, 0x46, 0xdb, 0x95,
+	0x85, 0x4a, 0x14, 0x41, 0xdb, 0xa4, 0x50, 0xa9, 0x05, 0x1e, 0xd2, 0x24, 0x6d, 0x96, 0x92, 0x26,
+	0x9a, 0xed, 0x45, 0x42, 0x02, 0xc9, 0xb1, 0x4f, 0x12, 0x13, 0xaf, 0xbd, 0x78, 0x66, 0xd3, 0xec,
+	0x63, 0x9f, 0x78, 0x02, 0xf1, 0xcc, 0xb7, 0xe0, 0x5b, 0xf0, 0x58, 0x78, 0xe2, 0x11, 0xb5, 0x1f,
+	0x80, 0xaf, 0x80, 0x66, 0x3c, 0xb6, 0xc7, 0x7b, 0x09, 0x45, 0x85, 0x97, 0xac, 0xcf, 0x65, 0x7e,
+	0x73, 0xce, 0x99, 0x73, 0x99, 0x09, 0xec, 0xb8, 0xd1, 0x60, 0x10, 0x85, 0x6c, 0xe8, 0xb8, 0xf8,
+	0x50, 0xfe, 0x65, 0xe3, 0xd0, 0x1d, 0xc6, 0x11, 0x8f, 0x1e, 0xca, 0xbf, 0x2c, 0xe7, 0x3e, 0x90,
+	0x0c, 0x52, 0xcb, 0x18, 0x36, 0xc2, 0xca, 0x11, 0x3a, 0x5e, 0x7f, 0x1c, 0xba, 0xd4, 0x09, 0x2f,
+	0x90, 0x10, 0x58, 0x3c, 0x8f, 0xa3, 0x41, 0xcb, 0xe8, 0x1a, 0x5b, 0x8b, 0x54, 0x7e, 0x93, 0x06,
+	0x98, 0x3c, 0x6a, 0x99, 0x92, 0x63, 0xf2, 0x88, 0x34, 0x61, 0x29, 0xf0, 0x07, 0x3e, 0x6f, 0x95,
+	0xba, 0xc6, 0xd6, 0x0a, 0x4d, 0x08, 0xd2, 0x86, 0x2a, 0x06, 0x38, 0xc0, 0x90, 0xb3, 0xd6, 0x62,
+	0xd7, 0xd8, 0xaa, 0xd2, 0x8c, 0xb6, 0x6f, 0xa0, 0x91, 0x6d, 0x83, 0x6c, 0x14, 0x70, 0xb1, 0xcf,
+	0xa5, 0xc3, 0x2e, 0xe5, 0x3e, 0x75, 0x2a, 0xbf, 0xc9, 0xcf, 0x34, 0x04, 0xb3, 0x5b, 0xda, 0x5a,
+	0xde, 0xed, 0x3e, 0xc8, 0x6d, 0x2f, 0x02, 0x1c, 0x26, 0x8a, 0xf9, 0x1e, 0xc2, 0x2a, 0x37, 0x1a,
+	0x85, 0x99, 0x55, 0x92, 0xb0, 0x7f, 0x0a, 0xeb, 0x33, 0x17, 0x0a, 0xa7, 0x7c, 0x4f, 0x6e, 0x5f,
+	0xa3, 0xa6, 0xef, 0x49, 0x83, 0xd0, 0xf1, 0xa4, 0x9b, 0x35, 0x2a, 0xbf, 0xed, 0x3f, 0x1a, 0x70,
+	0x27, 0x5f, 0xfd, 0xbb, 0x11, 0x32, 0x4e, 0x5a, 0x50, 0x91, 0x36, 0xf5, 0xd2, 0xc5, 0x29, 0x49,
+	0x1e, 0x41, 0x39, 0x16, 0x31, 0x4c, 0x8d, 0x6f, 0xcd, 0x32, 0x5e, 0x28, 0x50, 0xa5, 0x47, 0x1e,
+	0x42, 0xd5, 0xf3, 0xcf, 0xcf, 0xdf, 0x8c, 0x87, 0x28, 0xad, 0x6e, 0xec, 0xae, 0x69, 0x6b, 0x0e,
+	0x94, 0x88, 0x66, 0x4a, 0xf6, 0x0d, 0x58, 0x9a, 0x37, 0xc3, 0x28, 0x64, 0x48, 0x1e, 0x43, 0x25,
+	0x96, 0x9e, 0xb1, 0x96, 0x21, 0xf7, 0xfd, 0xde, 0xdc, 0xa0, 0xd1, 0x54, 0xb3, 0xb0, 0xb3, 0xf9,
+	0x35, 0x3b, 0xff, 0xdd, 0x80, 0xd5, 0x93, 0xb3, 0xdf, 0xa2, 0xcb, 0x05, 0xdc, 0x31, 0x32, 0xe6,
+	0x5c, 0xe0, 0x2d, 0xc1, 0xd8, 0x84, 0x5a, 0x9c, 0x44, 0xac, 0x97, 0xc6, 0x34, 0x67, 0x88, 0x75,
+	0x31, 0x0e, 0x83, 0x71, 0xcf, 0x93, 0x7e, 0xd7, 0x68, 0x4a, 0x0a, 0xc9, 0xd0, 0x19, 0x07, 0x91,
+	0xe3, 0xc9, 0x24, 0xaa, 0xd3, 0x94, 0x14, 0xf9, 0x15, 0x49, 0x03, 0x7a, 0x5e, 0x6b, 0x49, 0x2e,
+	0xca, 0x68, 0xf2, 0x13, 0x80, 0xe4, 0x5b, 0x3a, 0x54, 0x96, 0x0e, 0xad, 0x6b, 0x0e, 0x9d, 0x64,
+	0x42, 0xaa, 0x29, 0xda, 0x08, 0x56, 0x5f, 0xe8, 0x9c, 0x8e, 0xd8, 0x65, 0x7a, 0xbe, 0x3b, 0xb9,
+	0x01, 0xc2, 0xa5, 0xe5, 0xdd, 0x0d, 0x0d, 0x27, 0xd1, 0x4e, 0xc4, 0xb9, 0x65, 0x1d, 0x80, 0xfd,
+	0x18, 0x3d, 0x0c, 0xb9, 0xef, 0x04, 0xd2, 0xd9, 0x3a, 0xd5, 0x38, 0xf6, 0x1a, 0xac, 0x6a, 0xdb,
+	0x24, 0xc7, 0x66, 0xdb, 0xd9, 0xde, 0x41, 0x90, 0xee, 0x3d, 0x91, 0x93, 0xf6, 0x0b, 0x58, 0xd5,
+	0x74, 0xd4, 0x79, 0xff, 0xf7, 0x06, 0xda, 0x1f, 0x4d, 0xa8, 0xeb, 0x12, 0xb2, 0x07, 0xcb, 0x72,
+	0x8d, 0x48, 0x0f, 0x8c, 0x15, 0xce, 0x3d, 0x0d, 0x87, 0x3a, 0x1f, 0xfa, 0xb9, 0xc2, 0x7b, 0x9f,
+	0x5f, 0xf6, 0x3c, 0xaa, 0xaf, 0x11, 0x4e, 0x3b, 0x6e, 0xa0, 0x00, 0x53, 0xa7, 0x73, 0x0e, 0xb1,
+	0xa1, 0x9e, 0x53, 0xd9, 0x39, 0x17, 0x78, 0x64, 0x17, 0x9a, 0x12, 0xb2, 0x8f, 0x9c, 0xfb, 0xe1,
+	0x05, 0x3b, 0x2d, 0x9c, 0xfc, 0x4c, 0x19, 0x79, 0x02, 0xdf, 0xcd, 0xe2, 0x67, 0x49, 0x31, 0x47,
+	0x6a, 0xff, 0xcd, 0x80, 0x65, 0xcd, 0x25, 0x91, 0x4e, 0xbe, 0x3c, 0x20, 0x3e, 0x56, 0x4d, 0x28,
+	0xa3, 0x45, 0xf2, 0x72, 0x7f, 0x80, 0x8c, 0x3b, 0x83, 0xa1, 0x74, 0xad, 0x44, 0x73, 0x86, 0x90,
+	0xca, 0x3d, 0xb2, 0xb2, 0xad, 0xd1, 0x9c, 0x41, 0xee, 0x43, 0x43, 0xe4, 0xb2, 0xef, 0x3a, 0xdc,
+	0x8f, 0xc2, 0x57, 0x38, 0x96, 0xde, 0x2c, 0xd2, 0x09, 0xae, 0xe8, 0x37, 0x0c, 0x31, 0xb1, 0xba,
+	0x4e, 0xe5, 0x37, 0x79, 0x00, 0x44, 0x0b, 0x71, 0x1a, 0x8d, 0xb2, 0xd4, 0x98, 0x21, 0xb1, 0x4f,
+	0xa1, 0x51, 0x3c, 0x28, 0xd2, 0x9d, 0x3e, 0xd8, 0x7a, 0xf1, 0xdc, 0x84, 0xf5, 0xfe, 0x45, 0xe8,
+	0xf0, 0x51, 0x8c, 0xea, 0xd8, 0x72, 0x86, 0x7d, 0x00, 0xcd, 0x59, 0x47, 0x2f, 0xcb, 0xd9, 0xf9,
+	0x50, 0x40, 0xcd, 0x19, 0x2a, 0x6f, 0xcd, 0x2c, 0x6f, 0xff, 0x6c, 0x40, 0xb3, 0xaf, 0x1f, 0xc3,
+	0x7e, 0x14, 0x72, 0xd1, 0x74, 0x7f, 0x0e, 0xf5, 0xa4, 0xfc, 0x0e, 0x30, 0x40, 0x8e, 0x33, 0x12,
+	0xf8, 0x44, 0x13, 0x1f, 0x2d, 0xd0, 0x82, 0x3a, 0x79, 0xa6, 0xbc, 0x53, 0xab, 0x4d, 0xb9, 0xfa,
+	0xbb, 0xc9, 0xf4, 0xcf, 0x16, 0xeb, 0xca, 0xcf, 0x2b, 0xb0, 0x74, 0xed, 0x04, 0x23, 0xb4, 0x3b,
+	0x50, 0xd7, 0x37, 0x99, 0x2a, 0xba, 0x1e, 0x2c, 0xf7, 0x79, 0x14, 0xa7, 0xf1, 0x9a, 0xdf, 0xe2,
+	0x44, 0xac, 0x79, 0x14, 0x3b, 0x17, 0xf8, 0xda, 0x19, 0xa0, 0x72, 0x5f, 0x67, 0xd9, 0x8f, 0x55,
+	0xca, 0xa9, 0x9d, 0xbe, 0x0f, 0x2b, 0x9e, 0xfc, 0x8a, 0x4f, 0x11, 0xe3, 0x0c, 0xb0, 0xc8, 0xb4,
+	0x7f, 0x0d, 0xeb, 0x85, 0xd8, 0xf5, 0x43, 0x67, 0xc8, 0x2e, 0x23, 0x2e, 0x2a, 0x2e, 0xd1, 0xf4,
+	0x7a, 0x5e, 0xd2, 0xeb, 0x6b, 0x54, 0xe3, 0x4c, 0xc3, 0x9b, 0xb3, 0xe0, 0x7f, 0x6f, 0x40, 0x3d,
+	0x85, 0x3e, 0x70, 0xb8, 0x43, 0x9e, 0x42, 0xc5, 0x4d, 0x8e, 0x47, 0xcd, 0x8f, 0x7b, 0x93, 0x01,
+	0x9d, 0x38, 0x45, 0x9a, 0xea, 0x8b, 0x81, 0xcd, 0x94, 0x75, 0xea, 0x30, 0xba, 0xf3, 0xd6, 0xa6,
+	0x5e, 0xd0, 0x6c, 0x85, 0x7d, 0xa5, 0xba, 0x5b, 0x7f, 0x74, 0xc6, 0xdc, 0xd8, 0x1f, 0x8a, 0xca,
+	0x10, 0x65, 0xa9, 0xe2, 0x9b, 0xba, 0x98, 0xd1, 0xe4, 0x19, 0x94, 0x1d, 0x57, 0x68, 0xa9, 0x91,
+	0x65, 0x4f, 0x6d, 0xa6, 0x21, 0xed, 0x49, 0x4d, 0xaa, 0x56, 0xd8, 0x3d, 0x58, 0xdb, 0x73, 0x83,
+	0x3d, 0xcf, 0xa3, 0xe8, 0x46, 0xb1, 0xf7, 0x9f, 0xa7, 0xb9, 0x36, 0x88, 0xcc, 0xc2, 0x20, 0xb2,
+	0x7f, 0x09, 0xcd, 0x22, 0x94, 0x6a, 0xcc, 0x6d, 0xa8, 0xc6, 0x92, 0x93, 0x81, 0x65, 0xf4, 0x2d,
+	0x68, 0xbf, 0x90, 0x68, 0x2f, 0x91, 0x27, 0x68, 0xec, 0xab, 0x2c, 0x73, 0xdc, 0xe0, 0x28, 0xbf,
+	0xac, 0xa4, 0xa4, 0xbd, 0x03, 0xeb, 0x13, 0x58, 0xca, 0x34, 0x39, 0x6f, 0x25, 0x4b, 0x06, 0xb5,
+	0x4e, 0x53, 0xd2, 0xfe, 0x0d, 0x58, 0x32, 0xdb, 0xc5, 0xc8, 0xff, 0x3f, 0x5c, 0x71, 0xec, 0x23,
+	0x58, 0xd5, 0xf0, 0xbf, 0xe1, 0xca, 0x62, 0xff, 0xc5, 0x80, 0x15, 0x09, 0xf5, 0x0a, 0xc7, 0xef,
+	0x44, 0x25, 0x8b, 0xa6, 0x74, 0x85, 0xe3, 0x42, 0x2d, 0xe5, 0x0c, 0xd2, 0x54, 0x05, 0xaf, 0x02,
+	0x9e, 0x10, 0xe4, 0x87, 0xb0, 0x9a, 0xb6, 0xf9, 0x7e, 0xd6, 0x06, 0x4b, 0x52, 0x63, 0x5a, 0x20,
+	0x4a, 0x6a, 0x88, 0x18, 0xe7, 0x9a, 0xc9, 0x64, 0x2a, 0x32, 0xf5, 0x78, 0x2d, 0x15, 0xe2, 0x65,
+	0x1f, 0x41, 0xa3, 0x60, 0x32, 0x23, 0x4f, 0xa4, 0xcd, 0x09, 0xd1, 0x32, 0xa6, 0x82, 0x58, 0xd0,
+	0xa6, 0xb9, 0xaa, 0xfd, 0x2f, 0xcd, 0xfb, 0x5e, 0x18, 0x62, 0x2c, 0x06, 0x88, 0x30, 0x23, 0xbd,
+	0x41, 0x8b, 0xef, 0xc2, 0x50, 0x33, 0x27, 0x86, 0x5a, 0x16, 0x8f, 0x92, 0x1e, 0x8f, 0xfb, 0xd0,
+	0xc8, 0x26, 0xdb, 0xb1, 0xef, 0xc6, 0x91, 0x74, 0xb1, 0x44, 0x27, 0xb8, 0x22, 0xd6, 0x2a, 0xcb,
+	0x32, 0x2f, 0x73, 0x06, 0xb1, 0xa0, 0x74, 0x85, 0x63, 0x39, 0xa9, 0x6a, 0x54, 0x7c, 0x0a, 0x5c,
+	0xbc, 0x19, 0xfa, 0x31, 0xb2, 0x3d, 0x9e, 0xe0, 0x56, 0x12, 0xdc, 0x22, 0x57, 0xc4, 0x4e, 0xb5,
+	0xb0, 0x56, 0x55, 0x3e, 0x1a, 0x52, 0xd2, 0x7e, 0x95, 0x38, 0xec, 0x5c, 0xfc, 0x0f, 0x3a, 0xf1,
+	0xf6, 0x47, 0x13, 0xaa, 0x87, 0x71, 0xbc, 0x1f, 0x79, 0xc8, 0x48, 0x03, 0xe0, 0x6d, 0x88, 0x37,
+	0x43, 0x74, 0x39, 0x7a, 0xd6, 0x02, 0xb1, 0xd4, 0xed, 0xe8, 0xd8, 0x67, 0xcc, 0x0f, 0x2f, 0x2c,
+	0x83, 0xdc, 0x51, 0x8d, 0xfb, 0xf0, 0xc6, 0x67, 0x9c, 0x59, 0x26, 0x59, 0x83, 0x3b, 0x92, 0xf1,
+	0x3a, 0xe2, 0xbd, 0x70, 0xdf, 0x71, 0x2f, 0xd1, 0x2a, 0x11, 0x02, 0x0d, 0xc9, 0xec, 0xb1, 0xa4,
+	0xc1, 0x7b, 0xd6, 0x22, 0x69, 0x41, 0x53, 0xe6, 0x1f, 0x7b, 0x1d, 0x71, 0x95, 0xef, 0xfe, 0x59,
+	0x80, 0xd6, 0x12, 0x69, 0x82, 0x45, 0xd1, 0x45, 0x7f, 0xc8, 0x7b, 0xac, 0x17, 0x5e, 0x3b, 0x81,
+	0xef, 0x59, 0x65, 0x81, 0xa1, 0x08, 0x35, 0xd4, 0xad, 0x8a, 0xd0, 0x3c, 0x18, 0x25, 0x97, 0x05,
+	0x54, 0x35, 0x69, 0x55, 0xc9, 0x5d, 0xd8, 0x78, 0x13, 0x45, 0xc7, 0x4e, 0x38, 0x56, 0x3c, 0xf6,
+	0x22, 0x8e, 0x06, 0x62, 0x33, 0xab, 0x46, 0x56, 0x61, 0xe5, 0x00, 0xaf, 0x7d, 0x17, 0x29, 0x5e,
+	0x47, 0x57, 0xe8, 0x59, 0x20, 0x7c, 0x38, 0x8c, 0xe3, 0x28, 0x3e, 0x39, 0x3f, 0x67, 0xc8, 0x2d,
+	0x6f, 0xfb, 0x29, 0x6c, 0xcc, 0xe9, 0x92, 0x64, 0x05, 0x6a, 0x8a, 0x7b, 0x86, 0xd6, 0x82, 0x58,
+	0xfa, 0x36, 0x64, 0x19, 0xc3, 0xd8, 0xfe, 0x01, 0x54, 0xd3, 0x37, 0x01, 0x59, 0x86, 0x4a, 0x2f,
+	0xf4, 0xc5, 0xc5, 0xd6, 0x5a, 0x20, 0x65, 0x30, 0xdf, 0xed, 0x58, 0x86, 0xfc, 0xdd, 0xb5, 0xcc,
+	0xed, 0x1f, 0x01, 0xe4, 0x77, 0x6d, 0x52, 0x85, 0xc5, 0x37, 0x31, 0x0a, 0xc4, 0x0a, 0x94, 0xf6,
+	0xdc, 0xc0, 0x32, 0x48, 0x1d, 0xaa, 0x69, 0x7a, 0x5b, 0xe6, 0xee, 0x1f, 0xca, 0x50, 0x4b, 0x6c,
+	0x1a, 0x87, 0x2e, 0xd9, 0x87, 0x6a, 0x5a, 0xfc, 0xa4, 0x3d, 0xb3, 0x23, 0x48, 0xbf, 0xdb, 0x77,
+	0x67, 0xca, 0x54, 0x6f, 0x79, 0x01, 0xb5, 0xac, 0xe1, 0x90, 0xbb, 0x93, 0xa5, 0xa5, 0xb5, 0xb9,
+	0xf6, 0xe6, 0x6c, 0xa1, 0xc2, 0x79, 0xa9, 0xea, 0xed, 0x30, 0x7d, 0x5f, 0xce, 0x2d, 0xd3, 0xf6,
+	0x5c, 0xc9, 0x96, 0xf1, 0xc8, 0x90, 0x06, 0xa5, 0xb7, 0xff, 0xa2, 0x41, 0x13, 0x4f, 0x8f, 0xf6,
+	0xe6, 0x6c, 0xa1, 0xe6, 0x58, 0xfa, 0x18, 0x98, 0x85, 0x13, 0x04, 0xb7, 0xe0, 0x68, 0xef, 0x07,
+	0x0a, 0x56, 0xfe, 0x90, 0xeb, 0xf3, 0x18, 0x9d, 0x01, 0xd9, 0x9c, 0xba, 0x81, 0x69, 0xaf, 0xbc,
+	0xf6, 0xad, 0x52, 0xe9, 0xe3, 0x11, 0x40, 0x2e, 0xf8, 0x16, 0x34, 0xf2, 0x1e, 0x36, 0x72, 0xa6,
+	0x72, 0xe8, 0xdb, 0x8d, 0x7c, 0x64, 0x90, 0x13, 0xa8, 0xeb, 0x53, 0x9b, 0x74, 0x34, 0xfd, 0x19,
+	0x37, 0x83, 0xf6, 0xbd, 0xb9, 0xf2, 0x2c, 0x8e, 0x2b, 0x85, 0x61, 0x4b, 0x26, 0x56, 0x4c, 0x8d,
+	0xf4, 0x76, 0x77, 0xbe, 0x42, 0x82, 0xf9, 0xfc, 0xc7, 0x7f, 0xfd, 0xdc, 0x31, 0x3e, 0x7d, 0xee,
+	0x18, 0xff, 0xfc, 0xdc, 0x31, 0xfe, 0xf4, 0xa5, 0xb3, 0xf0, 0xe9, 0x4b, 0x67, 0xe1, 0x1f, 0x5f,
+	0x3a, 0x0b, 0xbf, 0x6a, 0xcf, 0xff, 0x37, 0xcf, 0x59, 0x59, 0xfe, 0x3c, 0xfe, 0xf7, 0x00, 0xaa,
+	0x17, 0xa2, 0x09, 0x0b, 0x12, 0x00, 0x00,
 }
 
 func (m *HeadSyncRange) Marshal() (dAtA []byte, err error) {
@@ -3577,6 +3600,21 @@ func (m *StoreKeyInner) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Deleted {
+		i--
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.ExpiresAtMicro != 0 {
+		i = encodeVarintSpacesync(dAtA, i, uint64(m.ExpiresAtMicro))
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.Key) > 0 {
 		i -= len(m.Key)
 		copy(dAtA[i:], m.Key)
@@ -4254,6 +4292,12 @@ func (m *StoreKeyInner) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovSpacesync(uint64(l))
 	}
+	if m.ExpiresAtMicro != 0 {
+		n += 1 + sovSpacesync(uint64(m.ExpiresAtMicro))
+	}
+	if m.Deleted {
+		n += 2
+	}
 	return n
 }
 
@@ -8014,6 +8058,45 @@ func (m *StoreKeyInner) Unmarshal(dAtA []byte) error {
 			}
 			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAtMicro", wireType)
+			}
+			m.ExpiresAtMicro = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSpacesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAtMicro |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSpacesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deleted = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSpacesync(dAtA[iNdEx:])
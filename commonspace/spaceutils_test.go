@@ -543,7 +543,7 @@ type spaceFixture struct {
 	cancelFunc           context.CancelFunc
 }
 
-func newFixture(t *testing.T) *spaceFixture {
+func newFixture(t *testing.T, components ...app.Component) *spaceFixture {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	fx := &spaceFixture{
 		ctx:                  ctx,
@@ -575,6 +575,9 @@ func newFixture(t *testing.T) *spaceFixture {
 		Register(fx.treeManager).
 		Register(fx.spaceService).
 		Register(NewRpcServer())
+	for _, c := range components {
+		fx.app.Register(c)
+	}
 	err := fx.app.Start(ctx)
 	if err != nil {
 		fx.cancelFunc()
@@ -4,6 +4,7 @@ import (
 	"github.com/anyproto/protobuf/proto"
 
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/commonspace/sync/syncdeps"
 )
 
 type InnerRequest interface {
@@ -15,6 +16,7 @@ type Request struct {
 	peerId   string
 	spaceId  string
 	objectId string
+	priority syncdeps.Priority
 	Inner    InnerRequest
 	Bytes    []byte
 }
@@ -34,6 +36,7 @@ func NewByteRequest(peerId, spaceId, objectId string, message []byte) *Request {
 		peerId:   peerId,
 		spaceId:  spaceId,
 		objectId: objectId,
+		priority: syncdeps.PriorityForeground,
 		Bytes:    message,
 	}
 }
@@ -43,6 +46,7 @@ func NewRequest(peerId, spaceId, objectId string, inner InnerRequest) *Request {
 		peerId:   peerId,
 		spaceId:  spaceId,
 		objectId: objectId,
+		priority: syncdeps.PriorityForeground,
 		Inner:    inner,
 	}
 }
@@ -55,6 +59,14 @@ func (r *Request) ObjectId() string {
 	return r.objectId
 }
 
+func (r *Request) Priority() syncdeps.Priority {
+	return r.priority
+}
+
+func (r *Request) SetPriority(priority syncdeps.Priority) {
+	r.priority = priority
+}
+
 func (r *Request) Proto() (proto.Message, error) {
 	msg, err := r.Inner.Marshall()
 	if err != nil {
@@ -72,7 +72,7 @@ func (r *requestManager) SendRequest(ctx context.Context, rq syncdeps.Request, c
 func (r *requestManager) QueueRequest(rq syncdeps.Request) error {
 	size := rq.MsgSize()
 	r.metric.UpdateQueueSize(size, syncdeps.MsgTypeOutgoingRequest, true)
-	r.requestPool.Add(rq.PeerId(), rq.ObjectId(), func(ctx context.Context) {
+	r.requestPool.Add(rq.PeerId(), rq.ObjectId(), int(rq.Priority()), size, func(ctx context.Context) {
 		r.handler.ApplyRequest(ctx, rq, r)
 	}, func() {
 		r.metric.UpdateQueueSize(size, syncdeps.MsgTypeOutgoingRequest, false)
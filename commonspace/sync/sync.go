@@ -129,6 +129,11 @@ func (s *syncService) HandleMessage(ctx context.Context, msg drpc.Message) error
 }
 
 func (s *syncService) QueueRequest(ctx context.Context, rq syncdeps.Request) error {
+	if priority, ok := syncdeps.CtxPriority(ctx); ok {
+		if setter, ok := rq.(syncdeps.PrioritySetter); ok {
+			setter.SetPriority(priority)
+		}
+	}
 	return s.manager.QueueRequest(rq)
 }
 
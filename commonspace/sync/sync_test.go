@@ -385,6 +385,10 @@ func (r *testRequest) MsgSize() uint64 {
 	return 0
 }
 
+func (r *testRequest) Priority() syncdeps.Priority {
+	return syncdeps.PriorityForeground
+}
+
 type testMessage struct {
 	objectId   string
 	objectType spacesyncproto.ObjectType
@@ -1,10 +1,62 @@
 package syncdeps
 
-import "github.com/anyproto/protobuf/proto"
+import (
+	"context"
+
+	"github.com/anyproto/protobuf/proto"
+)
+
+// Priority defines the order in which the queued requests are sent, the requests with higher priority are sent first
+type Priority int
+
+const (
+	// PriorityBackground is used for the bulk sync, e.g. for the trees found by headsync
+	PriorityBackground Priority = iota
+	// PriorityForeground is the default priority of the requests
+	PriorityForeground
+	// PriorityInteractive is used for the objects the user is waiting for,
+	// e.g. the trees which are built on demand through the tree builder
+	PriorityInteractive
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityBackground:
+		return "background"
+	case PriorityForeground:
+		return "foreground"
+	case PriorityInteractive:
+		return "interactive"
+	}
+	return "unknown"
+}
 
 type Request interface {
 	PeerId() string
 	ObjectId() string
 	Proto() (proto.Message, error)
+	// MsgSize is the size of the request itself, it is counted in the bandwidth budget of the peer,
+	// the size of the response is not known before sending and it is not counted
 	MsgSize() uint64
+	Priority() Priority
+}
+
+// PrioritySetter is implemented by the requests which priority can be changed before they are queued
+type PrioritySetter interface {
+	SetPriority(priority Priority)
+}
+
+type contextKey uint
+
+const contextKeyPriority contextKey = iota
+
+// CtxWithPriority sets the priority of the requests which are queued with the context
+func CtxWithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, contextKeyPriority, priority)
+}
+
+// CtxPriority returns the priority set with CtxWithPriority
+func CtxPriority(ctx context.Context) (priority Priority, ok bool) {
+	priority, ok = ctx.Value(contextKeyPriority).(Priority)
+	return
 }
@@ -0,0 +1,114 @@
+// Package syncfilter decides which missing trees of the space are pulled through headsync.
+// The trees which don't pass the filter are not pulled from the peers, but they still can be loaded
+// on demand through the tree builder. The trees in the local storage are always in the local diff,
+// so the trees loaded on demand and edited locally are synced as usual.
+package syncfilter
+
+import (
+	"context"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue"
+	"github.com/anyproto/any-sync/commonspace/spacestate"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/nodeconf"
+)
+
+const CName = "common.commonspace.syncfilter"
+
+// Filter decides whether the missing tree should be pulled. Only the id of the tree is known
+// before it is downloaded, so the trees can't be filtered by the contents of their roots
+type Filter interface {
+	ShouldSync(id string) bool
+}
+
+type FilterFunc func(id string) bool
+
+func (f FilterFunc) ShouldSync(id string) bool {
+	return f(id)
+}
+
+// Ids syncs only the trees with the given ids
+func Ids(ids ...string) Filter {
+	set := toSet(ids)
+	return FilterFunc(func(id string) bool {
+		_, ok := set[id]
+		return ok
+	})
+}
+
+// Any syncs the trees which pass at least one of the filters
+func Any(filters ...Filter) Filter {
+	return FilterFunc(func(id string) bool {
+		for _, f := range filters {
+			if f.ShouldSync(id) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+type SyncFilter interface {
+	app.Component
+	// ShouldSync returns true if the missing tree should be pulled from the peers
+	ShouldSync(ctx context.Context, id string) bool
+}
+
+// New creates the sync filter component, nil filter syncs everything
+func New(filter Filter) SyncFilter {
+	return &syncFilter{
+		filter: filter,
+	}
+}
+
+type syncFilter struct {
+	filter Filter
+	// spaceIds are the objects which are needed by the space itself, they are always synced
+	spaceIds map[string]struct{}
+}
+
+func (f *syncFilter) Init(a *app.App) (err error) {
+	if f.filter == nil {
+		return
+	}
+	state := a.MustComponent(spacestate.CName).(*spacestate.SpaceState)
+	// the nodes should always keep the whole space
+	if a.MustComponent(nodeconf.CName).(nodeconf.NodeConf).IsResponsible(state.SpaceId) {
+		f.filter = nil
+		return
+	}
+	storage := a.MustComponent(spacestorage.CName).(spacestorage.SpaceStorage)
+	spaceState, err := storage.StateStorage().GetState(context.Background())
+	if err != nil {
+		return
+	}
+	keyValueId, err := keyvalue.StorageIdFromSpace(state.SpaceId)
+	if err != nil {
+		return
+	}
+	f.spaceIds = toSet([]string{spaceState.AclId, spaceState.SettingsId, keyValueId})
+	return
+}
+
+func (f *syncFilter) Name() (name string) {
+	return CName
+}
+
+func (f *syncFilter) ShouldSync(ctx context.Context, id string) bool {
+	if f.filter == nil {
+		return true
+	}
+	if _, ok := f.spaceIds[id]; ok {
+		return true
+	}
+	return f.filter.ShouldSync(id)
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
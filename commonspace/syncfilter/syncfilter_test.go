@@ -0,0 +1,89 @@
+package syncfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/headsync/statestorage"
+	"github.com/anyproto/any-sync/commonspace/headsync/statestorage/mock_statestorage"
+	"github.com/anyproto/any-sync/commonspace/object/keyvalue"
+	"github.com/anyproto/any-sync/commonspace/spacestate"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/mock_spacestorage"
+	"github.com/anyproto/any-sync/nodeconf"
+	"github.com/anyproto/any-sync/nodeconf/mock_nodeconf"
+)
+
+var ctx = context.Background()
+
+func TestFilters(t *testing.T) {
+	ids := Ids("id1", "id2")
+	assert.True(t, ids.ShouldSync("id1"))
+	assert.False(t, ids.ShouldSync("id3"))
+
+	anyFilter := Any(ids, Ids("id3"))
+	assert.True(t, anyFilter.ShouldSync("id1"))
+	assert.True(t, anyFilter.ShouldSync("id3"))
+	assert.False(t, anyFilter.ShouldSync("id4"))
+}
+
+func TestSyncFilter(t *testing.T) {
+	t.Run("nil filter", func(t *testing.T) {
+		fx := newFixture(t, nil, false)
+		assert.True(t, fx.ShouldSync(ctx, "id"))
+	})
+	t.Run("ids", func(t *testing.T) {
+		fx := newFixture(t, Ids("tree"), false)
+		assert.True(t, fx.ShouldSync(ctx, "tree"))
+		assert.False(t, fx.ShouldSync(ctx, "other"))
+	})
+	t.Run("space objects are always synced", func(t *testing.T) {
+		fx := newFixture(t, Ids(), false)
+		keyValueId, err := keyvalue.StorageIdFromSpace("spaceId")
+		require.NoError(t, err)
+		assert.True(t, fx.ShouldSync(ctx, "aclId"))
+		assert.True(t, fx.ShouldSync(ctx, "settingsId"))
+		assert.True(t, fx.ShouldSync(ctx, keyValueId))
+	})
+	t.Run("responsible node syncs everything", func(t *testing.T) {
+		fx := newFixture(t, Ids(), true)
+		assert.True(t, fx.ShouldSync(ctx, "id"))
+	})
+}
+
+type fixture struct {
+	SyncFilter
+	ctrl    *gomock.Controller
+	storage *mock_spacestorage.MockSpaceStorage
+}
+
+func newFixture(t *testing.T, filter Filter, isResponsible bool) *fixture {
+	ctrl := gomock.NewController(t)
+	fx := &fixture{
+		SyncFilter: New(filter),
+		ctrl:       ctrl,
+		storage:    mock_spacestorage.NewMockSpaceStorage(ctrl),
+	}
+	conf := mock_nodeconf.NewMockService(ctrl)
+	conf.EXPECT().Name().Return(nodeconf.CName).AnyTimes()
+	conf.EXPECT().IsResponsible("spaceId").Return(isResponsible).AnyTimes()
+	stateStorage := mock_statestorage.NewMockStateStorage(ctrl)
+	stateStorage.EXPECT().GetState(gomock.Any()).Return(statestorage.State{
+		SpaceId:    "spaceId",
+		AclId:      "aclId",
+		SettingsId: "settingsId",
+	}, nil).AnyTimes()
+	fx.storage.EXPECT().Name().Return(spacestorage.CName).AnyTimes()
+	fx.storage.EXPECT().StateStorage().Return(stateStorage).AnyTimes()
+	a := new(app.App)
+	a.Register(&spacestate.SpaceState{SpaceId: "spaceId"}).
+		Register(conf).
+		Register(fx.storage)
+	require.NoError(t, fx.Init(a))
+	return fx
+}
@@ -113,3 +113,15 @@ func (mr *MockStatusUpdaterMockRecorder) ObjectReceive(arg0, arg1, arg2 any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectReceive", reflect.TypeOf((*MockStatusUpdater)(nil).ObjectReceive), arg0, arg1, arg2)
 }
+
+// SyncError mocks base method.
+func (m *MockStatusUpdater) SyncError(arg0, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SyncError", arg0, arg1, arg2)
+}
+
+// SyncError indicates an expected call of SyncError.
+func (mr *MockStatusUpdaterMockRecorder) SyncError(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncError", reflect.TypeOf((*MockStatusUpdater)(nil).SyncError), arg0, arg1, arg2)
+}
@@ -29,6 +29,9 @@ func (n *noOpSyncStatus) ObjectReceive(senderId, treeId string, heads []string)
 func (n *noOpSyncStatus) HeadsApply(senderId, treeId string, heads []string, allAdded bool) {
 }
 
+func (n *noOpSyncStatus) SyncError(senderId, treeId string, err error) {
+}
+
 func (n *noOpSyncStatus) HeadsReceive(senderId, treeId string, heads []string) {
 }
 
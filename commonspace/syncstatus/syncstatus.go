@@ -14,4 +14,5 @@ type StatusUpdater interface {
 	HeadsReceive(senderId, treeId string, heads []string)
 	ObjectReceive(senderId, treeId string, heads []string)
 	HeadsApply(senderId, treeId string, heads []string, allAdded bool)
+	SyncError(senderId, treeId string, err error)
 }
@@ -0,0 +1,511 @@
+package syncstatus
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	anystore "github.com/anyproto/any-store"
+	"github.com/anyproto/any-store/anyenc"
+	"github.com/cheggaaa/mb/v3"
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
+	"github.com/anyproto/any-sync/commonspace/spacestate"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/nodeconf"
+	"github.com/anyproto/any-sync/util/periodicsync"
+	"github.com/anyproto/any-sync/util/slice"
+	"github.com/anyproto/any-sync/util/storeutil"
+)
+
+var log = logger.NewNamed(CName)
+
+const (
+	CollName = "syncstatus"
+
+	peersKey = "p"
+	headsKey = "h"
+	errorKey = "e"
+
+	flushPeriodSecs = 10
+)
+
+var ErrSubscriptionClosed = errors.New("sync status subscription is closed")
+
+type SyncState int
+
+const (
+	// SyncStateUnknown means that the peer didn't report its heads of the object yet
+	SyncStateUnknown SyncState = iota
+	SyncStateSyncing
+	SyncStateSynced
+	SyncStateError
+)
+
+func (s SyncState) String() string {
+	switch s {
+	case SyncStateUnknown:
+		return "unknown"
+	case SyncStateSyncing:
+		return "syncing"
+	case SyncStateSynced:
+		return "synced"
+	case SyncStateError:
+		return "error"
+	}
+	return "invalid"
+}
+
+// PeerStatus is the sync state of the object with the responsible peer
+type PeerStatus struct {
+	PeerId string
+	State  SyncState
+	// Heads are the last heads of the object acknowledged by the peer
+	Heads []string
+	Error string
+}
+
+type ObjectStatus struct {
+	ObjectId string
+	// Heads are the local heads of the object
+	Heads []string
+	Peers []PeerStatus
+}
+
+// State returns the error state if the object failed to sync with any peer,
+// the synced state if it is synced with all the peers which reported the object and the syncing state otherwise
+func (s ObjectStatus) State() SyncState {
+	state := SyncStateUnknown
+	for _, p := range s.Peers {
+		switch {
+		case p.State == SyncStateError:
+			return SyncStateError
+		case p.State == SyncStateSyncing:
+			state = SyncStateSyncing
+		case p.State == SyncStateSynced && state == SyncStateUnknown:
+			state = SyncStateSynced
+		}
+	}
+	return state
+}
+
+// SpaceStatus is the number of the objects of the space in each state
+type SpaceStatus struct {
+	Unknown int
+	Syncing int
+	Synced  int
+	Error   int
+}
+
+func (s SpaceStatus) State() SyncState {
+	switch {
+	case s.Error != 0:
+		return SyncStateError
+	case s.Syncing != 0:
+		return SyncStateSyncing
+	case s.Synced != 0:
+		return SyncStateSynced
+	}
+	return SyncStateUnknown
+}
+
+// StatusTracker tracks the heads of the objects acknowledged by the responsible peers of the space.
+// The acknowledged heads are persisted in the space store, so the state survives the restarts
+type StatusTracker interface {
+	StatusUpdater
+	app.ComponentRunnable
+	ObjectStatus(objectId string) ObjectStatus
+	SpaceStatus() SpaceStatus
+	// Subscribe returns the subscription to the status changes of the objects
+	Subscribe() *StatusSubscription
+}
+
+// StatusSubscription receives the status of the object every time the state with any peer changes
+type StatusSubscription struct {
+	id      uint64
+	events  *mb.MB[ObjectStatus]
+	tracker *statusTracker
+}
+
+func (s *StatusSubscription) Next(ctx context.Context) (ObjectStatus, error) {
+	status, err := s.events.WaitOne(ctx)
+	if errors.Is(err, mb.ErrClosed) {
+		return status, ErrSubscriptionClosed
+	}
+	return status, err
+}
+
+func (s *StatusSubscription) Close() error {
+	s.tracker.unsubscribe(s.id)
+	_ = s.events.Close()
+	return nil
+}
+
+func NewStatusTracker() StatusTracker {
+	return &statusTracker{
+		objects:       make(map[string]*objectState),
+		dirty:         make(map[string]struct{}),
+		subscriptions: make(map[uint64]*StatusSubscription),
+	}
+}
+
+type peerState struct {
+	heads []string
+	err   string
+}
+
+type objectState struct {
+	heads []string
+	peers map[string]*peerState
+}
+
+type statusTracker struct {
+	spaceId       string
+	nodeConf      nodeconf.NodeConf
+	storage       spacestorage.SpaceStorage
+	coll          anystore.Collection
+	arena         *anyenc.Arena
+	periodicSync  periodicsync.PeriodicSync
+	objects       map[string]*objectState
+	dirty         map[string]struct{}
+	subscriptions map[uint64]*StatusSubscription
+	nextSubId     uint64
+	mu            sync.Mutex
+}
+
+func (t *statusTracker) Init(a *app.App) (err error) {
+	t.spaceId = a.MustComponent(spacestate.CName).(*spacestate.SpaceState).SpaceId
+	t.nodeConf = a.MustComponent(nodeconf.CName).(nodeconf.NodeConf)
+	t.storage = a.MustComponent(spacestorage.CName).(spacestorage.SpaceStorage)
+	t.arena = &anyenc.Arena{}
+	t.periodicSync = periodicsync.NewPeriodicSync(flushPeriodSecs, time.Minute, t.flush, log.With(zap.String("spaceId", t.spaceId)))
+	ctx := context.Background()
+	if t.coll, err = t.storage.AnyStore().Collection(ctx, CollName); err != nil {
+		return
+	}
+	if err = t.load(ctx); err != nil {
+		return
+	}
+	t.storage.HeadStorage().AddObserver(t)
+	return
+}
+
+func (t *statusTracker) Name() (name string) {
+	return CName
+}
+
+func (t *statusTracker) Run(ctx context.Context) (err error) {
+	t.periodicSync.Run()
+	return
+}
+
+// load reads the local heads from the head storage and the heads acknowledged by the peers from the collection
+func (t *statusTracker) load(ctx context.Context) (err error) {
+	err = t.storage.HeadStorage().IterateEntries(ctx, headstorage.IterOpts{}, func(entry headstorage.HeadsEntry) (bool, error) {
+		t.objects[entry.Id] = &objectState{heads: entry.Heads, peers: make(map[string]*peerState)}
+		return true, nil
+	})
+	if err != nil {
+		return
+	}
+	iter, err := t.coll.Find(nil).Iter(ctx)
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	for iter.Next() {
+		doc, err := iter.Doc()
+		if err != nil {
+			return err
+		}
+		obj, ok := t.objects[doc.Value().GetString("id")]
+		if !ok {
+			continue
+		}
+		peers := doc.Value().GetObject(peersKey)
+		if peers == nil {
+			continue
+		}
+		peers.Visit(func(peerId []byte, v *anyenc.Value) {
+			obj.peers[string(peerId)] = &peerState{
+				heads: storeutil.StringsFromArrayValue(v, headsKey),
+				err:   v.GetString(errorKey),
+			}
+		})
+	}
+	return iter.Err()
+}
+
+func (t *statusTracker) HeadsChange(treeId string, heads []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	obj := t.object(treeId)
+	before := t.objectStatus(treeId, obj)
+	obj.heads = slices.Clone(heads)
+	// the responsible peers don't have the local change, so they are syncing even if they didn't report the object yet
+	for _, peerId := range t.nodeConf.NodeIds(t.spaceId) {
+		if _, ok := obj.peers[peerId]; !ok {
+			obj.peers[peerId] = &peerState{}
+		}
+	}
+	t.changed(treeId, obj, before)
+}
+
+func (t *statusTracker) HeadsReceive(senderId, treeId string, heads []string) {
+	t.setPeerHeads(senderId, treeId, heads)
+}
+
+func (t *statusTracker) ObjectReceive(senderId, treeId string, heads []string) {
+	t.setPeerHeads(senderId, treeId, heads)
+}
+
+func (t *statusTracker) HeadsApply(senderId, treeId string, heads []string, allAdded bool) {
+	// the local heads are taken from the head storage and the heads of the peer are reported with HeadsReceive
+}
+
+func (t *statusTracker) SyncError(senderId, treeId string, err error) {
+	if !t.isResponsible(senderId) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	obj := t.object(treeId)
+	before := t.objectStatus(treeId, obj)
+	peer := t.peer(obj, senderId)
+	peer.err = err.Error()
+	t.changed(treeId, obj, before)
+}
+
+// OnUpdate receives the local heads of all the objects from the head storage
+func (t *statusTracker) OnUpdate(update headstorage.HeadsUpdate) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if update.DeletedStatus != nil && *update.DeletedStatus != headstorage.DeletedStatusNotDeleted {
+		if _, ok := t.objects[update.Id]; ok {
+			delete(t.objects, update.Id)
+			t.dirty[update.Id] = struct{}{}
+		}
+		return
+	}
+	if update.Heads == nil {
+		return
+	}
+	obj := t.object(update.Id)
+	before := t.objectStatus(update.Id, obj)
+	obj.heads = slices.Clone(update.Heads)
+	t.changed(update.Id, obj, before)
+}
+
+func (t *statusTracker) setPeerHeads(senderId, treeId string, heads []string) {
+	if !t.isResponsible(senderId) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	obj := t.object(treeId)
+	before := t.objectStatus(treeId, obj)
+	peer := t.peer(obj, senderId)
+	peer.heads = slices.Clone(heads)
+	if slice.UnsortedEquals(peer.heads, obj.heads) {
+		peer.err = ""
+	}
+	t.changed(treeId, obj, before)
+}
+
+func (t *statusTracker) isResponsible(peerId string) bool {
+	return slices.Contains(t.nodeConf.NodeIds(t.spaceId), peerId)
+}
+
+func (t *statusTracker) object(id string) *objectState {
+	obj, ok := t.objects[id]
+	if !ok {
+		obj = &objectState{peers: make(map[string]*peerState)}
+		t.objects[id] = obj
+	}
+	return obj
+}
+
+func (t *statusTracker) peer(obj *objectState, peerId string) *peerState {
+	peer, ok := obj.peers[peerId]
+	if !ok {
+		peer = &peerState{}
+		obj.peers[peerId] = peer
+	}
+	return peer
+}
+
+// changed marks the object to be flushed and notifies the subscribers if the state with any peer is changed
+func (t *statusTracker) changed(id string, obj *objectState, before ObjectStatus) {
+	t.dirty[id] = struct{}{}
+	after := t.objectStatus(id, obj)
+	if len(t.subscriptions) == 0 || samePeerStates(before, after) {
+		return
+	}
+	for _, sub := range t.subscriptions {
+		_ = sub.events.TryAdd(after)
+	}
+}
+
+func samePeerStates(a, b ObjectStatus) bool {
+	return slices.EqualFunc(a.Peers, b.Peers, func(a, b PeerStatus) bool {
+		return a.PeerId == b.PeerId && a.State == b.State
+	})
+}
+
+func (t *statusTracker) ObjectStatus(objectId string) ObjectStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.objectStatus(objectId, t.objects[objectId])
+}
+
+// objectStatus returns the status with every responsible peer of the space
+func (t *statusTracker) objectStatus(id string, obj *objectState) ObjectStatus {
+	status := ObjectStatus{ObjectId: id}
+	if obj != nil {
+		status.Heads = slices.Clone(obj.heads)
+	}
+	for _, peerId := range t.nodeConf.NodeIds(t.spaceId) {
+		peerStatus := PeerStatus{PeerId: peerId}
+		if obj != nil {
+			if peer, ok := obj.peers[peerId]; ok {
+				peerStatus.Heads = slices.Clone(peer.heads)
+				peerStatus.Error = peer.err
+				switch {
+				case peer.err != "":
+					peerStatus.State = SyncStateError
+				case slice.UnsortedEquals(peer.heads, obj.heads):
+					peerStatus.State = SyncStateSynced
+				default:
+					peerStatus.State = SyncStateSyncing
+				}
+			}
+		}
+		status.Peers = append(status.Peers, peerStatus)
+	}
+	return status
+}
+
+func (t *statusTracker) SpaceStatus() (status SpaceStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, obj := range t.objects {
+		switch t.objectStatus(id, obj).State() {
+		case SyncStateUnknown:
+			status.Unknown++
+		case SyncStateSyncing:
+			status.Syncing++
+		case SyncStateSynced:
+			status.Synced++
+		case SyncStateError:
+			status.Error++
+		}
+	}
+	return
+}
+
+func (t *statusTracker) Subscribe() *StatusSubscription {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextSubId++
+	sub := &StatusSubscription{
+		id:      t.nextSubId,
+		events:  mb.New[ObjectStatus](0),
+		tracker: t,
+	}
+	t.subscriptions[sub.id] = sub
+	return sub
+}
+
+func (t *statusTracker) unsubscribe(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.subscriptions, id)
+}
+
+// flush writes the heads acknowledged by the peers of the changed objects to the store.
+// The store is written without the lock, because the head storage calls OnUpdate inside its write transactions
+func (t *statusTracker) flush(ctx context.Context) (err error) {
+	t.mu.Lock()
+	if len(t.dirty) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	objects := make(map[string]map[string]peerState, len(t.dirty))
+	for id := range t.dirty {
+		peers := make(map[string]peerState)
+		if obj, ok := t.objects[id]; ok {
+			for peerId, peer := range obj.peers {
+				peers[peerId] = *peer
+			}
+		}
+		objects[id] = peers
+	}
+	clear(t.dirty)
+	t.mu.Unlock()
+	if err = t.write(ctx, objects); err != nil {
+		t.mu.Lock()
+		for id := range objects {
+			t.dirty[id] = struct{}{}
+		}
+		t.mu.Unlock()
+	}
+	return
+}
+
+func (t *statusTracker) write(ctx context.Context, objects map[string]map[string]peerState) (err error) {
+	tx, err := t.coll.WriteTx(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+	defer t.arena.Reset()
+	for id, peers := range objects {
+		if len(peers) == 0 {
+			if err = t.coll.DeleteId(tx.Context(), id); err != nil && !errors.Is(err, anystore.ErrDocNotFound) {
+				return
+			}
+			err = nil
+			continue
+		}
+		doc := t.arena.NewObject()
+		doc.Set("id", t.arena.NewString(id))
+		peersVal := t.arena.NewObject()
+		for peerId, peer := range peers {
+			peerVal := t.arena.NewObject()
+			peerVal.Set(headsKey, storeutil.NewStringArrayValue(peer.heads, t.arena))
+			if peer.err != "" {
+				peerVal.Set(errorKey, t.arena.NewString(peer.err))
+			}
+			peersVal.Set(peerId, peerVal)
+		}
+		doc.Set(peersKey, peersVal)
+		if err = t.coll.UpsertOne(tx.Context(), doc); err != nil {
+			return
+		}
+		t.arena.Reset()
+	}
+	return
+}
+
+func (t *statusTracker) Close(ctx context.Context) (err error) {
+	t.periodicSync.Close()
+	err = t.flush(ctx)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, sub := range t.subscriptions {
+		_ = sub.events.Close()
+		delete(t.subscriptions, id)
+	}
+	return
+}
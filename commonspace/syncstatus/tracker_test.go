@@ -0,0 +1,161 @@
+package syncstatus
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/commonspace/headsync/headstorage"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/spacepayloads"
+	"github.com/anyproto/any-sync/commonspace/spacestate"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/memstorage"
+	"github.com/anyproto/any-sync/nodeconf"
+	"github.com/anyproto/any-sync/nodeconf/mock_nodeconf"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ctx = context.Background()
+
+func TestStatusTracker(t *testing.T) {
+	t.Run("synced after the peers acknowledge the heads", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.updateHeads(t, "tree", "head")
+		assert.Equal(t, SyncStateUnknown, fx.ObjectStatus("tree").State())
+
+		fx.HeadsChange("tree", []string{"head2"})
+		fx.updateHeads(t, "tree", "head2")
+		status := fx.ObjectStatus("tree")
+		assert.Equal(t, SyncStateSyncing, status.State())
+		assert.Equal(t, []string{"head2"}, status.Heads)
+
+		fx.HeadsReceive("node1", "tree", []string{"head2"})
+		fx.HeadsReceive("client", "tree", []string{"head"})
+		status = fx.ObjectStatus("tree")
+		assert.Equal(t, []PeerStatus{
+			{PeerId: "node1", State: SyncStateSynced, Heads: []string{"head2"}},
+			{PeerId: "node2", State: SyncStateSyncing},
+		}, status.Peers)
+		assert.Equal(t, SyncStateSyncing, status.State())
+
+		fx.ObjectReceive("node2", "tree", []string{"head2"})
+		assert.Equal(t, SyncStateSynced, fx.ObjectStatus("tree").State())
+	})
+	t.Run("error", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.updateHeads(t, "tree", "head")
+		fx.SyncError("node1", "tree", errors.New("invalid change"))
+		status := fx.ObjectStatus("tree")
+		assert.Equal(t, SyncStateError, status.State())
+		assert.Equal(t, "invalid change", status.Peers[0].Error)
+
+		fx.HeadsReceive("node1", "tree", []string{"head"})
+		assert.Equal(t, SyncStateSynced, fx.ObjectStatus("tree").State())
+	})
+	t.Run("space status", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.updateHeads(t, "synced", "head")
+		fx.HeadsReceive("node1", "synced", []string{"head"})
+		fx.updateHeads(t, "syncing", "head")
+		fx.HeadsReceive("node1", "syncing", []string{"other"})
+		fx.updateHeads(t, "error", "head")
+		fx.SyncError("node2", "error", errors.New("error"))
+		fx.updateHeads(t, "deleted", "head")
+		deleted := headstorage.DeletedStatusDeleted
+		require.NoError(t, fx.storage.HeadStorage().UpdateEntry(ctx, headstorage.HeadsUpdate{Id: "deleted", DeletedStatus: &deleted}))
+
+		status := fx.SpaceStatus()
+		// the settings and acl are created with the space storage
+		assert.Equal(t, SpaceStatus{Unknown: 2, Syncing: 1, Synced: 1, Error: 1}, status)
+		assert.Equal(t, SyncStateError, status.State())
+	})
+	t.Run("subscription", func(t *testing.T) {
+		fx := newFixture(t)
+		sub := fx.Subscribe()
+		fx.updateHeads(t, "tree", "head")
+		fx.HeadsReceive("node1", "tree", []string{"head"})
+		// the state is not changed
+		fx.HeadsReceive("node1", "tree", []string{"head"})
+		fx.HeadsChange("tree", []string{"head2"})
+
+		status, err := sub.Next(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, SyncStateSynced, status.Peers[0].State)
+		status, err = sub.Next(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, SyncStateSyncing, status.State())
+
+		require.NoError(t, sub.Close())
+		_, err = sub.Next(ctx)
+		require.ErrorIs(t, err, ErrSubscriptionClosed)
+	})
+	t.Run("status is restored", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.updateHeads(t, "tree", "head")
+		fx.HeadsReceive("node1", "tree", []string{"head"})
+		fx.SyncError("node2", "tree", errors.New("error"))
+		require.NoError(t, fx.Close(ctx))
+
+		tracker := NewStatusTracker()
+		require.NoError(t, tracker.Init(fx.a))
+		assert.Equal(t, []PeerStatus{
+			{PeerId: "node1", State: SyncStateSynced, Heads: []string{"head"}},
+			{PeerId: "node2", State: SyncStateError, Heads: []string{}, Error: "error"},
+		}, tracker.ObjectStatus("tree").Peers)
+	})
+}
+
+type fixture struct {
+	StatusTracker
+	a       *app.App
+	storage spacestorage.SpaceStorage
+}
+
+func newFixture(t *testing.T) *fixture {
+	ctrl := gomock.NewController(t)
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	masterKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	metaKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	payload, err := spacepayloads.StoragePayloadForSpaceCreate(spacepayloads.SpaceCreatePayload{
+		SigningKey:     keys.SignKey,
+		SpaceType:      "space",
+		ReplicationKey: 10,
+		MasterKey:      masterKey,
+		ReadKey:        crypto.NewAES(),
+		MetadataKey:    metaKey,
+		Metadata:       []byte("account"),
+	})
+	require.NoError(t, err)
+	provider := memstorage.New()
+	t.Cleanup(func() {
+		_ = provider.Close(ctx)
+	})
+	fx := &fixture{
+		StatusTracker: NewStatusTracker(),
+		a:             new(app.App),
+	}
+	fx.storage, err = provider.CreateSpaceStorage(ctx, payload)
+	require.NoError(t, err)
+	nodeConf := mock_nodeconf.NewMockService(ctrl)
+	nodeConf.EXPECT().Name().Return(nodeconf.CName).AnyTimes()
+	nodeConf.EXPECT().NodeIds(fx.storage.Id()).Return([]string{"node1", "node2"}).AnyTimes()
+	fx.a.Register(&spacestate.SpaceState{SpaceId: fx.storage.Id(), SpaceIsClosed: &atomic.Bool{}}).
+		Register(nodeConf).
+		Register(fx.storage)
+	require.NoError(t, fx.Init(fx.a))
+	return fx
+}
+
+func (fx *fixture) updateHeads(t *testing.T, id string, heads ...string) {
+	require.NoError(t, fx.storage.HeadStorage().UpdateEntry(ctx, headstorage.HeadsUpdate{Id: id, Heads: heads}))
+}
@@ -274,6 +274,8 @@ type Record struct {
 	Identity  []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
 	Data      []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	Timestamp int64  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// DeviceCertificate is set if the record is signed by the device on behalf of the identity
+	DeviceCertificate []byte `protobuf:"bytes,5,opt,name=deviceCertificate,proto3" json:"deviceCertificate,omitempty"`
 }
 
 func (m *Record) Reset()         { *m = Record{} }
@@ -345,6 +347,13 @@ func (m *Record) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *Record) GetDeviceCertificate() []byte {
+	if m != nil {
+		return m.DeviceCertificate
+	}
+	return nil
+}
+
 type Ok struct {
 }
 
@@ -1127,59 +1136,61 @@ func init() {
 }
 
 var fileDescriptor_b8d7f1c16b400059 = []byte{
-	// 830 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x8e, 0xdb, 0x54,
-	0x10, 0x8e, 0xed, 0xfc, 0x4e, 0x68, 0x62, 0xa6, 0x08, 0x99, 0x88, 0xa6, 0x91, 0xb9, 0x09, 0x15,
-	0x4a, 0x21, 0x08, 0x21, 0x54, 0x21, 0xd4, 0x86, 0xac, 0x12, 0xc9, 0xcd, 0x2e, 0x2e, 0xa5, 0x12,
-	0x48, 0x48, 0xae, 0xcf, 0x89, 0xd7, 0xaa, 0xeb, 0x63, 0xec, 0x93, 0xb4, 0xb9, 0x85, 0x4b, 0x6e,
-	0x78, 0x0f, 0x5e, 0x04, 0x89, 0x9b, 0x5e, 0x72, 0x89, 0x76, 0x5f, 0x81, 0x07, 0x40, 0x3e, 0xfe,
-	0x49, 0xd6, 0x4e, 0x52, 0x50, 0xf7, 0x66, 0xd7, 0x33, 0xf3, 0xcd, 0x37, 0x73, 0xbe, 0x73, 0x66,
-	0x14, 0xb8, 0x6b, 0x33, 0x3f, 0xa2, 0x7e, 0xb4, 0x8a, 0xb6, 0x5f, 0x41, 0xc8, 0x38, 0xbb, 0x2b,
-	0xfe, 0xee, 0x78, 0x47, 0xc2, 0x81, 0x9d, 0xdc, 0x71, 0x16, 0xdb, 0xfa, 0x19, 0x28, 0x06, 0x73,
-	0xb0, 0x03, 0xb2, 0x4b, 0x34, 0x69, 0x20, 0x0d, 0x5b, 0xa6, 0xec, 0x12, 0xfc, 0x02, 0x1a, 0x21,
-	0xb5, 0x59, 0x48, 0x22, 0x4d, 0x19, 0x28, 0xc3, 0xf6, 0xf8, 0xf6, 0xe8, 0x6a, 0xe2, 0xc8, 0xb4,
-	0x5e, 0x98, 0x02, 0xf1, 0xc4, 0xe5, 0xe7, 0x73, 0x62, 0x66, 0x78, 0xfd, 0x4f, 0x09, 0x5a, 0x79,
-	0x10, 0x35, 0x68, 0x04, 0xd6, 0xc6, 0x63, 0x56, 0xc2, 0xfe, 0x96, 0x99, 0x99, 0xf8, 0x3e, 0xb4,
-	0x22, 0xd7, 0xf1, 0x2d, 0xbe, 0x0a, 0xa9, 0x26, 0x8b, 0xd8, 0xd6, 0x81, 0x77, 0x40, 0xb5, 0x6c,
-	0x9b, 0x06, 0x9c, 0x85, 0x73, 0x42, 0x7d, 0xee, 0xf2, 0x8d, 0xa6, 0x08, 0x50, 0xc9, 0x8f, 0x1f,
-	0xc1, 0xdb, 0x99, 0xef, 0x51, 0xce, 0x58, 0x15, 0xe0, 0x72, 0x60, 0x17, 0xfd, 0xad, 0xfb, 0x9c,
-	0x46, 0xdc, 0x7a, 0x1e, 0x68, 0xb5, 0x81, 0x34, 0x54, 0xcc, 0x72, 0x40, 0xbf, 0x07, 0xdd, 0xc2,
-	0x49, 0x8f, 0x1c, 0x29, 0x51, 0x51, 0xce, 0x54, 0xd4, 0x7d, 0xa8, 0xa7, 0x32, 0xbc, 0x0b, 0xf5,
-	0x20, 0xa4, 0xeb, 0x79, 0xa6, 0x71, 0x6a, 0x61, 0x0f, 0x9a, 0x6e, 0x76, 0xbc, 0x44, 0x83, 0xdc,
-	0x46, 0x84, 0x2a, 0xb1, 0xb8, 0x95, 0x1e, 0x5b, 0x7c, 0xc7, 0xa2, 0xf1, 0xbc, 0xe9, 0xaa, 0x68,
-	0x7a, 0xeb, 0xd0, 0xab, 0x20, 0x9f, 0x3e, 0xd3, 0x7f, 0x84, 0x1b, 0x06, 0x73, 0xee, 0x13, 0x62,
-	0xd2, 0x9f, 0x56, 0x34, 0xe2, 0xf8, 0x0e, 0xd4, 0x3c, 0xe6, 0xe4, 0xb5, 0x13, 0x03, 0x3f, 0x87,
-	0x7a, 0x72, 0x65, 0xa2, 0xf0, 0x7f, 0xb8, 0xe1, 0x14, 0xae, 0xff, 0x00, 0x6a, 0xe2, 0x7f, 0x6d,
-	0x89, 0x4f, 0x0a, 0x25, 0xde, 0x3b, 0x58, 0x22, 0x27, 0x7f, 0x08, 0x5d, 0x83, 0x39, 0x4f, 0x2c,
-	0x6e, 0x9f, 0x67, 0xdc, 0x3d, 0x68, 0xbe, 0x88, 0xed, 0x39, 0x89, 0x34, 0x69, 0xa0, 0x0c, 0x5b,
-	0x66, 0x6e, 0x63, 0x1f, 0x60, 0xe5, 0xe7, 0x51, 0x59, 0x44, 0x77, 0x3c, 0xfa, 0xaf, 0x92, 0x10,
-	0x43, 0xf0, 0x4d, 0xd7, 0xd4, 0x3f, 0xd4, 0xe9, 0xce, 0x7b, 0x97, 0xff, 0xdf, 0x7b, 0xc7, 0x0f,
-	0xa1, 0x46, 0xc3, 0x90, 0x85, 0xe2, 0x9e, 0xda, 0xe3, 0x9b, 0xc5, 0xc4, 0x69, 0x18, 0x9a, 0x09,
-	0x42, 0x1f, 0x82, 0x6a, 0x30, 0xe7, 0x6b, 0xea, 0x51, 0x4e, 0x8f, 0x2a, 0xa7, 0x7f, 0x06, 0xca,
-	0x34, 0x0c, 0x71, 0x94, 0x71, 0xc7, 0xc1, 0xce, 0x58, 0xdb, 0xc3, 0x3d, 0x61, 0x84, 0x46, 0x59,
-	0x81, 0x9f, 0x65, 0xb8, 0x69, 0x30, 0xe7, 0xd1, 0xc6, 0xb7, 0x27, 0xcc, 0xe7, 0xd4, 0xe7, 0xdf,
-	0x59, 0xde, 0x8a, 0xe2, 0x57, 0x00, 0xe7, 0xd4, 0x22, 0x8f, 0x03, 0x62, 0x71, 0x2a, 0xc8, 0xda,
-	0xe3, 0x5b, 0x45, 0x32, 0x83, 0x39, 0xb3, 0x1c, 0x34, 0xab, 0x98, 0x3b, 0x29, 0xb8, 0x80, 0xee,
-	0x72, 0xe5, 0x79, 0x31, 0x71, 0xda, 0x78, 0x7a, 0xa5, 0xfa, 0x1e, 0x96, 0x93, 0xab, 0xc8, 0x59,
-	0xc5, 0x2c, 0x26, 0xe3, 0x37, 0xa0, 0x6e, 0x5d, 0x51, 0x10, 0x53, 0xa4, 0xfa, 0x7d, 0x70, 0x94,
-	0x30, 0x81, 0xce, 0x2a, 0x66, 0x29, 0xfd, 0x41, 0x03, 0x6a, 0xeb, 0xf8, 0xb0, 0xfa, 0x06, 0x3a,
-	0xa9, 0x06, 0x0f, 0x69, 0x14, 0x59, 0x0e, 0x2d, 0x6d, 0xb7, 0x9d, 0x09, 0x96, 0xaf, 0x4e, 0xf0,
-	0x97, 0xd0, 0xb0, 0x13, 0xe1, 0x8e, 0xb4, 0x53, 0x94, 0xd7, 0xcc, 0x72, 0xd2, 0xd1, 0xdb, 0xaa,
-	0x18, 0xcf, 0x70, 0xac, 0x62, 0x5a, 0x5b, 0x7c, 0xbf, 0xc1, 0x5b, 0xd3, 0x6d, 0xc0, 0xb2, 0xbe,
-	0xd7, 0x5d, 0x84, 0x88, 0x37, 0x54, 0xd4, 0xfc, 0x9a, 0xab, 0xdc, 0xf9, 0x45, 0x82, 0x66, 0xf6,
-	0x7c, 0xb1, 0x03, 0xf0, 0xd8, 0xa7, 0x2f, 0x03, 0x6a, 0x73, 0x4a, 0xd4, 0x0a, 0xde, 0x80, 0x96,
-	0xc1, 0x9c, 0xe9, 0x4b, 0x37, 0xe2, 0x91, 0x2a, 0x61, 0x17, 0xda, 0x06, 0x73, 0x16, 0x8c, 0x9f,
-	0xb0, 0x95, 0x4f, 0x54, 0x19, 0x11, 0x3a, 0x09, 0xeb, 0x84, 0xf9, 0x4b, 0xcf, 0xb5, 0xb9, 0xaa,
-	0xc4, 0x39, 0x27, 0x2c, 0x7c, 0xea, 0x12, 0x42, 0x7d, 0xb5, 0x1a, 0x43, 0xe6, 0xfe, 0xda, 0xf2,
-	0x5c, 0x72, 0x96, 0xdc, 0xad, 0x5a, 0x43, 0x15, 0xda, 0xd3, 0x78, 0x4e, 0x4e, 0x97, 0xcb, 0x88,
-	0x72, 0xf5, 0x1f, 0x65, 0xfc, 0xbb, 0x0c, 0xad, 0x49, 0xd6, 0x31, 0xde, 0x83, 0x7a, 0xb2, 0x39,
-	0x71, 0xdf, 0x70, 0x6c, 0xd7, 0x5d, 0x0f, 0x8b, 0xe1, 0xd3, 0x67, 0xb8, 0x80, 0x56, 0xbe, 0x16,
-	0x71, 0x50, 0xd2, 0xa1, 0xb0, 0x31, 0x7b, 0xaf, 0x53, 0x0a, 0x17, 0xd0, 0xcc, 0x36, 0x17, 0xde,
-	0xde, 0xd3, 0xce, 0xee, 0x8e, 0xec, 0xdd, 0x3a, 0x04, 0x10, 0x4b, 0x6f, 0x28, 0x7d, 0x2c, 0xe1,
-	0x7d, 0xa1, 0x69, 0xb2, 0x7c, 0xca, 0xfd, 0x15, 0xf7, 0xd2, 0xbe, 0x23, 0x3e, 0x18, 0xff, 0x71,
-	0xd1, 0x97, 0x5e, 0x5d, 0xf4, 0xa5, 0xbf, 0x2f, 0xfa, 0xd2, 0x6f, 0x97, 0xfd, 0xca, 0xab, 0xcb,
-	0x7e, 0xe5, 0xaf, 0xcb, 0x7e, 0xe5, 0x7b, 0xed, 0xd0, 0xef, 0x90, 0xa7, 0x75, 0xf1, 0xef, 0xd3,
-	0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x12, 0x81, 0xbd, 0x26, 0xaa, 0x08, 0x00, 0x00,
+	// 850 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x8e, 0xdb, 0x54,
+	0x14, 0x8e, 0xed, 0x24, 0x33, 0x3e, 0xd3, 0xc9, 0x98, 0x33, 0x08, 0x99, 0x88, 0xa6, 0x91, 0xd9,
+	0x84, 0x0a, 0xa5, 0x10, 0x84, 0x10, 0xaa, 0x10, 0x6a, 0x43, 0x46, 0x89, 0xe4, 0x66, 0x06, 0x97,
+	0x52, 0x09, 0x24, 0x24, 0xd7, 0xf7, 0xc6, 0x63, 0xd5, 0xf5, 0x35, 0xf6, 0x4d, 0xda, 0x6c, 0x61,
+	0xc9, 0x86, 0x07, 0xe0, 0x0d, 0x78, 0x11, 0x24, 0x36, 0x5d, 0xb2, 0x44, 0x33, 0xaf, 0xc0, 0x03,
+	0x20, 0x5f, 0xff, 0x24, 0xb1, 0x93, 0x14, 0xd4, 0x6e, 0x92, 0x7b, 0xfe, 0xbe, 0x73, 0xce, 0x77,
+	0xef, 0x39, 0x32, 0xdc, 0x71, 0x58, 0x10, 0xd3, 0x20, 0x9e, 0xc7, 0xab, 0x53, 0x18, 0x31, 0xce,
+	0xee, 0x88, 0xdf, 0x35, 0x6d, 0x5f, 0x28, 0xb0, 0x55, 0x28, 0x2e, 0x12, 0xd9, 0xb8, 0x00, 0xc5,
+	0x64, 0x2e, 0xb6, 0x40, 0xf6, 0x88, 0x2e, 0x75, 0xa5, 0x9e, 0x6a, 0xc9, 0x1e, 0xc1, 0xcf, 0xe1,
+	0x20, 0xa2, 0x0e, 0x8b, 0x48, 0xac, 0x2b, 0x5d, 0xa5, 0x77, 0x34, 0xb8, 0xd5, 0xdf, 0x0c, 0xec,
+	0x5b, 0xf6, 0x73, 0x4b, 0x78, 0x3c, 0xf6, 0xf8, 0xe5, 0x84, 0x58, 0xb9, 0xbf, 0xf1, 0xa7, 0x04,
+	0x6a, 0x61, 0x44, 0x1d, 0x0e, 0x42, 0x7b, 0xe9, 0x33, 0x3b, 0x45, 0xbf, 0x61, 0xe5, 0x22, 0xbe,
+	0x07, 0x6a, 0xec, 0xb9, 0x81, 0xcd, 0xe7, 0x11, 0xd5, 0x65, 0x61, 0x5b, 0x29, 0xf0, 0x36, 0x68,
+	0xb6, 0xe3, 0xd0, 0x90, 0xb3, 0x68, 0x42, 0x68, 0xc0, 0x3d, 0xbe, 0xd4, 0x15, 0xe1, 0x54, 0xd1,
+	0xe3, 0x87, 0xf0, 0x56, 0xae, 0x7b, 0x58, 0x20, 0xd6, 0x85, 0x73, 0xd5, 0xb0, 0xee, 0xfd, 0x8d,
+	0xf7, 0x8c, 0xc6, 0xdc, 0x7e, 0x16, 0xea, 0x8d, 0xae, 0xd4, 0x53, 0xac, 0xaa, 0xc1, 0xb8, 0x0b,
+	0x27, 0xa5, 0x4e, 0xf7, 0xb4, 0x94, 0xb2, 0x28, 0xe7, 0x2c, 0x1a, 0xbf, 0x49, 0xd0, 0xcc, 0x78,
+	0x78, 0x07, 0x9a, 0x61, 0x44, 0x17, 0x93, 0x9c, 0xe4, 0x4c, 0xc2, 0x36, 0x1c, 0x7a, 0x79, 0x7f,
+	0x29, 0x09, 0x85, 0x8c, 0x08, 0x75, 0x62, 0x73, 0x3b, 0xeb, 0x5b, 0x9c, 0x13, 0xd6, 0x78, 0x51,
+	0x75, 0x5d, 0x54, 0xbd, 0x52, 0x24, 0xbd, 0x11, 0xba, 0xf0, 0x1c, 0x3a, 0xa4, 0x11, 0xf7, 0x66,
+	0x9e, 0x63, 0x73, 0x2a, 0x7a, 0xbb, 0x61, 0x55, 0x0d, 0x46, 0x1d, 0xe4, 0xf3, 0xa7, 0xc6, 0x0f,
+	0x70, 0x6c, 0x32, 0xf7, 0x1e, 0x21, 0x16, 0xfd, 0x71, 0x4e, 0x63, 0x8e, 0x6f, 0x43, 0xc3, 0x67,
+	0x6e, 0x51, 0x69, 0x2a, 0xe0, 0x67, 0xd0, 0x4c, 0x6f, 0x58, 0x94, 0xf9, 0x1f, 0x1e, 0x44, 0xe6,
+	0x6e, 0x7c, 0x0f, 0x5a, 0xaa, 0x7f, 0x65, 0x8a, 0x8f, 0x4b, 0x29, 0xde, 0xdd, 0x99, 0xa2, 0x00,
+	0x7f, 0x00, 0x27, 0x26, 0x73, 0x1f, 0xdb, 0xdc, 0xb9, 0xcc, 0xb1, 0xdb, 0x70, 0xf8, 0x3c, 0x91,
+	0x27, 0x24, 0xd6, 0xa5, 0xae, 0xd2, 0x53, 0xad, 0x42, 0xc6, 0x0e, 0xc0, 0x3c, 0x28, 0xac, 0xb2,
+	0xb0, 0xae, 0x69, 0x8c, 0x5f, 0x24, 0x38, 0xce, 0xf1, 0x46, 0x0b, 0x1a, 0xec, 0xaa, 0x74, 0x6d,
+	0x3c, 0xe4, 0xff, 0x37, 0x1e, 0xf8, 0x01, 0x34, 0x68, 0x14, 0xb1, 0x48, 0xdc, 0xea, 0xd1, 0xe0,
+	0xb4, 0x1c, 0x38, 0x8a, 0x22, 0x2b, 0xf5, 0x30, 0x7a, 0xa0, 0x99, 0xcc, 0xfd, 0x8a, 0xfa, 0x94,
+	0xd3, 0xbd, 0xcc, 0x19, 0x9f, 0x82, 0x32, 0x8a, 0x22, 0xec, 0xe7, 0xd8, 0x89, 0xb1, 0x35, 0xd0,
+	0xb7, 0x60, 0x0f, 0x19, 0xa1, 0x71, 0x9e, 0xe0, 0x27, 0x19, 0x4e, 0x4d, 0xe6, 0x3e, 0x5c, 0x06,
+	0xce, 0x90, 0x05, 0x9c, 0x06, 0xfc, 0x5b, 0xdb, 0x9f, 0x53, 0xfc, 0x12, 0xe0, 0x92, 0xda, 0xe4,
+	0x51, 0x48, 0x92, 0xf7, 0x23, 0x89, 0x42, 0x6f, 0x96, 0xc1, 0x4c, 0xe6, 0x8e, 0x0b, 0xa7, 0x71,
+	0xcd, 0x5a, 0x0b, 0xc1, 0x29, 0x9c, 0xcc, 0xe6, 0xbe, 0x9f, 0x00, 0x67, 0x85, 0x67, 0x57, 0x6a,
+	0x6c, 0x41, 0x39, 0xdb, 0xf4, 0x1c, 0xd7, 0xac, 0x72, 0x30, 0x7e, 0x0d, 0xda, 0x4a, 0x15, 0x87,
+	0x09, 0x44, 0xc6, 0xdf, 0xfb, 0x7b, 0x01, 0x53, 0xd7, 0x71, 0xcd, 0xaa, 0x84, 0xdf, 0x3f, 0x80,
+	0xc6, 0x22, 0x69, 0xd6, 0x58, 0x42, 0x2b, 0xe3, 0xe0, 0x01, 0x8d, 0x63, 0xdb, 0xa5, 0x95, 0x65,
+	0xb8, 0x36, 0xf0, 0xf2, 0xe6, 0xc0, 0x7f, 0x01, 0x07, 0x4e, 0x4a, 0xdc, 0x9e, 0x72, 0xca, 0xf4,
+	0x5a, 0x79, 0x4c, 0x36, 0x7a, 0x2b, 0x16, 0x93, 0x89, 0x4f, 0x58, 0xcc, 0x72, 0x8b, 0xf3, 0x6b,
+	0xbc, 0x35, 0xc3, 0x01, 0xac, 0xf2, 0xfb, 0xa6, 0x93, 0x10, 0x38, 0xdd, 0x48, 0x92, 0xf2, 0xfb,
+	0x86, 0xb3, 0xdc, 0xfe, 0x59, 0x82, 0xc3, 0xfc, 0xf9, 0x62, 0x0b, 0xe0, 0x51, 0x40, 0x5f, 0x84,
+	0xd4, 0xe1, 0x94, 0x68, 0x35, 0x3c, 0x06, 0xd5, 0x64, 0xee, 0xe8, 0x85, 0x17, 0xf3, 0x58, 0x93,
+	0xf0, 0x04, 0x8e, 0x4c, 0xe6, 0x4e, 0x19, 0x3f, 0x63, 0xf3, 0x80, 0x68, 0x32, 0x22, 0xb4, 0x52,
+	0xd4, 0x21, 0x0b, 0x66, 0xbe, 0xe7, 0x70, 0x4d, 0x49, 0x62, 0xce, 0x58, 0xf4, 0xc4, 0x23, 0x84,
+	0x06, 0x5a, 0x3d, 0x71, 0x99, 0x04, 0x0b, 0xdb, 0xf7, 0xc8, 0x45, 0x7a, 0xb7, 0x5a, 0x03, 0x35,
+	0x38, 0x1a, 0x25, 0x73, 0x72, 0x3e, 0x9b, 0xc5, 0x94, 0x6b, 0xff, 0x28, 0x83, 0xdf, 0x65, 0x50,
+	0x87, 0x79, 0xc5, 0x78, 0x17, 0x9a, 0xe9, 0xe6, 0xc4, 0x6d, 0xc3, 0xb1, 0x5a, 0x77, 0x6d, 0x2c,
+	0x9b, 0xcf, 0x9f, 0xe2, 0x14, 0xd4, 0x62, 0x2d, 0x62, 0xb7, 0xc2, 0x43, 0x69, 0x63, 0xb6, 0x5f,
+	0xc5, 0x14, 0x4e, 0xe1, 0x30, 0xdf, 0x5c, 0x78, 0x6b, 0x4b, 0x39, 0xeb, 0x3b, 0xb2, 0x7d, 0x73,
+	0x97, 0x83, 0x58, 0x7a, 0x3d, 0xe9, 0x23, 0x09, 0xef, 0x81, 0x5a, 0x2c, 0x9f, 0x6a, 0x7d, 0xe5,
+	0xbd, 0xb4, 0xad, 0xc5, 0xfb, 0x83, 0x3f, 0xae, 0x3a, 0xd2, 0xcb, 0xab, 0x8e, 0xf4, 0xf7, 0x55,
+	0x47, 0xfa, 0xf5, 0xba, 0x53, 0x7b, 0x79, 0xdd, 0xa9, 0xfd, 0x75, 0xdd, 0xa9, 0x7d, 0xa7, 0xef,
+	0xfa, 0x6c, 0x79, 0xd2, 0x14, 0x7f, 0x9f, 0xfc, 0x3b, 0x00, 0x76, 0x10, 0x41, 0x83, 0xd9, 0x08,
+	0x00, 0x00,
 }
 
 func (m *Log) Marshal() (dAtA []byte, err error) {
@@ -1339,6 +1350,13 @@ func (m *Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeviceCertificate) > 0 {
+		i -= len(m.DeviceCertificate)
+		copy(dAtA[i:], m.DeviceCertificate)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.DeviceCertificate)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintConsensus(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -2002,6 +2020,10 @@ func (m *Record) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovConsensus(uint64(m.Timestamp))
 	}
+	l = len(m.DeviceCertificate)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
 	return n
 }
 
@@ -2834,6 +2856,40 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceCertificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceCertificate = append(m.DeviceCertificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceCertificate == nil {
+				m.DeviceCertificate = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsensus(dAtA[iNdEx:])
@@ -60,7 +60,7 @@ type CoordinatorClient interface {
 type SpaceSignPayload struct {
 	SpaceId      string
 	SpaceHeader  []byte
-	OldAccount   crypto.PrivKey
+	OldAccount   crypto.Signer
 	Identity     crypto.PrivKey
 	ForceRequest bool
 }
@@ -190,11 +190,7 @@ func (c *coordinatorClient) SpaceSign(ctx context.Context, payload SpaceSignPayl
 	if err != nil {
 		return
 	}
-	newRaw, err := payload.Identity.GetPublic().Raw()
-	if err != nil {
-		return
-	}
-	newSignature, err := payload.OldAccount.Sign(newRaw)
+	succession, err := crypto.NewKeySuccession(payload.OldAccount, payload.Identity)
 	if err != nil {
 		return
 	}
@@ -207,7 +203,8 @@ func (c *coordinatorClient) SpaceSign(ctx context.Context, payload SpaceSignPayl
 			SpaceId:              payload.SpaceId,
 			Header:               payload.SpaceHeader,
 			OldIdentity:          oldIdentity,
-			NewIdentitySignature: newSignature,
+			NewIdentitySignature: succession.NewIdentitySignature,
+			OldIdentitySignature: succession.OldIdentitySignature,
 			ForceRequest:         payload.ForceRequest,
 		})
 		if err != nil {
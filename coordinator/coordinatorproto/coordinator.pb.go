@@ -4,6 +4,7 @@
 package coordinatorproto
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/anyproto/protobuf/proto"
 	io "io"
@@ -280,10 +281,12 @@ type SpaceSignRequest struct {
 	Header []byte `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
 	// OldIdentity is the old identity of the space owner
 	OldIdentity []byte `protobuf:"bytes,3,opt,name=oldIdentity,proto3" json:"oldIdentity,omitempty"`
-	// NewIdentitySignature is the new identity signed by the old one
+	// NewIdentitySignature is the key succession statement signed by the old identity
 	NewIdentitySignature []byte `protobuf:"bytes,4,opt,name=newIdentitySignature,proto3" json:"newIdentitySignature,omitempty"`
 	// ForceRequest if true, forces the creating space receipt even if the space is deleted before
 	ForceRequest bool `protobuf:"varint,5,opt,name=forceRequest,proto3" json:"forceRequest,omitempty"`
+	// OldIdentitySignature is the key succession statement signed by the new identity
+	OldIdentitySignature []byte `protobuf:"bytes,6,opt,name=oldIdentitySignature,proto3" json:"oldIdentitySignature,omitempty"`
 }
 
 func (m *SpaceSignRequest) Reset()         { *m = SpaceSignRequest{} }
@@ -362,6 +365,13 @@ func (m *SpaceSignRequest) GetForceRequest() bool {
 	return false
 }
 
+func (m *SpaceSignRequest) GetOldIdentitySignature() []byte {
+	if m != nil {
+		return m.OldIdentitySignature
+	}
+	return nil
+}
+
 type SpaceLimits struct {
 	ReadMembers  uint32 `protobuf:"varint,1,opt,name=readMembers,proto3" json:"readMembers,omitempty"`
 	WriteMembers uint32 `protobuf:"varint,2,opt,name=writeMembers,proto3" json:"writeMembers,omitempty"`
@@ -1377,6 +1387,10 @@ type NetworkConfigurationResponse struct {
 	Nodes []*Node `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	// unix timestamp of the creation time of configuration
 	CreationTimeUnix uint64 `protobuf:"varint,4,opt,name=creationTimeUnix,proto3" json:"creationTimeUnix,omitempty"`
+	// version of configuration, it grows with every new configuration of the network
+	Version uint64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// signature of configuration made by the network key
+	Signature []byte `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (m *NetworkConfigurationResponse) Reset()         { *m = NetworkConfigurationResponse{} }
@@ -1448,6 +1462,20 @@ func (m *NetworkConfigurationResponse) GetCreationTimeUnix() uint64 {
 	return 0
 }
 
+func (m *NetworkConfigurationResponse) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *NetworkConfigurationResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // Node describes one node in the network
 type Node struct {
 	// peerId - it's a peer identifier (libp2p format string) so it's an encoded publicKey
@@ -1456,6 +1484,8 @@ type Node struct {
 	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	// list of supported APIs
 	Types []NodeType `protobuf:"varint,3,rep,packed,name=types,proto3,enum=coordinator.NodeType" json:"types,omitempty"`
+	// relative capacity of the tree node, zero means the default capacity
+	Capacity float64 `protobuf:"fixed64,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
 }
 
 func (m *Node) Reset()         { *m = Node{} }
@@ -1520,6 +1550,13 @@ func (m *Node) GetTypes() []NodeType {
 	return nil
 }
 
+func (m *Node) GetCapacity() float64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
 // DeletionConfirmPayloadWithSignature contains protobuf encoded deletion payload and its signature
 type DeletionConfirmPayloadWithSignature struct {
 	DeletionPayload []byte `protobuf:"bytes,1,opt,name=deletionPayload,proto3" json:"deletionPayload,omitempty"`
@@ -2949,134 +2986,136 @@ func init() {
 }
 
 var fileDescriptor_d94f6f99586adae2 = []byte{
-	// 2031 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x19, 0x3d, 0x73, 0x1b, 0xc7,
-	0x95, 0x77, 0x00, 0x49, 0xe0, 0x81, 0xa4, 0x8e, 0x4b, 0x52, 0x82, 0x21, 0x18, 0x82, 0x2f, 0x8e,
-	0x4d, 0x23, 0x19, 0xdb, 0x81, 0x63, 0x4f, 0x34, 0x4e, 0x26, 0xa2, 0x28, 0xd9, 0x81, 0x22, 0x52,
-	0xcc, 0x51, 0xb4, 0x67, 0xd2, 0x78, 0x8e, 0x77, 0x4b, 0xf2, 0x86, 0xc0, 0x1e, 0xb2, 0xb7, 0x24,
-	0xc5, 0x3a, 0x65, 0x52, 0xa4, 0x4b, 0x9a, 0xf4, 0x29, 0x52, 0x64, 0x32, 0x93, 0x49, 0x91, 0x99,
-	0xd4, 0x29, 0x5d, 0xa6, 0xf4, 0x48, 0x7f, 0x22, 0x65, 0x66, 0xf7, 0xf6, 0x0e, 0xbb, 0x7b, 0x07,
-	0x10, 0x1e, 0x15, 0x6e, 0x48, 0xee, 0xfb, 0xde, 0x77, 0xef, 0x6b, 0x1f, 0xe1, 0xe3, 0x20, 0x8e,
-	0x69, 0x18, 0x11, 0x9f, 0xc5, 0xf4, 0x03, 0xe5, 0xef, 0x31, 0x8d, 0x59, 0xfc, 0x81, 0xf8, 0x99,
-	0xa8, 0xf0, 0xf7, 0x05, 0x08, 0x35, 0x14, 0x90, 0xfb, 0x6f, 0x0b, 0x9c, 0xc3, 0xb1, 0x1f, 0xe0,
-	0xc3, 0xe8, 0x94, 0x78, 0xf8, 0x37, 0x17, 0x38, 0x61, 0xa8, 0x09, 0xcb, 0x09, 0x87, 0x0d, 0xc2,
-	0xa6, 0xd5, 0xb5, 0xb6, 0xeb, 0x5e, 0x76, 0x44, 0xb7, 0x61, 0xe9, 0x0c, 0xfb, 0x21, 0xa6, 0x4d,
-	0xbb, 0x6b, 0x6d, 0xaf, 0x78, 0xf2, 0x84, 0xba, 0xd0, 0x88, 0x87, 0xe1, 0x20, 0xc4, 0x84, 0x45,
-	0xec, 0xba, 0x59, 0x11, 0x48, 0x15, 0x84, 0xfa, 0xb0, 0x49, 0xf0, 0x55, 0x76, 0xe4, 0xda, 0x7c,
-	0x76, 0x41, 0x71, 0xb3, 0x2a, 0x48, 0x4b, 0x71, 0xc8, 0x85, 0x95, 0x93, 0x98, 0x06, 0x58, 0xda,
-	0xd5, 0x5c, 0xec, 0x5a, 0xdb, 0x35, 0x4f, 0x83, 0xb9, 0x87, 0xd0, 0x10, 0xf6, 0x3f, 0x8d, 0x46,
-	0x11, 0x4b, 0xb8, 0x21, 0x14, 0xfb, 0xe1, 0x1e, 0x1e, 0x1d, 0x63, 0x9a, 0x08, 0xf3, 0x57, 0x3d,
-	0x15, 0xc4, 0x85, 0x5e, 0xd1, 0x88, 0xe1, 0x8c, 0xc4, 0x16, 0x24, 0x1a, 0xcc, 0xfd, 0xad, 0x0d,
-	0x28, 0xf5, 0x0a, 0xf3, 0xd9, 0x45, 0x72, 0xe0, 0x5f, 0x0f, 0x63, 0x3f, 0x44, 0x1f, 0xc2, 0x52,
-	0x22, 0x00, 0x42, 0xee, 0x5a, 0xbf, 0xf9, 0xbe, 0xea, 0x5d, 0x85, 0xc1, 0x93, 0x74, 0xe8, 0x87,
-	0xb0, 0x1e, 0xe2, 0x21, 0x66, 0x51, 0x4c, 0x9e, 0x47, 0x23, 0x9c, 0x30, 0x7f, 0x34, 0x16, 0x1a,
-	0x2b, 0x5e, 0x11, 0x81, 0x7e, 0x0e, 0x8d, 0x31, 0xa6, 0xa3, 0x28, 0x49, 0xa2, 0x98, 0x24, 0xc2,
-	0x8b, 0x6b, 0xfd, 0x37, 0x8b, 0x4a, 0x0e, 0x26, 0x44, 0x9e, 0xca, 0xc1, 0x0d, 0x1c, 0x0a, 0x3f,
-	0x08, 0xb7, 0x36, 0xca, 0x0c, 0x4c, 0xfd, 0xe4, 0x49, 0x3a, 0xd4, 0x82, 0x5a, 0x94, 0x1c, 0x9e,
-	0xf9, 0x14, 0x87, 0xd2, 0xbd, 0xf9, 0xd9, 0x3d, 0x82, 0x75, 0x25, 0x34, 0x92, 0x71, 0x4c, 0x12,
-	0x8c, 0x1e, 0xc0, 0x32, 0xc5, 0x01, 0x8e, 0xc6, 0x4c, 0x38, 0xa1, 0xd1, 0x7f, 0xa7, 0xa8, 0xc3,
-	0x4b, 0x09, 0xbe, 0x8c, 0xd8, 0x59, 0xfe, 0x31, 0xbd, 0x8c, 0xcd, 0x3d, 0x87, 0x37, 0xa6, 0x52,
-	0xa1, 0x0f, 0x61, 0x23, 0x51, 0x90, 0xd2, 0xf3, 0x42, 0xd5, 0x8a, 0x57, 0x86, 0x42, 0x6d, 0xa8,
-	0x27, 0x79, 0x34, 0xa5, 0x51, 0x39, 0x01, 0xb8, 0x7f, 0xb1, 0x60, 0x45, 0xd5, 0x36, 0x3b, 0xb6,
-	0xc7, 0x18, 0xd3, 0x41, 0x28, 0xa4, 0xd4, 0x3d, 0x79, 0x42, 0xdb, 0x70, 0xcb, 0x0f, 0x82, 0xf8,
-	0x82, 0x30, 0x23, 0xbe, 0x4d, 0x30, 0x37, 0x85, 0x60, 0x76, 0x15, 0xd3, 0xf3, 0x41, 0x28, 0xbe,
-	0x40, 0xdd, 0x9b, 0x00, 0x50, 0x07, 0xe0, 0xd2, 0x1f, 0x46, 0xe1, 0x11, 0x61, 0xd1, 0x50, 0x38,
-	0xbb, 0xea, 0x29, 0x10, 0xf7, 0x23, 0xb8, 0xa3, 0x84, 0xd0, 0xee, 0x19, 0x0e, 0xce, 0x6f, 0x4c,
-	0x48, 0xf7, 0x08, 0x9a, 0x45, 0x26, 0xf9, 0xa9, 0xee, 0xc3, 0xf2, 0x58, 0xf1, 0x5f, 0xa3, 0x7f,
-	0x6f, 0x5a, 0xbc, 0x4a, 0x5f, 0x7a, 0x19, 0xbd, 0x7b, 0x1f, 0xee, 0x9a, 0x62, 0xf7, 0x7c, 0x72,
-	0x9d, 0xd9, 0xd3, 0x82, 0x9a, 0x34, 0x80, 0xa7, 0x42, 0x65, 0xbb, 0xee, 0xe5, 0x67, 0xf7, 0xcf,
-	0x16, 0xb4, 0xcb, 0x79, 0xa5, 0x59, 0x9f, 0x42, 0x4d, 0xaa, 0x49, 0x99, 0xe7, 0xb0, 0x2b, 0x67,
-	0x40, 0x0f, 0x60, 0x55, 0x7a, 0x3d, 0x0d, 0x64, 0xf1, 0xad, 0x1a, 0xfd, 0x96, 0x26, 0x61, 0x47,
-	0xa5, 0xf0, 0x74, 0x06, 0xf7, 0x67, 0xb0, 0xaa, 0xe1, 0x79, 0x8e, 0x26, 0x22, 0xe0, 0x85, 0xe2,
-	0x44, 0x40, 0x65, 0xe1, 0x28, 0x22, 0xdc, 0x6f, 0x2c, 0xc3, 0xe3, 0x3e, 0x39, 0xc5, 0x37, 0x17,
-	0x4e, 0xa5, 0x10, 0xc8, 0x4b, 0xe5, 0x71, 0x56, 0x44, 0xf0, 0x90, 0x33, 0x80, 0x59, 0xc8, 0x19,
-	0x60, 0xe4, 0xc1, 0x86, 0x01, 0x7a, 0x7e, 0x3d, 0x4e, 0xab, 0xea, 0x5a, 0xbf, 0xab, 0x79, 0xe5,
-	0x51, 0x91, 0xce, 0x2b, 0x63, 0x76, 0xbf, 0x90, 0x09, 0xaa, 0xdf, 0xf0, 0xf5, 0x83, 0xea, 0x63,
-	0x29, 0x77, 0xcf, 0x3f, 0xc7, 0xa2, 0xc4, 0xf8, 0xc7, 0xc3, 0x9b, 0x5d, 0xe7, 0xb6, 0xa1, 0x55,
-	0xc6, 0x96, 0xda, 0xe3, 0xfe, 0x4a, 0x46, 0x2a, 0xc7, 0x1e, 0x91, 0x64, 0x6e, 0xb1, 0x1c, 0xe3,
-	0x07, 0xc3, 0x5f, 0x60, 0x3f, 0xfb, 0x0e, 0xd9, 0xd1, 0xed, 0xc8, 0x00, 0x2e, 0x88, 0x94, 0x2a,
-	0x3f, 0x85, 0xbb, 0xfb, 0x69, 0x56, 0xef, 0xc6, 0xe4, 0x24, 0x3a, 0xbd, 0xa0, 0x3e, 0x77, 0x61,
-	0xa6, 0xb2, 0x0d, 0xf5, 0xe0, 0x82, 0x52, 0xcc, 0x0b, 0x83, 0x54, 0x3a, 0x01, 0xb8, 0xff, 0xb2,
-	0xa0, 0x5d, 0xce, 0x2d, 0x1d, 0xbc, 0x0d, 0xb7, 0x02, 0x15, 0x91, 0x0b, 0x31, 0xc1, 0x7a, 0xb9,
-	0xb1, 0xcd, 0x72, 0xf3, 0x2e, 0x2c, 0x92, 0x38, 0xc4, 0xbc, 0x8d, 0xf0, 0x1c, 0x5b, 0xd7, 0x3e,
-	0xd3, 0x7e, 0x1c, 0x62, 0x2f, 0xc5, 0xa3, 0x1e, 0x38, 0x01, 0xc5, 0x7e, 0xd6, 0x8a, 0x8e, 0x48,
-	0xf4, 0x42, 0xc4, 0x4f, 0xd5, 0x2b, 0xc0, 0xdd, 0x08, 0xaa, 0x9c, 0x55, 0xa9, 0x95, 0x96, 0x56,
-	0x2b, 0xdb, 0x50, 0xf7, 0xc3, 0x90, 0xe2, 0x24, 0xc1, 0x3c, 0x35, 0x79, 0x65, 0x98, 0x00, 0xd0,
-	0x0f, 0x60, 0x91, 0x5d, 0x8f, 0xa5, 0x49, 0x6b, 0xfd, 0xad, 0x82, 0x49, 0x22, 0x26, 0x53, 0x1a,
-	0x77, 0x04, 0xdf, 0xcb, 0x22, 0x56, 0x38, 0x8a, 0x8e, 0x64, 0x40, 0xe9, 0x0d, 0xa3, 0x24, 0x55,
-	0xac, 0xf2, 0x54, 0x99, 0xdd, 0x28, 0xfe, 0x66, 0xc1, 0xed, 0x72, 0x7d, 0xdf, 0x61, 0xcb, 0x68,
-	0x43, 0x9d, 0xe5, 0x63, 0xc3, 0xa2, 0x18, 0x1b, 0x26, 0x00, 0xf7, 0x11, 0xa0, 0xcc, 0xe2, 0xa7,
-	0xf1, 0xa9, 0x12, 0xf1, 0xfe, 0x09, 0x53, 0xbe, 0x4d, 0x76, 0x44, 0x9b, 0xb0, 0x28, 0xba, 0xbe,
-	0x1c, 0x79, 0xd2, 0x83, 0x1b, 0xc1, 0x86, 0x26, 0x45, 0x86, 0xe1, 0x4f, 0x44, 0x9f, 0x8f, 0x69,
-	0x5e, 0xa4, 0x3b, 0xa5, 0xc5, 0x44, 0xb0, 0x70, 0x32, 0x2f, 0x23, 0xe7, 0x06, 0x9c, 0xf9, 0xc9,
-	0x5e, 0x2c, 0xbd, 0x5c, 0xf3, 0xb2, 0xa3, 0xfb, 0x4f, 0x0b, 0xd6, 0x0b, 0x8c, 0x68, 0x0d, 0xec,
-	0x28, 0xb3, 0xd5, 0x8e, 0x34, 0x77, 0xdb, 0xba, 0xbb, 0x7f, 0x9a, 0xcf, 0x5f, 0xe9, 0x68, 0xf4,
-	0xf6, 0x6c, 0x93, 0x8c, 0x59, 0x4c, 0x73, 0x66, 0xd5, 0x70, 0x26, 0xc7, 0x9e, 0x44, 0x43, 0xfc,
-	0x39, 0x8d, 0x2f, 0x52, 0x57, 0xd7, 0xbd, 0x09, 0xc0, 0xfd, 0xbb, 0x25, 0x07, 0x42, 0xa1, 0xe4,
-	0x3b, 0xac, 0xf7, 0x3d, 0x70, 0x32, 0xd0, 0x23, 0x59, 0x09, 0xe4, 0x5d, 0x0a, 0x70, 0x77, 0x00,
-	0x1b, 0x9a, 0xcd, 0xf2, 0xcb, 0xf6, 0x61, 0x93, 0xc5, 0x0f, 0x25, 0x34, 0x9c, 0x8c, 0xa5, 0x96,
-	0x10, 0x53, 0x8a, 0x73, 0x09, 0x6c, 0xca, 0xa6, 0xa9, 0x3b, 0xa0, 0xf4, 0x9a, 0xd6, 0xb7, 0xb8,
-	0xa6, 0x5d, 0x7a, 0x4d, 0x3e, 0x44, 0xbc, 0xa9, 0x2a, 0x2c, 0x26, 0xe5, 0xb4, 0x0a, 0x54, 0x92,
-	0x7a, 0xf6, 0x1c, 0xa9, 0x57, 0x99, 0x99, 0x7a, 0x66, 0xb4, 0xb8, 0xbf, 0x84, 0x2d, 0xc3, 0x1f,
-	0xaf, 0xe1, 0xdc, 0x0e, 0xb4, 0xa5, 0x30, 0x0f, 0x5f, 0x62, 0x9a, 0xdf, 0x38, 0x7b, 0xe2, 0xdc,
-	0xcb, 0x7d, 0x61, 0xe2, 0x65, 0x43, 0x1a, 0xc0, 0xc6, 0x4e, 0x30, 0xdc, 0x09, 0x43, 0x99, 0x8a,
-	0xf3, 0xf4, 0xbe, 0xb1, 0xf6, 0x01, 0xf2, 0x1e, 0xfd, 0x94, 0x7f, 0x68, 0x55, 0x94, 0xbc, 0x57,
-	0x0b, 0x6a, 0x69, 0x7e, 0xe7, 0xc2, 0xf2, 0xf3, 0x0c, 0x69, 0x4f, 0x84, 0xb4, 0xcf, 0x31, 0x4b,
-	0xa5, 0x25, 0xaf, 0xd3, 0x95, 0x7f, 0xc4, 0x5d, 0xae, 0xc9, 0x92, 0xa6, 0x35, 0xf5, 0x4a, 0xb5,
-	0x92, 0x57, 0x22, 0xf7, 0x77, 0x36, 0xdc, 0xd1, 0x66, 0xbd, 0x43, 0xce, 0x9d, 0x8f, 0xb0, 0x51,
-	0x16, 0x20, 0xf2, 0x42, 0xd9, 0x99, 0xc7, 0x16, 0xc5, 0x7e, 0x12, 0x93, 0xac, 0xac, 0xa7, 0x27,
-	0xf4, 0x63, 0xd8, 0xe2, 0x25, 0xe1, 0x90, 0xc5, 0xd4, 0x3f, 0x4d, 0x5f, 0x52, 0x0f, 0xaf, 0x19,
-	0x4e, 0xcb, 0x51, 0xd5, 0x2b, 0x47, 0xf2, 0x94, 0x15, 0xb7, 0x93, 0x8f, 0x4b, 0x8f, 0xdf, 0xad,
-	0x2a, 0x2a, 0x70, 0x01, 0x2e, 0x66, 0x51, 0x05, 0xf6, 0x25, 0x7f, 0x94, 0x8a, 0x6a, 0xc4, 0x67,
-	0x51, 0x13, 0x51, 0x3e, 0xb9, 0x2e, 0x4d, 0x9b, 0x5c, 0x5b, 0xd0, 0x2c, 0x3a, 0x43, 0x46, 0x10,
-	0x01, 0xb4, 0x13, 0x0c, 0x1f, 0x5f, 0x62, 0xc2, 0x94, 0x56, 0x52, 0x92, 0x4b, 0x72, 0x14, 0x31,
-	0x73, 0x49, 0x69, 0x3a, 0xf6, 0x94, 0xa6, 0x53, 0x31, 0x9a, 0x8e, 0xa6, 0x6f, 0xbe, 0xa6, 0xa3,
-	0xb1, 0xcc, 0xdb, 0x74, 0xfe, 0x61, 0xc1, 0x7a, 0x81, 0xf1, 0x5b, 0x34, 0x1d, 0xad, 0x10, 0x54,
-	0xcc, 0xb6, 0xf1, 0x09, 0x54, 0xd9, 0x64, 0xe0, 0x76, 0x67, 0x9b, 0x2b, 0xc6, 0x1b, 0x41, 0x8f,
-	0xba, 0xd0, 0xf0, 0x83, 0x61, 0x3a, 0x5b, 0x0f, 0x42, 0xd9, 0x70, 0x54, 0x50, 0xef, 0x7f, 0x16,
-	0xc0, 0x63, 0x4a, 0x63, 0xba, 0x2b, 0xa6, 0xb4, 0x35, 0x80, 0x23, 0x82, 0x5f, 0x8c, 0x71, 0xc0,
-	0x70, 0xe8, 0x2c, 0x20, 0x47, 0xbe, 0x6b, 0x65, 0x35, 0x71, 0x2c, 0xd4, 0x84, 0xcd, 0x09, 0x84,
-	0xd7, 0x52, 0x4c, 0xc2, 0x88, 0x9c, 0x3a, 0x76, 0x4e, 0xbb, 0xcb, 0xc7, 0x39, 0x1c, 0x3a, 0x15,
-	0x84, 0x60, 0x4d, 0x40, 0xf6, 0x63, 0xf6, 0xf8, 0x45, 0x94, 0xb0, 0xc4, 0xa9, 0xa2, 0x2d, 0xf9,
-	0xdc, 0x17, 0xd1, 0xe1, 0x61, 0x3f, 0x38, 0xc3, 0xa1, 0xb3, 0xc8, 0x49, 0xb5, 0x52, 0x17, 0x3a,
-	0x4b, 0x68, 0x15, 0xea, 0x9f, 0xc5, 0xf4, 0x38, 0x0a, 0x43, 0x4c, 0x9c, 0x65, 0xb4, 0x09, 0xce,
-	0x4e, 0x9a, 0xa5, 0x83, 0x64, 0x2f, 0x4a, 0x12, 0xae, 0xb5, 0x86, 0x6e, 0x41, 0x63, 0x27, 0x18,
-	0xee, 0xc7, 0xe4, 0xf1, 0x68, 0xcc, 0xae, 0x9d, 0x7a, 0xae, 0x60, 0x3f, 0x66, 0xf9, 0x1c, 0xef,
-	0x00, 0x72, 0xa0, 0x21, 0xee, 0xf9, 0xec, 0xe4, 0x24, 0xc1, 0xcc, 0xf9, 0xab, 0xdd, 0xfb, 0xa3,
-	0x25, 0x97, 0x3a, 0x69, 0x07, 0x47, 0xb7, 0xb5, 0x6d, 0x4c, 0x76, 0x8b, 0x05, 0xd4, 0x91, 0x2f,
-	0x03, 0xf9, 0xde, 0x48, 0xef, 0x9b, 0x5d, 0xdf, 0xb1, 0x0c, 0x7c, 0x86, 0x38, 0x64, 0x3e, 0xe5,
-	0xfc, 0xb6, 0x21, 0x37, 0xbb, 0x5e, 0x25, 0xf7, 0x64, 0x0a, 0x57, 0x7c, 0xd4, 0x7b, 0x22, 0xb7,
-	0x65, 0xca, 0x06, 0x06, 0xdd, 0x95, 0xef, 0x76, 0x05, 0x76, 0x44, 0xce, 0x49, 0x7c, 0x45, 0x9c,
-	0x05, 0xf4, 0x06, 0x6c, 0x99, 0xc8, 0x67, 0x57, 0x04, 0x53, 0xc7, 0xea, 0x5d, 0x41, 0x2d, 0x9b,
-	0x79, 0x51, 0x03, 0x96, 0x9f, 0x53, 0x8c, 0x77, 0x0e, 0x06, 0xce, 0x02, 0x3f, 0x7c, 0x16, 0x0d,
-	0xc5, 0xc1, 0xe2, 0xee, 0xdf, 0x9d, 0xc4, 0x14, 0x87, 0x89, 0xef, 0xb9, 0xcb, 0xf3, 0x85, 0x24,
-	0x17, 0x09, 0x87, 0x54, 0xd0, 0x3a, 0xac, 0xee, 0xfb, 0xa3, 0x88, 0x9c, 0x72, 0x89, 0x1c, 0x54,
-	0xe5, 0x97, 0x38, 0xf0, 0xaf, 0x47, 0x98, 0xb0, 0x03, 0x1a, 0x07, 0x58, 0x7c, 0x15, 0x8e, 0x59,
-	0xec, 0xdd, 0x9f, 0x4c, 0x7c, 0xca, 0xb3, 0x0f, 0xd5, 0xa0, 0xca, 0x6d, 0x48, 0x0d, 0x90, 0xdd,
-	0xd6, 0xb1, 0xf8, 0x41, 0x7e, 0x7f, 0xc7, 0xee, 0x3d, 0x80, 0x3b, 0x53, 0xc6, 0x2c, 0xb4, 0x04,
-	0xf6, 0xb3, 0x73, 0x67, 0x81, 0x9b, 0xe2, 0xe1, 0x51, 0x7c, 0x89, 0x0f, 0x28, 0x1e, 0xfb, 0x14,
-	0x3b, 0x16, 0x02, 0x58, 0x4a, 0x41, 0x8e, 0xdd, 0xfb, 0xbd, 0x25, 0xea, 0x78, 0x31, 0x31, 0x50,
-	0x0b, 0x6e, 0x4f, 0x4e, 0xea, 0xce, 0x26, 0x75, 0xa3, 0x81, 0x4b, 0x77, 0x54, 0x8e, 0xc5, 0xdd,
-	0x6f, 0xa0, 0xe4, 0x9b, 0x8d, 0x7f, 0xe1, 0x7b, 0x70, 0xd7, 0x40, 0xaa, 0xdd, 0xcd, 0xa9, 0xf4,
-	0xff, 0xd4, 0x80, 0x86, 0xe2, 0x5f, 0xf4, 0x04, 0xea, 0xf9, 0xce, 0x0b, 0x95, 0xac, 0xde, 0x94,
-	0x35, 0x69, 0xab, 0x33, 0x0d, 0x2d, 0xab, 0xd9, 0x57, 0xd9, 0x6a, 0x75, 0xb2, 0x08, 0x41, 0x6f,
-	0x4f, 0x7b, 0x2d, 0xab, 0xfb, 0x9e, 0xd6, 0xf7, 0x6f, 0xa0, 0x92, 0x0a, 0xce, 0xb5, 0x38, 0xcd,
-	0x37, 0x2d, 0x68, 0x7b, 0x26, 0xbb, 0xb2, 0xc8, 0x69, 0xbd, 0x37, 0x07, 0xa5, 0x54, 0x76, 0x9c,
-	0x6d, 0x03, 0x95, 0xad, 0x00, 0x9a, 0x61, 0xa8, 0xb2, 0x17, 0x69, 0xbd, 0x73, 0x13, 0x99, 0xd4,
-	0x81, 0x65, 0x42, 0x6a, 0x4f, 0x7d, 0x54, 0xc2, 0x5d, 0xb6, 0x42, 0x68, 0xbd, 0x7b, 0x23, 0x9d,
-	0xe1, 0x37, 0xe3, 0x81, 0x5f, 0xe6, 0xb7, 0xf2, 0xb5, 0x42, 0x99, 0xdf, 0xa6, 0x6c, 0x0b, 0xb8,
-	0xb2, 0xb2, 0xf7, 0xbe, 0xa1, 0x6c, 0xc6, 0x42, 0xc1, 0x50, 0x36, 0x73, 0x79, 0x70, 0x00, 0x0d,
-	0x25, 0x3f, 0xd1, 0xbd, 0xe9, 0x0f, 0xa4, 0x54, 0x74, 0x77, 0x3a, 0xc1, 0x44, 0xa2, 0xd2, 0x67,
-	0x50, 0xc9, 0xb6, 0x47, 0x7b, 0x11, 0x18, 0x12, 0xcb, 0xde, 0x1f, 0x5f, 0xe4, 0x0b, 0x38, 0x29,
-	0xf3, 0xad, 0xb2, 0xe5, 0x9d, 0x2e, 0xd5, 0x9d, 0x45, 0x22, 0xe5, 0x92, 0x7c, 0x26, 0xd7, 0xc7,
-	0x64, 0xf4, 0x5e, 0x19, 0x73, 0xe9, 0xa8, 0xdd, 0xea, 0xcd, 0x43, 0x2a, 0xf5, 0x1d, 0xc2, 0x8a,
-	0x5a, 0x4c, 0x50, 0xd7, 0x6c, 0xfe, 0xe6, 0x40, 0xde, 0x7a, 0x6b, 0x06, 0x85, 0xea, 0x1c, 0x65,
-	0xca, 0x2d, 0x38, 0xa7, 0x38, 0x4d, 0x17, 0x9c, 0x53, 0x36, 0x24, 0x7f, 0xc5, 0x5b, 0xb4, 0x3e,
-	0xfc, 0x19, 0xb5, 0x68, 0xca, 0xa0, 0x6c, 0xd4, 0xa2, 0x69, 0x13, 0x24, 0x8f, 0x13, 0xa5, 0xac,
-	0x1b, 0x71, 0x52, 0x9c, 0x2d, 0x5b, 0xdd, 0xe9, 0x04, 0xa9, 0xc4, 0x87, 0x9f, 0xfc, 0xe7, 0x65,
-	0xc7, 0xfa, 0xfa, 0x65, 0xc7, 0xfa, 0xe6, 0x65, 0xc7, 0xfa, 0xc3, 0xab, 0xce, 0xc2, 0xd7, 0xaf,
-	0x3a, 0x0b, 0xff, 0x7d, 0xd5, 0x59, 0xf8, 0x75, 0x7b, 0xd6, 0x3f, 0xbe, 0x8e, 0x97, 0xc4, 0xaf,
-	0x8f, 0xfe, 0x1f, 0x00, 0x00, 0xff, 0xff, 0xe7, 0xd7, 0x91, 0xfa, 0x1f, 0x1b, 0x00, 0x00,
+	// 2061 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x19, 0x4d, 0x73, 0xdb, 0xc6,
+	0x55, 0x20, 0x29, 0x9a, 0x7c, 0x94, 0x64, 0x68, 0x25, 0xd9, 0x34, 0x44, 0xd3, 0x0c, 0x9a, 0x0f,
+	0x99, 0xed, 0x24, 0x29, 0xd3, 0x64, 0xea, 0x49, 0x3b, 0xb5, 0x2c, 0x3b, 0x29, 0x5d, 0x4b, 0x56,
+	0x21, 0x2b, 0x99, 0xe9, 0xa5, 0x03, 0x01, 0x2b, 0x09, 0x23, 0x72, 0xc1, 0x2e, 0x56, 0x92, 0x75,
+	0xee, 0xf4, 0xd4, 0x1e, 0x7a, 0x6b, 0x2f, 0xbd, 0xf7, 0xd0, 0x43, 0xa7, 0x33, 0x9d, 0xfe, 0x85,
+	0x1e, 0x73, 0xec, 0x31, 0x63, 0xff, 0x83, 0xf6, 0xd2, 0x63, 0x66, 0x17, 0x0b, 0x60, 0xb1, 0x00,
+	0x29, 0x66, 0x7c, 0xc8, 0x45, 0xd2, 0xbe, 0xf7, 0xf6, 0x7d, 0xed, 0xfb, 0xc2, 0x13, 0x7c, 0xec,
+	0x85, 0x21, 0xf5, 0x03, 0xe2, 0xb2, 0x90, 0x7e, 0xa0, 0xfc, 0x3d, 0xa1, 0x21, 0x0b, 0x3f, 0x10,
+	0x3f, 0x23, 0x15, 0xfe, 0xbe, 0x00, 0xa1, 0x96, 0x02, 0xb2, 0xff, 0x6b, 0x80, 0x79, 0x30, 0x71,
+	0x3d, 0x7c, 0x10, 0x9c, 0x10, 0x07, 0xff, 0xe6, 0x1c, 0x47, 0x0c, 0xb5, 0xe1, 0x46, 0xc4, 0x61,
+	0x43, 0xbf, 0x6d, 0xf4, 0x8c, 0xad, 0xa6, 0x93, 0x1c, 0xd1, 0x2d, 0xa8, 0x9f, 0x62, 0xd7, 0xc7,
+	0xb4, 0x5d, 0xe9, 0x19, 0x5b, 0x4b, 0x8e, 0x3c, 0xa1, 0x1e, 0xb4, 0xc2, 0x91, 0x3f, 0xf4, 0x31,
+	0x61, 0x01, 0xbb, 0x6a, 0x57, 0x05, 0x52, 0x05, 0xa1, 0x01, 0xac, 0x13, 0x7c, 0x99, 0x1c, 0xb9,
+	0x34, 0x97, 0x9d, 0x53, 0xdc, 0xae, 0x09, 0xd2, 0x52, 0x1c, 0xb2, 0x61, 0xe9, 0x38, 0xa4, 0x1e,
+	0x96, 0x7a, 0xb5, 0x17, 0x7b, 0xc6, 0x56, 0xc3, 0xc9, 0xc1, 0x38, 0x5f, 0x45, 0x4c, 0xc6, 0xb7,
+	0x1e, 0xf3, 0x2d, 0xc3, 0xd9, 0x07, 0xd0, 0x12, 0x36, 0x3f, 0x0b, 0xc6, 0x01, 0x8b, 0xb8, 0xf2,
+	0x14, 0xbb, 0xfe, 0x2e, 0x1e, 0x1f, 0x61, 0x1a, 0x09, 0x93, 0x97, 0x1d, 0x15, 0xc4, 0x15, 0xb9,
+	0xa4, 0x01, 0xc3, 0x09, 0x49, 0x45, 0x90, 0xe4, 0x60, 0xf6, 0x6f, 0x2b, 0x80, 0x62, 0x4f, 0x32,
+	0x97, 0x9d, 0x47, 0xfb, 0xee, 0xd5, 0x28, 0x74, 0x7d, 0xf4, 0x21, 0xd4, 0x23, 0x01, 0x10, 0x7c,
+	0x57, 0x06, 0xed, 0xf7, 0xd5, 0x17, 0x51, 0x2e, 0x38, 0x92, 0x0e, 0xfd, 0x00, 0x56, 0x7d, 0x3c,
+	0xc2, 0x2c, 0x08, 0xc9, 0x8b, 0x60, 0x8c, 0x23, 0xe6, 0x8e, 0x27, 0x42, 0x62, 0xd5, 0x29, 0x22,
+	0xd0, 0xcf, 0xa0, 0x35, 0xc1, 0x74, 0x1c, 0x44, 0x51, 0x10, 0x92, 0x48, 0x78, 0x7e, 0x65, 0x70,
+	0xb7, 0x28, 0x64, 0x3f, 0x23, 0x72, 0xd4, 0x1b, 0x5c, 0xc1, 0x91, 0xf0, 0x83, 0x78, 0x8a, 0x56,
+	0x99, 0x82, 0xb1, 0x9f, 0x1c, 0x49, 0x87, 0x2c, 0x68, 0x04, 0xd1, 0xc1, 0xa9, 0x4b, 0xb1, 0x2f,
+	0x9f, 0x24, 0x3d, 0xdb, 0x87, 0xb0, 0xaa, 0x84, 0x53, 0x34, 0x09, 0x49, 0x84, 0xd1, 0x43, 0xb8,
+	0x41, 0xb1, 0x87, 0x83, 0x09, 0x13, 0x4e, 0x68, 0x0d, 0xde, 0x2d, 0xca, 0x70, 0x62, 0x82, 0x2f,
+	0x03, 0x76, 0x9a, 0x3e, 0x94, 0x93, 0x5c, 0xb3, 0xcf, 0xe0, 0xce, 0x54, 0x2a, 0xf4, 0x21, 0xac,
+	0x45, 0x0a, 0x52, 0x7a, 0x5e, 0x88, 0x5a, 0x72, 0xca, 0x50, 0xa8, 0x03, 0xcd, 0x28, 0x8d, 0x94,
+	0x38, 0x92, 0x33, 0x80, 0xfd, 0x57, 0x03, 0x96, 0x54, 0x69, 0xb3, 0xf3, 0x61, 0x82, 0x31, 0x1d,
+	0xfa, 0x82, 0x4b, 0xd3, 0x91, 0x27, 0xb4, 0x05, 0x37, 0x5d, 0xcf, 0x0b, 0xcf, 0x09, 0xd3, 0x72,
+	0x42, 0x07, 0x73, 0x55, 0x08, 0x66, 0x97, 0x21, 0x3d, 0x1b, 0xfa, 0xe2, 0x05, 0x9a, 0x4e, 0x06,
+	0x40, 0x5d, 0x80, 0x0b, 0x77, 0x14, 0xf8, 0x87, 0x84, 0x05, 0x23, 0xe1, 0xec, 0x9a, 0xa3, 0x40,
+	0xec, 0x8f, 0xe0, 0xb6, 0x12, 0x42, 0x3b, 0xa7, 0xd8, 0x3b, 0xbb, 0x36, 0x89, 0xed, 0x43, 0x68,
+	0x17, 0x2f, 0xc9, 0xa7, 0x7a, 0x00, 0x37, 0x26, 0x8a, 0xff, 0x5a, 0x83, 0x7b, 0xd3, 0xe2, 0x55,
+	0xfa, 0xd2, 0x49, 0xe8, 0xed, 0x07, 0xb0, 0xa9, 0xb3, 0xdd, 0x75, 0xc9, 0x55, 0xa2, 0x8f, 0x05,
+	0x0d, 0xa9, 0x00, 0x4f, 0x85, 0xea, 0x56, 0xd3, 0x49, 0xcf, 0xf6, 0x5f, 0x0c, 0xe8, 0x94, 0xdf,
+	0x95, 0x6a, 0x7d, 0x0a, 0x0d, 0x29, 0x26, 0xbe, 0x3c, 0x87, 0x5e, 0xe9, 0x05, 0xf4, 0x10, 0x96,
+	0xa5, 0xd7, 0xe3, 0x40, 0x16, 0x6f, 0xd5, 0x1a, 0x58, 0x39, 0x0e, 0xdb, 0x2a, 0x85, 0x93, 0xbf,
+	0x60, 0xff, 0x14, 0x96, 0x73, 0x78, 0x9e, 0xa3, 0x91, 0x08, 0x78, 0x21, 0x38, 0x12, 0x50, 0x59,
+	0x38, 0x8a, 0x08, 0xfb, 0x6b, 0x43, 0xf3, 0xb8, 0x4b, 0x4e, 0xf0, 0xf5, 0xc5, 0x56, 0x29, 0x04,
+	0xd2, 0xa8, 0x34, 0xce, 0x8a, 0x08, 0x1e, 0x72, 0x1a, 0x30, 0x09, 0x39, 0x0d, 0x8c, 0x1c, 0x58,
+	0xd3, 0x40, 0x2f, 0xae, 0x26, 0x71, 0x25, 0x5e, 0x19, 0xf4, 0x72, 0x5e, 0x79, 0x5c, 0xa4, 0x73,
+	0xca, 0x2e, 0xdb, 0x5f, 0xc0, 0x9d, 0x12, 0x0b, 0xdf, 0x3c, 0xa8, 0x3e, 0x96, 0x7c, 0x77, 0xdd,
+	0x33, 0x2c, 0x4a, 0x8c, 0x7b, 0x34, 0xba, 0xde, 0x75, 0x76, 0x07, 0xac, 0xb2, 0x6b, 0xb1, 0x3e,
+	0xf6, 0x2f, 0x61, 0x33, 0xc5, 0x1e, 0x92, 0x68, 0x6e, 0xb6, 0x1c, 0xe3, 0x7a, 0xa3, 0x9f, 0x63,
+	0x37, 0x79, 0x87, 0xe4, 0x68, 0x77, 0xa1, 0x53, 0xce, 0x52, 0x8a, 0xfc, 0x14, 0x36, 0xf7, 0xe2,
+	0xac, 0xde, 0x09, 0xc9, 0x71, 0x70, 0x72, 0x4e, 0x5d, 0xee, 0xc2, 0x44, 0x64, 0x07, 0x9a, 0xde,
+	0x39, 0xa5, 0x98, 0xb0, 0x54, 0x68, 0x06, 0xb0, 0xff, 0x67, 0x40, 0xa7, 0xfc, 0xb6, 0x74, 0xf0,
+	0x16, 0xdc, 0xf4, 0x54, 0x44, 0xca, 0x44, 0x07, 0xe7, 0xcb, 0x4d, 0x45, 0x2f, 0x37, 0xef, 0xc1,
+	0x22, 0x09, 0x7d, 0xcc, 0xdb, 0x08, 0xcf, 0xb1, 0xd5, 0xdc, 0x33, 0xed, 0x85, 0x3e, 0x76, 0x62,
+	0x3c, 0xea, 0x83, 0xe9, 0x51, 0xec, 0x26, 0xad, 0xe8, 0x90, 0x04, 0x2f, 0x45, 0xfc, 0xd4, 0x9c,
+	0x02, 0x9c, 0x3b, 0xed, 0x02, 0x53, 0xde, 0x6c, 0x64, 0x01, 0x4b, 0x8e, 0xf9, 0x32, 0x5c, 0xd7,
+	0xcb, 0xf0, 0xef, 0x0c, 0xa8, 0x71, 0x99, 0x4a, 0x91, 0x35, 0x72, 0x45, 0xb6, 0x03, 0x4d, 0xd7,
+	0xf7, 0x29, 0x8e, 0x22, 0xcc, 0x73, 0x9a, 0x97, 0x94, 0x0c, 0x80, 0xbe, 0x0f, 0x8b, 0xec, 0x6a,
+	0x22, 0x6d, 0x59, 0x19, 0x6c, 0x14, 0x6c, 0x11, 0xc1, 0x1c, 0xd3, 0xf0, 0xe2, 0xe4, 0xb9, 0x13,
+	0xd7, 0xe3, 0x85, 0x9a, 0xdb, 0x61, 0x38, 0xe9, 0xd9, 0x1e, 0xc3, 0xf7, 0x92, 0x34, 0x10, 0xde,
+	0xa7, 0x63, 0x19, 0xa5, 0xf9, 0x2e, 0x54, 0x92, 0x7f, 0x46, 0x79, 0xfe, 0xcd, 0xee, 0x3e, 0x7f,
+	0x37, 0xe0, 0x56, 0xb9, 0xbc, 0xef, 0xb0, 0x0f, 0x75, 0xa0, 0xc9, 0xd2, 0x59, 0x64, 0x51, 0xcc,
+	0x22, 0x19, 0xc0, 0x7e, 0x0c, 0x28, 0xd1, 0xf8, 0x59, 0x78, 0xa2, 0xa4, 0x91, 0x7b, 0xcc, 0x94,
+	0x77, 0x4b, 0x8e, 0x68, 0x1d, 0x16, 0xc5, 0x28, 0x21, 0xe7, 0xa8, 0xf8, 0x60, 0x07, 0xb0, 0x96,
+	0xe3, 0x22, 0x63, 0xfb, 0xc7, 0x62, 0x78, 0x08, 0x69, 0x5a, 0xf9, 0xbb, 0xa5, 0x15, 0x4a, 0x5c,
+	0xe1, 0x64, 0x4e, 0x42, 0xce, 0x15, 0x38, 0x75, 0xa3, 0xdd, 0x50, 0x7a, 0xb9, 0xe1, 0x24, 0x47,
+	0xfb, 0x5f, 0x06, 0xac, 0x16, 0x2e, 0xa2, 0x15, 0xa8, 0x04, 0x89, 0xae, 0x95, 0x20, 0xe7, 0xee,
+	0x4a, 0xde, 0xdd, 0x3f, 0x49, 0x87, 0xba, 0x78, 0xde, 0x7a, 0x7b, 0xb6, 0x4a, 0xda, 0x80, 0x97,
+	0x73, 0x66, 0x4d, 0x73, 0x26, 0xc7, 0x1e, 0x07, 0x23, 0xfc, 0x39, 0x0d, 0xcf, 0x63, 0x57, 0x37,
+	0x9d, 0x0c, 0x60, 0xff, 0xc3, 0x90, 0x53, 0xa6, 0x10, 0xf2, 0x1d, 0x36, 0x91, 0x3e, 0x98, 0x09,
+	0xe8, 0xb1, 0x2c, 0x2f, 0xd2, 0x96, 0x02, 0xdc, 0x1e, 0xc2, 0x5a, 0x4e, 0x67, 0xf9, 0xb2, 0x03,
+	0x58, 0x67, 0xe1, 0x23, 0x09, 0xf5, 0xb3, 0x59, 0xd7, 0x10, 0x6c, 0x4a, 0x71, 0x36, 0x81, 0x75,
+	0xd9, 0x89, 0xf3, 0x0e, 0x28, 0x35, 0xd3, 0xf8, 0x16, 0x66, 0x56, 0x4a, 0xcd, 0xe4, 0x93, 0xc9,
+	0x5d, 0x55, 0x60, 0x31, 0x29, 0xa7, 0x55, 0xa7, 0x92, 0xd4, 0xab, 0xcc, 0x91, 0x7a, 0xd5, 0x99,
+	0xa9, 0xa7, 0x47, 0x8b, 0xfd, 0x0b, 0xd8, 0xd0, 0xfc, 0xf1, 0x06, 0xce, 0xed, 0x42, 0x47, 0x32,
+	0x73, 0xf0, 0x05, 0xa6, 0xa9, 0xc5, 0xd2, 0xc9, 0xf6, 0x3d, 0xb8, 0x3b, 0x05, 0x2f, 0xbb, 0xdc,
+	0x10, 0xd6, 0xb6, 0xbd, 0xd1, 0xb6, 0xef, 0xcb, 0x54, 0x9c, 0xa7, 0xa1, 0x4e, 0x72, 0x0f, 0x90,
+	0x1c, 0xed, 0x67, 0xb0, 0x9e, 0x67, 0x25, 0xed, 0xb2, 0xa0, 0x11, 0xe7, 0x77, 0xca, 0x2c, 0x3d,
+	0xcf, 0xe0, 0xf6, 0x54, 0x70, 0xfb, 0x1c, 0xb3, 0x98, 0x5b, 0xf4, 0x26, 0xad, 0xfe, 0x87, 0xb0,
+	0xa1, 0xf1, 0x92, 0xaa, 0xb5, 0xf3, 0x95, 0x6a, 0x29, 0xad, 0x44, 0xf6, 0xef, 0x2b, 0x70, 0x3b,
+	0x37, 0x40, 0x1e, 0x60, 0x96, 0xa8, 0xc0, 0xbf, 0xa6, 0x92, 0x00, 0x91, 0x06, 0x25, 0x67, 0x1e,
+	0x5b, 0x14, 0xbb, 0x51, 0x48, 0x92, 0xb2, 0x1e, 0x9f, 0xd0, 0x8f, 0x60, 0x83, 0x97, 0x84, 0x03,
+	0x16, 0x52, 0xf7, 0x24, 0xfe, 0x3c, 0x7b, 0x74, 0xc5, 0x70, 0x5c, 0x8e, 0x6a, 0x4e, 0x39, 0x92,
+	0xa7, 0xac, 0xb0, 0x4e, 0x7e, 0xb1, 0x3a, 0xdc, 0xb6, 0x9a, 0xa8, 0xc0, 0x05, 0xb8, 0x18, 0x70,
+	0x15, 0xd8, 0x97, 0x34, 0x60, 0xb8, 0xbd, 0x28, 0x07, 0x5c, 0x1d, 0x51, 0x3e, 0x0e, 0xd7, 0xa7,
+	0x8d, 0xc3, 0x16, 0xb4, 0x8b, 0xce, 0x90, 0x11, 0x44, 0x00, 0x6d, 0x7b, 0xa3, 0x27, 0x17, 0x98,
+	0x30, 0xa5, 0x95, 0x94, 0xe4, 0x92, 0x9c, 0x6f, 0x34, 0xb0, 0xda, 0x74, 0x2a, 0x53, 0x9a, 0x4e,
+	0x55, 0x6b, 0x3a, 0x39, 0x79, 0xf3, 0x35, 0x9d, 0xdc, 0x95, 0x79, 0x9b, 0xce, 0x3f, 0x0d, 0x58,
+	0x2d, 0x5c, 0xfc, 0x16, 0x4d, 0x27, 0x57, 0x08, 0xaa, 0x7a, 0xdb, 0xf8, 0x04, 0x6a, 0x2c, 0x9b,
+	0xe2, 0xed, 0xd9, 0xea, 0x8a, 0xd1, 0x47, 0xd0, 0xf3, 0xe5, 0x87, 0xeb, 0x8d, 0xe2, 0x81, 0x7d,
+	0xe8, 0xcb, 0x86, 0xa3, 0x82, 0xfa, 0xff, 0x37, 0x00, 0x9e, 0x50, 0x1a, 0xd2, 0x1d, 0x31, 0xfa,
+	0xad, 0x00, 0x1c, 0x12, 0xfc, 0x72, 0x82, 0x3d, 0x86, 0x7d, 0x73, 0x01, 0x99, 0xf2, 0x63, 0x59,
+	0x56, 0x13, 0xd3, 0x40, 0x6d, 0x58, 0xcf, 0x20, 0xbc, 0x96, 0x62, 0xe2, 0x07, 0xe4, 0xc4, 0xac,
+	0xa4, 0xb4, 0x3b, 0x14, 0xbb, 0x9c, 0xb6, 0x8a, 0x10, 0xac, 0x08, 0xc8, 0x5e, 0xc8, 0x9e, 0xbc,
+	0x0c, 0x22, 0x16, 0x99, 0x35, 0xb4, 0x21, 0x77, 0x08, 0x22, 0x3a, 0x1c, 0xec, 0x7a, 0xa7, 0xd8,
+	0x37, 0x17, 0x39, 0x69, 0xae, 0xd4, 0xf9, 0x66, 0x1d, 0x2d, 0x43, 0xf3, 0xb3, 0x90, 0x1e, 0x05,
+	0xbe, 0x8f, 0x89, 0x79, 0x03, 0xad, 0x83, 0xb9, 0x1d, 0x67, 0xe9, 0x30, 0xda, 0xe5, 0x0b, 0x0e,
+	0x72, 0x62, 0x36, 0xd0, 0x4d, 0x68, 0x6d, 0x7b, 0xa3, 0xbd, 0x90, 0x3c, 0x19, 0x4f, 0xd8, 0x95,
+	0xd9, 0x4c, 0x05, 0xec, 0x85, 0x2c, 0xfd, 0x38, 0x30, 0x01, 0x99, 0xd0, 0x12, 0x76, 0x3e, 0x3f,
+	0x3e, 0x8e, 0x30, 0x33, 0xff, 0x56, 0xe9, 0xff, 0xc9, 0x90, 0x9b, 0xa2, 0xb8, 0x83, 0xa3, 0x5b,
+	0xb9, 0x15, 0x4f, 0x62, 0xc5, 0x02, 0xea, 0x82, 0xa5, 0xc0, 0xa5, 0xbd, 0x89, 0xf9, 0xa6, 0xa1,
+	0xe1, 0x13, 0xc4, 0x01, 0x73, 0x29, 0xbf, 0x5f, 0xd1, 0xf8, 0x26, 0xe6, 0x55, 0x53, 0x4f, 0xc6,
+	0x70, 0xc5, 0x47, 0xfd, 0xa7, 0x60, 0xea, 0x6b, 0x1d, 0xb4, 0x09, 0xb7, 0x75, 0xd8, 0x21, 0x39,
+	0x23, 0xe1, 0x25, 0x31, 0x17, 0xd0, 0x1d, 0xd8, 0xd0, 0x91, 0xcf, 0x2f, 0x09, 0xa6, 0xa6, 0xd1,
+	0xbf, 0x84, 0x46, 0x32, 0x0f, 0xa3, 0x16, 0xdc, 0x78, 0x41, 0x31, 0xde, 0xde, 0x1f, 0x9a, 0x0b,
+	0xfc, 0xf0, 0x59, 0x30, 0x12, 0x07, 0x83, 0xbb, 0x7f, 0x27, 0x8b, 0x29, 0x0e, 0x13, 0xef, 0xb9,
+	0xc3, 0xf3, 0x85, 0x44, 0xe7, 0x11, 0x87, 0x54, 0xd1, 0x2a, 0x2c, 0xef, 0xb9, 0xe3, 0x80, 0x9c,
+	0x70, 0x8e, 0x1c, 0x54, 0xe3, 0x46, 0xec, 0xbb, 0x57, 0x63, 0x4c, 0xd8, 0x3e, 0x0d, 0x3d, 0x2c,
+	0x5e, 0x85, 0x63, 0x16, 0xfb, 0x0f, 0xb2, 0x89, 0x4f, 0xf9, 0x96, 0x44, 0x0d, 0xa8, 0x71, 0x1d,
+	0x62, 0x05, 0x64, 0xb7, 0x35, 0x0d, 0x7e, 0x90, 0xef, 0x6f, 0x56, 0xfa, 0x0f, 0xe1, 0xf6, 0x94,
+	0x31, 0x0b, 0xd5, 0xa1, 0xf2, 0xfc, 0xcc, 0x5c, 0xe0, 0xaa, 0x38, 0x78, 0x1c, 0x5e, 0xe0, 0x7d,
+	0x8a, 0x27, 0x2e, 0xc5, 0xa6, 0x81, 0x00, 0xea, 0x31, 0xc8, 0xac, 0xf4, 0xff, 0x60, 0xc0, 0x46,
+	0x69, 0x62, 0x20, 0x0b, 0x6e, 0x65, 0x27, 0x75, 0x11, 0x14, 0xbb, 0x51, 0xc3, 0xc5, 0x8b, 0x2f,
+	0xd3, 0xe0, 0xee, 0xd7, 0x50, 0xf2, 0x43, 0x90, 0xbf, 0xf0, 0x3d, 0xd8, 0xd4, 0x90, 0x6a, 0x77,
+	0x33, 0xab, 0x83, 0x3f, 0xb7, 0xa0, 0xa5, 0xf8, 0x17, 0x3d, 0x85, 0x66, 0xba, 0x48, 0x43, 0x25,
+	0xfb, 0x3c, 0x65, 0x5f, 0x6b, 0x75, 0xa7, 0xa1, 0x65, 0x35, 0xfb, 0x75, 0xb2, 0xe3, 0xcd, 0xb6,
+	0x2b, 0xe8, 0xed, 0x69, 0x9f, 0xe0, 0xea, 0x12, 0xc9, 0x7a, 0xe7, 0x1a, 0x2a, 0x29, 0xe0, 0x0c,
+	0xd6, 0x75, 0x1c, 0x5f, 0xdf, 0xa0, 0xad, 0x99, 0xd7, 0x95, 0xed, 0x90, 0x75, 0x7f, 0x0e, 0x4a,
+	0x29, 0xec, 0x08, 0x56, 0x73, 0x78, 0x5e, 0xa6, 0xd0, 0x0c, 0x45, 0x95, 0x65, 0x8b, 0xf5, 0xee,
+	0x75, 0x64, 0x52, 0x06, 0x06, 0x94, 0x7e, 0xce, 0xa7, 0x25, 0x02, 0x95, 0xdc, 0x2e, 0xdb, 0x4b,
+	0x58, 0xef, 0x5d, 0x4b, 0xa7, 0xf9, 0x4d, 0xdb, 0x1a, 0x94, 0xf9, 0xad, 0x7c, 0x57, 0x61, 0xdd,
+	0x9f, 0x83, 0x32, 0x13, 0x56, 0xb6, 0x44, 0xd0, 0x84, 0xcd, 0xd8, 0x52, 0x58, 0xf7, 0xe7, 0xa0,
+	0x94, 0xc2, 0xf6, 0xa1, 0xa5, 0xe4, 0x27, 0xba, 0x37, 0xfd, 0x03, 0x29, 0x66, 0xdd, 0x9b, 0x4e,
+	0x90, 0x71, 0x54, 0xfa, 0x0c, 0x2a, 0x59, 0x21, 0xe5, 0xbe, 0x08, 0xac, 0xde, 0x74, 0x02, 0xc9,
+	0xf1, 0x8b, 0x74, 0xab, 0x27, 0x79, 0xbe, 0x55, 0xb6, 0x11, 0xcc, 0x73, 0xb5, 0x67, 0x91, 0x48,
+	0xbe, 0x04, 0x36, 0x4a, 0xc7, 0x64, 0x74, 0xbf, 0xec, 0x72, 0xe9, 0xa8, 0x6d, 0xf5, 0xe7, 0x21,
+	0x95, 0xf2, 0x0e, 0x60, 0x49, 0x2d, 0x26, 0xa8, 0xa7, 0x37, 0x7f, 0x7d, 0x20, 0xb7, 0xde, 0x9a,
+	0x41, 0xa1, 0x3a, 0x47, 0x99, 0x72, 0x0b, 0xce, 0x29, 0x4e, 0xd3, 0x96, 0x3d, 0x8b, 0x24, 0xab,
+	0x45, 0xfa, 0xf0, 0xa7, 0xd5, 0xa2, 0x29, 0x83, 0xb2, 0xf5, 0xce, 0x35, 0x54, 0x59, 0x9c, 0x28,
+	0x65, 0x5d, 0x8b, 0x93, 0xe2, 0x6c, 0x69, 0xf5, 0xa6, 0x13, 0xc4, 0x1c, 0x1f, 0x7d, 0xf2, 0xef,
+	0x57, 0x5d, 0xe3, 0xab, 0x57, 0x5d, 0xe3, 0xeb, 0x57, 0x5d, 0xe3, 0x8f, 0xaf, 0xbb, 0x0b, 0x5f,
+	0xbd, 0xee, 0x2e, 0xfc, 0xe7, 0x75, 0x77, 0xe1, 0x57, 0x9d, 0x59, 0xff, 0x81, 0x3b, 0xaa, 0x8b,
+	0x5f, 0x1f, 0x7d, 0x33, 0x00, 0xed, 0x1a, 0x5a, 0xa5, 0xa8, 0x1b, 0x00, 0x00,
 }
 
 func (m *SpaceSignRequest) Marshal() (dAtA []byte, err error) {
@@ -3099,6 +3138,13 @@ func (m *SpaceSignRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.OldIdentitySignature) > 0 {
+		i -= len(m.OldIdentitySignature)
+		copy(dAtA[i:], m.OldIdentitySignature)
+		i = encodeVarintCoordinator(dAtA, i, uint64(len(m.OldIdentitySignature)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.ForceRequest {
 		i--
 		if m.ForceRequest {
@@ -3782,6 +3828,18 @@ func (m *NetworkConfigurationResponse) MarshalToSizedBuffer(dAtA []byte) (int, e
 	_ = i
 	var l int
 	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintCoordinator(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Version != 0 {
+		i = encodeVarintCoordinator(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.CreationTimeUnix != 0 {
 		i = encodeVarintCoordinator(dAtA, i, uint64(m.CreationTimeUnix))
 		i--
@@ -3838,6 +3896,12 @@ func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Capacity != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Capacity))))
+		i--
+		dAtA[i] = 0x21
+	}
 	if len(m.Types) > 0 {
 		dAtA7 := make([]byte, len(m.Types)*10)
 		var j6 int
@@ -4743,6 +4807,10 @@ func (m *SpaceSignRequest) Size() (n int) {
 	if m.ForceRequest {
 		n += 2
 	}
+	l = len(m.OldIdentitySignature)
+	if l > 0 {
+		n += 1 + l + sovCoordinator(uint64(l))
+	}
 	return n
 }
 
@@ -5037,6 +5105,13 @@ func (m *NetworkConfigurationResponse) Size() (n int) {
 	if m.CreationTimeUnix != 0 {
 		n += 1 + sovCoordinator(uint64(m.CreationTimeUnix))
 	}
+	if m.Version != 0 {
+		n += 1 + sovCoordinator(uint64(m.Version))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovCoordinator(uint64(l))
+	}
 	return n
 }
 
@@ -5063,6 +5138,9 @@ func (m *Node) Size() (n int) {
 		}
 		n += 1 + sovCoordinator(uint64(l)) + l
 	}
+	if m.Capacity != 0 {
+		n += 9
+	}
 	return n
 }
 
@@ -5637,6 +5715,40 @@ func (m *SpaceSignRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ForceRequest = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldIdentitySignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCoordinator
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCoordinator
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCoordinator
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldIdentitySignature = append(m.OldIdentitySignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.OldIdentitySignature == nil {
+				m.OldIdentitySignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCoordinator(dAtA[iNdEx:])
@@ -7528,6 +7640,59 @@ func (m *NetworkConfigurationResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCoordinator
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCoordinator
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCoordinator
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCoordinator
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCoordinator(dAtA[iNdEx:])
@@ -7711,6 +7876,17 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
 			}
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Capacity = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCoordinator(dAtA[iNdEx:])
@@ -61,9 +61,10 @@ func (n *nodeConfSource) GetLast(ctx context.Context, currentId string) (c nodec
 			}
 		}
 		nodes[i] = nodeconf.Node{
-			PeerId:    node.PeerId,
-			Addresses: node.Addresses,
-			Types:     types,
+			PeerId:       node.PeerId,
+			Addresses:    node.Addresses,
+			Types:        types,
+			NodeCapacity: node.Capacity,
 		}
 	}
 
@@ -72,5 +73,7 @@ func (n *nodeConfSource) GetLast(ctx context.Context, currentId string) (c nodec
 		NetworkId:    res.NetworkId,
 		Nodes:        nodes,
 		CreationTime: time.Unix(int64(res.CreationTimeUnix), 0),
+		Version:      res.Version,
+		Signature:    res.Signature,
 	}, nil
 }
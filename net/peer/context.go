@@ -18,12 +18,14 @@ const (
 	contextKeyPeerAddr
 	contextKeyPeerClientVersion
 	contextKeyPeerProtoVersion
+	contextKeyDeviceKey
 )
 
 var (
 	ErrPeerIdNotFoundInContext       = errors.New("peer id not found in context")
 	ErrProtoVersionNotFoundInContext = errors.New("proto version not found in context")
 	ErrIdentityNotFoundInContext     = errors.New("identity not found in context")
+	ErrDeviceKeyNotFoundInContext    = errors.New("device key not found in context")
 )
 
 const CtxResponsiblePeers = "*"
@@ -103,3 +105,16 @@ func CtxPubKey(ctx context.Context) (crypto.PubKey, error) {
 func CtxWithIdentity(ctx context.Context, identity []byte) context.Context {
 	return context.WithValue(ctx, contextKeyIdentity, identity)
 }
+
+// CtxDeviceKey returns the device key of the peer, it is set only if the peer is authenticated with the device certificate
+func CtxDeviceKey(ctx context.Context) (crypto.PubKey, error) {
+	if deviceKey, ok := ctx.Value(contextKeyDeviceKey).([]byte); ok {
+		return crypto.UnmarshalEd25519PublicKeyProto(deviceKey)
+	}
+	return nil, ErrDeviceKeyNotFoundInContext
+}
+
+// CtxWithDeviceKey sets the device key in the context
+func CtxWithDeviceKey(ctx context.Context, deviceKey []byte) context.Context {
+	return context.WithValue(ctx, contextKeyDeviceKey, deviceKey)
+}
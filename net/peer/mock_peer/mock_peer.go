@@ -10,9 +10,11 @@ package mock_peer
 
 import (
 	context "context"
+	net "net"
 	reflect "reflect"
 	time "time"
 
+	handshakeproto "github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
 	gomock "go.uber.org/mock/gomock"
 	drpc "storj.io/drpc"
 )
@@ -139,6 +141,21 @@ func (mr *MockPeerMockRecorder) IsClosed() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClosed", reflect.TypeOf((*MockPeer)(nil).IsClosed))
 }
 
+// OpenProtoConn mocks base method.
+func (m *MockPeer) OpenProtoConn(arg0 context.Context, arg1 handshakeproto.ProtoType) (net.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenProtoConn", arg0, arg1)
+	ret0, _ := ret[0].(net.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenProtoConn indicates an expected call of OpenProtoConn.
+func (mr *MockPeerMockRecorder) OpenProtoConn(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenProtoConn", reflect.TypeOf((*MockPeer)(nil).OpenProtoConn), arg0, arg1)
+}
+
 // ReleaseDrpcConn mocks base method.
 func (m *MockPeer) ReleaseDrpcConn(arg0 drpc.Conn) {
 	m.ctrl.T.Helper()
@@ -3,8 +3,10 @@ package peer
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -27,17 +29,30 @@ import (
 
 var log = logger.NewNamed("common.net.peer")
 
+var ErrUnknownProto = errors.New("unknown proto type")
+
 type connCtrl interface {
 	ServeConn(ctx context.Context, conn net.Conn) (err error)
 	DrpcConfig() rpc.Config
 }
 
+// ProtoHandler serves the incoming sub connections of the registered proto type
+type ProtoHandler interface {
+	ServeConn(ctx context.Context, conn net.Conn) (err error)
+}
+
+// protoCtrl is implemented by the controllers which serve protocols besides drpc
+type protoCtrl interface {
+	ProtoHandlers() map[handshakeproto.ProtoType]ProtoHandler
+}
+
 func NewPeer(mc transport.MultiConn, ctrl connCtrl) (p Peer, err error) {
 	ctx := mc.Context()
 	pr := &peer{
-		active:    map[*subConn]struct{}{},
-		MultiConn: mc,
-		ctrl:      ctrl,
+		active:     map[*subConn]struct{}{},
+		protoConns: map[*protoConn]struct{}{},
+		MultiConn:  mc,
+		ctrl:       ctrl,
 		limiter: limiter{
 			// start throttling after 10 sub conns
 			startThreshold: 10,
@@ -45,6 +60,16 @@ func NewPeer(mc transport.MultiConn, ctrl connCtrl) (p Peer, err error) {
 		},
 		subConnRelease: make(chan drpc.Conn),
 		created:        time.Now(),
+		protoChecker:   defaultProtoChecker,
+	}
+	if pc, ok := ctrl.(protoCtrl); ok {
+		pr.protoHandlers = pc.ProtoHandlers()
+		pr.protoChecker = handshake.ProtoChecker{
+			AllowedProtoTypes: slices.Clone(defaultProtoChecker.AllowedProtoTypes),
+		}
+		for pt := range pr.protoHandlers {
+			pr.protoChecker.AllowedProtoTypes = append(pr.protoChecker.AllowedProtoTypes, pt)
+		}
 	}
 	pr.acceptCtx, pr.acceptCtxCancel = context.WithCancel(context.Background())
 	if pr.id, err = CtxPeerId(ctx); err != nil {
@@ -74,6 +99,9 @@ type Peer interface {
 	ReleaseDrpcConn(conn drpc.Conn)
 	DoDrpc(ctx context.Context, do func(conn drpc.Conn) error) error
 
+	// OpenProtoConn opens the sub connection with the given proto type, the caller must close it
+	OpenProtoConn(ctx context.Context, pt handshakeproto.ProtoType) (net.Conn, error)
+
 	IsClosed() bool
 	CloseChan() <-chan struct{}
 
@@ -90,17 +118,35 @@ type subConn struct {
 	*connutil.LastUsageConn
 }
 
+// protoConn is the outgoing sub connection of the additional protocol, it stays tracked until the caller closes it
+type protoConn struct {
+	*connutil.LastUsageConn
+	peer *peer
+}
+
+func (c *protoConn) Close() error {
+	c.peer.mu.Lock()
+	delete(c.peer.protoConns, c)
+	c.peer.mu.Unlock()
+	return c.LastUsageConn.Close()
+}
+
 type peer struct {
 	id string
 
 	ctrl connCtrl
 
+	protoChecker  handshake.ProtoChecker
+	protoHandlers map[handshakeproto.ProtoType]ProtoHandler
+
 	// drpc conn pool
 	// outgoing
 	inactive         []*subConn
 	active           map[*subConn]struct{}
 	subConnRelease   chan drpc.Conn
 	openingWaitCount atomic.Int32
+	// outgoing sub connections of the additional protocols
+	protoConns map[*protoConn]struct{}
 
 	incomingCount atomic.Int32
 	acceptCtx     context.Context
@@ -219,6 +265,23 @@ func (p *peer) openDrpcConn(ctx context.Context) (dconn *subConn, err error) {
 	}, nil
 }
 
+func (p *peer) OpenProtoConn(ctx context.Context, pt handshakeproto.ProtoType) (net.Conn, error) {
+	conn, err := p.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tconn := connutil.NewLastUsageConn(conn)
+	if err = handshake.OutgoingProtoHandshake(ctx, tconn, pt); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	pconn := &protoConn{LastUsageConn: tconn, peer: p}
+	p.mu.Lock()
+	p.protoConns[pconn] = struct{}{}
+	p.mu.Unlock()
+	return pconn, nil
+}
+
 func (p *peer) acceptLoop() {
 	var exitErr error
 	defer func() {
@@ -262,12 +325,19 @@ func (p *peer) serve(conn net.Conn) (err error) {
 		_ = conn.Close()
 	}()
 	hsCtx, cancel := context.WithTimeout(p.Context(), time.Second*20)
-	if _, err = handshake.IncomingProtoHandshake(hsCtx, conn, defaultProtoChecker); err != nil {
-		cancel()
+	pt, err := handshake.IncomingProtoHandshake(hsCtx, conn, p.protoChecker)
+	cancel()
+	if err != nil {
 		return
 	}
-	cancel()
-	return p.ctrl.ServeConn(p.Context(), conn)
+	if pt == handshakeproto.ProtoType_DRPC {
+		return p.ctrl.ServeConn(p.Context(), conn)
+	}
+	handler, ok := p.protoHandlers[pt]
+	if !ok {
+		return ErrUnknownProto
+	}
+	return handler.ServeConn(p.Context(), conn)
 }
 
 func (p *peer) SetTTL(ttl time.Duration) {
@@ -327,7 +397,14 @@ func (p *peer) gc(ttl time.Duration) (aliveCount int) {
 			continue
 		}
 	}
-	return len(p.active) + len(p.inactive) + int(p.incomingCount.Load())
+	for pconn := range p.protoConns {
+		if pconn.LastUsage().Before(minLastUsage) {
+			log.Warn("close proto connection because no activity", zap.String("peerId", p.id), zap.String("addr", p.Addr()))
+			_ = pconn.LastUsageConn.Close()
+			delete(p.protoConns, pconn)
+		}
+	}
+	return len(p.active) + len(p.inactive) + len(p.protoConns) + int(p.incomingCount.Load())
 }
 
 func (p *peer) Close() (err error) {
@@ -339,7 +416,7 @@ func (p *peer) ProvideStat() *Stat {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	protoVersion, _ := CtxProtoVersion(p.Context())
-	subConnectionsCount := len(p.active)
+	subConnectionsCount := len(p.active) + len(p.protoConns)
 	return &Stat{
 		PeerId:         p.id,
 		SubConnections: subConnectionsCount,
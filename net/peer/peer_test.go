@@ -119,6 +119,61 @@ func TestPeerAccept(t *testing.T) {
 	assert.NoError(t, <-outHandshakeCh)
 }
 
+func TestPeer_ProtoConn(t *testing.T) {
+	t.Run("accept registered proto", func(t *testing.T) {
+		handler := newTesCtrl()
+		fx := newFixtureWithProtos(t, "p1", map[handshakeproto.ProtoType]ProtoHandler{
+			handshakeproto.ProtoType_ByteStream: handler,
+		})
+		defer fx.finish()
+		defer handler.close()
+		in, out := net.Pipe()
+		defer out.Close()
+
+		var outHandshakeCh = make(chan error)
+		go func() {
+			outHandshakeCh <- handshake.OutgoingProtoHandshake(ctx, out, handshakeproto.ProtoType_ByteStream)
+		}()
+		fx.acceptCh <- acceptedConn{conn: in}
+		cn := <-handler.serveConn
+		assert.Equal(t, in, cn)
+		assert.NoError(t, <-outHandshakeCh)
+	})
+	t.Run("reject unregistered proto", func(t *testing.T) {
+		fx := newFixture(t, "p1")
+		defer fx.finish()
+		in, out := net.Pipe()
+		defer out.Close()
+
+		var outHandshakeCh = make(chan error)
+		go func() {
+			outHandshakeCh <- handshake.OutgoingProtoHandshake(ctx, out, handshakeproto.ProtoType_ByteStream)
+		}()
+		fx.acceptCh <- acceptedConn{conn: in}
+		assert.ErrorIs(t, <-outHandshakeCh, handshake.ErrRemoteIncompatibleProto)
+	})
+	t.Run("open", func(t *testing.T) {
+		fx := newFixture(t, "p1")
+		defer fx.finish()
+		in, out := net.Pipe()
+		defer out.Close()
+		var inHandshakeCh = make(chan handshakeproto.ProtoType)
+		go func() {
+			pt, _ := handshake.IncomingProtoHandshake(ctx, out, handshake.ProtoChecker{
+				AllowedProtoTypes: []handshakeproto.ProtoType{handshakeproto.ProtoType_HTTP2},
+			})
+			inHandshakeCh <- pt
+		}()
+		fx.mc.EXPECT().Open(gomock.Any()).Return(in, nil)
+		conn, err := fx.OpenProtoConn(ctx, handshakeproto.ProtoType_HTTP2)
+		require.NoError(t, err)
+		assert.Equal(t, handshakeproto.ProtoType_HTTP2, <-inHandshakeCh)
+		assert.Equal(t, 1, fx.ProvideStat().SubConnections)
+		require.NoError(t, conn.Close())
+		assert.Equal(t, 0, fx.ProvideStat().SubConnections)
+	})
+}
+
 func TestPeer_DrpcConn_AcceptThrottling(t *testing.T) {
 	fx := newFixture(t, "p1")
 	defer fx.finish()
@@ -177,6 +232,30 @@ func TestPeer_TryClose(t *testing.T) {
 		require.NoError(t, err)
 		assert.False(t, res)
 	})
+	t.Run("not close with open proto conn", func(t *testing.T) {
+		fx := newFixture(t, "p1")
+		defer fx.finish()
+		fx.peer.created = fx.peer.created.Add(-time.Minute * 2)
+
+		in, out := net.Pipe()
+		go func() {
+			handshake.IncomingProtoHandshake(ctx, out, handshake.ProtoChecker{
+				AllowedProtoTypes: []handshakeproto.ProtoType{handshakeproto.ProtoType_ByteStream},
+			})
+		}()
+		defer out.Close()
+		fx.mc.EXPECT().Open(gomock.Any()).Return(in, nil)
+		conn, err := fx.OpenProtoConn(ctx, handshakeproto.ProtoType_ByteStream)
+		require.NoError(t, err)
+		res, err := fx.TryClose(time.Minute)
+		require.NoError(t, err)
+		assert.False(t, res)
+
+		require.NoError(t, conn.Close())
+		res, err = fx.TryClose(time.Minute)
+		require.NoError(t, err)
+		assert.True(t, res)
+	})
 	t.Run("gc", func(t *testing.T) {
 		fx := newFixture(t, "p1")
 		defer fx.finish()
@@ -243,11 +322,19 @@ type acceptedConn struct {
 }
 
 func newFixture(t *testing.T, peerId string) *fixture {
+	return newFixtureWithProtos(t, peerId, nil)
+}
+
+func newFixtureWithProtos(t *testing.T, peerId string, protoHandlers map[handshakeproto.ProtoType]ProtoHandler) *fixture {
 	fx := &fixture{
 		ctrl:     gomock.NewController(t),
 		acceptCh: make(chan acceptedConn),
 		testCtrl: newTesCtrl(),
 	}
+	var ctrl connCtrl = fx.testCtrl
+	if protoHandlers != nil {
+		ctrl = testProtoCtrl{testCtrl: fx.testCtrl, protoHandlers: protoHandlers}
+	}
 	fx.mc = mock_transport.NewMockMultiConn(fx.ctrl)
 	ctx := CtxWithPeerId(context.Background(), peerId)
 	fx.mc.EXPECT().Context().Return(ctx).AnyTimes()
@@ -256,7 +343,7 @@ func newFixture(t *testing.T, peerId string) *fixture {
 		return ac.conn, ac.err
 	}).AnyTimes()
 	fx.mc.EXPECT().Close().AnyTimes()
-	p, err := NewPeer(fx.mc, fx.testCtrl)
+	p, err := NewPeer(fx.mc, ctrl)
 	require.NoError(t, err)
 	fx.peer = p.(*peer)
 	return fx
@@ -297,3 +384,12 @@ func (t *testCtrl) ServeConn(ctx context.Context, conn net.Conn) (err error) {
 func (t *testCtrl) close() {
 	close(t.closeCh)
 }
+
+type testProtoCtrl struct {
+	*testCtrl
+	protoHandlers map[handshakeproto.ProtoType]ProtoHandler
+}
+
+func (t testProtoCtrl) ProtoHandlers() map[handshakeproto.ProtoType]ProtoHandler {
+	return t.protoHandlers
+}
@@ -0,0 +1,140 @@
+package peerservice
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/net/transport"
+)
+
+// dialStaggerDelay is the delay before the next address is dialed while the previous attempts are still in progress
+const dialStaggerDelay = 250 * time.Millisecond
+
+type dialCandidate struct {
+	scheme string
+	addr   string
+}
+
+// key returns the address with the scheme, it is used to keep the dial history
+func (c dialCandidate) key() string {
+	return c.scheme + "://" + c.addr
+}
+
+type dialResult struct {
+	candidate dialCandidate
+	mc        transport.MultiConn
+	err       error
+}
+
+// addrDialStat is the dial history of the address
+type addrDialStat struct {
+	lastSuccess time.Time
+	failures    int
+}
+
+// dialCandidates returns the addresses of the registered transports in the order they should be dialed:
+// the addresses which were connected recently go first, then the ones without failures in the order of schemes,
+// the addresses which failed go last
+func (p *peerService) dialCandidates(schemes, addrs []string) (candidates []dialCandidate) {
+	for _, sch := range schemes {
+		if _, ok := p.transports[sch]; !ok {
+			continue
+		}
+		for _, addr := range addrs {
+			if scheme(addr) == sch {
+				candidates = append(candidates, dialCandidate{scheme: sch, addr: stripScheme(addr)})
+			}
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b dialCandidate) int {
+		aStat, bStat := p.dialStats[a.key()], p.dialStats[b.key()]
+		if c := bStat.lastSuccess.Compare(aStat.lastSuccess); c != 0 {
+			return c
+		}
+		return aStat.failures - bStat.failures
+	})
+	return
+}
+
+// dialParallel dials the candidates concurrently, every next attempt starts after dialStaggerDelay
+// or right after the failure of the previous one. The first connection which passed the handshake wins,
+// the rest of the attempts are cancelled and their connections are closed
+func (p *peerService) dialParallel(ctx context.Context, candidates []dialCandidate) (mc transport.MultiConn, err error) {
+	if len(candidates) == 0 {
+		return nil, ErrAddrsNotFound
+	}
+	dialCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results = make(chan dialResult, len(candidates))
+		next    int
+		pending int
+		stagger = time.NewTimer(p.dialStagger)
+	)
+	defer stagger.Stop()
+	dialNext := func() {
+		candidate := candidates[next]
+		next++
+		pending++
+		go func() {
+			mc, err := p.transports[candidate.scheme].Dial(dialCtx, candidate.addr)
+			results <- dialResult{candidate: candidate, mc: mc, err: err}
+		}()
+		stagger.Reset(p.dialStagger)
+	}
+
+	dialNext()
+	for pending > 0 {
+		select {
+		case <-stagger.C:
+			if next < len(candidates) {
+				dialNext()
+			}
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				cancel()
+				p.dialSucceeded(res.candidate)
+				go closeLateConns(results, pending)
+				return res.mc, nil
+			}
+			err = res.err
+			log.InfoCtx(ctx, "can't connect to host", zap.String("addr", res.candidate.key()), zap.Error(res.err))
+			if ctx.Err() == nil {
+				p.dialFailed(res.candidate)
+			}
+			if next < len(candidates) {
+				dialNext()
+			}
+		}
+	}
+	return
+}
+
+// closeLateConns waits for the attempts which are still in progress and closes the connections
+// which were established after the winner
+func closeLateConns(results <-chan dialResult, pending int) {
+	for ; pending > 0; pending-- {
+		if res := <-results; res.err == nil {
+			_ = res.mc.Close()
+		}
+	}
+}
+
+func (p *peerService) dialSucceeded(c dialCandidate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dialStats[c.key()] = addrDialStat{lastSuccess: time.Now()}
+}
+
+func (p *peerService) dialFailed(c dialCandidate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stat := p.dialStats[c.key()]
+	stat.failures++
+	p.dialStats[c.key()] = stat
+}
@@ -3,18 +3,20 @@ package peerservice
 import (
 	"context"
 	"errors"
-	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/app/logger"
 	"github.com/anyproto/any-sync/net/peer"
 	"github.com/anyproto/any-sync/net/pool"
 	"github.com/anyproto/any-sync/net/rpc/server"
+	"github.com/anyproto/any-sync/net/secureservice/handshake"
+	"github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
 	"github.com/anyproto/any-sync/net/transport"
-	"github.com/anyproto/any-sync/net/transport/quic"
-	"github.com/anyproto/any-sync/net/transport/yamux"
 	"github.com/anyproto/any-sync/nodeconf"
 	"go.uber.org/zap"
 )
@@ -26,6 +28,9 @@ var log = logger.NewNamed(CName)
 var (
 	ErrAddrsNotFound    = errors.New("addrs for peer not found")
 	ErrPeerIdMismatched = errors.New("peerId mismatched")
+	ErrNoTransports     = errors.New("no transports registered")
+	ErrProtoRegistered  = errors.New("proto type already registered")
+	ErrProtoNotAllowed  = errors.New("proto type is neither defined by any-sync nor in the application range")
 )
 
 func New() PeerService {
@@ -36,30 +41,57 @@ type PeerService interface {
 	Dial(ctx context.Context, peerId string) (pr peer.Peer, err error)
 	SetPeerAddrs(peerId string, addrs []string)
 	PreferQuic(prefer bool)
+	// RegisterProto registers the handler for the incoming sub connections with the given proto type.
+	// The type must be either defined in handshakeproto or be in the application range (see handshake.ProtoTypeApplicationMin).
+	// Should be called on the component init, the peers created before the call don't serve the proto
+	RegisterProto(pt handshakeproto.ProtoType, handler peer.ProtoHandler) (err error)
 	transport.Accepter
 	app.Component
 }
 
 type peerService struct {
-	yamux      transport.Transport
-	quic       transport.Transport
 	nodeConf   nodeconf.NodeConf
 	peerAddrs  map[string][]string
 	pool       pool.Pool
 	server     server.DRPCServer
 	preferQuic bool
 	mu         sync.RWMutex
+
+	// protoHandlers are the handlers for the sub connections besides drpc
+	protoHandlers map[handshakeproto.ProtoType]peer.ProtoHandler
+
+	// transports are the registered transports by the address schemes
+	transports map[string]transport.Transport
+	// extraSchemes are the schemes dialed after yamux and quic
+	extraSchemes []string
+	dialStats    map[string]addrDialStat
+	dialStagger  time.Duration
 }
 
 func (p *peerService) Init(a *app.App) (err error) {
-	p.yamux = a.MustComponent(yamux.CName).(transport.Transport)
-	p.quic = a.MustComponent(quic.CName).(transport.Transport)
+	p.transports = map[string]transport.Transport{}
+	a.IterateComponents(func(c app.Component) {
+		if tr, ok := c.(transport.SchemeTransport); ok {
+			p.transports[tr.Scheme()] = tr
+		}
+	})
+	if len(p.transports) == 0 {
+		return ErrNoTransports
+	}
+	for sch, tr := range p.transports {
+		if sch != transport.Yamux && sch != transport.Quic {
+			p.extraSchemes = append(p.extraSchemes, sch)
+		}
+		tr.SetAccepter(p)
+	}
+	slices.Sort(p.extraSchemes)
 	p.nodeConf = a.MustComponent(nodeconf.CName).(nodeconf.NodeConf)
 	p.pool = a.MustComponent(pool.CName).(pool.Pool)
 	p.server = a.MustComponent(server.CName).(server.DRPCServer)
 	p.peerAddrs = map[string][]string{}
-	p.yamux.SetAccepter(p)
-	p.quic.SetAccepter(p)
+	p.protoHandlers = map[handshakeproto.ProtoType]peer.ProtoHandler{}
+	p.dialStats = map[string]addrDialStat{}
+	p.dialStagger = dialStaggerDelay
 	return nil
 }
 
@@ -78,28 +110,57 @@ func (p *peerService) PreferQuic(prefer bool) {
 	p.mu.Unlock()
 }
 
+func (p *peerService) RegisterProto(pt handshakeproto.ProtoType, handler peer.ProtoHandler) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.protoHandlers[pt]; ok || pt == handshakeproto.ProtoType_DRPC {
+		return ErrProtoRegistered
+	}
+	// the types between the defined ones and the application range are kept for the future any-sync protocols
+	if _, defined := handshakeproto.ProtoType_name[int32(pt)]; !defined && !handshake.IsApplicationProtoType(pt) {
+		return ErrProtoNotAllowed
+	}
+	p.protoHandlers[pt] = handler
+	return nil
+}
+
+func (p *peerService) newPeer(mc transport.MultiConn) (peer.Peer, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.protoHandlers) == 0 {
+		return peer.NewPeer(mc, p.server)
+	}
+	return peer.NewPeer(mc, peerCtrl{DRPCServer: p.server, protoHandlers: maps.Clone(p.protoHandlers)})
+}
+
+// peerCtrl serves the drpc sub connections with the server and the rest with the registered handlers
+type peerCtrl struct {
+	server.DRPCServer
+	protoHandlers map[handshakeproto.ProtoType]peer.ProtoHandler
+}
+
+func (c peerCtrl) ProtoHandlers() map[handshakeproto.ProtoType]peer.ProtoHandler {
+	return c.protoHandlers
+}
+
 func (p *peerService) Dial(ctx context.Context, peerId string) (pr peer.Peer, err error) {
 	var schemes = yamuxPreferSchemes
 	p.mu.RLock()
 	if p.preferQuic {
 		schemes = quicPreferSchemes
 	}
+	schemes = append(slices.Clip(schemes), p.extraSchemes...)
 	addrs, err := p.getPeerAddrs(peerId)
 	if err != nil {
 		p.mu.RUnlock()
 		return
 	}
+	candidates := p.dialCandidates(schemes, addrs)
 	p.mu.RUnlock()
 
-	var mc transport.MultiConn
 	log.DebugCtx(ctx, "dial", zap.String("peerId", peerId), zap.Strings("addrs", addrs))
 
-	err = ErrAddrsNotFound
-	for _, sch := range schemes {
-		if mc, err = p.dialScheme(ctx, sch, addrs); err == nil {
-			break
-		}
-	}
+	mc, err := p.dialParallel(ctx, candidates)
 	if err != nil {
 		return
 	}
@@ -110,36 +171,11 @@ func (p *peerService) Dial(ctx context.Context, peerId string) (pr peer.Peer, er
 	if connPeerId != peerId {
 		return nil, ErrPeerIdMismatched
 	}
-	return peer.NewPeer(mc, p.server)
-}
-
-func (p *peerService) dialScheme(ctx context.Context, sch string, addrs []string) (mc transport.MultiConn, err error) {
-	var tr transport.Transport
-	switch sch {
-	case transport.Quic:
-		tr = p.quic
-	case transport.Yamux:
-		tr = p.yamux
-	default:
-		return nil, fmt.Errorf("unexpected transport: %v", sch)
-	}
-
-	err = ErrAddrsNotFound
-	for _, addr := range addrs {
-		if scheme(addr) != sch {
-			continue
-		}
-		if mc, err = tr.Dial(ctx, stripScheme(addr)); err == nil {
-			return
-		} else {
-			log.InfoCtx(ctx, "can't connect to host", zap.String("addr", addr), zap.Error(err))
-		}
-	}
-	return
+	return p.newPeer(mc)
 }
 
 func (p *peerService) Accept(mc transport.MultiConn) (err error) {
-	pr, err := peer.NewPeer(mc, p.server)
+	pr, err := p.newPeer(mc)
 	if err != nil {
 		return err
 	}
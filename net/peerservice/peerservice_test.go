@@ -7,8 +7,12 @@ import (
 	"github.com/anyproto/any-sync/net/peer"
 	"github.com/anyproto/any-sync/net/pool"
 	"github.com/anyproto/any-sync/net/rpc/rpctest"
+	"github.com/anyproto/any-sync/net/secureservice/handshake"
+	"github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
+	"github.com/anyproto/any-sync/net/transport"
 	"github.com/anyproto/any-sync/net/transport/mock_transport"
 	"github.com/anyproto/any-sync/net/transport/quic"
+	"github.com/anyproto/any-sync/net/transport/websocket"
 	"github.com/anyproto/any-sync/net/transport/yamux"
 	"github.com/anyproto/any-sync/nodeconf"
 	"github.com/anyproto/any-sync/nodeconf/mock_nodeconf"
@@ -16,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 var ctx = context.Background()
@@ -33,7 +38,7 @@ func TestPeerService_Dial(t *testing.T) {
 
 		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return(addrs, true)
 
-		fx.yamux.MockTransport.EXPECT().Dial(ctx, "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
 
 		p, err := fx.Dial(ctx, peerId)
 		require.NoError(t, err)
@@ -47,7 +52,7 @@ func TestPeerService_Dial(t *testing.T) {
 
 		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return(addrs, true)
 
-		fx.quic.MockTransport.EXPECT().Dial(ctx, "127.0.0.1:1112").Return(fx.mockMC(peerId), nil)
+		fx.quic.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1112").Return(fx.mockMC(peerId), nil)
 
 		p, err := fx.Dial(ctx, peerId)
 		require.NoError(t, err)
@@ -61,8 +66,8 @@ func TestPeerService_Dial(t *testing.T) {
 
 		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return(addrs, true)
 
-		fx.quic.MockTransport.EXPECT().Dial(ctx, "127.0.0.1:1112").Return(nil, fmt.Errorf("test"))
-		fx.yamux.MockTransport.EXPECT().Dial(ctx, "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
+		fx.quic.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1112").Return(nil, fmt.Errorf("test"))
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
 
 		p, err := fx.Dial(ctx, peerId)
 		require.NoError(t, err)
@@ -76,7 +81,7 @@ func TestPeerService_Dial(t *testing.T) {
 
 		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return(addrs, true)
 
-		fx.yamux.MockTransport.EXPECT().Dial(ctx, "127.0.0.1:1111").Return(fx.mockMC(peerId+"not valid"), nil)
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").Return(fx.mockMC(peerId+"not valid"), nil)
 
 		p, err := fx.Dial(ctx, peerId)
 		assert.EqualError(t, err, ErrPeerIdMismatched.Error())
@@ -91,12 +96,38 @@ func TestPeerService_Dial(t *testing.T) {
 		fx.SetPeerAddrs(peerId, addrs)
 		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return(nil, false)
 
-		fx.yamux.MockTransport.EXPECT().Dial(ctx, "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
 
 		p, err := fx.Dial(ctx, peerId)
 		require.NoError(t, err)
 		assert.NotNil(t, p)
 	})
+	t.Run("websocket fallback", func(t *testing.T) {
+		fx := newFixture(t)
+		defer fx.finish(t)
+		fx.PreferQuic(false)
+		var peerId = "p1"
+
+		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return(append(addrs, "ws://127.0.0.1:1113/sync"), true)
+
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").Return(nil, fmt.Errorf("test"))
+		fx.quic.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1112").Return(nil, fmt.Errorf("test"))
+		fx.ws.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1113/sync").Return(fx.mockMC(peerId), nil)
+
+		p, err := fx.Dial(ctx, peerId)
+		require.NoError(t, err)
+		assert.NotNil(t, p)
+	})
+	t.Run("unknown scheme", func(t *testing.T) {
+		fx := newFixture(t)
+		defer fx.finish(t)
+		var peerId = "p1"
+
+		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return([]string{"unknown://127.0.0.1:1111"}, true)
+
+		_, err := fx.Dial(ctx, peerId)
+		assert.ErrorIs(t, err, ErrAddrsNotFound)
+	})
 	t.Run("addr without scheme", func(t *testing.T) {
 		fx := newFixture(t)
 		defer fx.finish(t)
@@ -105,7 +136,7 @@ func TestPeerService_Dial(t *testing.T) {
 
 		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return([]string{"127.0.0.1:1111"}, true)
 
-		fx.yamux.MockTransport.EXPECT().Dial(ctx, "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").Return(fx.mockMC(peerId), nil)
 
 		p, err := fx.Dial(ctx, peerId)
 		require.NoError(t, err)
@@ -113,6 +144,77 @@ func TestPeerService_Dial(t *testing.T) {
 	})
 }
 
+func TestPeerService_DialParallel(t *testing.T) {
+	t.Run("slow address doesn't block", func(t *testing.T) {
+		fx := newFixture(t)
+		defer fx.finish(t)
+		fx.PeerService.(*peerService).dialStagger = time.Millisecond * 10
+		var peerId = "p1"
+
+		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return([]string{"yamux://127.0.0.1:1111", "quic://127.0.0.1:1112"}, true)
+
+		cancelled := make(chan struct{})
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").DoAndReturn(func(ctx context.Context, addr string) (transport.MultiConn, error) {
+			<-ctx.Done()
+			close(cancelled)
+			return nil, ctx.Err()
+		})
+		fx.quic.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1112").Return(fx.mockMC(peerId), nil)
+
+		p, err := fx.Dial(ctx, peerId)
+		require.NoError(t, err)
+		assert.NotNil(t, p)
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			require.True(t, false, "timeout")
+		}
+	})
+	t.Run("late connection is closed", func(t *testing.T) {
+		fx := newFixture(t)
+		defer fx.finish(t)
+		fx.PeerService.(*peerService).dialStagger = time.Millisecond * 10
+		var peerId = "p1"
+
+		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return([]string{"yamux://127.0.0.1:1111", "quic://127.0.0.1:1112"}, true)
+
+		won := make(chan struct{})
+		closed := make(chan struct{})
+		lateMC := mock_transport.NewMockMultiConn(fx.ctrl)
+		lateMC.EXPECT().Close().Do(func() { close(closed) })
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").DoAndReturn(func(ctx context.Context, addr string) (transport.MultiConn, error) {
+			<-won
+			return lateMC, nil
+		})
+		fx.quic.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1112").Return(fx.mockMC(peerId), nil)
+
+		p, err := fx.Dial(ctx, peerId)
+		require.NoError(t, err)
+		assert.NotNil(t, p)
+		close(won)
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			require.True(t, false, "timeout")
+		}
+	})
+	t.Run("successful address goes first", func(t *testing.T) {
+		fx := newFixture(t)
+		defer fx.finish(t)
+		var peerId = "p1"
+		addrs := []string{"yamux://127.0.0.1:1111", "yamux://127.0.0.1:1112"}
+
+		fx.nodeConf.EXPECT().PeerAddresses(peerId).Return(addrs, true).Times(2)
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1111").Return(nil, fmt.Errorf("test"))
+		fx.yamux.MockTransport.EXPECT().Dial(gomock.Any(), "127.0.0.1:1112").Return(fx.mockMC(peerId), nil).Times(2)
+
+		_, err := fx.Dial(ctx, peerId)
+		require.NoError(t, err)
+		_, err = fx.Dial(ctx, peerId)
+		require.NoError(t, err)
+	})
+}
+
 func TestPeerService_Accept(t *testing.T) {
 	fx := newFixture(t)
 	defer fx.finish(t)
@@ -121,12 +223,28 @@ func TestPeerService_Accept(t *testing.T) {
 	require.NoError(t, fx.Accept(mc))
 }
 
+func TestPeerService_RegisterProto(t *testing.T) {
+	fx := newFixture(t)
+	defer fx.finish(t)
+
+	handler := rpctest.NewTestServer()
+	require.NoError(t, fx.RegisterProto(handshakeproto.ProtoType_ByteStream, handler))
+	assert.ErrorIs(t, fx.RegisterProto(handshakeproto.ProtoType_ByteStream, handler), ErrProtoRegistered)
+	assert.ErrorIs(t, fx.RegisterProto(handshakeproto.ProtoType_DRPC, handler), ErrProtoRegistered)
+	assert.ErrorIs(t, fx.RegisterProto(handshakeproto.ProtoType(10), handler), ErrProtoNotAllowed)
+	require.NoError(t, fx.RegisterProto(handshake.ProtoTypeApplicationMin, handler))
+
+	mc := fx.mockMC("p1")
+	require.NoError(t, fx.Accept(mc))
+}
+
 type fixture struct {
 	PeerService
 	a        *app.App
 	ctrl     *gomock.Controller
 	quic     mock_transport.TransportComponent
 	yamux    mock_transport.TransportComponent
+	ws       mock_transport.TransportComponent
 	nodeConf *mock_nodeconf.MockService
 }
 
@@ -136,20 +254,22 @@ func newFixture(t *testing.T) *fixture {
 		PeerService: New(),
 		ctrl:        ctrl,
 		a:           new(app.App),
-		quic:        mock_transport.NewTransportComponent(ctrl, quic.CName),
-		yamux:       mock_transport.NewTransportComponent(ctrl, yamux.CName),
+		quic:        mock_transport.NewTransportComponent(ctrl, quic.CName, transport.Quic),
+		yamux:       mock_transport.NewTransportComponent(ctrl, yamux.CName, transport.Yamux),
+		ws:          mock_transport.NewTransportComponent(ctrl, websocket.CName, transport.Websocket),
 		nodeConf:    mock_nodeconf.NewMockService(ctrl),
 	}
 
 	fx.quic.EXPECT().SetAccepter(fx.PeerService)
 	fx.yamux.EXPECT().SetAccepter(fx.PeerService)
+	fx.ws.EXPECT().SetAccepter(fx.PeerService)
 
 	fx.nodeConf.EXPECT().Name().Return(nodeconf.CName).AnyTimes()
 	fx.nodeConf.EXPECT().Init(gomock.Any())
 	fx.nodeConf.EXPECT().Run(gomock.Any())
 	fx.nodeConf.EXPECT().Close(gomock.Any())
 
-	fx.a.Register(fx.PeerService).Register(fx.quic).Register(fx.yamux).Register(fx.nodeConf).Register(pool.New()).Register(rpctest.NewTestServer())
+	fx.a.Register(fx.PeerService).Register(fx.quic).Register(fx.yamux).Register(fx.ws).Register(fx.nodeConf).Register(pool.New()).Register(rpctest.NewTestServer())
 
 	require.NoError(t, fx.a.Start(ctx))
 	return fx
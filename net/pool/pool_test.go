@@ -16,6 +16,7 @@ import (
 	"github.com/anyproto/any-sync/net"
 	"github.com/anyproto/any-sync/net/peer"
 	"github.com/anyproto/any-sync/net/secureservice/handshake"
+	"github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
 )
 
 var ctx = context.Background()
@@ -410,6 +411,10 @@ func (t *testPeer) AcquireDrpcConn(ctx context.Context) (drpc.Conn, error) {
 
 func (t *testPeer) ReleaseDrpcConn(conn drpc.Conn) {}
 
+func (t *testPeer) OpenProtoConn(ctx context.Context, pt handshakeproto.ProtoType) (net2.Conn, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (t *testPeer) Context() context.Context {
 	//TODO implement me
 	panic("implement me")
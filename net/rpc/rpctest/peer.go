@@ -2,6 +2,7 @@ package rpctest
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"storj.io/drpc"
@@ -9,6 +10,7 @@ import (
 	"github.com/anyproto/any-sync/net/peer"
 	"github.com/anyproto/any-sync/net/rpc/rpctest/multiconntest"
 	"github.com/anyproto/any-sync/net/secureservice"
+	"github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
 	"github.com/anyproto/any-sync/net/transport"
 )
 
@@ -54,6 +56,10 @@ func (m MockPeer) DoDrpc(ctx context.Context, do func(conn drpc.Conn) error) err
 	return nil
 }
 
+func (m MockPeer) OpenProtoConn(ctx context.Context, pt handshakeproto.ProtoType) (net.Conn, error) {
+	return nil, nil
+}
+
 func (m MockPeer) IsClosed() bool {
 	return false
 }
@@ -65,6 +65,12 @@ type peerSignVerifier struct {
 }
 
 func (p *peerSignVerifier) MakeCredentials(remotePeerId string) *handshakeproto.Credentials {
+	protoVersion := p.protoVersion
+	// the device signs on behalf of the account, the peers of the older versions can't verify it
+	deviceCert := crypto.MarshalledDeviceCertificate(p.account.SignKey)
+	if deviceCert != nil && protoVersion < DeviceCertificateVersion {
+		protoVersion = DeviceCertificateVersion
+	}
 	sign, err := p.account.SignKey.Sign([]byte(p.account.PeerId + remotePeerId))
 	if err != nil {
 		log.Warn("can't sign identity credentials", zap.Error(err))
@@ -72,14 +78,15 @@ func (p *peerSignVerifier) MakeCredentials(remotePeerId string) *handshakeproto.
 	// this will actually be called only once
 	marshalled, _ := p.account.SignKey.GetPublic().Marshall()
 	msg := &handshakeproto.PayloadSignedPeerIds{
-		Identity: marshalled,
-		Sign:     sign,
+		Identity:          marshalled,
+		Sign:              sign,
+		DeviceCertificate: deviceCert,
 	}
 	payload, _ := msg.Marshal()
 	return &handshakeproto.Credentials{
 		Type:          handshakeproto.CredentialsType_SignedPeerIds,
 		Payload:       payload,
-		Version:       p.protoVersion,
+		Version:       protoVersion,
 		ClientVersion: p.clientVersion,
 	}
 }
@@ -103,14 +110,21 @@ func (p *peerSignVerifier) CheckCredential(remotePeerId string, cred *handshakep
 		err = handshake.ErrInvalidCredentials
 		return
 	}
-	ok, err := pubKey.Verify([]byte((remotePeerId + p.account.PeerId)), msg.Sign)
-	if err != nil {
+	if msg.DeviceCertificate != nil && cred.Version < DeviceCertificateVersion {
+		err = handshake.ErrIncompatibleVersion
 		return
 	}
-	if !ok {
+	devicePubKey, err := crypto.VerifyIdentitySignature(pubKey, []byte((remotePeerId + p.account.PeerId)), msg.Sign, msg.DeviceCertificate)
+	if err != nil {
 		err = handshake.ErrInvalidCredentials
 		return
 	}
+	var deviceKey []byte
+	if devicePubKey != nil {
+		if deviceKey, err = devicePubKey.Marshall(); err != nil {
+			return
+		}
+	}
 	// Hotfix for a bad version
 	if strings.Contains(cred.ClientVersion, "middle:v0.36.6") {
 		err = handshake.ErrIncompatibleVersion
@@ -118,6 +132,7 @@ func (p *peerSignVerifier) CheckCredential(remotePeerId string, cred *handshakep
 	}
 	return handshake.Result{
 		Identity:      msg.Identity,
+		DeviceKey:     deviceKey,
 		ProtoVersion:  cred.Version,
 		ClientVersion: cred.ClientVersion,
 	}, nil
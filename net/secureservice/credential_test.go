@@ -8,7 +8,9 @@ import (
 
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/net/secureservice/handshake"
+	"github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
 	"github.com/anyproto/any-sync/testutil/accounttest"
+	"github.com/anyproto/any-sync/util/crypto"
 )
 
 func TestPeerSignVerifier_CheckCredential(t *testing.T) {
@@ -81,6 +83,48 @@ func TestIncompatibleVersion_Issue4423(t *testing.T) {
 	assert.ErrorIs(t, err, handshake.ErrIncompatibleVersion)
 }
 
+func TestPeerSignVerifier_DeviceCredential(t *testing.T) {
+	a1 := newTestAccData(t)
+	a2 := newTestAccData(t)
+	identity1, _ := a1.SignKey.GetPublic().Marshall()
+	deviceKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	cert, err := crypto.NewDeviceCertificate(a1.SignKey, deviceKey.GetPublic())
+	require.NoError(t, err)
+	device, err := accountdata.NewDevice(a1.PeerKey, deviceKey, cert)
+	require.NoError(t, err)
+
+	versions := []uint32{NewInvitesVersion, DeviceCertificateVersion}
+	cc1 := newPeerSignVerifier(NewInvitesVersion, versions, "test:v1", device)
+	cc2 := newPeerSignVerifier(NewInvitesVersion, versions, "test:v1", a2)
+	cr1 := cc1.MakeCredentials(a2.PeerId)
+	assert.Equal(t, DeviceCertificateVersion, cr1.Version)
+	res, err := cc2.CheckCredential(a1.PeerId, cr1)
+	require.NoError(t, err)
+	assert.Equal(t, identity1, res.Identity)
+	protoDeviceKey, _ := deviceKey.GetPublic().Marshall()
+	assert.Equal(t, protoDeviceKey, res.DeviceKey)
+
+	t.Run("certificate requires the version", func(t *testing.T) {
+		cr := cc1.MakeCredentials(a2.PeerId)
+		cr.Version = NewInvitesVersion
+		_, err := cc2.CheckCredential(a1.PeerId, cr)
+		assert.ErrorIs(t, err, handshake.ErrIncompatibleVersion)
+	})
+	t.Run("certificate of another account", func(t *testing.T) {
+		otherCert, err := crypto.NewDeviceCertificate(a2.SignKey, deviceKey.GetPublic())
+		require.NoError(t, err)
+		cr := cc1.MakeCredentials(a2.PeerId)
+		msg := &handshakeproto.PayloadSignedPeerIds{}
+		require.NoError(t, msg.Unmarshal(cr.Payload))
+		msg.DeviceCertificate = otherCert.Marshall()
+		cr.Payload, err = msg.Marshal()
+		require.NoError(t, err)
+		_, err = cc2.CheckCredential(a1.PeerId, cr)
+		assert.EqualError(t, err, handshake.ErrInvalidCredentials.Error())
+	})
+}
+
 func newTestAccData(t *testing.T) *accountdata.AccountKeys {
 	as := accounttest.AccountTestService{}
 	require.NoError(t, as.Init(nil))
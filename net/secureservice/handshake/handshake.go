@@ -68,7 +68,9 @@ type CredentialChecker interface {
 }
 
 type Result struct {
-	Identity      []byte
+	Identity []byte
+	// DeviceKey is the proto encoded device key, it is set if the peer is authenticated with the device certificate
+	DeviceKey     []byte
 	ProtoVersion  uint32
 	ClientVersion string
 }
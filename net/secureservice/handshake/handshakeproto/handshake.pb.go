@@ -92,18 +92,28 @@ func (Error) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_60283fc75f020893, []int{1}
 }
 
+// ProtoType is the protocol of the sub connection
+// DRPC is served by the drpc server, other types are served by the handlers registered in the peer service
 type ProtoType int32
 
 const (
 	ProtoType_DRPC ProtoType = 0
+	// ByteStream is a raw byte stream, e.g. for the bulk file transfer
+	ProtoType_ByteStream ProtoType = 1
+	// HTTP2 is the http/2 tunnel, e.g. for the debug UI
+	ProtoType_HTTP2 ProtoType = 2
 )
 
 var ProtoType_name = map[int32]string{
 	0: "DRPC",
+	1: "ByteStream",
+	2: "HTTP2",
 }
 
 var ProtoType_value = map[string]int32{
-	"DRPC": 0,
+	"DRPC":       0,
+	"ByteStream": 1,
+	"HTTP2":      2,
 }
 
 func (x ProtoType) String() string {
@@ -195,6 +205,8 @@ type PayloadSignedPeerIds struct {
 	Identity []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
 	// sign of (localPeerId + remotePeerId)
 	Sign []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`
+	// signed device certificate, if it is set the sign is made by the device key instead of the identity
+	DeviceCertificate []byte `protobuf:"bytes,3,opt,name=deviceCertificate,proto3" json:"deviceCertificate,omitempty"`
 }
 
 func (m *PayloadSignedPeerIds) Reset()         { *m = PayloadSignedPeerIds{} }
@@ -252,6 +264,13 @@ func (m *PayloadSignedPeerIds) GetSign() []byte {
 	return nil
 }
 
+func (m *PayloadSignedPeerIds) GetDeviceCertificate() []byte {
+	if m != nil {
+		return m.DeviceCertificate
+	}
+	return nil
+}
+
 type Ack struct {
 	Error Error `protobuf:"varint,1,opt,name=error,proto3,enum=anyHandshake.Error" json:"error,omitempty"`
 }
@@ -371,36 +390,39 @@ func init() {
 }
 
 var fileDescriptor_60283fc75f020893 = []byte{
-	// 457 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xf5, 0x36, 0x71, 0x3f, 0x86, 0xa4, 0x6c, 0xb7, 0x29, 0xb5, 0x90, 0xb0, 0xa2, 0x88, 0x43,
-	0x88, 0x44, 0xc2, 0x97, 0xb8, 0x87, 0xa6, 0x88, 0x5c, 0xaa, 0xc8, 0x85, 0x1e, 0xb8, 0x6d, 0xbd,
-	0x43, 0xbb, 0xca, 0xb2, 0xb6, 0xd6, 0xdb, 0x50, 0xff, 0x0b, 0xce, 0xfc, 0x0a, 0x7e, 0x06, 0xc7,
-	0x1e, 0x39, 0xa2, 0xe4, 0x8f, 0x20, 0x6f, 0x9c, 0xc6, 0xe1, 0xc4, 0xc5, 0xde, 0x99, 0xf7, 0x66,
-	0xe7, 0xbd, 0x67, 0xc3, 0x50, 0xa3, 0x1d, 0x64, 0x18, 0xdf, 0x18, 0xcc, 0xd0, 0xcc, 0x64, 0x8c,
-	0x83, 0x6b, 0xae, 0x45, 0x76, 0xcd, 0xa7, 0x95, 0x53, 0x6a, 0x12, 0x9b, 0x0c, 0xdc, 0x33, 0x5b,
-	0x77, 0xfb, 0xae, 0xc1, 0x1a, 0x5c, 0xe7, 0x1f, 0x56, 0xbd, 0xce, 0x0f, 0x02, 0x0f, 0x4e, 0x0c,
-	0x0a, 0xd4, 0x56, 0x72, 0x95, 0xb1, 0x97, 0x50, 0xb7, 0x79, 0x8a, 0x01, 0x69, 0x93, 0xee, 0xfe,
-	0xab, 0x27, 0xfd, 0x2a, 0xb9, 0x5f, 0x21, 0x7e, 0xcc, 0x53, 0x8c, 0x1c, 0x95, 0x05, 0xb0, 0x93,
-	0xf2, 0x5c, 0x25, 0x5c, 0x04, 0x5b, 0x6d, 0xd2, 0x6d, 0x44, 0xab, 0xb2, 0x40, 0x66, 0x68, 0x32,
-	0x99, 0xe8, 0xa0, 0xd6, 0x26, 0xdd, 0x66, 0xb4, 0x2a, 0xd9, 0x53, 0x68, 0xc6, 0x4a, 0xa2, 0xb6,
-	0x17, 0x25, 0x5e, 0x6f, 0x93, 0xee, 0x5e, 0xb4, 0xd9, 0xec, 0xbc, 0x87, 0xd6, 0x64, 0x79, 0xd5,
-	0xb9, 0xbc, 0xd2, 0x28, 0x26, 0x88, 0x66, 0x2c, 0x32, 0xf6, 0x18, 0x76, 0xa5, 0x13, 0x62, 0x73,
-	0x27, 0xb4, 0x11, 0xdd, 0xd7, 0x8c, 0x41, 0x3d, 0x93, 0x57, 0xba, 0x94, 0xe2, 0xce, 0x9d, 0x17,
-	0x50, 0x1b, 0xc6, 0x53, 0xf6, 0x0c, 0x7c, 0x34, 0x26, 0x31, 0xa5, 0xb9, 0xc3, 0x4d, 0x73, 0xa7,
-	0x05, 0x14, 0x2d, 0x19, 0x9d, 0xb7, 0xe0, 0x4f, 0x5c, 0x5a, 0xcf, 0xc1, 0x77, 0xb1, 0x95, 0x33,
-	0xc7, 0x9b, 0x33, 0x8e, 0xe3, 0xa2, 0x58, 0xb2, 0x7a, 0x6f, 0xe0, 0xe1, 0x3f, 0x21, 0xb1, 0x7d,
-	0x80, 0xf3, 0xa9, 0x4c, 0x2f, 0xd0, 0xc8, 0x2f, 0x39, 0xf5, 0xd8, 0x01, 0x34, 0x37, 0xdc, 0x50,
-	0xd2, 0xfb, 0x49, 0xc0, 0x77, 0xeb, 0xd9, 0x2e, 0xd4, 0xcf, 0x6e, 0x94, 0xa2, 0x5e, 0x31, 0xf6,
-	0x49, 0xe3, 0x6d, 0x8a, 0xb1, 0x45, 0x41, 0x09, 0x7b, 0x04, 0x6c, 0xac, 0x67, 0x5c, 0x49, 0x51,
-	0x59, 0x40, 0xb7, 0xd8, 0x11, 0x1c, 0xac, 0x79, 0x65, 0x5a, 0xb4, 0xc6, 0x02, 0x68, 0xad, 0xb7,
-	0x9e, 0x25, 0x76, 0xa8, 0x54, 0xf2, 0x0d, 0x05, 0xad, 0xb3, 0x16, 0xd0, 0x11, 0x72, 0xa1, 0xa4,
-	0xc6, 0xd3, 0xdb, 0x18, 0x51, 0xa0, 0xa0, 0x3e, 0x3b, 0x86, 0xc3, 0xb1, 0x8e, 0x93, 0xaf, 0x29,
-	0xb7, 0xf2, 0x52, 0x61, 0xf9, 0x05, 0xe8, 0x76, 0x71, 0x7f, 0x15, 0x70, 0x8e, 0xe9, 0x4e, 0xef,
-	0x08, 0xf6, 0xee, 0xcd, 0x17, 0xaa, 0x47, 0xd1, 0xe4, 0x84, 0x7a, 0xef, 0x46, 0xbf, 0xe6, 0x21,
-	0xb9, 0x9b, 0x87, 0xe4, 0xcf, 0x3c, 0x24, 0xdf, 0x17, 0xa1, 0x77, 0xb7, 0x08, 0xbd, 0xdf, 0x8b,
-	0xd0, 0xfb, 0xdc, 0xfb, 0xff, 0x3f, 0xf7, 0x72, 0xdb, 0xbd, 0x5e, 0xff, 0x0d, 0x00, 0x00, 0xff,
-	0xff, 0xe2, 0xfa, 0x40, 0x67, 0xee, 0x02, 0x00, 0x00,
+	// 505 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xc1, 0x6e, 0xda, 0x40,
+	0x10, 0x65, 0x01, 0x07, 0x98, 0x42, 0xba, 0x6c, 0x68, 0x83, 0x2a, 0xd5, 0x42, 0xa8, 0x07, 0x8a,
+	0x5a, 0x68, 0xd3, 0xaa, 0x97, 0x9e, 0x08, 0x44, 0x0a, 0x97, 0x08, 0x19, 0x9a, 0x43, 0x6f, 0x8e,
+	0x77, 0x92, 0xac, 0x70, 0xd6, 0xd6, 0x7a, 0x43, 0xe3, 0xbf, 0xe8, 0xb9, 0x5f, 0xd1, 0xcf, 0xe8,
+	0x31, 0xc7, 0x1e, 0x2b, 0xb8, 0xf4, 0x2f, 0xa8, 0xbc, 0x98, 0x00, 0xed, 0xa5, 0x17, 0x7b, 0xe7,
+	0xbd, 0x37, 0x3b, 0x6f, 0x9e, 0x65, 0xe8, 0x49, 0xd4, 0xdd, 0x08, 0xbd, 0x5b, 0x85, 0x11, 0xaa,
+	0x99, 0xf0, 0xb0, 0x7b, 0xed, 0x4a, 0x1e, 0x5d, 0xbb, 0xd3, 0xad, 0x53, 0xa8, 0x02, 0x1d, 0x74,
+	0xcd, 0x33, 0xda, 0xa0, 0x1d, 0x03, 0xb0, 0xb2, 0x2b, 0xe3, 0xd3, 0x35, 0xd6, 0xfc, 0x46, 0xe0,
+	0x51, 0x5f, 0x21, 0x47, 0xa9, 0x85, 0xeb, 0x47, 0xec, 0x2d, 0xe4, 0x75, 0x1c, 0x62, 0x9d, 0x34,
+	0x48, 0x6b, 0xff, 0xe8, 0x79, 0x67, 0x5b, 0xdc, 0xd9, 0x12, 0x4e, 0xe2, 0x10, 0x1d, 0x23, 0x65,
+	0x75, 0x28, 0x84, 0x6e, 0xec, 0x07, 0x2e, 0xaf, 0x67, 0x1b, 0xa4, 0x55, 0x76, 0xd6, 0x65, 0xc2,
+	0xcc, 0x50, 0x45, 0x22, 0x90, 0xf5, 0x5c, 0x83, 0xb4, 0x2a, 0xce, 0xba, 0x64, 0x2f, 0xa0, 0xe2,
+	0xf9, 0x02, 0xa5, 0x3e, 0x4f, 0xf9, 0x7c, 0x83, 0xb4, 0x4a, 0xce, 0x2e, 0xd8, 0xd4, 0x50, 0x1b,
+	0xad, 0xae, 0x1a, 0x8b, 0x2b, 0x89, 0x7c, 0x84, 0xa8, 0x86, 0x3c, 0x62, 0xcf, 0xa0, 0x28, 0x8c,
+	0x11, 0x1d, 0x1b, 0xa3, 0x65, 0xe7, 0xa1, 0x66, 0x0c, 0xf2, 0x91, 0xb8, 0x92, 0xa9, 0x15, 0x73,
+	0x66, 0xaf, 0xa0, 0xca, 0x31, 0x09, 0xab, 0x8f, 0x4a, 0x8b, 0x4b, 0xe1, 0xb9, 0x1a, 0x8d, 0xa3,
+	0xb2, 0xf3, 0x2f, 0xd1, 0x7c, 0x03, 0xb9, 0x9e, 0x37, 0x65, 0x2f, 0xc1, 0x42, 0xa5, 0x02, 0x95,
+	0x46, 0x71, 0xb0, 0x1b, 0xc5, 0x49, 0x42, 0x39, 0x2b, 0x45, 0xf3, 0x03, 0x58, 0x23, 0x93, 0xed,
+	0x6b, 0xb0, 0x4c, 0xc8, 0x69, 0xcf, 0xe1, 0x6e, 0x8f, 0xd1, 0x98, 0xe0, 0x56, 0xaa, 0xf6, 0x7b,
+	0x78, 0xfc, 0x57, 0xa4, 0x6c, 0x1f, 0x60, 0x3c, 0x15, 0xe1, 0x39, 0x2a, 0x71, 0x19, 0xd3, 0x0c,
+	0xab, 0x42, 0x65, 0x67, 0x77, 0x4a, 0xda, 0xdf, 0x09, 0x58, 0x66, 0x3c, 0x2b, 0x42, 0xfe, 0xec,
+	0xd6, 0xf7, 0x69, 0x26, 0x69, 0xfb, 0x24, 0xf1, 0x2e, 0x44, 0x4f, 0x23, 0xa7, 0x84, 0x3d, 0x05,
+	0x36, 0x94, 0x33, 0xd7, 0x17, 0x7c, 0x6b, 0x00, 0xcd, 0xb2, 0x27, 0x50, 0xdd, 0xe8, 0xd2, 0x6c,
+	0x69, 0x8e, 0xd5, 0xa1, 0xb6, 0x99, 0x7a, 0x16, 0xe8, 0x9e, 0xef, 0x07, 0x5f, 0x90, 0xd3, 0x3c,
+	0xab, 0x01, 0x1d, 0xa0, 0xcb, 0x7d, 0x21, 0xf1, 0xe4, 0xce, 0x43, 0xe4, 0xc8, 0xa9, 0xc5, 0x0e,
+	0xe1, 0x60, 0x28, 0xbd, 0xe0, 0x26, 0x74, 0xb5, 0xb8, 0xf0, 0x31, 0xfd, 0x5e, 0x74, 0x2f, 0xb9,
+	0x7f, 0x9b, 0x30, 0x1b, 0xd3, 0x42, 0xfb, 0x23, 0x94, 0x1e, 0x96, 0x4f, 0x5c, 0x0f, 0x9c, 0x51,
+	0x7f, 0xe5, 0xfa, 0x38, 0xd6, 0x38, 0xd6, 0x0a, 0xdd, 0x1b, 0x4a, 0x58, 0x09, 0xac, 0xd3, 0xc9,
+	0x64, 0x74, 0x44, 0xb3, 0xcd, 0x52, 0xf1, 0x77, 0x81, 0x2e, 0x97, 0xcb, 0x65, 0xe1, 0x78, 0xf0,
+	0x63, 0x6e, 0x93, 0xfb, 0xb9, 0x4d, 0x7e, 0xcd, 0x6d, 0xf2, 0x75, 0x61, 0x67, 0xee, 0x17, 0x76,
+	0xe6, 0xe7, 0xc2, 0xce, 0x7c, 0x6e, 0xff, 0xff, 0xdf, 0x70, 0xb1, 0x67, 0x5e, 0xef, 0xfe, 0x0c,
+	0x00, 0x9b, 0x61, 0xbe, 0x91, 0x42, 0x03, 0x00, 0x00,
 }
 
 func (m *Credentials) Marshal() (dAtA []byte, err error) {
@@ -470,6 +492,13 @@ func (m *PayloadSignedPeerIds) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeviceCertificate) > 0 {
+		i -= len(m.DeviceCertificate)
+		copy(dAtA[i:], m.DeviceCertificate)
+		i = encodeVarintHandshake(dAtA, i, uint64(len(m.DeviceCertificate)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Sign) > 0 {
 		i -= len(m.Sign)
 		copy(dAtA[i:], m.Sign)
@@ -591,6 +620,10 @@ func (m *PayloadSignedPeerIds) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovHandshake(uint64(l))
 	}
+	l = len(m.DeviceCertificate)
+	if l > 0 {
+		n += 1 + l + sovHandshake(uint64(l))
+	}
 	return n
 }
 
@@ -875,6 +908,40 @@ func (m *PayloadSignedPeerIds) Unmarshal(dAtA []byte) error {
 				m.Sign = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceCertificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceCertificate = append(m.DeviceCertificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceCertificate == nil {
+				m.DeviceCertificate = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandshake(dAtA[iNdEx:])
@@ -7,6 +7,15 @@ import (
 	"net"
 )
 
+// ProtoTypeApplicationMin is the first proto type of the range reserved for the application defined protocols,
+// any-sync defines its own types below it
+const ProtoTypeApplicationMin handshakeproto.ProtoType = 1000
+
+// IsApplicationProtoType checks if the proto type belongs to the range reserved for the applications
+func IsApplicationProtoType(pt handshakeproto.ProtoType) bool {
+	return pt >= ProtoTypeApplicationMin
+}
+
 type ProtoChecker struct {
 	AllowedProtoTypes []handshakeproto.ProtoType
 }
@@ -32,13 +32,15 @@ var (
 	// ProtoVersion 5 - sync with no entry space
 	// ProtoVersion 6 - sync with key value messages
 	// ProtoVersion 7 - sync with new invites
-	CompatibleVersion = uint32(5)
-	ProtoVersion      = uint32(6)
-	NewInvitesVersion = uint32(7)
+	// ProtoVersion 8 - device certificates
+	CompatibleVersion        = uint32(5)
+	ProtoVersion             = uint32(6)
+	NewInvitesVersion        = uint32(7)
+	DeviceCertificateVersion = uint32(8)
 )
 
 var (
-	compatibleVersions = []uint32{CompatibleVersion, ProtoVersion, NewInvitesVersion}
+	compatibleVersions = []uint32{CompatibleVersion, ProtoVersion, NewInvitesVersion, DeviceCertificateVersion}
 )
 
 func New() SecureService {
@@ -81,11 +83,8 @@ func (s *secureService) Init(a *app.App) (err error) {
 		conf = cg.GetSecureService()
 	}
 
-	peerKey, err := account.Account().PeerKey.Raw()
-	if err != nil {
-		return
-	}
-	if s.key, err = crypto.UnmarshalEd25519PrivateKey(peerKey); err != nil {
+	// the peer key can be backed by the external signer, so it is not unmarshalled from the raw bytes
+	if s.key, err = account.Account().PeerKey.LibP2P(); err != nil {
 		return
 	}
 	s.noVerifyChecker = newNoVerifyChecker(s.protoVersion, s.compatibleVersions, a.VersionName())
@@ -130,6 +129,9 @@ func (s *secureService) HandshakeInbound(ctx context.Context, conn io.ReadWriteC
 	cctx = context.Background()
 	cctx = peer.CtxWithPeerId(cctx, peerId)
 	cctx = peer.CtxWithIdentity(cctx, res.Identity)
+	if res.DeviceKey != nil {
+		cctx = peer.CtxWithDeviceKey(cctx, res.DeviceKey)
+	}
 	cctx = peer.CtxWithClientVersion(cctx, res.ClientVersion)
 	cctx = peer.CtxWithProtoVersion(cctx, res.ProtoVersion)
 	return
@@ -158,6 +160,9 @@ func (s *secureService) HandshakeOutbound(ctx context.Context, conn io.ReadWrite
 	cctx = context.Background()
 	cctx = peer.CtxWithPeerId(cctx, peerId)
 	cctx = peer.CtxWithIdentity(cctx, res.Identity)
+	if res.DeviceKey != nil {
+		cctx = peer.CtxWithDeviceKey(cctx, res.DeviceKey)
+	}
 	cctx = peer.CtxWithClientVersion(cctx, res.ClientVersion)
 	cctx = peer.CtxWithProtoVersion(cctx, res.ProtoVersion)
 	return cctx, nil
@@ -5,15 +5,17 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func NewTransportComponent(ctrl *gomock.Controller, name string) TransportComponent {
+func NewTransportComponent(ctrl *gomock.Controller, name, scheme string) TransportComponent {
 	return TransportComponent{
 		CName:         name,
+		scheme:        scheme,
 		MockTransport: NewMockTransport(ctrl),
 	}
 }
 
 type TransportComponent struct {
-	CName string
+	CName  string
+	scheme string
 	*MockTransport
 }
 
@@ -24,3 +26,7 @@ func (t TransportComponent) Init(a *app.App) (err error) {
 func (t TransportComponent) Name() (name string) {
 	return t.CName
 }
+
+func (t TransportComponent) Scheme() string {
+	return t.scheme
+}
@@ -30,7 +30,7 @@ func New() Quic {
 
 type Quic interface {
 	ListenAddrs(ctx context.Context, addrs ...string) (listenAddrs []net.Addr, err error)
-	transport.Transport
+	transport.SchemeTransport
 	app.ComponentRunnable
 }
 
@@ -66,6 +66,10 @@ func (q *quicTransport) Name() (name string) {
 	return CName
 }
 
+func (q *quicTransport) Scheme() string {
+	return transport.Quic
+}
+
 func (q *quicTransport) SetAccepter(accepter transport.Accepter) {
 	q.accepter = accepter
 }
@@ -12,8 +12,11 @@ var (
 )
 
 const (
-	Yamux = "yamux"
-	Quic  = "quic"
+	Yamux     = "yamux"
+	Quic      = "quic"
+	Websocket = "ws"
+	// WebsocketSecure is the websocket over tls
+	WebsocketSecure = "wss"
 )
 
 // Transport is a common interface for a network transport
@@ -25,6 +28,14 @@ type Transport interface {
 	Dial(ctx context.Context, addr string) (mc MultiConn, err error)
 }
 
+// SchemeTransport is a transport serving the addresses with the given scheme, like "quic://host:port".
+// All the app components implementing it are registered by the peer service
+type SchemeTransport interface {
+	Transport
+	// Scheme returns the address scheme of the transport
+	Scheme() string
+}
+
 // MultiConn is an object of multiplexing connection containing handshake info
 type MultiConn interface {
 	// Context returns the connection context that contains handshake details
@@ -0,0 +1,60 @@
+package websocket
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	"github.com/hashicorp/yamux"
+
+	"github.com/anyproto/any-sync/net/connutil"
+	"github.com/anyproto/any-sync/net/peer"
+	"github.com/anyproto/any-sync/net/transport"
+)
+
+func newConn(cctx context.Context, luConn *connutil.LastUsageConn, scheme, addr string, sess *yamux.Session) *wsConn {
+	cctx = peer.CtxWithPeerAddr(cctx, scheme+"://"+addr)
+	return &wsConn{
+		ctx:     cctx,
+		luConn:  luConn,
+		scheme:  scheme,
+		addr:    addr,
+		Session: sess,
+	}
+}
+
+// wsConn multiplexes the sub connections with yamux over the single websocket connection
+type wsConn struct {
+	ctx    context.Context
+	luConn *connutil.LastUsageConn
+	scheme string
+	addr   string
+	*yamux.Session
+}
+
+func (w *wsConn) Open(ctx context.Context) (conn net.Conn, err error) {
+	return w.Session.Open()
+}
+
+func (w *wsConn) LastUsage() time.Time {
+	return w.luConn.LastUsage()
+}
+
+func (w *wsConn) Context() context.Context {
+	return w.ctx
+}
+
+func (w *wsConn) Addr() string {
+	return w.scheme + "://" + w.addr
+}
+
+func (w *wsConn) Accept() (conn net.Conn, err error) {
+	if conn, err = w.Session.Accept(); err != nil {
+		if err == yamux.ErrSessionShutdown || err == io.EOF {
+			err = transport.ErrConnClosed
+		}
+		return
+	}
+	return
+}
@@ -0,0 +1,374 @@
+// Package websocket implements transport.Transport over the websocket protocol for the networks where
+// only http traffic is allowed. Connections are secured by secureservice and multiplexed with yamux,
+// the addresses have the "ws" scheme, e.g. "ws://host:port/path", or the "wss" one for the websocket over tls.
+// The outgoing connections go through the http proxy when it is configured.
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/yamux"
+	"go.uber.org/zap"
+	xwebsocket "golang.org/x/net/websocket"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/net/connutil"
+	"github.com/anyproto/any-sync/net/secureservice"
+	"github.com/anyproto/any-sync/net/transport"
+)
+
+const (
+	CName       = "net.transport.websocket"
+	CNameSecure = "net.transport.websocket.secure"
+)
+
+var log = logger.NewNamed(CName)
+
+// New creates the transport for the "ws" addresses
+func New() Websocket {
+	return &wsTransport{name: CName, scheme: transport.Websocket}
+}
+
+// NewSecure creates the transport for the "wss" addresses, it listens to Config.TLSListenAddrs
+func NewSecure() Websocket {
+	return &wsTransport{name: CNameSecure, scheme: transport.WebsocketSecure}
+}
+
+// Websocket implements transport.Transport with websocket+yamux
+type Websocket interface {
+	transport.SchemeTransport
+	// ListenAddrs returns the addresses of the running listeners
+	ListenAddrs() []net.Addr
+	app.ComponentRunnable
+}
+
+type wsTransport struct {
+	name     string
+	scheme   string
+	secure   secureservice.SecureService
+	accepter transport.Accepter
+	conf     Config
+
+	proxy   func(*http.Request) (*url.URL, error)
+	tlsConf *tls.Config
+	// dialTLSConf is the base tls config of the outgoing connections, the system roots are used when it's nil
+	dialTLSConf *tls.Config
+	servers     []*http.Server
+	listeners   []net.Listener
+	// conns are the accepted connections, they are hijacked from the http servers, so they are closed by the transport
+	conns     map[*wsConn]struct{}
+	yamuxConf *yamux.Config
+	closed    bool
+	mu        sync.Mutex
+}
+
+func (w *wsTransport) Init(a *app.App) (err error) {
+	w.secure = a.MustComponent(secureservice.CName).(secureservice.SecureService)
+	w.conf = a.MustComponent("config").(configGetter).GetWebsocket()
+	if w.conf.DialTimeoutSec <= 0 {
+		w.conf.DialTimeoutSec = 10
+	}
+	if w.conf.WriteTimeoutSec <= 0 {
+		w.conf.WriteTimeoutSec = 10
+	}
+	if w.conf.Path == "" {
+		w.conf.Path = "/"
+	}
+	w.proxy = http.ProxyFromEnvironment
+	if w.conf.Proxy != "" {
+		proxyUrl, err := url.Parse(w.conf.Proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url: %w", err)
+		}
+		w.proxy = http.ProxyURL(proxyUrl)
+	}
+	if w.scheme == transport.WebsocketSecure && len(w.conf.TLSListenAddrs) != 0 {
+		cert, err := tls.LoadX509KeyPair(w.conf.TLSCertFile, w.conf.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("can't load tls certificate: %w", err)
+		}
+		w.tlsConf = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+	w.conns = map[*wsConn]struct{}{}
+
+	w.yamuxConf = yamux.DefaultConfig()
+	if w.conf.KeepAlivePeriodSec < 0 {
+		w.yamuxConf.EnableKeepAlive = false
+	} else {
+		w.yamuxConf.EnableKeepAlive = true
+		if w.conf.KeepAlivePeriodSec != 0 {
+			w.yamuxConf.KeepAliveInterval = time.Duration(w.conf.KeepAlivePeriodSec) * time.Second
+		}
+	}
+	w.yamuxConf.StreamOpenTimeout = time.Duration(w.conf.DialTimeoutSec) * time.Second
+	w.yamuxConf.ConnectionWriteTimeout = time.Duration(w.conf.WriteTimeoutSec) * time.Second
+	return
+}
+
+func (w *wsTransport) Name() string {
+	return w.name
+}
+
+func (w *wsTransport) Scheme() string {
+	return w.scheme
+}
+
+func (w *wsTransport) Run(ctx context.Context) (err error) {
+	if w.accepter == nil {
+		return fmt.Errorf("can't run service without accepter")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	listenAddrs := w.conf.ListenAddrs
+	if w.scheme == transport.WebsocketSecure {
+		listenAddrs = w.conf.TLSListenAddrs
+	}
+	for _, listAddr := range listenAddrs {
+		list, err := net.Listen("tcp", listAddr)
+		if err != nil {
+			return err
+		}
+		if w.tlsConf != nil {
+			list = tls.NewListener(list, w.tlsConf)
+		}
+		w.listeners = append(w.listeners, list)
+	}
+	mux := http.NewServeMux()
+	// the server doesn't check the origin, the peers are authenticated by the secure handshake
+	mux.Handle(w.conf.Path, xwebsocket.Server{Handler: w.serveConn})
+	for _, list := range w.listeners {
+		srv := &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: time.Duration(w.conf.DialTimeoutSec) * time.Second,
+		}
+		w.servers = append(w.servers, srv)
+		go w.serve(srv, list)
+	}
+	return
+}
+
+func (w *wsTransport) ListenAddrs() (addrs []net.Addr) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, list := range w.listeners {
+		addrs = append(addrs, list.Addr())
+	}
+	return
+}
+
+func (w *wsTransport) SetAccepter(accepter transport.Accepter) {
+	w.accepter = accepter
+}
+
+func (w *wsTransport) Dial(ctx context.Context, addr string) (mc transport.MultiConn, err error) {
+	dialTimeout := time.Duration(w.conf.DialTimeoutSec) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	if !strings.Contains(addr, "/") {
+		addr += w.conf.Path
+	}
+	origin := "http://"
+	if w.scheme == transport.WebsocketSecure {
+		origin = "https://"
+	}
+	wsConf, err := xwebsocket.NewConfig(w.scheme+"://"+addr, origin+addr)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := w.dialWebsocket(ctx, wsConf, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	ws.PayloadType = xwebsocket.BinaryFrame
+	cctx, err := w.secure.SecureOutbound(ctx, ws)
+	if err != nil {
+		_ = ws.Close()
+		return nil, err
+	}
+	luc := connutil.NewLastUsageConn(connutil.NewTimeout(ws, time.Duration(w.conf.WriteTimeoutSec)*time.Second))
+	sess, err := yamux.Client(luc, w.yamuxConf)
+	if err != nil {
+		_ = ws.Close()
+		return
+	}
+	return newConn(cctx, luc, w.scheme, addr, sess), nil
+}
+
+// dialWebsocket connects to the websocket endpoint directly or through the http proxy with the CONNECT method
+func (w *wsTransport) dialWebsocket(ctx context.Context, wsConf *xwebsocket.Config, dialTimeout time.Duration) (ws *xwebsocket.Conn, err error) {
+	host := wsConf.Location.Host
+	if wsConf.Location.Port() == "" {
+		if w.scheme == transport.WebsocketSecure {
+			host = net.JoinHostPort(host, "443")
+		} else {
+			host = net.JoinHostPort(host, "80")
+		}
+	}
+	proxyUrl, err := w.proxy(&http.Request{URL: &url.URL{Scheme: wsConf.Origin.Scheme, Host: host}})
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	if proxyUrl == nil {
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	} else {
+		conn, err = dialProxy(ctx, dialer, proxyUrl, host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the handshakes below don't take the context, so its deadline is set to the connection
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if w.scheme == transport.WebsocketSecure {
+		tlsConf := &tls.Config{}
+		if w.dialTLSConf != nil {
+			tlsConf = w.dialTLSConf.Clone()
+		}
+		tlsConf.ServerName = wsConf.Location.Hostname()
+		tlsConn := tls.Client(conn, tlsConf)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+	if ws, err = xwebsocket.NewClient(wsConf, conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ws, nil
+}
+
+// dialProxy opens the tunnel to addr with the CONNECT request to the http proxy
+func dialProxy(ctx context.Context, dialer *net.Dialer, proxyUrl *url.URL, addr string) (conn net.Conn, err error) {
+	proxyAddr := proxyUrl.Host
+	if proxyUrl.Port() == "" {
+		proxyAddr = net.JoinHostPort(proxyAddr, "80")
+	}
+	if conn, err = dialer.DialContext(ctx, "tcp", proxyAddr); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = conn.Close()
+		}
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if proxyUrl.User != nil {
+		password, _ := proxyUrl.User.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(proxyUrl.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err = req.Write(conn); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy connect error: %s", resp.Status)
+	}
+	if br.Buffered() != 0 {
+		return nil, fmt.Errorf("unexpected data from proxy")
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+func (w *wsTransport) serve(srv *http.Server, list net.Listener) {
+	l := log.With(zap.String("localAddr", list.Addr().String()))
+	l.Info("websocket listener started")
+	if err := srv.Serve(list); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		l.Error("listener closed with error", zap.Error(err))
+	} else {
+		l.Info("listener closed")
+	}
+}
+
+// serveConn handles the incoming websocket connection, the connection is closed by the server when it returns
+func (w *wsTransport) serveConn(ws *xwebsocket.Conn) {
+	ws.PayloadType = xwebsocket.BinaryFrame
+	remoteAddr := ws.Request().RemoteAddr
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(w.conf.DialTimeoutSec)*time.Second)
+	cctx, err := w.secure.SecureInbound(ctx, ws)
+	cancel()
+	if err != nil {
+		log.Info("incoming connection handshake error", zap.Error(err), zap.String("remoteAddr", remoteAddr))
+		return
+	}
+	luc := connutil.NewLastUsageConn(connutil.NewTimeout(ws, time.Duration(w.conf.WriteTimeoutSec)*time.Second))
+	sess, err := yamux.Server(luc, w.yamuxConf)
+	if err != nil {
+		log.Info("incoming connection yamux session error", zap.Error(err), zap.String("remoteAddr", remoteAddr))
+		return
+	}
+	mc := newConn(cctx, luc, w.scheme, remoteAddr, sess)
+	if !w.addConn(mc) {
+		_ = mc.Close()
+		return
+	}
+	defer w.removeConn(mc)
+	if err = w.accepter.Accept(mc); err != nil {
+		log.Info("connection accept error", zap.Error(err), zap.String("remoteAddr", remoteAddr))
+		_ = mc.Close()
+		return
+	}
+	<-mc.CloseChan()
+}
+
+func (w *wsTransport) addConn(mc *wsConn) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return false
+	}
+	w.conns[mc] = struct{}{}
+	return true
+}
+
+func (w *wsTransport) removeConn(mc *wsConn) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.conns, mc)
+}
+
+func (w *wsTransport) Close(ctx context.Context) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	for _, srv := range w.servers {
+		_ = srv.Close()
+	}
+	// http.Server.Close doesn't close the hijacked connections
+	for mc := range w.conns {
+		_ = mc.Close()
+	}
+	return
+}
@@ -0,0 +1,301 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/net/peer"
+	"github.com/anyproto/any-sync/net/secureservice"
+	"github.com/anyproto/any-sync/net/transport"
+	"github.com/anyproto/any-sync/nodeconf"
+	"github.com/anyproto/any-sync/nodeconf/mock_nodeconf"
+	"github.com/anyproto/any-sync/testutil/accounttest"
+	"github.com/anyproto/any-sync/testutil/testnodeconf"
+)
+
+var ctx = context.Background()
+
+func TestWebsocketTransport_Dial(t *testing.T) {
+	fxS := newFixture(t)
+	defer fxS.finish(t)
+	fxC := newFixture(t)
+	defer fxC.finish(t)
+
+	mcC, err := fxC.Dial(ctx, fxS.addr)
+	require.NoError(t, err)
+	var mcS transport.MultiConn
+	select {
+	case mcS = <-fxS.accepter.mcs:
+	case <-time.After(time.Second * 5):
+		require.True(t, false, "timeout")
+	}
+
+	serverPeerId, err := peer.CtxPeerId(mcC.Context())
+	require.NoError(t, err)
+	assert.Equal(t, fxS.acc.Account().PeerId, serverPeerId)
+	clientPeerId, err := peer.CtxPeerId(mcS.Context())
+	require.NoError(t, err)
+	assert.Equal(t, fxC.acc.Account().PeerId, clientPeerId)
+	assert.Equal(t, transport.Websocket+"://"+fxS.addr+"/", mcC.Addr())
+
+	var (
+		sData     string
+		acceptErr error
+		copyErr   error
+		done      = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+		conn, serr := mcS.Accept()
+		if serr != nil {
+			acceptErr = serr
+			return
+		}
+		buf := bytes.NewBuffer(nil)
+		_, copyErr = io.Copy(buf, conn)
+		sData = buf.String()
+	}()
+
+	conn, err := mcC.Open(ctx)
+	require.NoError(t, err)
+	data := "some data"
+	_, err = conn.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	<-done
+
+	assert.NoError(t, acceptErr)
+	assert.Equal(t, data, sData)
+	assert.NoError(t, copyErr)
+
+	require.NoError(t, mcC.Close())
+	select {
+	case <-mcS.CloseChan():
+	case <-time.After(time.Second * 5):
+		require.True(t, false, "timeout")
+	}
+}
+
+func TestWebsocketTransport_Close(t *testing.T) {
+	fxS := newFixture(t)
+	fxC := newFixture(t)
+	defer fxC.finish(t)
+
+	mcC, err := fxC.Dial(ctx, fxS.addr)
+	require.NoError(t, err)
+	select {
+	case <-fxS.accepter.mcs:
+	case <-time.After(time.Second * 5):
+		require.True(t, false, "timeout")
+	}
+
+	fxS.finish(t)
+	select {
+	case <-mcC.CloseChan():
+	case <-time.After(time.Second * 5):
+		require.True(t, false, "timeout")
+	}
+}
+
+func TestWebsocketTransport_DialSecure(t *testing.T) {
+	certFile, keyFile, roots := genCert(t)
+	conf := testWsConf()
+	conf.ListenAddrs = nil
+	conf.TLSListenAddrs = []string{"127.0.0.1:0"}
+	conf.TLSCertFile, conf.TLSKeyFile = certFile, keyFile
+	fxS := newFixtureWithConf(t, NewSecure(), conf)
+	defer fxS.finish(t)
+	fxC := newFixtureWithConf(t, NewSecure(), testWsConf())
+	defer fxC.finish(t)
+	assert.Empty(t, fxC.ListenAddrs())
+
+	_, err := fxC.Dial(ctx, fxS.addr)
+	require.Error(t, err, "the certificate is not trusted")
+
+	fxC.dialTLSConf = &tls.Config{RootCAs: roots}
+	mcC, err := fxC.Dial(ctx, fxS.addr)
+	require.NoError(t, err)
+	defer mcC.Close()
+	assert.Equal(t, transport.WebsocketSecure+"://"+fxS.addr+"/", mcC.Addr())
+	select {
+	case mcS := <-fxS.accepter.mcs:
+		clientPeerId, err := peer.CtxPeerId(mcS.Context())
+		require.NoError(t, err)
+		assert.Equal(t, fxC.acc.Account().PeerId, clientPeerId)
+	case <-time.After(time.Second * 5):
+		require.True(t, false, "timeout")
+	}
+}
+
+func TestWebsocketTransport_DialProxy(t *testing.T) {
+	var connects atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect || r.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNz" {
+			rw.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		connects.Add(1)
+		target, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		conn, _, err := rw.(http.Hijacker).Hijack()
+		if err != nil {
+			_ = target.Close()
+			return
+		}
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			_, _ = io.Copy(target, conn)
+			_ = target.Close()
+		}()
+		_, _ = io.Copy(conn, target)
+		_ = conn.Close()
+	}))
+	defer proxy.Close()
+
+	fxS := newFixture(t)
+	defer fxS.finish(t)
+	conf := testWsConf()
+	conf.Proxy = strings.Replace(proxy.URL, "http://", "http://user:pass@", 1)
+	fxC := newFixtureWithConf(t, New(), conf)
+	defer fxC.finish(t)
+
+	mcC, err := fxC.Dial(ctx, fxS.addr)
+	require.NoError(t, err)
+	defer mcC.Close()
+	assert.Equal(t, int32(1), connects.Load())
+	select {
+	case <-fxS.accepter.mcs:
+	case <-time.After(time.Second * 5):
+		require.True(t, false, "timeout")
+	}
+}
+
+func genCert(t *testing.T) (certFile, keyFile string, roots *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	roots = x509.NewCertPool()
+	roots.AddCert(cert)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return
+}
+
+type fixture struct {
+	*wsTransport
+	a            *app.App
+	ctrl         *gomock.Controller
+	mockNodeConf *mock_nodeconf.MockService
+	acc          *accounttest.AccountTestService
+	accepter     *testAccepter
+	addr         string
+}
+
+func newFixture(t *testing.T) *fixture {
+	return newFixtureWithConf(t, New(), testWsConf())
+}
+
+func newFixtureWithConf(t *testing.T, tr Websocket, conf Config) *fixture {
+	fx := &fixture{
+		wsTransport: tr.(*wsTransport),
+		ctrl:        gomock.NewController(t),
+		acc:         &accounttest.AccountTestService{},
+		accepter:    &testAccepter{mcs: make(chan transport.MultiConn, 100)},
+		a:           new(app.App),
+	}
+
+	fx.mockNodeConf = mock_nodeconf.NewMockService(fx.ctrl)
+	fx.mockNodeConf.EXPECT().Init(gomock.Any())
+	fx.mockNodeConf.EXPECT().Name().Return(nodeconf.CName).AnyTimes()
+	fx.mockNodeConf.EXPECT().Run(ctx)
+	fx.mockNodeConf.EXPECT().Close(ctx)
+	fx.mockNodeConf.EXPECT().NodeTypes(gomock.Any()).Return([]nodeconf.NodeType{nodeconf.NodeTypeTree}).AnyTimes()
+	fx.a.Register(fx.acc).Register(&testConf{Config: testnodeconf.GenNodeConfig(1), ws: conf}).Register(fx.mockNodeConf).Register(secureservice.New()).Register(fx.wsTransport).Register(fx.accepter)
+	require.NoError(t, fx.a.Start(ctx))
+	if addrs := fx.ListenAddrs(); len(addrs) != 0 {
+		fx.addr = addrs[0].String()
+	}
+	return fx
+}
+
+func (fx *fixture) finish(t *testing.T) {
+	require.NoError(t, fx.a.Close(ctx))
+	fx.ctrl.Finish()
+}
+
+func testWsConf() Config {
+	return Config{
+		ListenAddrs:     []string{"127.0.0.1:0"},
+		WriteTimeoutSec: 10,
+		DialTimeoutSec:  10,
+	}
+}
+
+type testConf struct {
+	*testnodeconf.Config
+	ws Config
+}
+
+func (c *testConf) GetWebsocket() Config {
+	return c.ws
+}
+
+type testAccepter struct {
+	err error
+	mcs chan transport.MultiConn
+}
+
+func (t *testAccepter) Accept(mc transport.MultiConn) (err error) {
+	t.mcs <- mc
+	return t.err
+}
+
+func (t *testAccepter) Init(a *app.App) (err error) {
+	app.MustComponent[Websocket](a).SetAccepter(t)
+	return nil
+}
+
+func (t *testAccepter) Name() (name string) { return "testAccepter" }
@@ -26,7 +26,7 @@ func New() Yamux {
 
 // Yamux implements transport.Transport with tcp+yamux
 type Yamux interface {
-	transport.Transport
+	transport.SchemeTransport
 	AddListener(lis net.Listener)
 	app.ComponentRunnable
 }
@@ -72,6 +72,10 @@ func (y *yamuxTransport) Name() string {
 	return CName
 }
 
+func (y *yamuxTransport) Scheme() string {
+	return transport.Yamux
+}
+
 func (y *yamuxTransport) Run(ctx context.Context) (err error) {
 	if y.accepter == nil {
 		return fmt.Errorf("can't run service without accepter")
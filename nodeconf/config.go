@@ -13,6 +13,12 @@ type ConfigUpdateGetter interface {
 	GetNodeConfUpdateInterval() int
 }
 
+// ConfigSignatureGetter enables the strict mode, when only the configurations signed by the network key are accepted.
+// Without it the strict mode is enabled as soon as the signed configuration of the network is seen
+type ConfigSignatureGetter interface {
+	GetNodeConfRequireSignature() bool
+}
+
 var (
 	ErrConfigurationNotFound = errors.New("node nodeConf not found")
 )
@@ -33,6 +39,8 @@ type Node struct {
 	PeerId    string     `yaml:"peerId" bson:"peerId"`
 	Addresses []string   `yaml:"addresses" bson:"addresses"`
 	Types     []NodeType `yaml:"types,omitempty" bson:"types"`
+	// NodeCapacity is the relative capacity of the tree node, the node gets partitions in proportion to it
+	NodeCapacity float64 `yaml:"capacity,omitempty" bson:"capacity,omitempty"`
 }
 
 func (n Node) Id() string {
@@ -40,7 +48,10 @@ func (n Node) Id() string {
 }
 
 func (n Node) Capacity() float64 {
-	return 1
+	if n.NodeCapacity <= 0 {
+		return 1
+	}
+	return n.NodeCapacity
 }
 
 func (n Node) HasType(t NodeType) bool {
@@ -57,4 +68,8 @@ type Configuration struct {
 	NetworkId    string    `yaml:"networkId"`
 	Nodes        []Node    `yaml:"nodes"`
 	CreationTime time.Time `yaml:"creationTime"`
+	// Version grows with every new configuration of the network, the older versions are rejected
+	Version uint64 `yaml:"version,omitempty"`
+	// Signature is made by the network key over SignedPayload
+	Signature []byte `yaml:"signature,omitempty"`
 }
@@ -174,3 +174,88 @@ func (t testMember) Id() string {
 func (t testMember) Capacity() float64 {
 	return 1
 }
+
+func testTreeConfiguration(capacities ...float64) Configuration {
+	var conf Configuration
+	for i, capacity := range capacities {
+		conf.Nodes = append(conf.Nodes, Node{
+			PeerId:       fmt.Sprint("tree", i+1),
+			Types:        []NodeType{NodeTypeTree},
+			NodeCapacity: capacity,
+		})
+	}
+	return conf
+}
+
+func TestNode_Capacity(t *testing.T) {
+	var conf Configuration
+	require.NoError(t, yaml.Unmarshal([]byte(`
+nodes:
+  - peerId: tree1
+    types:
+      - tree
+  - peerId: tree2
+    types:
+      - tree
+    capacity: 2.5
+`), &conf))
+	assert.Equal(t, float64(1), conf.Nodes[0].Capacity())
+	assert.Equal(t, 2.5, conf.Nodes[1].Capacity())
+
+	nodeConf, err := сonfigurationToNodeConf(testTreeConfiguration(1, 1, 1, 1, 2))
+	require.NoError(t, err)
+	partitions := map[string]int{}
+	for part := 0; part < PartitionCount; part++ {
+		ids, err := partitionMemberIds(nodeConf.chash, part)
+		require.NoError(t, err)
+		for _, id := range ids {
+			partitions[id]++
+		}
+	}
+	// the node with the double capacity gets more partitions than any other
+	for _, id := range []string{"tree1", "tree2", "tree3", "tree4"} {
+		assert.Greater(t, partitions["tree5"], partitions[id]*3/2)
+	}
+}
+
+func TestDiffConfigurations(t *testing.T) {
+	t.Run("same configuration", func(t *testing.T) {
+		plan, err := DiffConfigurations(testTreeConfiguration(1, 1, 1, 1), testTreeConfiguration(1, 1, 1, 1))
+		require.NoError(t, err)
+		assert.Empty(t, plan.Moves)
+		assert.Empty(t, plan.SpaceMoves([]string{"space.1", "space.2"}))
+	})
+	t.Run("node added", func(t *testing.T) {
+		plan, err := DiffConfigurations(testTreeConfiguration(1, 1, 1, 1), testTreeConfiguration(1, 1, 1, 1, 2))
+		require.NoError(t, err)
+		require.NotEmpty(t, plan.Moves)
+		added, removed := plan.NodePartitions()
+		assert.NotContains(t, removed, "tree5")
+		assert.Greater(t, added["tree5"], 0)
+
+		fromConf, err := сonfigurationToNodeConf(testTreeConfiguration(1, 1, 1, 1))
+		require.NoError(t, err)
+		toConf, err := сonfigurationToNodeConf(testTreeConfiguration(1, 1, 1, 1, 2))
+		require.NoError(t, err)
+		var spaceIds []string
+		for i := 0; i < 100; i++ {
+			spaceIds = append(spaceIds, fmt.Sprintf("space.%d", i))
+		}
+		moves := plan.SpaceMoves(spaceIds)
+		require.NotEmpty(t, moves)
+		for _, move := range moves {
+			fromIds, err := partitionMemberIds(fromConf.chash, fromConf.Partition(move.SpaceId))
+			require.NoError(t, err)
+			toIds, err := partitionMemberIds(toConf.chash, toConf.Partition(move.SpaceId))
+			require.NoError(t, err)
+			for _, id := range move.From {
+				assert.Contains(t, fromIds, id)
+				assert.NotContains(t, toIds, id)
+			}
+			for _, id := range move.To {
+				assert.NotContains(t, fromIds, id)
+				assert.Contains(t, toIds, id)
+			}
+		}
+	})
+}
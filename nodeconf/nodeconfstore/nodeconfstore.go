@@ -18,6 +18,7 @@ func New() NodeConfStore {
 type NodeConfStore interface {
 	app.Component
 	nodeconf.Store
+	nodeconf.SignedMarkStore
 }
 
 type nodeConfStore struct {
@@ -64,3 +65,19 @@ func (n *nodeConfStore) SaveLast(ctx context.Context, c nodeconf.Configuration)
 	}
 	return os.WriteFile(path, data, 0o644)
 }
+
+func (n *nodeConfStore) IsSigned(ctx context.Context, netId string) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, err := os.Stat(filepath.Join(n.path, netId+".signed"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (n *nodeConfStore) MarkSigned(ctx context.Context, netId string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return os.WriteFile(filepath.Join(n.path, netId+".signed"), nil, 0o644)
+}
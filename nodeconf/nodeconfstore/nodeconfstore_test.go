@@ -35,6 +35,8 @@ func TestNodeConfStore_GetLast(t *testing.T) {
 				},
 			},
 			CreationTime: time.Now().Round(time.Second),
+			Version:      3,
+			Signature:    []byte{0, 1, 2, 0xff},
 		}
 		require.NoError(t, fx.SaveLast(ctx, c))
 
@@ -85,3 +87,15 @@ func (c config) Init(a *app.App) (err error) {
 func (c config) Name() (name string) {
 	return "config"
 }
+
+func TestNodeConfStore_MarkSigned(t *testing.T) {
+	fx := newFixture(t)
+	defer fx.finish(t)
+	signed, err := fx.IsSigned(ctx, "456")
+	require.NoError(t, err)
+	assert.False(t, signed)
+	require.NoError(t, fx.MarkSigned(ctx, "456"))
+	signed, err = fx.IsSigned(ctx, "456")
+	require.NoError(t, err)
+	assert.True(t, signed)
+}
@@ -0,0 +1,108 @@
+package nodeconf
+
+import (
+	"slices"
+
+	"github.com/anyproto/go-chash"
+)
+
+// PartitionMove describes how the tree nodes responsible for the partition change
+type PartitionMove struct {
+	Partition int
+	// From are the nodes which stop being responsible for the partition
+	From []string
+	// To are the nodes which become responsible for the partition
+	To []string
+}
+
+// SpaceMove describes how the tree nodes responsible for the space change
+type SpaceMove struct {
+	SpaceId string
+	PartitionMove
+}
+
+// RebalancePlan is the difference of the partitions distribution between two configurations
+type RebalancePlan struct {
+	Moves []PartitionMove
+	chash chash.CHash
+	moves map[int]int
+}
+
+// DiffConfigurations calculates which partitions change their tree nodes when the configuration is replaced
+func DiffConfigurations(from, to Configuration) (plan RebalancePlan, err error) {
+	fromConf, err := сonfigurationToNodeConf(from)
+	if err != nil {
+		return
+	}
+	toConf, err := сonfigurationToNodeConf(to)
+	if err != nil {
+		return
+	}
+	plan.chash = toConf.chash
+	plan.moves = make(map[int]int)
+	for part := 0; part < toConf.chash.PartitionCount(); part++ {
+		fromIds, err := partitionMemberIds(fromConf.chash, part)
+		if err != nil {
+			return plan, err
+		}
+		toIds, err := partitionMemberIds(toConf.chash, part)
+		if err != nil {
+			return plan, err
+		}
+		move := PartitionMove{Partition: part}
+		for _, id := range fromIds {
+			if !slices.Contains(toIds, id) {
+				move.From = append(move.From, id)
+			}
+		}
+		for _, id := range toIds {
+			if !slices.Contains(fromIds, id) {
+				move.To = append(move.To, id)
+			}
+		}
+		if len(move.From) != 0 || len(move.To) != 0 {
+			plan.moves[part] = len(plan.Moves)
+			plan.Moves = append(plan.Moves, move)
+		}
+	}
+	return
+}
+
+// SpaceMoves returns the moves of the given spaces, the spaces which stay on the same nodes are skipped
+func (p RebalancePlan) SpaceMoves(spaceIds []string) (moves []SpaceMove) {
+	if p.chash == nil {
+		return nil
+	}
+	for _, spaceId := range spaceIds {
+		if idx, ok := p.moves[p.chash.GetPartition(ReplKey(spaceId))]; ok {
+			moves = append(moves, SpaceMove{SpaceId: spaceId, PartitionMove: p.Moves[idx]})
+		}
+	}
+	return
+}
+
+// NodePartitions returns the number of partitions each node gains and loses
+func (p RebalancePlan) NodePartitions() (added, removed map[string]int) {
+	added, removed = make(map[string]int), make(map[string]int)
+	for _, move := range p.Moves {
+		for _, id := range move.To {
+			added[id]++
+		}
+		for _, id := range move.From {
+			removed[id]++
+		}
+	}
+	return
+}
+
+func partitionMemberIds(ch chash.CHash, part int) (ids []string, err error) {
+	members, err := ch.GetPartitionMembers(part)
+	if err != nil {
+		return
+	}
+	ids = make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Id())
+	}
+	return
+}
@@ -59,6 +59,8 @@ type service struct {
 	mu        sync.RWMutex
 	sync      periodicsync.PeriodicSync
 
+	requireSignature bool
+
 	compatibilityStatus        NetworkCompatibilityStatus
 	networkProtoVersionChecker NetworkProtoVersionChecker
 }
@@ -114,17 +116,44 @@ func (s *service) Init(a *app.App) (err error) {
 	s.accountId = a.MustComponent(commonaccount.CName).(commonaccount.Service).Account().PeerId
 	s.source = a.MustComponent(CNameSource).(Source)
 	s.store = a.MustComponent(CNameStore).(Store)
+	if confSign, ok := a.MustComponent("config").(ConfigSignatureGetter); ok {
+		s.requireSignature = confSign.GetNodeConfRequireSignature()
+	}
+	if len(s.config.Signature) != 0 {
+		s.requireSignature = true
+	} else if markStore, ok := s.store.(SignedMarkStore); ok && !s.requireSignature {
+		if s.requireSignature, err = markStore.IsSigned(context.Background(), s.config.NetworkId); err != nil {
+			return
+		}
+	}
 	lastStored, err := s.store.GetLast(context.Background(), s.config.NetworkId)
 	if errors.Is(err, ErrConfigurationNotFound) {
 		lastStored = s.config
 		err = nil
+	} else if checkErr := checkConfiguration(s.config, lastStored, s.requireSignature); checkErr != nil {
+		// the app config is trusted, so it replaces the stored configuration which can't be verified
+		log.Warn("stored configuration is rejected", zap.String("id", lastStored.Id), zap.Error(checkErr))
+		lastStored = s.config
+		err = nil
 	} else {
+		if len(lastStored.Signature) != 0 {
+			if err = s.markSigned(context.Background()); err != nil {
+				return
+			}
+		}
 		// merge coordinator nodes from app config to lasStored to have up-to-date coordinator
 		mustRewriteLocalConfig := mergeCoordinatorAddrs(&s.config, &lastStored)
 		if mustRewriteLocalConfig {
-			// saving last configuration if changed
 			lastStored.Id = "-1" // forces configuration to be re-pulled from consensus node
-			err = s.saveAndSetLastConfiguration(context.Background(), lastStored)
+			if len(lastStored.Signature) != 0 {
+				// the merged configuration doesn't match the signature anymore, so the signature is dropped
+				// and the signed one is kept in the store
+				lastStored.Signature = nil
+				err = s.setLastConfiguration(lastStored)
+			} else {
+				// saving last configuration if changed
+				err = s.saveAndSetLastConfiguration(context.Background(), lastStored)
+			}
 			if err != nil {
 				return
 			}
@@ -179,10 +208,32 @@ func (s *service) updateConfiguration(ctx context.Context) (err error) {
 		return err
 	}
 
+	s.mu.RLock()
+	requireSignature := s.requireSignature
+	s.mu.RUnlock()
+	if err = checkConfiguration(s.Configuration(), last, requireSignature); err != nil {
+		log.Warn("received configuration is rejected", zap.String("id", last.Id), zap.Error(err))
+		return err
+	}
+
 	if err = s.saveAndSetLastConfiguration(ctx, last); err != nil {
 		return err
 	}
 
+	if len(last.Signature) != 0 {
+		return s.markSigned(ctx)
+	}
+	return nil
+}
+
+// markSigned enables the strict mode after the signed configuration is accepted and remembers it in the store
+func (s *service) markSigned(ctx context.Context) error {
+	s.mu.Lock()
+	s.requireSignature = true
+	s.mu.Unlock()
+	if markStore, ok := s.store.(SignedMarkStore); ok {
+		return markStore.MarkSigned(ctx, s.config.NetworkId)
+	}
 	return nil
 }
 
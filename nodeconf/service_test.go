@@ -14,6 +14,7 @@ import (
 	"github.com/anyproto/any-sync/net"
 	"github.com/anyproto/any-sync/net/secureservice/handshake"
 	"github.com/anyproto/any-sync/testutil/accounttest"
+	"github.com/anyproto/any-sync/util/crypto"
 )
 
 var ctx = context.Background()
@@ -87,6 +88,142 @@ func TestService_NetworkGetLast(t *testing.T) {
 	})
 }
 
+func TestService_ConfigurationSignature(t *testing.T) {
+	newSigned := func(t *testing.T, fx *fixture, networkKey crypto.PrivKey, id string, version uint64) Configuration {
+		c := fx.testConf.Configuration
+		c.Id, c.Version = id, version
+		signed, err := c.Sign(networkKey)
+		require.NoError(t, err)
+		return signed
+	}
+	t.Run("signed configuration is applied", func(t *testing.T) {
+		fx, networkKey := newSignedFixture(t)
+		defer fx.finish(t)
+		fx.testSource.conf = newSigned(t, fx, networkKey, "signed", 1)
+		fx.run(t)
+		time.Sleep(time.Millisecond * 10)
+		assert.Equal(t, "signed", fx.Configuration().Id)
+		stored, err := fx.testStore.GetLast(ctx, fx.testConf.NetworkId)
+		require.NoError(t, err)
+		assert.NoError(t, stored.Verify())
+	})
+	t.Run("tampered configuration is rejected", func(t *testing.T) {
+		fx, networkKey := newSignedFixture(t)
+		defer fx.finish(t)
+		conf := newSigned(t, fx, networkKey, "signed", 1)
+		conf.Nodes = append([]Node{}, conf.Nodes...)
+		conf.Nodes[1].Addresses = []string{"10.0.0.1:4730"}
+		fx.testSource.conf = conf
+		fx.run(t)
+		time.Sleep(time.Millisecond * 10)
+		assert.Equal(t, "test", fx.Configuration().Id)
+		_, err := fx.testStore.GetLast(ctx, fx.testConf.NetworkId)
+		assert.ErrorIs(t, err, ErrConfigurationNotFound)
+	})
+	t.Run("configuration of another network is rejected", func(t *testing.T) {
+		fx, _ := newSignedFixture(t)
+		defer fx.finish(t)
+		otherKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		conf := fx.testConf.Configuration
+		conf.Id, conf.NetworkId = "other", otherKey.GetPublic().Network()
+		conf, err = conf.Sign(otherKey)
+		require.NoError(t, err)
+		fx.testSource.conf = conf
+		fx.run(t)
+		time.Sleep(time.Millisecond * 10)
+		assert.Equal(t, "test", fx.Configuration().Id)
+	})
+	t.Run("rollback is rejected", func(t *testing.T) {
+		fx, networkKey := newSignedFixture(t)
+		defer fx.finish(t)
+		stored := newSigned(t, fx, networkKey, "stored", 5)
+		fx.testStore.conf = &stored
+		fx.testSource.conf = newSigned(t, fx, networkKey, "older", 3)
+		fx.run(t)
+		time.Sleep(time.Millisecond * 10)
+		assert.Equal(t, "stored", fx.Configuration().Id)
+		assert.Equal(t, "stored", fx.testStore.conf.Id)
+	})
+	t.Run("unsigned configuration is rejected after signed one", func(t *testing.T) {
+		fx, networkKey := newSignedFixture(t)
+		defer fx.finish(t)
+		stored := newSigned(t, fx, networkKey, "stored", 1)
+		fx.testStore.conf = &stored
+		fx.testSource.conf = fx.testConf.Configuration
+		fx.testSource.conf.Id, fx.testSource.conf.Version = "unsigned", 2
+		fx.run(t)
+		time.Sleep(time.Millisecond * 10)
+		assert.Equal(t, "stored", fx.Configuration().Id)
+	})
+	t.Run("unsigned configuration is rejected in strict mode", func(t *testing.T) {
+		fx, _ := newSignedFixture(t)
+		defer fx.finish(t)
+		fx.testConf.requireSignature = true
+		fx.testSource.conf = fx.testConf.Configuration
+		fx.testSource.conf.Id = "unsigned"
+		fx.run(t)
+		time.Sleep(time.Millisecond * 10)
+		assert.Equal(t, "test", fx.Configuration().Id)
+	})
+	t.Run("tampered stored configuration is replaced with app config", func(t *testing.T) {
+		fx, networkKey := newSignedFixture(t)
+		defer fx.finish(t)
+		stored := newSigned(t, fx, networkKey, "stored", 1)
+		stored.Nodes = stored.Nodes[1:]
+		fx.testStore.conf = &stored
+		fx.testSource.err = ErrConfigurationNotChanged
+		fx.run(t)
+		assert.Equal(t, "test", fx.Configuration().Id)
+	})
+	t.Run("tampered unsigned stored configuration is rejected after signed one", func(t *testing.T) {
+		fx, networkKey := newSignedFixture(t)
+		defer fx.finish(t)
+		fx.testSource.conf = newSigned(t, fx, networkKey, "signed", 1)
+		fx.run(t)
+		time.Sleep(time.Millisecond * 10)
+		require.Equal(t, "signed", fx.Configuration().Id)
+		require.True(t, fx.testStore.signed)
+		require.NoError(t, fx.a.Close(ctx))
+
+		tampered := fx.testSource.conf
+		tampered.Id, tampered.Signature = "tampered", nil
+		tampered.Nodes = tampered.Nodes[1:]
+		fx2 := newFixture(t)
+		defer fx2.finish(t)
+		fx2.testConf.NetworkId = fx.testConf.NetworkId
+		fx2.testStore.conf, fx2.testStore.signed = &tampered, true
+		fx2.testSource.err = ErrConfigurationNotChanged
+		fx2.run(t)
+		assert.Equal(t, "test", fx2.Configuration().Id)
+	})
+	t.Run("merged signed configuration drops the signature", func(t *testing.T) {
+		fx, networkKey := newSignedFixture(t)
+		defer fx.finish(t)
+		stored := newSigned(t, fx, networkKey, "stored", 1)
+		stored.Nodes = append([]Node{}, stored.Nodes...)
+		stored.Nodes[0].Addresses = []string{"127.0.0.2:4830"}
+		stored, err := stored.Sign(networkKey)
+		require.NoError(t, err)
+		fx.testStore.conf = &stored
+		fx.testSource.err = ErrConfigurationNotChanged
+		fx.run(t)
+		assert.Equal(t, "-1", fx.Configuration().Id)
+		assert.Empty(t, fx.Configuration().Signature)
+		assert.Equal(t, "stored", fx.testStore.conf.Id)
+		assert.NoError(t, fx.testStore.conf.Verify())
+		assert.True(t, fx.testStore.signed)
+	})
+}
+
+func newSignedFixture(t *testing.T) (*fixture, crypto.PrivKey) {
+	networkKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	fx := newFixture(t)
+	fx.testConf.NetworkId = networkKey.GetPublic().Network()
+	return fx, networkKey
+}
+
 func newFixture(t *testing.T) *fixture {
 	fx := &fixture{
 		Service:         New(),
@@ -145,8 +282,9 @@ func (t *testSource) GetLast(ctx context.Context, currentId string) (c Configura
 }
 
 type testStore struct {
-	conf *Configuration
-	mu   sync.Mutex
+	conf   *Configuration
+	signed bool
+	mu     sync.Mutex
 }
 
 func (t *testStore) Init(a *app.App) error { return nil }
@@ -156,7 +294,14 @@ func (t *testStore) GetLast(ctx context.Context, netId string) (c Configuration,
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	if t.conf != nil {
-		return *t.conf, nil
+		c = *t.conf
+		// the file store returns the new configuration on every call
+		c.Nodes = make([]Node, len(t.conf.Nodes))
+		for i, n := range t.conf.Nodes {
+			n.Addresses = append([]string{}, n.Addresses...)
+			c.Nodes[i] = n
+		}
+		return c, nil
 	} else {
 		err = ErrConfigurationNotFound
 	}
@@ -170,8 +315,22 @@ func (t *testStore) SaveLast(ctx context.Context, c Configuration) (err error) {
 	return
 }
 
+func (t *testStore) IsSigned(ctx context.Context, netId string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.signed, nil
+}
+
+func (t *testStore) MarkSigned(ctx context.Context, netId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.signed = true
+	return nil
+}
+
 type testConf struct {
 	Configuration
+	requireSignature bool
 }
 
 func (t *testConf) Init(a *app.App) error { return nil }
@@ -181,9 +340,13 @@ func (t *testConf) GetNodeConf() Configuration {
 	return t.Configuration
 }
 
+func (t *testConf) GetNodeConfRequireSignature() bool {
+	return t.requireSignature
+}
+
 func newTestConf() *testConf {
 	return &testConf{
-		Configuration{
+		Configuration: Configuration{
 			Id:        "test",
 			NetworkId: "testNetwork",
 			Nodes: []Node{
@@ -0,0 +1,104 @@
+package nodeconf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var (
+	ErrConfigurationNotSigned        = errors.New("network configuration is not signed")
+	ErrInvalidConfigurationSignature = errors.New("invalid network configuration signature")
+	ErrConfigurationRollback         = errors.New("network configuration is older than the current one")
+)
+
+type signedNode struct {
+	PeerId    string     `json:"peerId"`
+	Addresses []string   `json:"addresses"`
+	Types     []NodeType `json:"types"`
+	Capacity  float64    `json:"capacity,omitempty"`
+}
+
+type signedConfiguration struct {
+	Id           string       `json:"id"`
+	NetworkId    string       `json:"networkId"`
+	Version      uint64       `json:"version"`
+	CreationTime int64        `json:"creationTime"`
+	Nodes        []signedNode `json:"nodes"`
+}
+
+// SignedPayload returns the canonical representation of the configuration which is signed by the network key.
+// It doesn't depend on the way the configuration was transferred or stored, so the creation time is
+// taken with the precision of seconds and the empty lists are equal to the nil ones
+func (c Configuration) SignedPayload() ([]byte, error) {
+	sc := signedConfiguration{
+		Id:           c.Id,
+		NetworkId:    c.NetworkId,
+		Version:      c.Version,
+		CreationTime: c.CreationTime.Unix(),
+		Nodes:        make([]signedNode, 0, len(c.Nodes)),
+	}
+	for _, n := range c.Nodes {
+		sc.Nodes = append(sc.Nodes, signedNode{
+			PeerId:    n.PeerId,
+			Addresses: append([]string{}, n.Addresses...),
+			Types:     append([]NodeType{}, n.Types...),
+			Capacity:  n.NodeCapacity,
+		})
+	}
+	return json.Marshal(sc)
+}
+
+// Sign returns the copy of the configuration signed by the network key, NetworkId must be derived from this key
+func (c Configuration) Sign(networkKey crypto.PrivKey) (Configuration, error) {
+	if networkKey.GetPublic().Network() != c.NetworkId {
+		return c, fmt.Errorf("network key doesn't match network id %s", c.NetworkId)
+	}
+	payload, err := c.SignedPayload()
+	if err != nil {
+		return c, err
+	}
+	if c.Signature, err = networkKey.Sign(payload); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// Verify checks the signature of the configuration with the network key derived from NetworkId
+func (c Configuration) Verify() error {
+	if len(c.Signature) == 0 {
+		return ErrConfigurationNotSigned
+	}
+	networkKey, err := crypto.DecodeNetworkId(c.NetworkId)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfigurationSignature, err)
+	}
+	payload, err := c.SignedPayload()
+	if err != nil {
+		return err
+	}
+	if ok, err := networkKey.Verify(payload, c.Signature); err != nil || !ok {
+		return ErrInvalidConfigurationSignature
+	}
+	return nil
+}
+
+// checkConfiguration decides whether next may replace current. The signed configurations are always verified,
+// the unsigned ones are accepted only if the signatures are not required and current is not signed as well.
+// The version of next must not be lower than the current one
+func checkConfiguration(current, next Configuration, requireSignature bool) error {
+	if len(next.Signature) != 0 || requireSignature || len(current.Signature) != 0 {
+		if err := next.Verify(); err != nil {
+			return err
+		}
+	}
+	if next.NetworkId != current.NetworkId {
+		return fmt.Errorf("network id mismatch: %s != %s", next.NetworkId, current.NetworkId)
+	}
+	if next.Version < current.Version {
+		return fmt.Errorf("%w: version %d < %d", ErrConfigurationRollback, next.Version, current.Version)
+	}
+	return nil
+}
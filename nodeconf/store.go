@@ -8,3 +8,11 @@ type Store interface {
 	GetLast(ctx context.Context, netId string) (c Configuration, err error)
 	SaveLast(ctx context.Context, c Configuration) (err error)
 }
+
+// SignedMarkStore is implemented by the stores which remember that the network has signed configurations.
+// After the first signed configuration is seen, the unsigned ones are rejected by default,
+// including the stored configuration returned by GetLast
+type SignedMarkStore interface {
+	IsSigned(ctx context.Context, netId string) (bool, error)
+	MarkSigned(ctx context.Context, netId string) error
+}
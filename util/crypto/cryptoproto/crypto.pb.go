@@ -28,18 +28,25 @@ const (
 	KeyType_Ed25519Public  KeyType = 0
 	KeyType_Ed25519Private KeyType = 1
 	KeyType_AES            KeyType = 2
+	// X25519MlKem768 are the hybrid keys which are used only for encryption
+	KeyType_X25519MlKem768Public  KeyType = 3
+	KeyType_X25519MlKem768Private KeyType = 4
 )
 
 var KeyType_name = map[int32]string{
 	0: "Ed25519Public",
 	1: "Ed25519Private",
 	2: "AES",
+	3: "X25519MlKem768Public",
+	4: "X25519MlKem768Private",
 }
 
 var KeyType_value = map[string]int32{
-	"Ed25519Public":  0,
-	"Ed25519Private": 1,
-	"AES":            2,
+	"Ed25519Public":         0,
+	"Ed25519Private":        1,
+	"AES":                   2,
+	"X25519MlKem768Public":  3,
+	"X25519MlKem768Private": 4,
 }
 
 func (x KeyType) String() string {
@@ -110,9 +117,142 @@ func (m *Key) GetData() []byte {
 	return nil
 }
 
+// DeviceCertificate allows the device key to act on behalf of the account identity
+type DeviceCertificate struct {
+	Identity  []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	DeviceKey []byte `protobuf:"bytes,2,opt,name=deviceKey,proto3" json:"deviceKey,omitempty"`
+	// Timestamp is a unix time (seconds) when the certificate was issued
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *DeviceCertificate) Reset()         { *m = DeviceCertificate{} }
+func (m *DeviceCertificate) String() string { return proto.CompactTextString(m) }
+func (*DeviceCertificate) ProtoMessage()    {}
+func (*DeviceCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddfeb19e486561de, []int{1}
+}
+func (m *DeviceCertificate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeviceCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeviceCertificate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeviceCertificate) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *DeviceCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceCertificate.Merge(m, src)
+}
+func (m *DeviceCertificate) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeviceCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceCertificate proto.InternalMessageInfo
+
+func (m *DeviceCertificate) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *DeviceCertificate) GetDeviceKey() []byte {
+	if m != nil {
+		return m.DeviceKey
+	}
+	return nil
+}
+
+func (m *DeviceCertificate) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// SignedDeviceCertificate contains the marshalled DeviceCertificate signed by the account identity
+type SignedDeviceCertificate struct {
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Signature   []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedDeviceCertificate) Reset()         { *m = SignedDeviceCertificate{} }
+func (m *SignedDeviceCertificate) String() string { return proto.CompactTextString(m) }
+func (*SignedDeviceCertificate) ProtoMessage()    {}
+func (*SignedDeviceCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddfeb19e486561de, []int{2}
+}
+func (m *SignedDeviceCertificate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignedDeviceCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignedDeviceCertificate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignedDeviceCertificate) XXX_MarshalAppend(b []byte, newLen int) ([]byte, error) {
+	b = b[:newLen]
+	_, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+func (m *SignedDeviceCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedDeviceCertificate.Merge(m, src)
+}
+func (m *SignedDeviceCertificate) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignedDeviceCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedDeviceCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedDeviceCertificate proto.InternalMessageInfo
+
+func (m *SignedDeviceCertificate) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *SignedDeviceCertificate) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("crypto.KeyType", KeyType_name, KeyType_value)
 	proto.RegisterType((*Key)(nil), "crypto.Key")
+	proto.RegisterType((*DeviceCertificate)(nil), "crypto.DeviceCertificate")
+	proto.RegisterType((*SignedDeviceCertificate)(nil), "crypto.SignedDeviceCertificate")
 }
 
 func init() {
@@ -120,19 +260,28 @@ func init() {
 }
 
 var fileDescriptor_ddfeb19e486561de = []byte{
-	// 191 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x2e, 0x2d, 0xc9, 0xcc,
-	0xd1, 0x4f, 0x2e, 0xaa, 0x2c, 0x28, 0xc9, 0x87, 0x52, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0xfa, 0x60,
-	0xb2, 0x18, 0x2a, 0xa4, 0x07, 0xe6, 0x09, 0xb1, 0x41, 0x78, 0x4a, 0x76, 0x5c, 0xcc, 0xde, 0xa9,
-	0x95, 0x42, 0xca, 0x5c, 0x2c, 0x21, 0x95, 0x05, 0xa9, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x7c, 0x46,
-	0xfc, 0x7a, 0x50, 0xb5, 0xde, 0xa9, 0x95, 0x20, 0xe1, 0x20, 0xb0, 0xa4, 0x90, 0x10, 0x17, 0x8b,
-	0x4b, 0x62, 0x49, 0xa2, 0x04, 0x93, 0x02, 0xa3, 0x06, 0x4f, 0x10, 0x98, 0xad, 0x65, 0xc9, 0xc5,
-	0x0e, 0x55, 0x24, 0x24, 0xc8, 0xc5, 0xeb, 0x9a, 0x62, 0x64, 0x6a, 0x6a, 0x68, 0x19, 0x50, 0x9a,
-	0x94, 0x93, 0x99, 0x2c, 0xc0, 0x20, 0x24, 0xc4, 0xc5, 0x07, 0x13, 0x2a, 0xca, 0x2c, 0x4b, 0x2c,
-	0x49, 0x15, 0x60, 0x14, 0x62, 0xe7, 0x62, 0x76, 0x74, 0x0d, 0x16, 0x60, 0x72, 0x32, 0x3c, 0xf1,
-	0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8,
-	0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x28, 0x71, 0x1c, 0x3e, 0x49, 0x62, 0x03, 0x53,
-	0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x27, 0xb9, 0xba, 0xd8, 0xeb, 0x00, 0x00, 0x00,
+	// 322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x4f, 0x4b, 0x02, 0x41,
+	0x18, 0xc6, 0x77, 0x5c, 0xd1, 0x7a, 0x33, 0xd3, 0xa1, 0x70, 0x8b, 0x58, 0x16, 0xbb, 0x48, 0x81,
+	0xa2, 0x61, 0x7f, 0x2e, 0x41, 0xa5, 0xa7, 0x25, 0x88, 0xb5, 0x43, 0x75, 0x5b, 0x77, 0xdf, 0x64,
+	0x48, 0xd7, 0x65, 0x7d, 0x15, 0xe6, 0x5b, 0xf4, 0xb1, 0x3a, 0x7a, 0xec, 0x18, 0xfa, 0x45, 0xc2,
+	0x69, 0xd4, 0x28, 0xba, 0xec, 0xbb, 0xcf, 0xef, 0x99, 0xf7, 0x79, 0x18, 0x06, 0x4e, 0xc6, 0x24,
+	0xfa, 0xb5, 0x20, 0x91, 0x31, 0x0d, 0xf5, 0x88, 0x93, 0x21, 0x0d, 0x6b, 0xea, 0x3b, 0xd2, 0xa8,
+	0xaa, 0x14, 0xcf, 0x7c, 0xab, 0xf2, 0x15, 0x98, 0x2e, 0x4a, 0x7e, 0x04, 0xe9, 0x07, 0x19, 0xa3,
+	0xc5, 0x1c, 0x56, 0xc9, 0x37, 0x76, 0xaa, 0xfa, 0xac, 0x8b, 0x72, 0x81, 0x3d, 0x65, 0x72, 0x0e,
+	0xe9, 0x96, 0x4f, 0xbe, 0x95, 0x72, 0x58, 0x25, 0xe7, 0xa9, 0xff, 0xf2, 0x2b, 0x14, 0x5b, 0x38,
+	0x11, 0x01, 0xde, 0x62, 0x42, 0xe2, 0x45, 0x04, 0x3e, 0x21, 0x3f, 0x80, 0x0d, 0x11, 0x62, 0x44,
+	0x82, 0xa4, 0x4a, 0xcc, 0x79, 0x2b, 0xcd, 0x0f, 0x61, 0x33, 0x54, 0x0b, 0x2e, 0x4a, 0x9d, 0xb4,
+	0x06, 0x0b, 0x97, 0xc4, 0x00, 0x47, 0xe4, 0x0f, 0x62, 0xcb, 0x74, 0x58, 0xc5, 0xf4, 0xd6, 0xa0,
+	0xfc, 0x04, 0xa5, 0x8e, 0xe8, 0x45, 0x18, 0xfe, 0xad, 0x74, 0x60, 0x2b, 0x58, 0x4b, 0xdd, 0xfa,
+	0x13, 0x2d, 0xa2, 0x47, 0xa2, 0x17, 0xf9, 0x34, 0x4e, 0x70, 0x59, 0xbc, 0x02, 0xc7, 0x11, 0x64,
+	0xf5, 0x65, 0x79, 0x11, 0xb6, 0xdb, 0x61, 0xa3, 0xd9, 0xac, 0x5f, 0xde, 0x8f, 0xbb, 0x7d, 0x11,
+	0x14, 0x0c, 0xce, 0x21, 0xbf, 0x44, 0x89, 0x98, 0xf8, 0x84, 0x05, 0xc6, 0xb3, 0x60, 0x5e, 0xb7,
+	0x3b, 0x85, 0x14, 0xb7, 0x60, 0xf7, 0x51, 0x79, 0x77, 0x7d, 0x17, 0x07, 0xe7, 0x67, 0x17, 0x7a,
+	0xcd, 0xe4, 0xfb, 0xb0, 0xf7, 0xcb, 0xd1, 0xdb, 0xe9, 0x9b, 0xfa, 0xfb, 0xcc, 0x66, 0xd3, 0x99,
+	0xcd, 0x3e, 0x67, 0x36, 0x7b, 0x9b, 0xdb, 0xc6, 0x74, 0x6e, 0x1b, 0x1f, 0x73, 0xdb, 0x78, 0x2e,
+	0xfd, 0xf3, 0x8c, 0xdd, 0x8c, 0x1a, 0xa7, 0x5f, 0x03, 0x00, 0x62, 0x5b, 0x75, 0x7c, 0xe8, 0x01,
+	0x00, 0x00,
 }
 
 func (m *Key) Marshal() (dAtA []byte, err error) {
@@ -170,6 +319,85 @@ func (m *Key) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *DeviceCertificate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceCertificate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeviceCertificate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		i = encodeVarintCrypto(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.DeviceKey) > 0 {
+		i -= len(m.DeviceKey)
+		copy(dAtA[i:], m.DeviceKey)
+		i = encodeVarintCrypto(dAtA, i, uint64(len(m.DeviceKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintCrypto(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignedDeviceCertificate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignedDeviceCertificate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignedDeviceCertificate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintCrypto(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Certificate) > 0 {
+		i -= len(m.Certificate)
+		copy(dAtA[i:], m.Certificate)
+		i = encodeVarintCrypto(dAtA, i, uint64(len(m.Certificate)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintCrypto(dAtA []byte, offset int, v uint64) int {
 	offset -= sovCrypto(v)
 	base := offset
@@ -197,6 +425,43 @@ func (m *Key) Size() (n int) {
 	return n
 }
 
+func (m *DeviceCertificate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovCrypto(uint64(l))
+	}
+	l = len(m.DeviceKey)
+	if l > 0 {
+		n += 1 + l + sovCrypto(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovCrypto(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *SignedDeviceCertificate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovCrypto(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovCrypto(uint64(l))
+	}
+	return n
+}
+
 func sovCrypto(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -306,6 +571,261 @@ func (m *Key) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DeviceCertificate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCrypto
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceCertificate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrypto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrypto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceKey = append(m.DeviceKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeviceKey == nil {
+				m.DeviceKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrypto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCrypto(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignedDeviceCertificate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCrypto
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedDeviceCertificate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedDeviceCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrypto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCrypto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCrypto(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCrypto
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCrypto(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
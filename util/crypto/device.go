@@ -0,0 +1,202 @@
+package crypto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/anyproto/any-sync/util/crypto/cryptoproto"
+)
+
+var (
+	ErrInvalidDeviceCertificate = errors.New("invalid device certificate")
+	ErrDeviceKeyMismatch        = errors.New("device key doesn't match the certificate")
+	ErrDeviceCantDecrypt        = errors.New("device key can't decrypt the messages of the identity")
+	ErrInvalidSignature         = errors.New("invalid signature")
+)
+
+// DeviceSigner is implemented by the keys which sign on behalf of the identity with the device certificate
+type DeviceSigner interface {
+	DeviceCertificate() *DeviceCertificate
+}
+
+// DeviceCertificate is issued by the account identity to the device key,
+// so the device can act on behalf of the account without having the identity key
+type DeviceCertificate struct {
+	Identity  PubKey
+	DeviceKey PubKey
+	// Timestamp is a unix time in seconds when the certificate was issued
+	Timestamp int64
+
+	marshalled []byte
+}
+
+// NewDeviceCertificate signs the certificate for the device key with the identity
+func NewDeviceCertificate(identity Signer, deviceKey PubKey) (*DeviceCertificate, error) {
+	protoIdentity, err := identity.GetPublic().Marshall()
+	if err != nil {
+		return nil, err
+	}
+	protoDeviceKey, err := deviceKey.Marshall()
+	if err != nil {
+		return nil, err
+	}
+	cert := &cryptoproto.DeviceCertificate{
+		Identity:  protoIdentity,
+		DeviceKey: protoDeviceKey,
+		Timestamp: time.Now().Unix(),
+	}
+	marshalledCert, err := cert.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := identity.Sign(marshalledCert)
+	if err != nil {
+		return nil, err
+	}
+	marshalled, err := (&cryptoproto.SignedDeviceCertificate{
+		Certificate: marshalledCert,
+		Signature:   signature,
+	}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &DeviceCertificate{
+		Identity:   identity.GetPublic(),
+		DeviceKey:  deviceKey,
+		Timestamp:  cert.Timestamp,
+		marshalled: marshalled,
+	}, nil
+}
+
+// UnmarshalDeviceCertificate unmarshalls the certificate and checks that it is signed by the identity
+func UnmarshalDeviceCertificate(data []byte) (*DeviceCertificate, error) {
+	signed := &cryptoproto.SignedDeviceCertificate{}
+	if err := signed.Unmarshal(data); err != nil {
+		return nil, ErrInvalidDeviceCertificate
+	}
+	cert := &cryptoproto.DeviceCertificate{}
+	if err := cert.Unmarshal(signed.Certificate); err != nil {
+		return nil, ErrInvalidDeviceCertificate
+	}
+	identity, err := UnmarshalEd25519PublicKeyProto(cert.Identity)
+	if err != nil {
+		return nil, ErrInvalidDeviceCertificate
+	}
+	deviceKey, err := UnmarshalEd25519PublicKeyProto(cert.DeviceKey)
+	if err != nil {
+		return nil, ErrInvalidDeviceCertificate
+	}
+	if identity.Equals(deviceKey) {
+		return nil, ErrInvalidDeviceCertificate
+	}
+	ok, err := identity.Verify(signed.Certificate, signed.Signature)
+	if err != nil || !ok {
+		return nil, ErrInvalidDeviceCertificate
+	}
+	return &DeviceCertificate{
+		Identity:   identity,
+		DeviceKey:  deviceKey,
+		Timestamp:  cert.Timestamp,
+		marshalled: data,
+	}, nil
+}
+
+// Marshall returns the signed certificate in proto encoding
+func (c *DeviceCertificate) Marshall() []byte {
+	return c.marshalled
+}
+
+// NewDeviceSignKey returns the key which acts as the identity of the certificate, but signs with the device key.
+// The signatures of this key must be verified with VerifyIdentitySignature and the certificate.
+// The device doesn't have the identity key, so it can't decrypt the messages encrypted for the identity
+// and the key can't be exported
+func NewDeviceSignKey(deviceKey PrivKey, cert *DeviceCertificate) (PrivKey, error) {
+	if !cert.DeviceKey.Equals(deviceKey.GetPublic()) {
+		return nil, ErrDeviceKeyMismatch
+	}
+	return &deviceSignKey{deviceKey: deviceKey, cert: cert}, nil
+}
+
+type deviceSignKey struct {
+	deviceKey PrivKey
+	cert      *DeviceCertificate
+}
+
+func (k *deviceSignKey) GetPublic() PubKey {
+	return k.cert.Identity
+}
+
+func (k *deviceSignKey) Sign(data []byte) ([]byte, error) {
+	return k.deviceKey.Sign(data)
+}
+
+func (k *deviceSignKey) Decrypt(message []byte) ([]byte, error) {
+	return nil, ErrDeviceCantDecrypt
+}
+
+func (k *deviceSignKey) Equals(o Key) bool {
+	other, ok := o.(*deviceSignKey)
+	if !ok {
+		return false
+	}
+	return k.deviceKey.Equals(other.deviceKey)
+}
+
+func (k *deviceSignKey) Raw() ([]byte, error) {
+	return nil, ErrKeyNotExportable
+}
+
+func (k *deviceSignKey) Marshall() ([]byte, error) {
+	return nil, ErrKeyNotExportable
+}
+
+func (k *deviceSignKey) LibP2P() (crypto.PrivKey, error) {
+	return nil, ErrKeyNotExportable
+}
+
+func (k *deviceSignKey) DeviceCertificate() *DeviceCertificate {
+	return k.cert
+}
+
+// MarshalledDeviceCertificate returns the certificate of the key if it signs on behalf of the identity, otherwise nil
+func MarshalledDeviceCertificate(key Signer) []byte {
+	if deviceSigner, ok := key.(DeviceSigner); ok {
+		return deviceSigner.DeviceCertificate().Marshall()
+	}
+	return nil
+}
+
+// DeviceKeyFromCertificate returns the device key of the certificate, the certificate must be issued by the identity
+func DeviceKeyFromCertificate(identity PubKey, marshalledCert []byte) (PubKey, error) {
+	cert, err := UnmarshalDeviceCertificate(marshalledCert)
+	if err != nil {
+		return nil, err
+	}
+	if !cert.Identity.Equals(identity) {
+		return nil, ErrInvalidDeviceCertificate
+	}
+	return cert.DeviceKey, nil
+}
+
+// VerifyIdentitySignature verifies the signature made on behalf of the identity. If the certificate is set,
+// the signature must be made by the device key of the certificate issued by the identity,
+// the device key is returned in this case
+func VerifyIdentitySignature(identity PubKey, data, signature, marshalledCert []byte) (deviceKey PubKey, err error) {
+	signer := identity
+	if len(marshalledCert) != 0 {
+		if deviceKey, err = DeviceKeyFromCertificate(identity, marshalledCert); err != nil {
+			return nil, err
+		}
+		signer = deviceKey
+	}
+	ok, err := signer.Verify(data, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+	return deviceKey, nil
+}
@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/util/crypto/cryptoproto"
+)
+
+func TestDeviceCertificate(t *testing.T) {
+	identity, _, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	_, deviceKey, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+
+	t.Run("issue and unmarshall", func(t *testing.T) {
+		cert, err := NewDeviceCertificate(NewSignerKey(identity), deviceKey)
+		require.NoError(t, err)
+		res, err := UnmarshalDeviceCertificate(cert.Marshall())
+		require.NoError(t, err)
+		require.True(t, res.Identity.Equals(identity.GetPublic()))
+		require.True(t, res.DeviceKey.Equals(deviceKey))
+		require.Equal(t, cert.Timestamp, res.Timestamp)
+	})
+	t.Run("signed by another key", func(t *testing.T) {
+		cert, err := NewDeviceCertificate(identity, deviceKey)
+		require.NoError(t, err)
+		otherKey, _, err := GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		signed := &cryptoproto.SignedDeviceCertificate{}
+		require.NoError(t, signed.Unmarshal(cert.Marshall()))
+		signed.Signature, err = otherKey.Sign(signed.Certificate)
+		require.NoError(t, err)
+		data, err := signed.Marshal()
+		require.NoError(t, err)
+		_, err = UnmarshalDeviceCertificate(data)
+		require.ErrorIs(t, err, ErrInvalidDeviceCertificate)
+	})
+	t.Run("identity can't be its own device", func(t *testing.T) {
+		cert, err := NewDeviceCertificate(identity, identity.GetPublic())
+		require.NoError(t, err)
+		_, err = UnmarshalDeviceCertificate(cert.Marshall())
+		require.ErrorIs(t, err, ErrInvalidDeviceCertificate)
+	})
+	t.Run("garbage", func(t *testing.T) {
+		_, err := UnmarshalDeviceCertificate([]byte("garbage"))
+		require.ErrorIs(t, err, ErrInvalidDeviceCertificate)
+	})
+}
+
+func TestDeviceSignKey(t *testing.T) {
+	identity, _, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	deviceKey, _, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	cert, err := NewDeviceCertificate(identity, deviceKey.GetPublic())
+	require.NoError(t, err)
+	key, err := NewDeviceSignKey(deviceKey, cert)
+	require.NoError(t, err)
+	require.True(t, key.GetPublic().Equals(identity.GetPublic()))
+	_, err = key.Marshall()
+	require.ErrorIs(t, err, ErrKeyNotExportable)
+	_, err = key.Decrypt([]byte("message"))
+	require.ErrorIs(t, err, ErrDeviceCantDecrypt)
+
+	t.Run("verify signature with the certificate", func(t *testing.T) {
+		data := []byte("data")
+		sign, err := key.Sign(data)
+		require.NoError(t, err)
+		marshalledCert := MarshalledDeviceCertificate(key)
+		require.Equal(t, cert.Marshall(), marshalledCert)
+		signer, err := VerifyIdentitySignature(identity.GetPublic(), data, sign, marshalledCert)
+		require.NoError(t, err)
+		require.True(t, signer.Equals(deviceKey.GetPublic()))
+
+		// the device signature is not the signature of the identity
+		_, err = VerifyIdentitySignature(identity.GetPublic(), data, sign, nil)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+		otherIdentity, _, err := GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		_, err = VerifyIdentitySignature(otherIdentity.GetPublic(), data, sign, marshalledCert)
+		require.ErrorIs(t, err, ErrInvalidDeviceCertificate)
+	})
+	t.Run("identity signature", func(t *testing.T) {
+		data := []byte("data")
+		sign, err := identity.Sign(data)
+		require.NoError(t, err)
+		require.Nil(t, MarshalledDeviceCertificate(identity))
+		signer, err := VerifyIdentitySignature(identity.GetPublic(), data, sign, nil)
+		require.NoError(t, err)
+		require.Nil(t, signer)
+	})
+	t.Run("device key mismatch", func(t *testing.T) {
+		otherKey, _, err := GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		_, err = NewDeviceSignKey(otherKey, cert)
+		require.ErrorIs(t, err, ErrDeviceKeyMismatch)
+	})
+}
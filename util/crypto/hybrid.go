@@ -0,0 +1,236 @@
+package crypto
+
+import (
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha3"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/anyproto/protobuf/proto"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/anyproto/any-sync/util/crypto/cryptoproto"
+)
+
+// The hybrid keys combine X25519 and ML-KEM-768, so the encrypted messages stay secret
+// while at least one of the algorithms is not broken.
+// The shared secrets are combined in the same way as in X-Wing KEM
+
+const (
+	x25519KeySize        = 32
+	hybridPrivKeySize    = x25519KeySize + mlkem.SeedSize
+	hybridPubKeySize     = x25519KeySize + mlkem.EncapsulationKeySize768
+	hybridCiphertextSize = x25519KeySize + mlkem.CiphertextSize768
+	hybridLabel          = "anysync-x25519-mlkem768"
+)
+
+var (
+	ErrHybridDecryptionFailed = errors.New("failed decryption with hybrid key")
+	ErrIncorrectHybridKeySize = errors.New("incorrect hybrid key size")
+)
+
+// HybridDecryptor decrypts the messages encrypted with the hybrid public key,
+// like the Signer it allows to keep the private key outside the process memory
+type HybridDecryptor interface {
+	// GetPublic returns the public key which is used for encryption
+	GetPublic() *HybridPubKey
+	// Decrypt decrypts the message encrypted with the public key
+	Decrypt(message []byte) ([]byte, error)
+}
+
+var _ HybridDecryptor = (*HybridPrivKey)(nil)
+
+// HybridPrivKey is the X25519 + ML-KEM-768 key which is used for decryption.
+// The key is independent of the account keys, so it must be generated randomly and stored along with them
+type HybridPrivKey struct {
+	x25519Priv []byte
+	x25519Pub  []byte
+	dk         *mlkem.DecapsulationKey768
+}
+
+// HybridPubKey is the X25519 + ML-KEM-768 key which is used for encryption
+type HybridPubKey struct {
+	x25519Pub []byte
+	ek        *mlkem.EncapsulationKey768
+}
+
+// GenerateRandomHybridKey generates the new random hybrid key
+func GenerateRandomHybridKey() (*HybridPrivKey, error) {
+	data := make([]byte, hybridPrivKeySize)
+	if _, err := rand.Read(data); err != nil {
+		return nil, err
+	}
+	return NewHybridPrivKeyFromBytes(data)
+}
+
+// NewHybridPrivKeyFromBytes creates the key from the X25519 scalar followed by the ML-KEM seed
+func NewHybridPrivKeyFromBytes(data []byte) (*HybridPrivKey, error) {
+	if len(data) != hybridPrivKeySize {
+		return nil, ErrIncorrectHybridKeySize
+	}
+	x25519Priv := make([]byte, x25519KeySize)
+	copy(x25519Priv, data[:x25519KeySize])
+	x25519Pub, err := curve25519.X25519(x25519Priv, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	dk, err := mlkem.NewDecapsulationKey768(data[x25519KeySize:])
+	if err != nil {
+		return nil, err
+	}
+	return &HybridPrivKey{
+		x25519Priv: x25519Priv,
+		x25519Pub:  x25519Pub,
+		dk:         dk,
+	}, nil
+}
+
+// NewHybridPubKeyFromBytes creates the key from the X25519 public key followed by the ML-KEM encapsulation key
+func NewHybridPubKeyFromBytes(data []byte) (*HybridPubKey, error) {
+	if len(data) != hybridPubKeySize {
+		return nil, ErrIncorrectHybridKeySize
+	}
+	ek, err := mlkem.NewEncapsulationKey768(data[x25519KeySize:])
+	if err != nil {
+		return nil, err
+	}
+	x25519Pub := make([]byte, x25519KeySize)
+	copy(x25519Pub, data[:x25519KeySize])
+	return &HybridPubKey{x25519Pub: x25519Pub, ek: ek}, nil
+}
+
+func UnmarshalHybridPrivateKeyProto(bytes []byte) (*HybridPrivKey, error) {
+	msg := &cryptoproto.Key{}
+	if err := proto.Unmarshal(bytes, msg); err != nil {
+		return nil, err
+	}
+	if msg.Type != cryptoproto.KeyType_X25519MlKem768Private {
+		return nil, ErrIncorrectKeyType
+	}
+	return NewHybridPrivKeyFromBytes(msg.Data)
+}
+
+func UnmarshalHybridPublicKeyProto(bytes []byte) (*HybridPubKey, error) {
+	msg := &cryptoproto.Key{}
+	if err := proto.Unmarshal(bytes, msg); err != nil {
+		return nil, err
+	}
+	if msg.Type != cryptoproto.KeyType_X25519MlKem768Public {
+		return nil, ErrIncorrectKeyType
+	}
+	return NewHybridPubKeyFromBytes(msg.Data)
+}
+
+// Raw returns the X25519 scalar followed by the ML-KEM seed
+func (k *HybridPrivKey) Raw() ([]byte, error) {
+	return append(append([]byte{}, k.x25519Priv...), k.dk.Bytes()...), nil
+}
+
+func (k *HybridPrivKey) Equals(o Key) bool {
+	if _, ok := o.(*HybridPrivKey); !ok {
+		return false
+	}
+	return KeyEquals(k, o)
+}
+
+// Marshall marshalls the key into proto
+func (k *HybridPrivKey) Marshall() ([]byte, error) {
+	raw, _ := k.Raw()
+	msg := &cryptoproto.Key{
+		Type: cryptoproto.KeyType_X25519MlKem768Private,
+		Data: raw,
+	}
+	return msg.Marshal()
+}
+
+// GetPublic returns the associated public key
+func (k *HybridPrivKey) GetPublic() *HybridPubKey {
+	return &HybridPubKey{x25519Pub: k.x25519Pub, ek: k.dk.EncapsulationKey()}
+}
+
+// Decrypt decrypts the message encrypted with the public key
+func (k *HybridPrivKey) Decrypt(msg []byte) ([]byte, error) {
+	if len(msg) < hybridCiphertextSize+chacha20poly1305.Overhead {
+		return nil, ErrHybridDecryptionFailed
+	}
+	ephPub, kemCiphertext := msg[:x25519KeySize], msg[x25519KeySize:hybridCiphertextSize]
+	xShared, err := curve25519.X25519(k.x25519Priv, ephPub)
+	if err != nil {
+		return nil, ErrHybridDecryptionFailed
+	}
+	kemShared, err := k.dk.Decapsulate(kemCiphertext)
+	if err != nil {
+		return nil, ErrHybridDecryptionFailed
+	}
+	aead, err := chacha20poly1305.New(combineHybridSecrets(kemShared, xShared, ephPub, k.x25519Pub))
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := aead.Open(nil, make([]byte, aead.NonceSize()), msg[hybridCiphertextSize:], nil)
+	if err != nil {
+		return nil, ErrHybridDecryptionFailed
+	}
+	return decrypted, nil
+}
+
+// Raw returns the X25519 public key followed by the ML-KEM encapsulation key
+func (k *HybridPubKey) Raw() ([]byte, error) {
+	return append(append([]byte{}, k.x25519Pub...), k.ek.Bytes()...), nil
+}
+
+func (k *HybridPubKey) Equals(o Key) bool {
+	other, ok := o.(*HybridPubKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(k.x25519Pub, other.x25519Pub) == 1 &&
+		subtle.ConstantTimeCompare(k.ek.Bytes(), other.ek.Bytes()) == 1
+}
+
+// Marshall marshalls the key into proto
+func (k *HybridPubKey) Marshall() ([]byte, error) {
+	raw, _ := k.Raw()
+	msg := &cryptoproto.Key{
+		Type: cryptoproto.KeyType_X25519MlKem768Public,
+		Data: raw,
+	}
+	return msg.Marshal()
+}
+
+// Encrypt encrypts the message, the result contains the ephemeral X25519 key, ML-KEM ciphertext and the sealed message
+func (k *HybridPubKey) Encrypt(msg []byte) ([]byte, error) {
+	ephPriv := make([]byte, x25519KeySize)
+	if _, err := rand.Read(ephPriv); err != nil {
+		return nil, err
+	}
+	ephPub, err := curve25519.X25519(ephPriv, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	xShared, err := curve25519.X25519(ephPriv, k.x25519Pub)
+	if err != nil {
+		return nil, err
+	}
+	kemShared, kemCiphertext := k.ek.Encapsulate()
+	// the key is used only once, so the nonce can be zero
+	aead, err := chacha20poly1305.New(combineHybridSecrets(kemShared, xShared, ephPub, k.x25519Pub))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, 0, hybridCiphertextSize+len(msg)+aead.Overhead())
+	res = append(res, ephPub...)
+	res = append(res, kemCiphertext...)
+	return aead.Seal(res, make([]byte, aead.NonceSize()), msg, nil), nil
+}
+
+func combineHybridSecrets(kemShared, xShared, ephPub, x25519Pub []byte) []byte {
+	h := sha3.New256()
+	h.Write(kemShared)
+	h.Write(xShared)
+	h.Write(ephPub)
+	h.Write(x25519Pub)
+	h.Write([]byte(hybridLabel))
+	return h.Sum(nil)
+}
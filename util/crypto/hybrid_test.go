@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHybridKey(t *testing.T) {
+	msg := []byte("read key")
+	t.Run("encrypt decrypt", func(t *testing.T) {
+		privKey, err := GenerateRandomHybridKey()
+		require.NoError(t, err)
+		enc, err := privKey.GetPublic().Encrypt(msg)
+		require.NoError(t, err)
+		dec, err := privKey.Decrypt(enc)
+		require.NoError(t, err)
+		require.Equal(t, msg, dec)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		privKey, err := GenerateRandomHybridKey()
+		require.NoError(t, err)
+		otherKey, err := GenerateRandomHybridKey()
+		require.NoError(t, err)
+		enc, err := privKey.GetPublic().Encrypt(msg)
+		require.NoError(t, err)
+		_, err = otherKey.Decrypt(enc)
+		require.ErrorIs(t, err, ErrHybridDecryptionFailed)
+	})
+	t.Run("broken message", func(t *testing.T) {
+		privKey, err := GenerateRandomHybridKey()
+		require.NoError(t, err)
+		enc, err := privKey.GetPublic().Encrypt(msg)
+		require.NoError(t, err)
+		enc[len(enc)-1] ^= 1
+		_, err = privKey.Decrypt(enc)
+		require.ErrorIs(t, err, ErrHybridDecryptionFailed)
+		_, err = privKey.Decrypt(enc[:10])
+		require.ErrorIs(t, err, ErrHybridDecryptionFailed)
+	})
+	t.Run("random keys are independent", func(t *testing.T) {
+		first, err := GenerateRandomHybridKey()
+		require.NoError(t, err)
+		second, err := GenerateRandomHybridKey()
+		require.NoError(t, err)
+		require.False(t, first.Equals(second))
+		require.False(t, first.GetPublic().Equals(second.GetPublic()))
+	})
+	t.Run("marshall", func(t *testing.T) {
+		privKey, err := GenerateRandomHybridKey()
+		require.NoError(t, err)
+		marshalledPriv, err := privKey.Marshall()
+		require.NoError(t, err)
+		unmarshalledPriv, err := UnmarshalHybridPrivateKeyProto(marshalledPriv)
+		require.NoError(t, err)
+		require.True(t, privKey.Equals(unmarshalledPriv))
+
+		marshalledPub, err := privKey.GetPublic().Marshall()
+		require.NoError(t, err)
+		pubKey, err := UnmarshalHybridPublicKeyProto(marshalledPub)
+		require.NoError(t, err)
+		require.True(t, privKey.GetPublic().Equals(pubKey))
+		enc, err := pubKey.Encrypt(msg)
+		require.NoError(t, err)
+		dec, err := unmarshalledPriv.Decrypt(enc)
+		require.NoError(t, err)
+		require.Equal(t, msg, dec)
+
+		_, err = UnmarshalHybridPublicKeyProto(marshalledPriv)
+		require.ErrorIs(t, err, ErrIncorrectKeyType)
+		_, err = UnmarshalEd25519PublicKeyProto(marshalledPub)
+		require.ErrorIs(t, err, ErrIncorrectKeyType)
+	})
+}
@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"errors"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto/pb"
+)
+
+var ErrKeyNotExportable = errors.New("key is not exportable")
+
+// Signer performs the private key operations, the key itself can be kept outside the process memory,
+// e.g. by the local agent or the hardware token
+type Signer interface {
+	// GetPublic returns the public key of the signer
+	GetPublic() PubKey
+	// Sign signs the raw bytes and returns the signature
+	Sign(data []byte) ([]byte, error)
+	// Decrypt decrypts the message encrypted with the public key of the signer
+	Decrypt(message []byte) ([]byte, error)
+}
+
+var _ Signer = (PrivKey)(nil)
+
+// NewSignerKey wraps the signer into PrivKey, so it can be used as the account key.
+// The key can't be exported, so Raw and Marshall return ErrKeyNotExportable
+func NewSignerKey(signer Signer) PrivKey {
+	return &signerKey{Signer: signer}
+}
+
+type signerKey struct {
+	Signer
+}
+
+func (k *signerKey) Equals(o Key) bool {
+	other, ok := o.(PrivKey)
+	if !ok {
+		return false
+	}
+	return k.GetPublic().Equals(other.GetPublic())
+}
+
+func (k *signerKey) Raw() ([]byte, error) {
+	return nil, ErrKeyNotExportable
+}
+
+func (k *signerKey) Marshall() ([]byte, error) {
+	return nil, ErrKeyNotExportable
+}
+
+// LibP2P returns libp2p key which signs through the signer
+func (k *signerKey) LibP2P() (crypto.PrivKey, error) {
+	pubKey, err := k.GetPublic().LibP2P()
+	if err != nil {
+		return nil, err
+	}
+	return &libP2PSignerKey{signer: k.Signer, pubKey: pubKey}, nil
+}
+
+type libP2PSignerKey struct {
+	signer Signer
+	pubKey crypto.PubKey
+}
+
+func (k *libP2PSignerKey) Equals(o crypto.Key) bool {
+	other, ok := o.(crypto.PrivKey)
+	if !ok {
+		return false
+	}
+	return k.pubKey.Equals(other.GetPublic())
+}
+
+func (k *libP2PSignerKey) Raw() ([]byte, error) {
+	return nil, ErrKeyNotExportable
+}
+
+func (k *libP2PSignerKey) Type() pb.KeyType {
+	return k.pubKey.Type()
+}
+
+func (k *libP2PSignerKey) Sign(data []byte) ([]byte, error) {
+	return k.signer.Sign(data)
+}
+
+func (k *libP2PSignerKey) GetPublic() crypto.PubKey {
+	return k.pubKey
+}
@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignerKey(t *testing.T) {
+	privKey, pubKey, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	key := NewSignerKey(privKey)
+	assert.True(t, key.GetPublic().Equals(pubKey))
+	assert.True(t, key.Equals(privKey))
+
+	sig, err := key.Sign([]byte("data"))
+	require.NoError(t, err)
+	ok, err := pubKey.Verify([]byte("data"), sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	encrypted, err := pubKey.Encrypt([]byte("message"))
+	require.NoError(t, err)
+	decrypted, err := key.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("message"), decrypted)
+
+	_, err = key.Raw()
+	require.ErrorIs(t, err, ErrKeyNotExportable)
+	_, err = key.Marshall()
+	require.ErrorIs(t, err, ErrKeyNotExportable)
+
+	libP2PKey, err := key.LibP2P()
+	require.NoError(t, err)
+	sig, err = libP2PKey.Sign([]byte("data"))
+	require.NoError(t, err)
+	ok, err = libP2PKey.GetPublic().Verify([]byte("data"), sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	libP2PPubKey, err := pubKey.LibP2P()
+	require.NoError(t, err)
+	assert.Equal(t, libP2PPubKey.Type(), libP2PKey.Type())
+}
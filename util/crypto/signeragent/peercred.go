@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package signeragent
+
+import (
+	"errors"
+	"net"
+)
+
+var errPeerCredUnsupported = errors.New("peer credentials are not supported on this platform")
+
+// peerUid is not implemented, so the agent rejects every client
+func peerUid(conn *net.UnixConn) (uid int, err error) {
+	return 0, errPeerCredUnsupported
+}
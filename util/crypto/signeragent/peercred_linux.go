@@ -0,0 +1,26 @@
+//go:build linux
+
+package signeragent
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+func peerUid(conn *net.UnixConn) (uid int, err error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return
+	}
+	var credErr error
+	if err = raw.Control(func(fd uintptr) {
+		var cred *unix.Ucred
+		if cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED); credErr == nil {
+			uid = int(cred.Uid)
+		}
+	}); err != nil {
+		return
+	}
+	return uid, credErr
+}
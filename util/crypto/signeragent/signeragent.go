@@ -0,0 +1,350 @@
+// Package signeragent keeps the private keys in the separate process and gives access to them through crypto.Signer.
+// The agent listens on the unix socket and serves the public key, signing and decryption requests for the named keys
+// including the hybrid encryption keys,
+// the keys are never sent to the client. The socket must be accessible only by its owner and the agent serves
+// only the clients running with the same uid as the agent.
+package signeragent
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var log = logger.NewNamed("common.util.signeragent")
+
+const maxMessageSize = 4 << 20
+
+const (
+	opPublic byte = iota + 1
+	opSign
+	opDecrypt
+	opEncryptionPublic
+	opEncryptionDecrypt
+)
+
+const (
+	statusOk byte = iota
+	statusError
+)
+
+var (
+	ErrUnknownKey       = errors.New("unknown key")
+	ErrUnknownOperation = errors.New("unknown operation")
+	ErrMessageTooBig    = errors.New("message is too big")
+	ErrNotUnixSocket    = errors.New("signer agent works only over the unix socket")
+	ErrSocketMode       = errors.New("signer agent socket is accessible by other users")
+)
+
+// socketMode is the only allowed mode of the agent socket
+const socketMode os.FileMode = 0600
+
+// Agent serves the signers and the hybrid encryption keys by their key ids
+type Agent struct {
+	signers        map[string]crypto.Signer
+	encryptionKeys map[string]crypto.HybridDecryptor
+	// uid is the only user which can use the agent
+	uid int
+}
+
+func NewAgent(signers map[string]crypto.Signer) *Agent {
+	return &Agent{signers: signers, encryptionKeys: map[string]crypto.HybridDecryptor{}, uid: os.Getuid()}
+}
+
+// AddEncryptionKey adds the hybrid key which is used to decrypt the acl read keys, it must be called before Serve
+func (a *Agent) AddEncryptionKey(keyId string, key crypto.HybridDecryptor) {
+	a.encryptionKeys[keyId] = key
+}
+
+// Listen creates the unix socket which is accessible only by the current user
+func Listen(path string) (net.Listener, error) {
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, socketMode); err != nil {
+		_ = lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
+
+// Serve accepts the connections until the listener is closed,
+// the listener must be the unix socket with the 0600 mode, e.g. created by Listen
+func (a *Agent) Serve(lis net.Listener) error {
+	unixLis, ok := lis.(*net.UnixListener)
+	if !ok {
+		return ErrNotUnixSocket
+	}
+	info, err := os.Stat(unixLis.Addr().String())
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm() != socketMode {
+		return ErrSocketMode
+	}
+	for {
+		conn, err := unixLis.AcceptUnix()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go a.handleConn(conn)
+	}
+}
+
+func (a *Agent) handleConn(conn *net.UnixConn) {
+	defer conn.Close()
+	uid, err := peerUid(conn)
+	if err != nil {
+		log.Warn("failed to get peer credentials", zap.Error(err))
+		return
+	}
+	if uid != a.uid {
+		log.Warn("rejected the client of another user", zap.Int("uid", uid))
+		return
+	}
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	for {
+		op, err := rw.ReadByte()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Warn("failed to read request", zap.Error(err))
+			}
+			return
+		}
+		keyId, err := readBytes(rw)
+		if err != nil {
+			return
+		}
+		data, err := readBytes(rw)
+		if err != nil {
+			return
+		}
+		res, err := a.handle(op, string(keyId), data)
+		if err != nil {
+			err = writeMessage(rw, statusError, []byte(err.Error()))
+		} else {
+			err = writeMessage(rw, statusOk, res)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (a *Agent) handle(op byte, keyId string, data []byte) ([]byte, error) {
+	switch op {
+	case opEncryptionPublic, opEncryptionDecrypt:
+		return a.handleEncryption(op, keyId, data)
+	}
+	signer, ok := a.signers[keyId]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	switch op {
+	case opPublic:
+		return signer.GetPublic().Marshall()
+	case opSign:
+		return signer.Sign(data)
+	case opDecrypt:
+		return signer.Decrypt(data)
+	}
+	return nil, ErrUnknownOperation
+}
+
+func (a *Agent) handleEncryption(op byte, keyId string, data []byte) ([]byte, error) {
+	key, ok := a.encryptionKeys[keyId]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if op == opEncryptionPublic {
+		return key.GetPublic().Marshall()
+	}
+	return key.Decrypt(data)
+}
+
+// Client sends the requests to the agent, it reconnects if the connection is broken
+type Client struct {
+	addr string
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	mu   sync.Mutex
+}
+
+// Dial connects to the agent socket, e.g. Dial("/run/user/1000/anysync-signer.sock")
+func Dial(addr string) (*Client, error) {
+	c := &Client{addr: addr}
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Client) connect() error {
+	conn, err := net.Dial("unix", c.addr)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	c.rw = bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	return nil
+}
+
+// Signer returns the signer of the agent key
+func (c *Client) Signer(keyId string) (crypto.Signer, error) {
+	marshalled, err := c.call(opPublic, keyId, nil)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := crypto.UnmarshalEd25519PublicKeyProto(marshalled)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteSigner{client: c, keyId: keyId, pubKey: pubKey}, nil
+}
+
+// EncryptionKey returns the hybrid key of the agent
+func (c *Client) EncryptionKey(keyId string) (crypto.HybridDecryptor, error) {
+	marshalled, err := c.call(opEncryptionPublic, keyId, nil)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := crypto.UnmarshalHybridPublicKeyProto(marshalled)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteEncryptionKey{client: c, keyId: keyId, pubKey: pubKey}, nil
+}
+
+func (c *Client) call(op byte, keyId string, data []byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		if err := c.connect(); err != nil {
+			return nil, err
+		}
+	}
+	status, res, err := c.roundTrip(op, keyId, data)
+	if err != nil {
+		// the response can't be matched with the request anymore, so the connection is dropped
+		_ = c.conn.Close()
+		c.conn = nil
+		return nil, err
+	}
+	if status != statusOk {
+		return nil, fmt.Errorf("signer agent: %s", res)
+	}
+	return res, nil
+}
+
+func (c *Client) roundTrip(op byte, keyId string, data []byte) (status byte, res []byte, err error) {
+	if err = c.rw.WriteByte(op); err != nil {
+		return
+	}
+	if err = writeBytes(c.rw, []byte(keyId)); err != nil {
+		return
+	}
+	if err = writeBytes(c.rw, data); err != nil {
+		return
+	}
+	if err = c.rw.Flush(); err != nil {
+		return
+	}
+	if status, err = c.rw.ReadByte(); err != nil {
+		return
+	}
+	res, err = readBytes(c.rw)
+	return
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+type remoteSigner struct {
+	client *Client
+	keyId  string
+	pubKey crypto.PubKey
+}
+
+func (s *remoteSigner) GetPublic() crypto.PubKey {
+	return s.pubKey
+}
+
+func (s *remoteSigner) Sign(data []byte) ([]byte, error) {
+	return s.client.call(opSign, s.keyId, data)
+}
+
+func (s *remoteSigner) Decrypt(message []byte) ([]byte, error) {
+	return s.client.call(opDecrypt, s.keyId, message)
+}
+
+type remoteEncryptionKey struct {
+	client *Client
+	keyId  string
+	pubKey *crypto.HybridPubKey
+}
+
+func (k *remoteEncryptionKey) GetPublic() *crypto.HybridPubKey {
+	return k.pubKey
+}
+
+func (k *remoteEncryptionKey) Decrypt(message []byte) ([]byte, error) {
+	return k.client.call(opEncryptionDecrypt, k.keyId, message)
+}
+
+func writeMessage(w *bufio.ReadWriter, status byte, payload []byte) error {
+	if err := w.WriteByte(status); err != nil {
+		return err
+	}
+	if err := writeBytes(w, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func writeBytes(w io.Writer, data []byte) error {
+	if len(data) > maxMessageSize {
+		return ErrMessageTooBig
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(data)))
+	if _, err := w.Write(lenBuf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readBytes(r *bufio.ReadWriter) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxMessageSize {
+		return nil, ErrMessageTooBig
+	}
+	data := make([]byte, size)
+	_, err = io.ReadFull(r, data)
+	return data, err
+}
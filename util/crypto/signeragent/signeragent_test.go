@@ -0,0 +1,129 @@
+package signeragent
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestSignerAgent(t *testing.T) {
+	privKey, pubKey, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	addr := filepath.Join(t.TempDir(), "signer.sock")
+	lis, err := Listen(addr)
+	require.NoError(t, err)
+	encKey, err := crypto.GenerateRandomHybridKey()
+	require.NoError(t, err)
+	agent := NewAgent(map[string]crypto.Signer{"account": privKey})
+	agent.AddEncryptionKey("encryption", encKey)
+	done := make(chan error, 1)
+	go func() {
+		done <- agent.Serve(lis)
+	}()
+	client, err := Dial(addr)
+	require.NoError(t, err)
+
+	t.Run("sign and decrypt", func(t *testing.T) {
+		signer, err := client.Signer("account")
+		require.NoError(t, err)
+		assert.True(t, signer.GetPublic().Equals(pubKey))
+
+		sig, err := signer.Sign([]byte("data"))
+		require.NoError(t, err)
+		ok, err := pubKey.Verify([]byte("data"), sig)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		encrypted, err := pubKey.Encrypt([]byte("message"))
+		require.NoError(t, err)
+		decrypted, err := signer.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("message"), decrypted)
+	})
+	t.Run("hybrid decrypt", func(t *testing.T) {
+		remoteKey, err := client.EncryptionKey("encryption")
+		require.NoError(t, err)
+		assert.True(t, remoteKey.GetPublic().Equals(encKey.GetPublic()))
+
+		encrypted, err := remoteKey.GetPublic().Encrypt([]byte("message"))
+		require.NoError(t, err)
+		decrypted, err := remoteKey.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("message"), decrypted)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := client.Signer("unknown")
+		require.ErrorContains(t, err, ErrUnknownKey.Error())
+		_, err = client.EncryptionKey("account")
+		require.ErrorContains(t, err, ErrUnknownKey.Error())
+	})
+	t.Run("decryption error", func(t *testing.T) {
+		signer, err := client.Signer("account")
+		require.NoError(t, err)
+		_, otherKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		encrypted, err := otherKey.Encrypt([]byte("message"))
+		require.NoError(t, err)
+		_, err = signer.Decrypt(encrypted)
+		require.Error(t, err)
+		// the connection is still usable
+		_, err = signer.Sign([]byte("data"))
+		require.NoError(t, err)
+	})
+	t.Run("reconnect", func(t *testing.T) {
+		signer, err := client.Signer("account")
+		require.NoError(t, err)
+		require.NoError(t, client.Close())
+		_, err = signer.Sign([]byte("data"))
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, client.Close())
+	require.NoError(t, lis.Close())
+	require.NoError(t, <-done)
+}
+
+func TestSignerAgentAccess(t *testing.T) {
+	privKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	signers := map[string]crypto.Signer{"account": privKey}
+
+	t.Run("tcp listener", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer lis.Close()
+		require.ErrorIs(t, NewAgent(signers).Serve(lis), ErrNotUnixSocket)
+	})
+	t.Run("socket accessible by others", func(t *testing.T) {
+		addr := filepath.Join(t.TempDir(), "signer.sock")
+		lis, err := net.Listen("unix", addr)
+		require.NoError(t, err)
+		defer lis.Close()
+		require.NoError(t, os.Chmod(addr, 0666))
+		require.ErrorIs(t, NewAgent(signers).Serve(lis), ErrSocketMode)
+	})
+	t.Run("client of another user", func(t *testing.T) {
+		addr := filepath.Join(t.TempDir(), "signer.sock")
+		lis, err := Listen(addr)
+		require.NoError(t, err)
+		agent := NewAgent(signers)
+		agent.uid = os.Getuid() + 1
+		done := make(chan error, 1)
+		go func() {
+			done <- agent.Serve(lis)
+		}()
+		client, err := Dial(addr)
+		require.NoError(t, err)
+		_, err = client.Signer("account")
+		require.Error(t, err)
+		require.NoError(t, client.Close())
+		require.NoError(t, lis.Close())
+		require.NoError(t, <-done)
+	})
+}
@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var ErrInvalidSuccession = errors.New("invalid key succession")
+
+// successionLabel binds the succession signatures to the succession, so they can't be reused in the other protocols
+const successionLabel = "acl-key-succession"
+
+// KeySuccession links the old identity with the new one.
+// Both keys sign the labeled statement with the old and new identities,
+// so the record proves that the owners of both keys agree on the rotation
+type KeySuccession struct {
+	OldIdentity PubKey
+	NewIdentity PubKey
+	// NewIdentitySignature is the succession statement signed by the old key
+	NewIdentitySignature []byte
+	// OldIdentitySignature is the succession statement signed by the new key
+	OldIdentitySignature []byte
+}
+
+// NewKeySuccession creates the succession from the old key to the new one
+func NewKeySuccession(oldKey, newKey Signer) (*KeySuccession, error) {
+	statement, err := successionStatement(oldKey.GetPublic(), newKey.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+	newSignature, err := oldKey.Sign(statement)
+	if err != nil {
+		return nil, err
+	}
+	oldSignature, err := newKey.Sign(statement)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySuccession{
+		OldIdentity:          oldKey.GetPublic(),
+		NewIdentity:          newKey.GetPublic(),
+		NewIdentitySignature: newSignature,
+		OldIdentitySignature: oldSignature,
+	}, nil
+}
+
+// Verify checks that the identities are different and both signatures are valid
+func (s *KeySuccession) Verify() error {
+	if s.OldIdentity == nil || s.NewIdentity == nil || s.OldIdentity.Equals(s.NewIdentity) {
+		return ErrInvalidSuccession
+	}
+	statement, err := successionStatement(s.OldIdentity, s.NewIdentity)
+	if err != nil {
+		return ErrInvalidSuccession
+	}
+	if !verify(s.OldIdentity, statement, s.NewIdentitySignature) || !verify(s.NewIdentity, statement, s.OldIdentitySignature) {
+		return ErrInvalidSuccession
+	}
+	return nil
+}
+
+// successionStatement is the label followed by the length-prefixed raw old and new identities
+func successionStatement(oldIdentity, newIdentity PubKey) ([]byte, error) {
+	oldRaw, err := oldIdentity.Raw()
+	if err != nil {
+		return nil, err
+	}
+	newRaw, err := newIdentity.Raw()
+	if err != nil {
+		return nil, err
+	}
+	statement := make([]byte, 0, len(successionLabel)+len(oldRaw)+len(newRaw)+2*binary.MaxVarintLen64)
+	statement = append(statement, successionLabel...)
+	statement = binary.AppendUvarint(statement, uint64(len(oldRaw)))
+	statement = append(statement, oldRaw...)
+	statement = binary.AppendUvarint(statement, uint64(len(newRaw)))
+	statement = append(statement, newRaw...)
+	return statement, nil
+}
+
+func verify(key PubKey, data, signature []byte) bool {
+	ok, err := key.Verify(data, signature)
+	return err == nil && ok
+}
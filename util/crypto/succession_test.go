@@ -0,0 +1,60 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySuccession(t *testing.T) {
+	oldKey, _, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	newKey, _, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	otherKey, _, err := GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+
+	t.Run("valid succession", func(t *testing.T) {
+		succession, err := NewKeySuccession(oldKey, NewSignerKey(newKey))
+		require.NoError(t, err)
+		require.NoError(t, succession.Verify())
+		require.True(t, succession.OldIdentity.Equals(oldKey.GetPublic()))
+		require.True(t, succession.NewIdentity.Equals(newKey.GetPublic()))
+	})
+	t.Run("raw identity signatures are not accepted", func(t *testing.T) {
+		newRaw, err := newKey.GetPublic().Raw()
+		require.NoError(t, err)
+		oldRaw, err := oldKey.GetPublic().Raw()
+		require.NoError(t, err)
+		newSignature, err := oldKey.Sign(newRaw)
+		require.NoError(t, err)
+		oldSignature, err := newKey.Sign(oldRaw)
+		require.NoError(t, err)
+		succession := &KeySuccession{
+			OldIdentity:          oldKey.GetPublic(),
+			NewIdentity:          newKey.GetPublic(),
+			NewIdentitySignature: newSignature,
+			OldIdentitySignature: oldSignature,
+		}
+		require.ErrorIs(t, succession.Verify(), ErrInvalidSuccession)
+	})
+	t.Run("signature of another key", func(t *testing.T) {
+		succession, err := NewKeySuccession(oldKey, newKey)
+		require.NoError(t, err)
+		succession.NewIdentity = otherKey.GetPublic()
+		require.ErrorIs(t, succession.Verify(), ErrInvalidSuccession)
+	})
+	t.Run("one-sided succession", func(t *testing.T) {
+		succession, err := NewKeySuccession(oldKey, newKey)
+		require.NoError(t, err)
+		other, err := NewKeySuccession(oldKey, otherKey)
+		require.NoError(t, err)
+		succession.OldIdentitySignature = other.OldIdentitySignature
+		require.ErrorIs(t, succession.Verify(), ErrInvalidSuccession)
+	})
+	t.Run("same key", func(t *testing.T) {
+		succession, err := NewKeySuccession(oldKey, oldKey)
+		require.NoError(t, err)
+		require.ErrorIs(t, succession.Verify(), ErrInvalidSuccession)
+	})
+}
@@ -12,7 +12,9 @@ import (
 
 type ActionPool interface {
 	Run()
-	Add(peerId, objectId string, action func(ctx context.Context), remove func())
+	// Add queues the action for the object, the actions with higher priority are called first,
+	// size is spent from the bandwidth of the peer if it is limited
+	Add(peerId, objectId string, priority int, size uint64, action func(ctx context.Context), remove func())
 	Close()
 }
 
@@ -79,7 +81,7 @@ func (rp *actionPool) gc(ctx context.Context) error {
 	return nil
 }
 
-func (rp *actionPool) Add(peerId, objectId string, action func(ctx context.Context), remove func()) {
+func (rp *actionPool) Add(peerId, objectId string, priority int, size uint64, action func(ctx context.Context), remove func()) {
 	rp.mu.Lock()
 	if rp.isClosed {
 		rp.mu.Unlock()
@@ -105,7 +107,7 @@ func (rp *actionPool) Add(peerId, objectId string, action func(ctx context.Conte
 		action(rp.ctx)
 		rp.release(peerId, objectId)
 	}
-	queue.Replace(objectId, wrappedAction, remove)
+	queue.Replace(objectId, priority, size, wrappedAction, remove)
 }
 
 func (rp *actionPool) Close() {
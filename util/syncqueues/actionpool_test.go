@@ -20,11 +20,11 @@ func TestRequestPool(t *testing.T) {
 		wait := make(chan struct{})
 		wg := &sync.WaitGroup{}
 		wg.Add(2)
-		rp.Add("peerId", "objectId", func(ctx context.Context) {
+		rp.Add("peerId", "objectId", 0, 0, func(ctx context.Context) {
 			wg.Done()
 			<-wait
 		}, func() {})
-		rp.Add("peerId1", "objectId", func(ctx context.Context) {
+		rp.Add("peerId1", "objectId", 0, 0, func(ctx context.Context) {
 			wg.Done()
 			<-wait
 		}, func() {})
@@ -40,11 +40,11 @@ func TestRequestPool(t *testing.T) {
 		wait := make(chan struct{})
 		wg := &sync.WaitGroup{}
 		wg.Add(2)
-		rp.Add("peerId", "objectId", func(ctx context.Context) {
+		rp.Add("peerId", "objectId", 0, 0, func(ctx context.Context) {
 			wg.Done()
 			<-wait
 		}, func() {})
-		rp.Add("peerId", "objectId1", func(ctx context.Context) {
+		rp.Add("peerId", "objectId1", 0, 0, func(ctx context.Context) {
 			wg.Done()
 			<-wait
 		}, func() {})
@@ -62,13 +62,13 @@ func TestRequestPool(t *testing.T) {
 		cnt := atomic.NewBool(false)
 		wg := &sync.WaitGroup{}
 		wg.Add(1)
-		rp.Add("peerId", "objectId", func(ctx context.Context) {
+		rp.Add("peerId", "objectId", 0, 0, func(ctx context.Context) {
 			cnt.Store(true)
 			wg.Done()
 			<-wait
 		}, func() {})
 		time.Sleep(100 * time.Millisecond)
-		rp.Add("peerId", "objectId", func(ctx context.Context) {
+		rp.Add("peerId", "objectId", 0, 0, func(ctx context.Context) {
 			require.Fail(t, "should not be called")
 			wg.Done()
 			<-wait
@@ -88,13 +88,13 @@ func TestRequestPool(t *testing.T) {
 		cnt := atomic.NewBool(false)
 		wg := &sync.WaitGroup{}
 		wg.Add(1)
-		rp.Add("peerId", "objectId", func(ctx context.Context) {
+		rp.Add("peerId", "objectId", 0, 0, func(ctx context.Context) {
 			<-wait
 		}, func() {})
-		rp.Add("peerId", "objectId1", func(ctx context.Context) {
+		rp.Add("peerId", "objectId1", 0, 0, func(ctx context.Context) {
 			require.Fail(t, "should not be called")
 		}, func() {})
-		rp.Add("peerId", "objectId1", func(ctx context.Context) {
+		rp.Add("peerId", "objectId1", 0, 0, func(ctx context.Context) {
 			cnt.Store(true)
 			wg.Done()
 		}, func() {})
@@ -113,15 +113,15 @@ func TestRequestPool(t *testing.T) {
 		wait := make(chan struct{})
 		wg := &sync.WaitGroup{}
 		wg.Add(2)
-		rp.Add("peerId", "objectId", func(ctx context.Context) {
+		rp.Add("peerId", "objectId", 0, 0, func(ctx context.Context) {
 			<-wait
 		}, func() {})
 		time.Sleep(100 * time.Millisecond)
-		rp.Add("peerId", "objectId1", func(ctx context.Context) {
+		rp.Add("peerId", "objectId1", 0, 0, func(ctx context.Context) {
 			wg.Done()
 		}, func() {
 		})
-		rp.Add("peerId", "objectId2", func(ctx context.Context) {
+		rp.Add("peerId", "objectId2", 0, 0, func(ctx context.Context) {
 		}, func() {
 			wg.Done()
 		})
@@ -129,6 +129,54 @@ func TestRequestPool(t *testing.T) {
 		wg.Wait()
 		rp.Close()
 	})
+	t.Run("priority", func(t *testing.T) {
+		rp := NewActionPool(time.Minute, time.Minute, func(peerId string) *replaceableQueue {
+			return newReplaceableQueue(1, 10)
+		})
+		rp.Run()
+		wait := make(chan struct{})
+		started := make(chan struct{})
+		called := make(chan string, 3)
+		rp.Add("peerId", "objectId", 0, 0, func(ctx context.Context) {
+			close(started)
+			<-wait
+		}, func() {})
+		<-started
+		add := func(objectId string, priority int) {
+			rp.Add("peerId", objectId, priority, 0, func(ctx context.Context) {
+				called <- objectId
+			}, func() {})
+		}
+		add("background", 0)
+		add("foreground", 1)
+		add("interactive", 2)
+		// the replaced action gets the higher priority
+		add("background", 2)
+		close(wait)
+		require.Equal(t, "interactive", <-called)
+		require.Equal(t, "background", <-called)
+		require.Equal(t, "foreground", <-called)
+		rp.Close()
+	})
+	t.Run("bandwidth", func(t *testing.T) {
+		rp := NewActionPool(time.Minute, time.Minute, func(peerId string) *replaceableQueue {
+			queue := newReplaceableQueue(2, 10)
+			queue.setBandwidth(1000)
+			return queue
+		})
+		rp.Run()
+		called := make(chan time.Time, 2)
+		start := time.Now()
+		for _, objectId := range []string{"objectId1", "objectId2"} {
+			rp.Add("peerId", objectId, 0, 600, func(ctx context.Context) {
+				called <- time.Now()
+			}, func() {})
+		}
+		require.Less(t, (<-called).Sub(start), 100*time.Millisecond)
+		// the second action waits until the bucket has enough bytes
+		require.Greater(t, (<-called).Sub(start), 150*time.Millisecond)
+		rp.Close()
+	})
 	t.Run("gc", func(t *testing.T) {
 		rp := NewActionPool(time.Millisecond*20, time.Millisecond*20, func(peerId string) *replaceableQueue {
 			return newReplaceableQueue(2, 2)
@@ -136,10 +184,10 @@ func TestRequestPool(t *testing.T) {
 		rp.Run()
 		wg := &sync.WaitGroup{}
 		wg.Add(2)
-		rp.Add("peerId1", "objectId1", func(ctx context.Context) {
+		rp.Add("peerId1", "objectId1", 0, 0, func(ctx context.Context) {
 			wg.Done()
 		}, func() {})
-		rp.Add("peerId2", "objectId2", func(ctx context.Context) {
+		rp.Add("peerId2", "objectId2", 0, 0, func(ctx context.Context) {
 			wg.Done()
 		}, func() {})
 		wg.Wait()
@@ -0,0 +1,43 @@
+package syncqueues
+
+import "time"
+
+// bandwidth is the token bucket of the bytes which can be sent to the peer,
+// it is refilled every second and can't hold more than one second of the traffic
+type bandwidth struct {
+	bytesPerSecond float64
+	available      float64
+	updated        time.Time
+}
+
+func newBandwidth(bytesPerSecond uint64, now time.Time) *bandwidth {
+	return &bandwidth{
+		bytesPerSecond: float64(bytesPerSecond),
+		available:      float64(bytesPerSecond),
+		updated:        now,
+	}
+}
+
+// delay returns the time to wait until the message with the given size can be sent,
+// the messages which are bigger than the bucket wait only for the full bucket
+func (b *bandwidth) delay(now time.Time, size uint64) time.Duration {
+	b.refill(now)
+	need := min(float64(size), b.bytesPerSecond)
+	if b.available >= need {
+		return 0
+	}
+	return time.Duration((need - b.available) / b.bytesPerSecond * float64(time.Second))
+}
+
+// take spends the bytes of the message, the bucket can go below zero for the big messages
+func (b *bandwidth) take(now time.Time, size uint64) {
+	b.refill(now)
+	b.available -= float64(size)
+}
+
+func (b *bandwidth) refill(now time.Time) {
+	if now.After(b.updated) {
+		b.available = min(b.bytesPerSecond, b.available+now.Sub(b.updated).Seconds()*b.bytesPerSecond)
+		b.updated = now
+	}
+}
@@ -0,0 +1,22 @@
+package syncqueues
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidth(t *testing.T) {
+	now := time.Now()
+	b := newBandwidth(1000, now)
+	require.Zero(t, b.delay(now, 800))
+	b.take(now, 800)
+	require.Equal(t, 300*time.Millisecond, b.delay(now, 500))
+	require.Zero(t, b.delay(now.Add(300*time.Millisecond), 500))
+
+	// the message bigger than the bucket waits for the full bucket
+	b.take(now.Add(300*time.Millisecond), 3000)
+	require.Equal(t, 2800*time.Millisecond, b.delay(now.Add(time.Second), 5000))
+	require.Zero(t, b.delay(now.Add(3800*time.Millisecond), 5000))
+}
@@ -0,0 +1,13 @@
+package syncqueues
+
+type configGetter interface {
+	GetSyncQueues() Config
+}
+
+type Config struct {
+	// PeerBandwidth limits the size of the requests sent to one peer per second, zero means no limit,
+	// the limit is not applied between the responsible nodes.
+	// Only the outgoing requests are counted, the responses are not limited, because their size
+	// is not known when the request is sent, so the budget doesn't bound the downloaded bytes
+	PeerBandwidth uint64 `yaml:"peerBandwidth"`
+}
@@ -1,19 +1,54 @@
 package syncqueues
 
 import (
+	"container/heap"
 	"context"
 	"sync"
-	"sync/atomic"
 	"time"
-
-	"github.com/cheggaaa/mb/v3"
-	"go.uber.org/zap"
 )
 
 type entry struct {
 	call     func()
 	onRemove func()
-	cnt      uint64
+	priority int
+	size     uint64
+	// order is the order of the queue item which serves the entry
+	order uint64
+}
+
+type queueItem struct {
+	id       string
+	priority int
+	order    uint64
+}
+
+// priorityQueue serves the items with higher priority first and the items with the same priority in the order of adding
+type priorityQueue []queueItem
+
+func (q priorityQueue) Len() int {
+	return len(q)
+}
+
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].order < q[j].order
+}
+
+func (q priorityQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *priorityQueue) Push(x any) {
+	*q = append(*q, x.(queueItem))
+}
+
+func (q *priorityQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
 }
 
 func newReplaceableQueue(workers, maxSize int) *replaceableQueue {
@@ -22,8 +57,9 @@ func newReplaceableQueue(workers, maxSize int) *replaceableQueue {
 		ctx:     ctx,
 		cancel:  cancel,
 		workers: workers,
-		batch:   mb.New[string](maxSize),
+		maxSize: maxSize,
 		entries: map[string]entry{},
+		changed: make(chan struct{}),
 	}
 	return ss
 }
@@ -32,48 +68,69 @@ type replaceableQueue struct {
 	ctx        context.Context
 	cancel     context.CancelFunc
 	workers    int
-	cnt        atomic.Uint64
+	maxSize    int
+	order      uint64
 	lastServed time.Time
 	entries    map[string]entry
-	batch      *mb.MB[string]
+	queue      priorityQueue
+	bandwidth  *bandwidth
+	changed    chan struct{}
+	isClosed   bool
 	mx         sync.Mutex
 }
 
-func (rp *replaceableQueue) Replace(id string, call, remove func()) {
-	curCnt := rp.cnt.Load()
-	rp.cnt.Add(1)
+// setBandwidth limits the size of the messages which are sent to the peer per second
+func (rp *replaceableQueue) setBandwidth(bytesPerSecond uint64) {
+	rp.bandwidth = newBandwidth(bytesPerSecond, time.Now())
+}
+
+// Replace adds the call to the queue or replaces the call which is already queued with the same id,
+// the replaced call keeps the highest of the priorities
+func (rp *replaceableQueue) Replace(id string, priority int, size uint64, call, remove func()) {
 	rp.mx.Lock()
-	if prevEntry, ok := rp.entries[id]; ok {
-		rp.entries[id] = entry{
-			call:     call,
-			onRemove: remove,
-			cnt:      curCnt,
+	if rp.isClosed {
+		rp.mx.Unlock()
+		if remove != nil {
+			remove()
 		}
+		return
+	}
+	prevEntry, exists := rp.entries[id]
+	if !exists && len(rp.entries) >= rp.maxSize {
 		rp.mx.Unlock()
-		prevEntry.onRemove()
+		if remove != nil {
+			remove()
+		}
 		return
 	}
 	ent := entry{
 		call:     call,
 		onRemove: remove,
-		cnt:      curCnt,
+		priority: priority,
+		size:     size,
+	}
+	if exists && prevEntry.priority >= priority {
+		ent.priority = prevEntry.priority
+		ent.order = prevEntry.order
+	} else {
+		// the previous item of the entry is skipped when it is taken from the queue
+		ent.order = rp.push(id, priority)
 	}
 	rp.entries[id] = ent
 	rp.mx.Unlock()
-	err := rp.batch.TryAdd(id)
-	if err != nil {
-		rp.mx.Lock()
-		curEntry := rp.entries[id]
-		if curEntry.cnt == curCnt {
-			delete(rp.entries, id)
-		}
-		rp.mx.Unlock()
-		if ent.onRemove != nil {
-			ent.onRemove()
-		}
+	if exists && prevEntry.onRemove != nil {
+		prevEntry.onRemove()
 	}
 }
 
+func (rp *replaceableQueue) push(id string, priority int) uint64 {
+	rp.order++
+	heap.Push(&rp.queue, queueItem{id: id, priority: priority, order: rp.order})
+	close(rp.changed)
+	rp.changed = make(chan struct{})
+	return rp.order
+}
+
 func (rp *replaceableQueue) Run() {
 	for i := 0; i < rp.workers; i++ {
 		go rp.callLoop()
@@ -82,16 +139,11 @@ func (rp *replaceableQueue) Run() {
 
 func (rp *replaceableQueue) callLoop() {
 	for {
-		id, err := rp.batch.WaitOne(rp.ctx)
-		if err != nil {
-			log.Debug("close call loop", zap.Error(err))
+		curEntry, ok := rp.waitNext()
+		if !ok {
+			log.Debug("close call loop")
 			return
 		}
-		rp.mx.Lock()
-		rp.lastServed = time.Now()
-		curEntry := rp.entries[id]
-		delete(rp.entries, id)
-		rp.mx.Unlock()
 		if curEntry.call != nil {
 			curEntry.call()
 			if curEntry.onRemove != nil {
@@ -101,13 +153,71 @@ func (rp *replaceableQueue) callLoop() {
 	}
 }
 
+// waitNext waits for the entry with the highest priority which fits into the bandwidth of the peer
+func (rp *replaceableQueue) waitNext() (entry, bool) {
+	for {
+		rp.mx.Lock()
+		ent, wait, ok := rp.next(time.Now())
+		changed := rp.changed
+		rp.mx.Unlock()
+		if ok {
+			return ent, true
+		}
+		var (
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			timeout = timer.C
+		}
+		select {
+		case <-rp.ctx.Done():
+		case <-changed:
+		case <-timeout:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if rp.ctx.Err() != nil {
+			return entry{}, false
+		}
+	}
+}
+
+// next takes the entry from the queue, if the queue is not empty but the bandwidth is exhausted it returns the time to wait
+func (rp *replaceableQueue) next(now time.Time) (ent entry, wait time.Duration, ok bool) {
+	for rp.queue.Len() > 0 {
+		item := rp.queue[0]
+		ent, exists := rp.entries[item.id]
+		if !exists || ent.order != item.order {
+			heap.Pop(&rp.queue)
+			continue
+		}
+		if rp.bandwidth != nil {
+			if wait = rp.bandwidth.delay(now, ent.size); wait > 0 {
+				return entry{}, wait, false
+			}
+			rp.bandwidth.take(now, ent.size)
+		}
+		heap.Pop(&rp.queue)
+		delete(rp.entries, item.id)
+		rp.lastServed = now
+		return ent, 0, true
+	}
+	return
+}
+
 func (rp *replaceableQueue) ShouldClose(curTime time.Time, timeout time.Duration) bool {
 	rp.mx.Lock()
 	defer rp.mx.Unlock()
-	return curTime.Sub(rp.lastServed) > timeout && rp.batch.Len() == 0
+	return curTime.Sub(rp.lastServed) > timeout && len(rp.entries) == 0
 }
 
 func (rp *replaceableQueue) Close() (err error) {
+	rp.mx.Lock()
+	rp.isClosed = true
+	rp.mx.Unlock()
 	rp.cancel()
-	return rp.batch.Close()
+	return
 }
@@ -27,6 +27,7 @@ func New() SyncQueues {
 }
 
 type syncQueues struct {
+	config         Config
 	limit          *Limit
 	pool           ActionPool
 	nodeConf       nodeconf.Service
@@ -36,6 +37,9 @@ type syncQueues struct {
 func (g *syncQueues) Init(a *app.App) (err error) {
 	g.nodeConf = a.MustComponent(nodeconf.CName).(nodeconf.Service)
 	g.accountService = a.MustComponent(accountService.CName).(accountService.Service)
+	if cg, ok := a.Component("config").(configGetter); ok {
+		g.config = cg.GetSyncQueues()
+	}
 	var (
 		nodeIds        []string
 		iAmResponsible bool
@@ -51,9 +55,12 @@ func (g *syncQueues) Init(a *app.App) (err error) {
 		// increase limits between responsible nodes
 		if slices.Contains(nodeIds, peerId) && iAmResponsible {
 			return newReplaceableQueue(30, 400)
-		} else {
-			return newReplaceableQueue(10, 100)
 		}
+		queue := newReplaceableQueue(10, 100)
+		if g.config.PeerBandwidth > 0 {
+			queue.setBandwidth(g.config.PeerBandwidth)
+		}
+		return queue
 	})
 	g.limit = NewLimit([]int{20, 15, 10, 5}, []int{200, 400, 800}, nodeIds, 100)
 	return